@@ -2,50 +2,191 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/docs"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/cleansing"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/economy"
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/grpcserver"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/infestation"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/messagebus"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/middleware"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/tracing"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/webhook"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// maxForecastTicks bounds GET /api/simulation/forecast and /project.
+const maxForecastTicks = 1000
+
+// maxSimulationForks bounds the number of live forks created via
+// POST /api/simulation/fork.
+const maxSimulationForks = 16
+
 func main() {
+	generateKey := flag.Bool("generate-key", false, "print a new random API key and exit")
+	flag.Parse()
+	if *generateKey {
+		key, err := middleware.GenerateAPIKey()
+		if err != nil {
+			log.Fatalf("[Logistics] Failed to generate API key: %v", err)
+		}
+		fmt.Println(key)
+		return
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "12065"
 	}
 
+	// Cancelled on shutdown to stop background loops (real-time playback)
+	appCtx, appCancel := context.WithCancel(context.Background())
+	defer appCancel()
+
 	// Initialize engines
 	rebConfig := rebellion.DefaultConfig()
 	rebEngine := rebellion.NewEngine(rebConfig)
-	simEngine := simulation.NewSimulationEngine(rebEngine)
+	if path := os.Getenv("REBELLION_ACTIONS_FILE"); path != "" {
+		if err := rebEngine.LoadActionsFromFile(path); err != nil {
+			log.Fatalf("[Logistics] Failed to load rebellion actions: %v", err)
+		}
+	}
+	simConfig := simulation.DefaultConfig()
+	simConfig.BaseSimProduction = envFloat("SIM_BASE_PRODUCTION", simConfig.BaseSimProduction)
+	simConfig.RefineryMineralConsumptionBase = envFloat("SIM_REFINERY_MINERAL_CONSUMPTION", simConfig.RefineryMineralConsumptionBase)
+	simConfig.RefineryRapidlumProductionBase = envFloat("SIM_REFINERY_RAPIDLUM_PRODUCTION", simConfig.RefineryRapidlumProductionBase)
+	simConfig.NPCMoraleRecoveryRate = envFloat("NPC_MORALE_RECOVERY_RATE", simConfig.NPCMoraleRecoveryRate)
+	simConfig.NPCTraumaDecayRate = envFloat("NPC_TRAUMA_DECAY_RATE", simConfig.NPCTraumaDecayRate)
+	simConfig.WorldAgingRate = envFloat("SIM_WORLD_AGING_RATE", simConfig.WorldAgingRate)
+	if err := simConfig.Validate(); err != nil {
+		log.Fatalf("[Logistics] Invalid simulation config: %v", err)
+	}
+	simEngine := simulation.NewSimulationEngineWithConfig(rebEngine, simConfig)
+	disruptionConfig := simulation.DefaultDisruptionConfig()
+	disruptionConfig.DisruptionProbabilityPerTick = envFloat("SIM_DISRUPTION_PROBABILITY", disruptionConfig.DisruptionProbabilityPerTick)
+	disruptionConfig.DisruptionDuration = envInt("SIM_DISRUPTION_DURATION", disruptionConfig.DisruptionDuration)
+	if err := simEngine.SetDisruptionConfig(disruptionConfig); err != nil {
+		log.Fatalf("[Logistics] Invalid disruption config: %v", err)
+	}
+	if err := simEngine.SetTelemetryTickInterval(envInt("SIM_TELEMETRY_TICK_INTERVAL", simulation.DefaultTelemetryTickInterval)); err != nil {
+		log.Fatalf("[Logistics] Invalid telemetry tick interval: %v", err)
+	}
+	if err := simEngine.SetAuditLogSize(envInt("SIM_AUDIT_LOG_SIZE", simulation.DefaultAuditLogSize)); err != nil {
+		log.Fatalf("[Logistics] Invalid audit log size: %v", err)
+	}
 	behaviorEngine := npc.NewBehaviorEngine()
+	simEngine.AttachBehaviorEngine(behaviorEngine)
+	rebEngine.SetRelationshipSource(behaviorEngine)
+	rebEngine.SetTickSource(simEngine.CurrentTick)
+	rebEngine.SetProfileSource(behaviorEngine)
+	behaviorEngine.SetRegistrationListener(func(npcID string) {
+		rebEngine.TrackRegistrationTick(npcID, simEngine.CurrentTick())
+	})
+	simEvents := simulation.NewEventSourcedSimulationEngine(simEngine)
 	econEngine := economy.NewEconomyEngine()
 	cleansingEngine := cleansing.NewEngine(cleansing.DefaultConfig())
 
+	// Rebellion halt webhooks (WEBHOOK_URL; updatable via /api/config/webhook)
+	webhooks, err := webhook.NewWebhookDispatcher(webhook.WebhookConfig{
+		URL:        os.Getenv("WEBHOOK_URL"),
+		Secret:     os.Getenv("WEBHOOK_SECRET"),
+		RetryCount: envInt("WEBHOOK_RETRY_COUNT", 3),
+	}, nil)
+	if err != nil {
+		log.Fatalf("[Logistics] Invalid webhook config: %v", err)
+	}
+
+	// Rebellion halts and Plague Heart toggles on the message bus (NATS_URL)
+	if natsURL := os.Getenv("NATS_URL"); natsURL != "" {
+		natsPub, err := messagebus.NewNATSPublisher(natsURL)
+		if err != nil {
+			log.Fatalf("[Logistics] Failed to connect to NATS: %v", err)
+		}
+		defer natsPub.Close()
+		rebEngine.SetPublisher(natsPub)
+		simEngine.GetInfestationEngine().SetPublisher(natsPub)
+		log.Printf("[Logistics] Publishing rebellion and infestation events to NATS")
+	}
+
 	// Start gRPC server
 	grpcPort := os.Getenv("GRPC_PORT")
 	if grpcPort == "" {
 		grpcPort = grpcserver.DefaultGRPCPort
 	}
-	grpcSrv := grpcserver.NewEpochGRPCServer(grpcPort, rebEngine, simEngine, behaviorEngine, cleansingEngine)
+	grpcCfg := grpcserver.GRPCServerConfig{Port: grpcPort}
+	if certFile, keyFile := os.Getenv("GRPC_TLS_CERT_FILE"), os.Getenv("GRPC_TLS_KEY_FILE"); certFile != "" && keyFile != "" {
+		grpcCfg.TLS = &grpcserver.TLSConfig{
+			CertFile:     certFile,
+			KeyFile:      keyFile,
+			ClientCAFile: os.Getenv("GRPC_TLS_CLIENT_CA_FILE"),
+		}
+	}
+	grpcSrv := grpcserver.NewEpochGRPCServer(grpcCfg, rebEngine, simEngine, behaviorEngine, cleansingEngine)
+	grpcSrv.SetHaltNotifier(webhooks)
+	behaviorEngine.SetEmotionalStateListener(grpcSrv.TelemetrySvc.EmitEmotionalStateChange)
+	behaviorEngine.SetBreakdownRecoveryListener(grpcSrv.TelemetrySvc.EmitResolvedMentalBreakdown)
+	simEngine.SetDisruptionListener(grpcSrv.TelemetrySvc.EmitDisruption)
+	simEngine.SetAutoScaleListener(grpcSrv.TelemetrySvc.EmitAutoScale)
+	simEngine.SetTelemetryService(grpcSrv.TelemetrySvc)
+	simEngine.SetRandomEventListener(grpcSrv.TelemetrySvc.EmitRandomEvent)
+	simEngine.SetResourceDecayListener(grpcSrv.TelemetrySvc.EmitResourceDecay)
+	econEngine.SetIndexAlertListener(grpcSrv.TelemetrySvc.EmitCommodityIndexAlert)
+	simEngine.GetInfestationEngine().SetTelemetryService(grpcSrv.TelemetrySvc)
 	go func() {
 		if err := grpcSrv.Start(); err != nil {
 			log.Fatalf("[gRPC] Failed to start: %v", err)
 		}
 	}()
 
-	r := gin.Default()
+	readTimeout := envSeconds("SERVER_READ_TIMEOUT_SEC", 15*time.Second)
+	writeTimeout := envSeconds("SERVER_WRITE_TIMEOUT_SEC", 30*time.Second)
+	idleTimeout := envSeconds("SERVER_IDLE_TIMEOUT_SEC", 60*time.Second)
+
+	// Trace IDs (X-Request-ID) first, so access log lines and handlers see them
+	r := gin.New()
+	r.Use(middleware.NewTraceMiddleware(), gin.LoggerWithFormatter(middleware.TraceLogFormatter), gin.Recovery())
+
+	// Bound request bodies (413 above 1 MiB) and per-request time
+	r.Use(middleware.NewRequestLimiterMiddleware(middleware.DefaultMaxBodyBytes, readTimeout, writeTimeout))
+
+	// CORS for browser clients (CORS_ALLOWED_ORIGINS, comma-separated; "*" = any)
+	if origins := middleware.ParseCSV(os.Getenv("CORS_ALLOWED_ORIGINS")); len(origins) > 0 {
+		corsCfg := middleware.DefaultCORSConfig()
+		corsCfg.AllowedOrigins = origins
+		r.Use(middleware.NewCORSMiddleware(corsCfg))
+	}
+
+	// API key auth (API_KEYS, comma-separated); /health stays open
+	if apiKeys := middleware.ParseCSV(os.Getenv("API_KEYS")); len(apiKeys) > 0 {
+		r.Use(middleware.NewAPIKeyMiddleware(apiKeys))
+	} else {
+		log.Println("[Logistics] WARNING: API_KEYS not set, REST endpoints are unauthenticated")
+	}
+
+	// ETag + short private caching for GETs, no-cache for mutations
+	r.Use(middleware.NewCacheMiddleware(time.Second))
+
+	// Admin endpoints require ?admin_token= matching ADMIN_TOKEN (disabled when unset)
+	adminOnly := requireAdminToken(os.Getenv("ADMIN_TOKEN"))
 
 	// Health check
 	r.GET("/health", func(c *gin.Context) {
@@ -57,30 +198,28 @@ func main() {
 		})
 	})
 
+	// OpenAPI specification (docs/swagger.json)
+	r.GET("/swagger/*any", docs.Handler())
+
 	// Simulation status
-	r.GET("/api/simulation/status", func(c *gin.Context) {
-		status := simEngine.GetStatus()
+	r.GET("/api/simulation/status", middleware.NewGzipMiddleware(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, simulationStatusJSON(simEngine.GetStatus()))
+	})
 
-		resources := make(map[string]gin.H)
-		for rType, rState := range status.Resources {
-			resources[string(rType)] = gin.H{
-				"quantity":         rState.Quantity,
-				"production_rate":  rState.ProductionRate,
-				"consumption_rate": rState.ConsumptionRate,
-			}
+	// Freeze/unfreeze the simulation; ticks while paused are counted, not run
+	r.POST("/api/simulation/pause", func(c *gin.Context) {
+		if err := simEngine.Pause(); err != nil {
+			c.JSON(errorStatus(err, http.StatusConflict), gin.H{"error": err.Error()})
+			return
 		}
-
-		c.JSON(http.StatusOK, gin.H{
-			"refineries":              status.Refineries,
-			"mines":                   status.Mines,
-			"resources":               resources,
-			"overall_rebellion_prob":   status.OverallRebellionProb,
-			"active_npcs":             status.ActiveNPCs,
-			"tick_count":              status.TickCount,
-			"infestation_level":       status.InfestationLevel,
-			"is_plague_heart":         status.IsPlagueHeart,
-			"throttle_multiplier":     status.ThrottleMultiplier,
-		})
+		c.JSON(http.StatusOK, simulationStatusJSON(simEngine.GetStatus()))
+	})
+	r.POST("/api/simulation/resume", func(c *gin.Context) {
+		if err := simEngine.Resume(); err != nil {
+			c.JSON(errorStatus(err, http.StatusConflict), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, simulationStatusJSON(simEngine.GetStatus()))
 	})
 
 	// Advance simulation by one tick
@@ -102,228 +241,2052 @@ func main() {
 		})
 	})
 
-	// Get rebellion probability for a specific NPC
-	r.GET("/api/rebellion/probability/:npcId", func(c *gin.Context) {
-		npcID := c.Param("npcId")
-
-		npcBehavior, ok := behaviorEngine.GetNPC(npcID)
-		if !ok {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": fmt.Sprintf("NPC %q not found", npcID),
-			})
+	// Real-time playback: tick at a wall-clock rate until paused
+	r.POST("/api/simulation/realtime/start", func(c *gin.Context) {
+		var req struct {
+			TicksPerSecond float64 `json:"ticks_per_second" binding:"required,gt=0,lte=100"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		profile := rebellion.NPCRebellionProfile{
-			NPCID:          npcBehavior.NPCID,
-			AvgTrauma:      0.0, // Trauma comes from memory graph; default 0 here
-			WorkEfficiency: npcBehavior.WorkEfficiency,
-			Morale:         npcBehavior.Morale,
-			MemoryCount:    0,
+		simEngine.SetTickRate(req.TicksPerSecond)
+		if err := simEngine.StartRealtime(appCtx); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
 		}
-
-		result := rebEngine.CalculateProbability(profile)
-
+		c.JSON(http.StatusOK, gin.H{"running": true, "ticks_per_second": simEngine.TickRate()})
+	})
+	r.POST("/api/simulation/realtime/pause", func(c *gin.Context) {
+		simEngine.PauseRealtime()
+		c.JSON(http.StatusOK, gin.H{"running": simEngine.IsRunning()})
+	})
+	r.POST("/api/simulation/realtime/resume", func(c *gin.Context) {
+		if err := simEngine.ResumeRealtime(); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"running": true, "ticks_per_second": simEngine.TickRate()})
+	})
+	r.GET("/api/simulation/realtime", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
-			"npc_id":             result.NPCID,
-			"probability":        result.Probability,
-			"threshold_exceeded": result.ThresholdExceeded,
-			"halt_triggered":     result.HaltTriggered,
-			"factors": gin.H{
-				"base":                result.Factors.Base,
-				"trauma_modifier":     result.Factors.TraumaModifier,
-				"efficiency_modifier": result.Factors.EfficiencyModifier,
-				"morale_modifier":     result.Factors.MoraleModifier,
-			},
+			"running":          simEngine.IsRunning(),
+			"ticks_per_second": simEngine.TickRate(),
 		})
 	})
 
-	// Apply an action to an NPC
-	r.POST("/api/npc/:npcId/action", func(c *gin.Context) {
-		npcID := c.Param("npcId")
-
-		var req struct {
-			ActionType string  `json:"action_type" binding:"required"`
-			Intensity  float64 `json:"intensity" binding:"required"`
-		}
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	// Resource forecast; projects ticks without advancing the simulation
+	r.GET("/api/simulation/forecast", func(c *gin.Context) {
+		ticks, err := strconv.Atoi(c.DefaultQuery("ticks", "20"))
+		if err != nil || ticks < 1 || ticks > maxForecastTicks {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ticks must be an integer in [1, %d]", maxForecastTicks)})
 			return
 		}
 
-		// Auto-register NPC if not exists
-		behaviorEngine.RegisterNPC(npcID)
-
-		// Apply behavioral effects
-		action := rebellion.NPCAction{
-			ActionID:   fmt.Sprintf("act-%d", time.Now().UnixNano()),
-			NPCID:      npcID,
-			ActionType: req.ActionType,
-			Intensity:  req.Intensity,
+		forecast := simEngine.ForecastResources(ticks)
+		entries := make([]gin.H, len(forecast))
+		for i, f := range forecast {
+			deficits := make([]string, len(f.EstimatedDeficits))
+			for j, rt := range f.EstimatedDeficits {
+				deficits[j] = string(rt)
+			}
+			entries[i] = gin.H{
+				"tick":               f.Tick,
+				"quantities":         f.Quantities,
+				"estimated_deficits": deficits,
+			}
 		}
+		c.JSON(http.StatusOK, gin.H{"forecast": entries})
+	})
 
-		// Get current profile from behavior engine
-		npcBehavior, _ := behaviorEngine.GetNPC(npcID)
-		profile := rebellion.NPCRebellionProfile{
-			NPCID:          npcBehavior.NPCID,
-			AvgTrauma:      0.0,
-			WorkEfficiency: npcBehavior.WorkEfficiency,
-			Morale:         npcBehavior.Morale,
-			MemoryCount:    0,
+	// Status projection; ticks a copy of the simulation, leaving it untouched
+	r.GET("/api/simulation/project", func(c *gin.Context) {
+		ticks, err := strconv.Atoi(c.DefaultQuery("ticks", "10"))
+		if err != nil || ticks < 1 || ticks > maxForecastTicks {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ticks must be an integer in [1, %d]", maxForecastTicks)})
+			return
 		}
 
-		updatedProfile := rebEngine.ProcessAction(profile, action)
-
-		// Sync updated values back to behavior engine
-		_ = behaviorEngine.ApplyWorkEfficiencyModifier(npcID, updatedProfile.WorkEfficiency-npcBehavior.WorkEfficiency)
-		_ = behaviorEngine.ApplyMoraleModifier(npcID, updatedProfile.Morale-npcBehavior.Morale)
-
-		// Calculate new rebellion probability
-		result := rebEngine.CalculateProbability(updatedProfile)
+		projected := simEngine.ProjectTicks(ticks)
+		statuses := make([]gin.H, len(projected))
+		for i, st := range projected {
+			statuses[i] = simulationStatusJSON(st)
+		}
+		c.JSON(http.StatusOK, gin.H{"projection": statuses})
+	})
 
+	// Throttled production periods and the production they cost
+	r.GET("/api/simulation/throttle-history", func(c *gin.Context) {
+		history := simEngine.GetThrottleHistory()
+		records := make([]gin.H, len(history))
+		for i, rec := range history {
+			records[i] = gin.H{
+				"start_tick": rec.StartTick,
+				"end_tick":   rec.EndTick,
+				"multiplier": rec.Multiplier,
+			}
+		}
 		c.JSON(http.StatusOK, gin.H{
-			"npc_id":      npcID,
-			"action_type": req.ActionType,
-			"updated_state": gin.H{
-				"work_efficiency": updatedProfile.WorkEfficiency,
-				"morale":          updatedProfile.Morale,
-				"avg_trauma":      updatedProfile.AvgTrauma,
-			},
-			"rebellion_probability": result.Probability,
-			"halt_triggered":        result.HaltTriggered,
+			"records":               records,
+			"total_production_lost": simEngine.TotalProductionLostToThrottle(),
 		})
 	})
 
-	// Register NPC with role (testing convenience)
-	r.POST("/api/npc/:npcId/register", func(c *gin.Context) {
-		npcID := c.Param("npcId")
-		var req struct {
-			Role string `json:"role"`
+	// Per-tick production accounting for one resource over [from, to] (to defaults to the current tick)
+	r.GET("/api/simulation/audit/:resource", func(c *gin.Context) {
+		rt, err := simulation.ParseResourceType(c.Param("resource"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-		if err := c.ShouldBindJSON(&req); err != nil {
-			req.Role = "worker"
+		from, err := strconv.ParseInt(c.DefaultQuery("from", "0"), 10, 64)
+		if err != nil || from < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be a non-negative integer"})
+			return
 		}
-		if req.Role == "" {
-			req.Role = "worker"
+		to := simEngine.CurrentTick()
+		if raw := c.Query("to"); raw != "" {
+			if to, err = strconv.ParseInt(raw, 10, 64); err != nil || to < from {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "to must be an integer no less than from"})
+				return
+			}
 		}
 
-		npcBehavior := behaviorEngine.RegisterNPCWithRole(npcID, req.Role)
+		summary := simEngine.GetAuditSummary(rt, from, to)
+		entries := make([]gin.H, 0, summary.Entries)
+		for _, e := range simEngine.GetResourceAuditLog(rt, 0) {
+			if e.Tick < from || e.Tick > to {
+				continue
+			}
+			entries = append(entries, gin.H{
+				"tick":            e.Tick,
+				"quantity_before": e.QuantityBefore,
+				"quantity_after":  e.QuantityAfter,
+				"produced":        e.Produced,
+				"consumed":        e.Consumed,
+				"wasted_to_decay": e.WastedToDecay,
+				"wasted_to_cap":   e.WastedToCap,
+			})
+		}
 		c.JSON(http.StatusOK, gin.H{
-			"npc_id":          npcBehavior.NPCID,
-			"role":            npcBehavior.Role,
-			"work_efficiency": npcBehavior.WorkEfficiency,
-			"morale":          npcBehavior.Morale,
+			"resource":  rt,
+			"from_tick": summary.FromTick,
+			"to_tick":   summary.ToTick,
+			"summary": gin.H{
+				"total_produced": summary.TotalProduced,
+				"total_consumed": summary.TotalConsumed,
+				"total_wasted":   summary.TotalWasted,
+				"net_change":     summary.NetChange,
+			},
+			"entries": entries,
 		})
 	})
 
-	// Deploy Sheriff Protocol cleansing operation
-	r.POST("/api/cleansing/deploy", func(c *gin.Context) {
-		// Check plague heart is active
-		infState := simEngine.GetInfestationState()
-		if !infState.IsPlagueHeart {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"success":       false,
-				"error_message": "Plague Heart is not active",
-			})
+	// Bulk mine/refinery setup; invalid entries are skipped and reported
+	r.POST("/api/simulation/import", func(c *gin.Context) {
+		data, err := c.GetRawData()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-
-		// Gather warriors and guards
-		warriors := behaviorEngine.GetNPCsByRole("warrior")
-		guards := behaviorEngine.GetNPCsByRole("guard")
-
-		participants := make([]cleansing.CleansingParticipant, 0, len(warriors)+len(guards))
-		for _, w := range warriors {
-			participants = append(participants, cleansing.CleansingParticipant{
-				NPCID:      w.NPCID,
-				Role:       w.Role,
-				AvgTrauma:  1.0 - w.Morale,
-				Morale:     w.Morale,
-				Confidence: w.Morale,
-			})
+		summary, err := simEngine.ImportInfrastructure(data)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-		for _, g := range guards {
-			participants = append(participants, cleansing.CleansingParticipant{
-				NPCID:      g.NPCID,
-				Role:       g.Role,
-				AvgTrauma:  1.0 - g.Morale,
-				Morale:     g.Morale,
-				Confidence: g.Morale,
-			})
+		c.JSON(http.StatusOK, gin.H{
+			"mines_added":      summary.MinesAdded,
+			"refineries_added": summary.RefineriesAdded,
+			"errors":           summary.Errors,
+		})
+	})
+	r.GET("/api/simulation/export", func(c *gin.Context) {
+		data, err := simEngine.ExportInfrastructure()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
 		}
+		c.Data(http.StatusOK, "application/json", data)
+	})
 
-		result, err := cleansingEngine.Execute(participants, true)
+	// Full save/restore; /import and /export above only cover infrastructure
+	r.GET("/api/simulation/state/export", func(c *gin.Context) {
+		snap, err := simEngine.ExportState()
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"success":       false,
-				"error_message": err.Error(),
-			})
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-
-		// On success: cleanse the infestation
-		if result.Success {
-			if infEngine := simEngine.GetInfestationEngine(); infEngine != nil {
-				_ = infEngine.Cleanse()
-			}
+		c.JSON(http.StatusOK, snap)
+	})
+	r.POST("/api/simulation/state/import", adminOnly, func(c *gin.Context) {
+		var snap simulation.SimulationSnapshot
+		if err := c.ShouldBindJSON(&snap); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-
-		c.JSON(http.StatusOK, gin.H{
-			"success":           result.Success,
-			"success_rate":      result.SuccessRate,
-			"participant_count": result.ParticipantCount,
-			"participant_ids":   result.Participants,
-			"rolled_value":      result.RolledValue,
-			"factors": gin.H{
-				"base":                     result.Factors.BaseFactor,
-				"avg_morale":               result.Factors.AvgMorale,
-				"morale_contribution":      result.Factors.MoraleContrib,
-				"avg_trauma":               result.Factors.AvgTrauma,
-				"trauma_penalty":           result.Factors.TraumaPenalty,
-				"avg_confidence":           result.Factors.AvgConfidence,
-				"confidence_contribution":  result.Factors.ConfidenceContrib,
-			},
-		})
+		if err := simEngine.ImportState(snap); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, simulationStatusJSON(simEngine.GetStatus()))
 	})
 
-	// Get resource prices
-	r.GET("/api/economy/prices", func(c *gin.Context) {
-		prices := make(map[string]gin.H)
-		for _, rt := range []economy.ResourceType{economy.ResourceSim, economy.ResourceRapidlum, economy.ResourceMineral} {
-			if p, ok := econEngine.GetPrice(rt); ok {
-				prices[string(rt)] = gin.H{
-					"buy_price":  p.BuyPrice,
-					"sell_price": p.SellPrice,
-				}
-			}
+	// Mine and refinery inventory, paginated in the order added
+	r.GET("/api/simulation/mines", func(c *gin.Context) {
+		page, pageSize, ok := bindPage(c)
+		if !ok {
+			return
+		}
+		mines := simEngine.GetAllMines()
+		start, end := pageSlice(len(mines), page, pageSize)
+		entries := make([]gin.H, 0, end-start)
+		for _, m := range mines[start:end] {
+			entries = append(entries, mineJSON(m))
 		}
-		c.JSON(http.StatusOK, gin.H{"prices": prices})
+		c.JSON(http.StatusOK, gin.H{"mines": entries, "total": len(mines), "page": page, "page_size": pageSize})
 	})
-
-	// Graceful shutdown
-	addr := fmt.Sprintf(":%s", port)
-	srv := &http.Server{
-		Addr:    addr,
-		Handler: r,
-	}
-
-	go func() {
-		log.Printf("[Logistics] Golang backend online — port %s", port)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+	r.GET("/api/simulation/mines/:mineId", func(c *gin.Context) {
+		mine, err := simEngine.GetMine(c.Param("mineId"))
+		if err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
 		}
-	}()
-
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	log.Println("[Logistics] Shutting down gracefully...")
+		detail := mineJSON(mine)
+		detail["disrupted_ticks"] = mine.DisruptedTicks
+		detail["assigned_npcs"] = assignedNPCsJSON(behaviorEngine, mine.AssignedNPCs)
+		c.JSON(http.StatusOK, detail)
+	})
+	r.GET("/api/simulation/refineries", func(c *gin.Context) {
+		page, pageSize, ok := bindPage(c)
+		if !ok {
+			return
+		}
+		refineries := simEngine.GetAllRefineries()
+		start, end := pageSlice(len(refineries), page, pageSize)
+		entries := make([]gin.H, 0, end-start)
+		for _, r := range refineries[start:end] {
+			entries = append(entries, refineryJSON(r))
+		}
+		c.JSON(http.StatusOK, gin.H{"refineries": entries, "total": len(refineries), "page": page, "page_size": pageSize})
+	})
+	r.GET("/api/simulation/refineries/:refineryId", func(c *gin.Context) {
+		refinery, err := simEngine.GetRefinery(c.Param("refineryId"))
+		if err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		detail := refineryJSON(refinery)
+		detail["disrupted_ticks"] = refinery.DisruptedTicks
+		detail["assigned_npcs"] = assignedNPCsJSON(behaviorEngine, refinery.AssignedNPCs)
+		c.JSON(http.StatusOK, detail)
+	})
+
+	// Scripted mine outage; the mine yields nothing for duration_ticks ticks
+	r.POST("/api/simulation/mines/:mineId/disrupt", func(c *gin.Context) {
+		var req struct {
+			DurationTicks int `json:"duration_ticks" binding:"required,min=1"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := simEngine.DisruptMine(c.Param("mineId"), req.DurationTicks); err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"mine_id": c.Param("mineId"), "duration_ticks": req.DurationTicks})
+	})
+
+	// NPC work assignments; assigned NPCs' average efficiency scales the yield
+	r.GET("/api/simulation/mines/:mineId/assignments", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"mine_id": c.Param("mineId"), "npc_ids": simEngine.GetMineAssignments(c.Param("mineId"))})
+	})
+	r.POST("/api/simulation/mines/:mineId/assign", func(c *gin.Context) {
+		var req struct {
+			NpcID string `json:"npc_id" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		mineID := c.Param("mineId")
+		if err := simEngine.AssignNPCToMine(req.NpcID, mineID); err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"mine_id": mineID, "npc_ids": simEngine.GetMineAssignments(mineID)})
+	})
+	r.POST("/api/simulation/mines/:mineId/unassign", func(c *gin.Context) {
+		var req struct {
+			NpcID string `json:"npc_id" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		mineID := c.Param("mineId")
+		if !slices.Contains(simEngine.GetMineAssignments(mineID), req.NpcID) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("NPC %q is not assigned to mine %q", req.NpcID, mineID)})
+			return
+		}
+		if err := simEngine.UnassignNPC(req.NpcID); err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"mine_id": mineID, "npc_ids": simEngine.GetMineAssignments(mineID)})
+	})
+
+	// Event log of mine/refinery changes and ticks; replayable to rebuild state
+	r.GET("/api/simulation/events", func(c *gin.Context) {
+		events := simEvents.ExportEventLog()
+		entries := make([]gin.H, len(events))
+		for i, ev := range events {
+			entries[i] = simulationEventJSON(ev)
+		}
+		c.JSON(http.StatusOK, gin.H{"events": entries, "count": len(entries)})
+	})
+	r.POST("/api/simulation/events", adminOnly, func(c *gin.Context) {
+		events, ok := bindSimulationEvents(c)
+		if !ok {
+			return
+		}
+		if err := simEvents.ImportEventLog(events); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		status := simEngine.GetStatus()
+		c.JSON(http.StatusOK, gin.H{
+			"imported":   len(events),
+			"tick_count": status.TickCount,
+			"mines":      status.Mines,
+			"refineries": status.Refineries,
+			"resources":  simulationQuantities(status),
+		})
+	})
+
+	// Replay an event log into a scratch engine; the live simulation is untouched
+	r.POST("/api/simulation/replay", func(c *gin.Context) {
+		events, ok := bindSimulationEvents(c)
+		if !ok {
+			return
+		}
+		replayed, err := simEvents.ReplayFromEvents(events)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		status := replayed.GetStatus()
+		c.JSON(http.StatusOK, gin.H{
+			"tick_count": status.TickCount,
+			"mines":      status.Mines,
+			"refineries": status.Refineries,
+			"resources":  simulationQuantities(status),
+		})
+	})
+
+	// Compare two status snapshots; neither needs to come from this server
+	r.POST("/api/simulation/diff", func(c *gin.Context) {
+		before, after, ok := bindSimulationStatusPair(c)
+		if !ok {
+			return
+		}
+		c.JSON(http.StatusOK, simulationDiffJSON(before.Diff(after)))
+	})
+
+	// Simulation production config
+	r.GET("/api/simulation/config", func(c *gin.Context) {
+		cfg := simEngine.GetConfig()
+		c.JSON(http.StatusOK, simulationConfigJSON(cfg))
+	})
+
+	// Update simulation production config; applied between ticks
+	r.POST("/api/simulation/config", func(c *gin.Context) {
+		cfg := simEngine.GetConfig()
+		var req struct {
+			BaseSimProduction              *float64           `json:"base_sim_production"`
+			RefineryMineralConsumptionBase *float64           `json:"refinery_mineral_consumption_base"`
+			RefineryRapidlumProductionBase *float64           `json:"refinery_rapidlum_production_base"`
+			LowMoraleThreshold             *float64           `json:"low_morale_threshold"`
+			NPCMoraleRecoveryRate          *float64           `json:"npc_morale_recovery_rate"`
+			NPCTraumaDecayRate             *float64           `json:"npc_trauma_decay_rate"`
+			WorldAgingRate                 *float64           `json:"world_aging_rate"`
+			ResourceDecayRate              map[string]float64 `json:"resource_decay_rate"` // merged; 0 removes a rate
+			EfficiencyAggregation          *string            `json:"efficiency_aggregation"`
+			RoleEfficiencyWeights          map[string]float64 `json:"role_efficiency_weights"` // replaces the whole map
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.BaseSimProduction != nil {
+			cfg.BaseSimProduction = *req.BaseSimProduction
+		}
+		if req.RefineryMineralConsumptionBase != nil {
+			cfg.RefineryMineralConsumptionBase = *req.RefineryMineralConsumptionBase
+		}
+		if req.RefineryRapidlumProductionBase != nil {
+			cfg.RefineryRapidlumProductionBase = *req.RefineryRapidlumProductionBase
+		}
+		if req.LowMoraleThreshold != nil {
+			cfg.LowMoraleThreshold = *req.LowMoraleThreshold
+		}
+		if req.NPCMoraleRecoveryRate != nil {
+			cfg.NPCMoraleRecoveryRate = *req.NPCMoraleRecoveryRate
+		}
+		if req.NPCTraumaDecayRate != nil {
+			cfg.NPCTraumaDecayRate = *req.NPCTraumaDecayRate
+		}
+		if req.WorldAgingRate != nil {
+			cfg.WorldAgingRate = *req.WorldAgingRate
+		}
+		for name, rate := range req.ResourceDecayRate {
+			if cfg.ResourceDecayRate == nil {
+				cfg.ResourceDecayRate = make(map[simulation.ResourceType]float64)
+			}
+			if rate == 0 {
+				delete(cfg.ResourceDecayRate, simulation.ResourceType(name))
+				continue
+			}
+			cfg.ResourceDecayRate[simulation.ResourceType(name)] = rate
+		}
+		if req.EfficiencyAggregation != nil {
+			mode, err := simulation.ParseEfficiencyAggregation(*req.EfficiencyAggregation)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			cfg.EfficiencyAggregation = mode
+		}
+		if req.RoleEfficiencyWeights != nil {
+			cfg.RoleEfficiencyWeights = req.RoleEfficiencyWeights
+		}
+
+		if err := simEngine.UpdateConfig(cfg); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, simulationConfigJSON(cfg))
+	})
+
+	// Admin: reset the world age multiplier to 1.0 (a major rebuild)
+	r.POST("/api/simulation/world/rejuvenate", adminOnly, func(c *gin.Context) {
+		simEngine.Rejuvenate()
+		c.JSON(http.StatusOK, gin.H{"world_age_multiplier": simEngine.GetWorldAge()})
+	})
+
+	// Auto-scaling adds mines while mineral is low; POST updates the settings
+	r.GET("/api/simulation/autoscale", func(c *gin.Context) {
+		c.JSON(http.StatusOK, autoScaleJSON(simEngine.GetAutoScaleConfig(), simEngine.GetAutoMineCount()))
+	})
+	r.POST("/api/simulation/autoscale", func(c *gin.Context) {
+		cfg := simEngine.GetAutoScaleConfig()
+		var req struct {
+			EnableAutoScale    *bool    `json:"enable_auto_scale"`
+			MinMineralQuantity *float64 `json:"min_mineral_quantity"`
+			AutoMineYieldRate  *float64 `json:"auto_mine_yield_rate"`
+			MaxAutoMines       *int     `json:"max_auto_mines"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.EnableAutoScale != nil {
+			cfg.EnableAutoScale = *req.EnableAutoScale
+		}
+		if req.MinMineralQuantity != nil {
+			cfg.MinMineralQuantity = *req.MinMineralQuantity
+		}
+		if req.AutoMineYieldRate != nil {
+			cfg.AutoMineYieldRate = *req.AutoMineYieldRate
+		}
+		if req.MaxAutoMines != nil {
+			cfg.MaxAutoMines = *req.MaxAutoMines
+		}
+		if err := simEngine.SetAutoScaleConfig(cfg); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, autoScaleJSON(cfg, simEngine.GetAutoMineCount()))
+	})
+
+	// Random events rolled each tick; POST replaces the definitions
+	r.GET("/api/simulation/random-events", func(c *gin.Context) {
+		defs := simEngine.GetRandomEvents().EventDefinitions
+		events := make([]gin.H, len(defs))
+		for i, def := range defs {
+			effects := make(map[string]float64, len(def.ResourceEffects))
+			for rt, m := range def.ResourceEffects {
+				effects[string(rt)] = m
+			}
+			events[i] = gin.H{
+				"name":                 def.Name,
+				"probability_per_tick": def.ProbabilityPerTick,
+				"resource_effects":     effects,
+				"npc_morale_effect":    def.NPCMoraleEffect,
+				"telemetry_message":    def.TelemetryMessage,
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{"events": events, "active_events": simEngine.GetActiveEvents()})
+	})
+	r.POST("/api/simulation/random-events", func(c *gin.Context) {
+		var req struct {
+			Seed   *int64 `json:"seed"`
+			Events []struct {
+				Name               string             `json:"name" binding:"required"`
+				ProbabilityPerTick float64            `json:"probability_per_tick"`
+				ResourceEffects    map[string]float64 `json:"resource_effects"`
+				NPCMoraleEffect    float64            `json:"npc_morale_effect"`
+				TelemetryMessage   string             `json:"telemetry_message"`
+			} `json:"events" binding:"dive"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cfg := simulation.RandomEventConfig{EventDefinitions: make([]simulation.EventDefinition, len(req.Events))}
+		for i, ev := range req.Events {
+			effects := make(map[simulation.ResourceType]float64, len(ev.ResourceEffects))
+			for name, m := range ev.ResourceEffects {
+				effects[simulation.ResourceType(name)] = m
+			}
+			cfg.EventDefinitions[i] = simulation.EventDefinition{
+				Name:               ev.Name,
+				ProbabilityPerTick: ev.ProbabilityPerTick,
+				ResourceEffects:    effects,
+				NPCMoraleEffect:    ev.NPCMoraleEffect,
+				TelemetryMessage:   ev.TelemetryMessage,
+			}
+		}
+		seed := time.Now().UnixNano()
+		if req.Seed != nil {
+			seed = *req.Seed
+		}
+		if err := simEngine.SetRandomEvents(cfg, seed); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"events": len(cfg.EventDefinitions), "seed": seed})
+	})
+
+	// Forks: independent copies of the simulation for comparing policies.
+	// Merging a fork consumes it.
+	var forksMu sync.Mutex
+	forks := make(map[string]*simulation.SimulationEngine)
+	nextForkID := 1
+	lookupFork := func(c *gin.Context) (*simulation.SimulationEngine, bool) {
+		forksMu.Lock()
+		fork, ok := forks[c.Param("forkId")]
+		forksMu.Unlock()
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("fork %q not found", c.Param("forkId"))})
+		}
+		return fork, ok
+	}
+	forkStatusJSON := func(forkID string, fork *simulation.SimulationEngine) gin.H {
+		status := fork.GetStatus()
+		return gin.H{
+			"fork_id":           forkID,
+			"tick_count":        status.TickCount,
+			"mines":             status.Mines,
+			"refineries":        status.Refineries,
+			"resources":         simulationQuantities(status),
+			"infestation_level": fork.GetInfestationState().Counter,
+		}
+	}
+	r.POST("/api/simulation/fork", func(c *gin.Context) {
+		forksMu.Lock()
+		if len(forks) >= maxSimulationForks {
+			forksMu.Unlock()
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("at most %d forks may exist; merge or delete one first", maxSimulationForks)})
+			return
+		}
+		forkID := fmt.Sprintf("fork-%d", nextForkID)
+		nextForkID++
+		fork := simEngine.Fork()
+		forks[forkID] = fork
+		forksMu.Unlock()
+
+		c.JSON(http.StatusOK, forkStatusJSON(forkID, fork))
+	})
+	r.GET("/api/simulation/fork/:forkId", func(c *gin.Context) {
+		fork, ok := lookupFork(c)
+		if !ok {
+			return
+		}
+		c.JSON(http.StatusOK, forkStatusJSON(c.Param("forkId"), fork))
+	})
+	r.POST("/api/simulation/fork/:forkId/tick", func(c *gin.Context) {
+		ticks, err := strconv.Atoi(c.DefaultQuery("ticks", "1"))
+		if err != nil || ticks < 1 || ticks > maxForecastTicks {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ticks must be an integer in [1, %d]", maxForecastTicks)})
+			return
+		}
+		fork, ok := lookupFork(c)
+		if !ok {
+			return
+		}
+		for i := 0; i < ticks; i++ {
+			fork.Tick()
+		}
+		c.JSON(http.StatusOK, forkStatusJSON(c.Param("forkId"), fork))
+	})
+	r.POST("/api/simulation/fork/:forkId/merge", func(c *gin.Context) {
+		var req struct {
+			Strategy string `json:"strategy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		strategy, err := simulation.ParseMergeStrategy(req.Strategy)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		forksMu.Lock()
+		fork, ok := forks[c.Param("forkId")]
+		delete(forks, c.Param("forkId"))
+		forksMu.Unlock()
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("fork %q not found", c.Param("forkId"))})
+			return
+		}
+
+		merged, err := simEngine.MergeFrom(fork, strategy)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		status := simEngine.GetStatus()
+		c.JSON(http.StatusOK, gin.H{
+			"merged":            merged,
+			"strategy":          strategy,
+			"tick_count":        status.TickCount,
+			"mines":             status.Mines,
+			"refineries":        status.Refineries,
+			"resources":         simulationQuantities(status),
+			"infestation_level": status.InfestationLevel,
+		})
+	})
+	r.DELETE("/api/simulation/fork/:forkId", func(c *gin.Context) {
+		forksMu.Lock()
+		_, ok := forks[c.Param("forkId")]
+		delete(forks, c.Param("forkId"))
+		forksMu.Unlock()
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("fork %q not found", c.Param("forkId"))})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"fork_id": c.Param("forkId"), "deleted": true})
+	})
+
+	// Transfer resources between simulation engines. Only the primary engine
+	// exists today; the registry is keyed by ID for future multi-engine support.
+	simEngines := map[string]*simulation.SimulationEngine{
+		"primary": simEngine,
+	}
+	r.POST("/api/simulation/transfer", func(c *gin.Context) {
+		var req struct {
+			SourceEngineID string  `json:"source_engine_id"`
+			TargetEngineID string  `json:"target_engine_id" binding:"required"`
+			Resource       string  `json:"resource" binding:"required"`
+			Amount         float64 `json:"amount" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.SourceEngineID == "" {
+			req.SourceEngineID = "primary"
+		}
+
+		rt, err := simulation.ParseResourceType(req.Resource)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		source, ok := simEngines[req.SourceEngineID]
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("engine %q not found", req.SourceEngineID)})
+			return
+		}
+		target, ok := simEngines[req.TargetEngineID]
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("engine %q not found", req.TargetEngineID)})
+			return
+		}
+
+		if err := source.TransferResource(target, rt, req.Amount); err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"source_engine_id": req.SourceEngineID,
+			"target_engine_id": req.TargetEngineID,
+			"resource":         req.Resource,
+			"amount":           req.Amount,
+			"source_quantity":  source.GetStatus().Resources[rt].Quantity,
+			"target_quantity":  target.GetStatus().Resources[rt].Quantity,
+		})
+	})
+
+	// Get rebellion probability for a specific NPC
+	r.GET("/api/rebellion/probability/:npcId", func(c *gin.Context) {
+		npcID := c.Param("npcId")
+
+		npcBehavior, ok := behaviorEngine.GetNPC(npcID)
+		if !ok {
+			err := &npc.NPCNotFoundError{NpcID: npcID}
+			c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+			return
+		}
+
+		profile := rebellion.NPCRebellionProfile{
+			NPCID:          npcBehavior.NPCID,
+			AvgTrauma:      0.0, // Trauma comes from memory graph; default 0 here
+			WorkEfficiency: npcBehavior.WorkEfficiency,
+			Morale:         npcBehavior.Morale,
+			MemoryCount:    0,
+			Role:           npcBehavior.Role,
+		}
+
+		result := rebEngine.CalculateProbability(profile)
+
+		c.JSON(http.StatusOK, gin.H{
+			"npc_id":             result.NPCID,
+			"probability":        result.Probability,
+			"threshold_exceeded": result.ThresholdExceeded,
+			"halt_triggered":     result.HaltTriggered,
+			"veto_triggered":     result.VetoTriggered,
+			"rebellion_type":     result.RebellionType,
+			"factors": gin.H{
+				"base":                  result.Factors.Base,
+				"trauma_modifier":       result.Factors.TraumaModifier,
+				"memory_modifier":       result.Factors.MemoryModifier,
+				"efficiency_modifier":   result.Factors.EfficiencyModifier,
+				"morale_modifier":       result.Factors.MoraleModifier,
+				"relationship_modifier": result.Factors.RelationshipModifier,
+				"role_modifier":         result.Factors.RoleModifier,
+			},
+		})
+	})
+
+	// Rebellion probabilities for many NPCs in one call; unknown IDs are
+	// registered with defaults and duplicate IDs are evaluated once
+	r.POST("/api/rebellion/probability/batch", func(c *gin.Context) {
+		var req struct {
+			NPCIDs         []string `json:"npc_ids"`
+			IncludeFactors bool     `json:"include_factors"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		seen := make(map[string]bool, len(req.NPCIDs))
+		profiles := make([]rebellion.NPCRebellionProfile, 0, len(req.NPCIDs))
+		for _, npcID := range req.NPCIDs {
+			if npcID == "" || seen[npcID] {
+				continue
+			}
+			seen[npcID] = true
+
+			behaviorEngine.RegisterNPC(npcID)
+			npcBehavior, _ := behaviorEngine.GetNPC(npcID)
+			profiles = append(profiles, rebellion.NPCRebellionProfile{
+				NPCID:          npcBehavior.NPCID,
+				AvgTrauma:      0.0, // Trauma comes from memory graph; default 0 here
+				WorkEfficiency: npcBehavior.WorkEfficiency,
+				Morale:         npcBehavior.Morale,
+				Role:           npcBehavior.Role,
+			})
+		}
+
+		results := rebEngine.BatchCalculate(profiles)
+		entries := make([]gin.H, len(results))
+		for i, result := range results {
+			entries[i] = gin.H{
+				"npc_id":             result.NPCID,
+				"probability":        result.Probability,
+				"threshold_exceeded": result.ThresholdExceeded,
+				"halt_triggered":     result.HaltTriggered,
+				"veto_triggered":     result.VetoTriggered,
+				"rebellion_type":     result.RebellionType,
+			}
+			if req.IncludeFactors {
+				entries[i]["factors"] = gin.H{
+					"base":                  result.Factors.Base,
+					"trauma_modifier":       result.Factors.TraumaModifier,
+					"memory_modifier":       result.Factors.MemoryModifier,
+					"efficiency_modifier":   result.Factors.EfficiencyModifier,
+					"morale_modifier":       result.Factors.MoraleModifier,
+					"relationship_modifier": result.Factors.RelationshipModifier,
+					"role_modifier":         result.Factors.RoleModifier,
+				}
+			}
+		}
+
+		summary := rebellion.SummarizeResults(results)
+		c.JSON(http.StatusOK, gin.H{
+			"results":                 entries,
+			"avg_probability":         summary.AvgProbability,
+			"highest_probability_npc": summary.HighestProbabilityNPC,
+			"below_threshold_count":   summary.BelowThresholdCount,
+			"above_threshold_count":   summary.AboveThresholdCount,
+		})
+	})
+
+	// Faction-level rebellion risk for a set of NPCs (?npc_ids=a,b,c)
+	r.GET("/api/rebellion/group-risk", func(c *gin.Context) {
+		var npcIDs []string
+		for _, raw := range c.QueryArray("npc_ids") {
+			npcIDs = append(npcIDs, middleware.ParseCSV(raw)...)
+		}
+		if len(npcIDs) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "npc_ids is required"})
+			return
+		}
+
+		profiles := make([]rebellion.NPCRebellionProfile, 0, len(npcIDs))
+		for _, npcID := range npcIDs {
+			npcBehavior, ok := behaviorEngine.GetNPC(npcID)
+			if !ok {
+				err := &npc.NPCNotFoundError{NpcID: npcID}
+				c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+				return
+			}
+			profiles = append(profiles, rebellion.NPCRebellionProfile{
+				NPCID:          npcBehavior.NPCID,
+				AvgTrauma:      0.0, // Trauma comes from memory graph; default 0 here
+				WorkEfficiency: npcBehavior.WorkEfficiency,
+				Morale:         npcBehavior.Morale,
+				Role:           npcBehavior.Role,
+			})
+		}
+
+		avg, exceeded := rebEngine.AvgGroupProbability(profiles)
+		c.JSON(http.StatusOK, gin.H{
+			"risk_level":      rebellion.RiskLevelFor(exceeded, len(profiles)),
+			"avg_probability": avg,
+			"above_threshold": exceeded,
+			"total":           len(profiles),
+		})
+	})
+
+	// Registered NPCs at or above a rebellion probability (?threshold=,
+	// default HaltThreshold), highest first, capped at ?limit= (0 = all)
+	r.GET("/api/rebellion/high-risk", func(c *gin.Context) {
+		threshold := rebEngine.GetConfig().HaltThreshold
+		if raw := c.Query("threshold"); raw != "" {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil || v < 0 || v > 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "threshold must be a number in [0, 1]"})
+				return
+			}
+			threshold = v
+		}
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+		if err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+			return
+		}
+
+		npcs := behaviorEngine.SnapshotNPCs()
+		profiles := make([]rebellion.NPCRebellionProfile, 0, len(npcs))
+		for _, npcBehavior := range npcs {
+			profiles = append(profiles, rebellion.NPCRebellionProfile{
+				NPCID:          npcBehavior.NPCID,
+				AvgTrauma:      0.0, // Trauma comes from memory graph; default 0 here
+				WorkEfficiency: npcBehavior.WorkEfficiency,
+				Morale:         npcBehavior.Morale,
+				Role:           npcBehavior.Role,
+			})
+		}
+		// Deterministic order among equal probabilities
+		slices.SortFunc(profiles, func(a, b rebellion.NPCRebellionProfile) int { return strings.Compare(a.NPCID, b.NPCID) })
+
+		results := rebEngine.GetHighRiskNPCs(profiles, threshold)
+		if limit > 0 && limit < len(results) {
+			results = results[:limit]
+		}
+		entries := make([]gin.H, len(results))
+		for i, result := range results {
+			entries[i] = gin.H{
+				"npc_id":             result.NPCID,
+				"probability":        result.Probability,
+				"threshold_exceeded": result.ThresholdExceeded,
+				"halt_triggered":     result.HaltTriggered,
+				"veto_triggered":     result.VetoTriggered,
+				"rebellion_type":     result.RebellionType,
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"threshold": threshold,
+			"results":   entries,
+		})
+	})
+
+	// Trend of the simulation's overall rebellion probability over the last
+	// ?lookback= ticks (default 10)
+	r.GET("/api/rebellion/trend", func(c *gin.Context) {
+		lookback, err := strconv.Atoi(c.DefaultQuery("lookback", "10"))
+		if err != nil || lookback < 2 || lookback > simulation.RebellionHistorySize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("lookback must be an integer in [2, %d]", simulation.RebellionHistorySize)})
+			return
+		}
+
+		trend := simEngine.GetRebellionTrend(lookback)
+		c.JSON(http.StatusOK, gin.H{
+			"lookback":        lookback,
+			"direction":       trend.Direction,
+			"change_per_tick": trend.ChangePerTick,
+			"confidence":      trend.Confidence,
+			"samples":         trend.Samples,
+		})
+	})
+
+	// Balance testing: play an NPC profile through repeated actions without
+	// touching live NPCs or engine statistics
+	r.POST("/api/rebellion/simulate", func(c *gin.Context) {
+		var req struct {
+			Initial struct {
+				NPCID          string  `json:"npc_id"`
+				AvgTrauma      float64 `json:"avg_trauma" binding:"min=0,max=1"`
+				WorkEfficiency float64 `json:"work_efficiency" binding:"min=0,max=1"`
+				Morale         float64 `json:"morale" binding:"min=0,max=1"`
+				Role           string  `json:"role"`
+			} `json:"initial" binding:"required"`
+			Actions []struct {
+				ActionType string  `json:"action_type" binding:"required"`
+				Intensity  float64 `json:"intensity" binding:"min=0,max=1"`
+			} `json:"actions" binding:"dive"`
+			Ticks int `json:"ticks" binding:"required,min=1,max=10000"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		effects := rebEngine.ActionEffects()
+		actions := make([]rebellion.NPCAction, len(req.Actions))
+		for i, a := range req.Actions {
+			if _, ok := effects[a.ActionType]; !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("actions[%d]: unknown action type %q", i, a.ActionType)})
+				return
+			}
+			actions[i] = rebellion.NPCAction{NPCID: req.Initial.NPCID, ActionType: a.ActionType, Intensity: a.Intensity}
+		}
+
+		trace := rebEngine.SimulateNPCOverTicks(rebellion.NPCRebellionProfile{
+			NPCID:          req.Initial.NPCID,
+			AvgTrauma:      req.Initial.AvgTrauma,
+			WorkEfficiency: req.Initial.WorkEfficiency,
+			Morale:         req.Initial.Morale,
+			Role:           req.Initial.Role,
+		}, actions, req.Ticks)
+
+		snapshots := make([]gin.H, len(trace.Snapshots))
+		for i, snap := range trace.Snapshots {
+			snapshots[i] = gin.H{
+				"tick":            snap.Tick,
+				"avg_trauma":      snap.Profile.AvgTrauma,
+				"work_efficiency": snap.Profile.WorkEfficiency,
+				"morale":          snap.Profile.Morale,
+				"probability":     snap.Probability,
+				"halt_triggered":  snap.HaltTriggered,
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"snapshots":         snapshots,
+			"first_halt_tick":   trace.FirstHaltTick,
+			"final_probability": trace.FinalProbability,
+		})
+	})
+
+	// Rebellion engine configuration (POST applies a partial update)
+	r.GET("/api/config/rebellion", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"version": rebEngine.GetCurrentConfigVersion(),
+			"config":  rebellionConfigJSON(rebEngine.GetConfig()),
+		})
+	})
+	r.POST("/api/config/rebellion", func(c *gin.Context) {
+		cfg := rebEngine.GetConfig()
+		var req struct {
+			BaseProbability  *float64 `json:"base_probability"`
+			TraumaWeight     *float64 `json:"trauma_weight"`
+			EfficiencyWeight *float64 `json:"efficiency_weight"`
+			MoraleWeight     *float64 `json:"morale_weight"`
+			HaltThreshold    *float64 `json:"halt_threshold"`
+			VetoThreshold    *float64 `json:"veto_threshold"`
+			IdleDecayFactor  *float64 `json:"idle_decay_factor"`
+			MaxDecayTicks    *int64   `json:"max_decay_ticks"`
+
+			MemoryWeightFactor *float64 `json:"memory_weight_factor"`
+
+			// Replace the whole map when present
+			ActionProbabilityFloor   map[string]float64 `json:"action_probability_floor"`
+			ActionProbabilityCeiling map[string]float64 `json:"action_probability_ceiling"`
+			RoleRebellionModifiers   map[string]float64 `json:"role_rebellion_modifiers"`
+
+			ActionEffects map[string]rebellion.ActionEffect `json:"action_effects"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		for _, f := range []struct {
+			src *float64
+			dst *float64
+		}{
+			{req.BaseProbability, &cfg.BaseProbability},
+			{req.TraumaWeight, &cfg.TraumaWeight},
+			{req.EfficiencyWeight, &cfg.EfficiencyWeight},
+			{req.MoraleWeight, &cfg.MoraleWeight},
+			{req.HaltThreshold, &cfg.HaltThreshold},
+			{req.VetoThreshold, &cfg.VetoThreshold},
+			{req.IdleDecayFactor, &cfg.IdleDecayFactor},
+			{req.MemoryWeightFactor, &cfg.MemoryWeightFactor},
+		} {
+			if f.src != nil {
+				*f.dst = *f.src
+			}
+		}
+		if req.MaxDecayTicks != nil {
+			cfg.MaxDecayTicks = *req.MaxDecayTicks
+		}
+		if req.ActionProbabilityFloor != nil {
+			cfg.ActionProbabilityFloor = req.ActionProbabilityFloor
+		}
+		if req.ActionProbabilityCeiling != nil {
+			cfg.ActionProbabilityCeiling = req.ActionProbabilityCeiling
+		}
+		if req.RoleRebellionModifiers != nil {
+			cfg.RoleRebellionModifiers = req.RoleRebellionModifiers
+		}
+		if req.ActionEffects != nil {
+			cfg.ActionEffects = req.ActionEffects
+		}
+
+		if err := rebEngine.UpdateConfig(cfg); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"version": rebEngine.GetCurrentConfigVersion(),
+			"config":  rebellionConfigJSON(rebEngine.GetConfig()),
+		})
+	})
+	r.GET("/api/config/rebellion/history", func(c *gin.Context) {
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+		if err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+			return
+		}
+
+		history := rebEngine.GetConfigHistory(limit)
+		changes := make([]gin.H, len(history))
+		for i, ch := range history {
+			changes[i] = gin.H{
+				"version":        ch.Version,
+				"changed_at":     ch.ChangedAt.UTC().Format(time.RFC3339Nano),
+				"changed_fields": ch.ChangedFields,
+				"old_config":     rebellionConfigJSON(ch.OldConfig),
+				"new_config":     rebellionConfigJSON(ch.NewConfig),
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"current_version": rebEngine.GetCurrentConfigVersion(),
+			"changes":         changes,
+		})
+	})
+
+	// Replace rebellion action effects; body is the action config JSON
+	// ({"bribe": {"morale_delta": 0.2, "trauma_delta": 0, "efficiency_delta": -0.1}, ...})
+	r.POST("/api/config/rebellion/actions", func(c *gin.Context) {
+		data, err := c.GetRawData()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		effects, err := rebellion.ParseActionConfigJSON(data)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := rebEngine.SetActionEffects(effects); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"actions": rebEngine.ActionEffects()})
+	})
+
+	// Webhook target for rebellion halt events (admin)
+	r.POST("/api/config/webhook", adminOnly, func(c *gin.Context) {
+		var req struct {
+			URL        string  `json:"url"`
+			Secret     *string `json:"secret"`
+			RetryCount *int    `json:"retry_count"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Omitted secret/retry_count keep their current values; empty url disables
+		cfg := webhooks.Config()
+		cfg.URL = req.URL
+		if req.Secret != nil {
+			cfg.Secret = *req.Secret
+		}
+		if req.RetryCount != nil {
+			cfg.RetryCount = *req.RetryCount
+		}
+		if err := webhooks.UpdateConfig(cfg); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"url":         cfg.URL,
+			"retry_count": cfg.RetryCount,
+			"signed":      cfg.Secret != "",
+		})
+	})
+
+	// Rebellion engine operational statistics
+	r.GET("/api/rebellion/stats", func(c *gin.Context) {
+		stats := rebEngine.GetEngineStats()
+		c.JSON(http.StatusOK, gin.H{
+			"total_calculations":           stats.TotalCalculations,
+			"total_actions_processed":      stats.TotalActionsProcessed,
+			"halt_triggered_count":         stats.HaltTriggeredCount,
+			"veto_triggered_count":         stats.VetoTriggeredCount,
+			"avg_probability_last_n":       stats.AvgProbabilityLastN,
+			"highest_recorded_probability": stats.HighestRecordedProbability,
+			"most_volatile_npc_id":         stats.MostVolatileNPCID,
+		})
+	})
+
+	// Apply an action to an NPC
+	r.POST("/api/npc/:npcId/action", func(c *gin.Context) {
+		npcID := c.Param("npcId")
+
+		var req struct {
+			ActionType       string  `json:"action_type" binding:"required"`
+			Intensity        float64 `json:"intensity" binding:"required"`
+			SuppressDuration int     `json:"suppress_duration"` // "suppress" only; 0 = engine default
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Auto-register NPC if not exists
+		behaviorEngine.RegisterNPC(npcID)
+
+		// Apply behavioral effects
+		action := rebellion.NPCAction{
+			ActionID:         fmt.Sprintf("act-%d", time.Now().UnixNano()),
+			NPCID:            npcID,
+			ActionType:       req.ActionType,
+			Intensity:        req.Intensity,
+			SuppressDuration: req.SuppressDuration,
+		}
+
+		// Get current profile from behavior engine
+		npcBehavior, _ := behaviorEngine.GetNPC(npcID)
+		profile := rebellion.NPCRebellionProfile{
+			NPCID:          npcBehavior.NPCID,
+			AvgTrauma:      0.0,
+			WorkEfficiency: npcBehavior.WorkEfficiency,
+			Morale:         npcBehavior.Morale,
+			MemoryCount:    0,
+			Role:           npcBehavior.Role,
+		}
+
+		updatedProfile := rebEngine.ProcessAction(profile, action)
+		if req.ActionType == rebellion.SuppressActionType {
+			rebEngine.ApplySuppression(action)
+		}
+
+		// Sync updated values back to behavior engine
+		reason := req.ActionType + " action"
+		_ = behaviorEngine.ApplyWorkEfficiencyModifier(npcID, updatedProfile.WorkEfficiency-npcBehavior.WorkEfficiency, reason)
+		_ = behaviorEngine.ApplyMoraleModifier(npcID, updatedProfile.Morale-npcBehavior.Morale, reason)
+
+		// Calculate new rebellion probability
+		result := rebEngine.CalculateProbability(updatedProfile)
+		_ = behaviorEngine.RecordRebellionProbability(npcID, result.Probability)
+		rebEngine.PublishHalt(result)
+		if result.HaltTriggered {
+			webhooks.NotifyHalt(webhook.HaltEvent{
+				NPCID:       npcID,
+				ActionID:    action.ActionID,
+				ActionType:  req.ActionType,
+				Probability: result.Probability,
+				Timestamp:   time.Now(),
+			})
+		}
+
+		resp := gin.H{
+			"npc_id":      npcID,
+			"action_type": req.ActionType,
+			"updated_state": gin.H{
+				"work_efficiency": updatedProfile.WorkEfficiency,
+				"morale":          updatedProfile.Morale,
+				"avg_trauma":      updatedProfile.AvgTrauma,
+			},
+			"rebellion_probability": result.Probability,
+			"halt_triggered":        result.HaltTriggered,
+			"veto_triggered":        result.VetoTriggered,
+		}
+		if expiresAt, ok := rebEngine.GetActiveSuppression(npcID); ok {
+			resp["suppressed_until_tick"] = expiresAt
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	// NPC groups (squads) for group-wide actions; members must be registered
+	r.POST("/api/npc/groups", func(c *gin.Context) {
+		var req struct {
+			GroupID string   `json:"group_id" binding:"required"`
+			NPCIDs  []string `json:"npc_ids"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := behaviorEngine.CreateGroup(req.GroupID, req.NPCIDs); err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		members, _ := behaviorEngine.GetGroup(req.GroupID)
+		c.JSON(http.StatusOK, gin.H{"group_id": req.GroupID, "npc_ids": members})
+	})
+
+	r.POST("/api/npc/groups/:groupId/action", func(c *gin.Context) {
+		groupID := c.Param("groupId")
+		var req struct {
+			ActionType string  `json:"action_type" binding:"required"`
+			Intensity  float64 `json:"intensity" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		failures, err := behaviorEngine.ApplyGroupAction(groupID, req.ActionType, req.Intensity)
+		if err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+
+		// Member stats changed outside ProcessAction, so drop cached probabilities
+		members, _ := behaviorEngine.GetGroup(groupID)
+		for _, id := range members {
+			rebEngine.InvalidateCache(id)
+		}
+
+		failed := make(map[string]string, len(failures))
+		for id, ferr := range failures {
+			failed[id] = ferr.Error()
+		}
+		avgMorale, _ := behaviorEngine.GetGroupAverageMorale(groupID)
+		c.JSON(http.StatusOK, gin.H{
+			"group_id":    groupID,
+			"action_type": req.ActionType,
+			"applied":     len(members) - len(failures),
+			"failed":      failed,
+			"avg_morale":  avgMorale,
+		})
+	})
+
+	// Bulk NPC export (?format=csv, the default, or json)
+	r.GET("/api/npc/export", func(c *gin.Context) {
+		switch format := c.DefaultQuery("format", "csv"); format {
+		case "csv":
+			data, err := behaviorEngine.ExportNPCsCSV()
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.Header("Content-Disposition", `attachment; filename="npcs.csv"`)
+			c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+		case "json":
+			npcs := behaviorEngine.SnapshotNPCs()
+			out := make([]gin.H, 0, len(npcs))
+			for _, n := range npcs {
+				out = append(out, npcJSON(n))
+			}
+			c.JSON(http.StatusOK, gin.H{"npcs": out})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported format %q (want csv or json)", format)})
+		}
+	})
+
+	// Bulk NPC import; body is CSV (text/csv) or {"npcs": [...]} (application/json).
+	// Existing NPCs are skipped unless ?overwrite=true.
+	r.POST("/api/npc/import", func(c *gin.Context) {
+		overwrite := c.Query("overwrite") == "true"
+
+		var result npc.ImportResult
+		switch c.ContentType() {
+		case "text/csv":
+			data, err := c.GetRawData()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			result, err = behaviorEngine.ImportNPCsCSV(data, overwrite)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		case "application/json":
+			var req struct {
+				NPCs []struct {
+					NPCID          string  `json:"npc_id"`
+					Role           string  `json:"role"`
+					WorkEfficiency float64 `json:"work_efficiency"`
+					Morale         float64 `json:"morale"`
+					AvgTrauma      float64 `json:"avg_trauma"`
+					Confidence     float64 `json:"confidence"`
+					AssignedTask   string  `json:"assigned_task"`
+				} `json:"npcs" binding:"required"`
+			}
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			npcs := make([]*npc.NPCBehavior, 0, len(req.NPCs))
+			for _, n := range req.NPCs {
+				npcs = append(npcs, &npc.NPCBehavior{
+					NPCID:          n.NPCID,
+					Role:           n.Role,
+					WorkEfficiency: n.WorkEfficiency,
+					Morale:         n.Morale,
+					AvgTrauma:      n.AvgTrauma,
+					Confidence:     n.Confidence,
+					AssignedTask:   n.AssignedTask,
+				})
+			}
+			result = behaviorEngine.ImportNPCs(npcs, overwrite)
+		default:
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be text/csv or application/json"})
+			return
+		}
+
+		errs := result.Errors
+		if errs == nil {
+			errs = []string{}
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"imported": result.Imported,
+			"skipped":  result.Skipped,
+			"errors":   errs,
+		})
+	})
+
+	// Audit trail of the NPC's most recent attribute changes, oldest first
+	r.GET("/api/npc/:npcId/history", func(c *gin.Context) {
+		npcID := c.Param("npcId")
+		history, ok := behaviorEngine.GetNPCStatHistory(npcID)
+		if !ok {
+			err := &npc.NPCNotFoundError{NpcID: npcID}
+			c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+			return
+		}
+		changes := make([]gin.H, 0, len(history))
+		for _, ch := range history {
+			changes = append(changes, gin.H{
+				"attribute":  ch.Attribute,
+				"old_value":  ch.OldValue,
+				"new_value":  ch.NewValue,
+				"changed_at": ch.ChangedAt.UTC().Format(time.RFC3339Nano),
+				"reason":     ch.Reason,
+			})
+		}
+		c.JSON(http.StatusOK, gin.H{"npc_id": npcID, "history": changes})
+	})
+
+	// Post-action rebellion probabilities of the NPC, oldest first
+	r.GET("/api/npc/:npcId/probability-history", func(c *gin.Context) {
+		npcID := c.Param("npcId")
+		history, err := behaviorEngine.GetProbabilityHistory(npcID)
+		if err != nil {
+			c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"npc_id": npcID, "probabilities": history})
+	})
+
+	// NPC relationships (symmetric affinity in [-1, 1]); they feed the
+	// relationship modifier of rebellion probability
+	r.GET("/api/npc/:npcId/relationships", func(c *gin.Context) {
+		npcID := c.Param("npcId")
+		if _, ok := behaviorEngine.GetNPC(npcID); !ok {
+			err := &npc.NPCNotFoundError{NpcID: npcID}
+			c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"npc_id": npcID, "relationships": behaviorEngine.GetRelationships(npcID)})
+	})
+
+	r.POST("/api/npc/:npcId/relationships", func(c *gin.Context) {
+		npcID := c.Param("npcId")
+		var req struct {
+			PeerID   string   `json:"peer_id" binding:"required"`
+			Affinity *float64 `json:"affinity" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := behaviorEngine.SetRelationship(npcID, req.PeerID, *req.Affinity); err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"npc_id": npcID, "relationships": behaviorEngine.GetRelationships(npcID)})
+	})
+
+	r.DELETE("/api/npc/:npcId/relationships/:peerId", func(c *gin.Context) {
+		npcID := c.Param("npcId")
+		behaviorEngine.RemoveRelationship(npcID, c.Param("peerId"))
+		c.JSON(http.StatusOK, gin.H{"npc_id": npcID, "relationships": behaviorEngine.GetRelationships(npcID)})
+	})
+
+	// Mental breakdown recovery: rolls against the pending breakdown's
+	// recovery probability and clears it on success
+	r.POST("/api/npc/:npcId/recover", func(c *gin.Context) {
+		npcID := c.Param("npcId")
+		recovered, err := behaviorEngine.AttemptBreakdownRecovery(npcID)
+		if err != nil {
+			c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+			return
+		}
+
+		var pending gin.H
+		if npcBehavior, ok := behaviorEngine.GetNPC(npcID); ok && npcBehavior.PendingBreakdown != nil {
+			record := npcBehavior.PendingBreakdown
+			pending = gin.H{
+				"event_id":             record.EventID,
+				"breakdown_type":       record.BreakdownType.String(),
+				"occurred_at":          record.OccurredAt.UTC().Format(time.RFC3339Nano),
+				"recovery_probability": record.RecoveryProbability,
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"npc_id":            npcID,
+			"recovered":         recovered,
+			"pending_breakdown": pending,
+		})
+	})
+
+	// Register NPC with role (testing convenience)
+	r.POST("/api/npc/:npcId/register", func(c *gin.Context) {
+		npcID := c.Param("npcId")
+		var req struct {
+			Role string `json:"role"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			req.Role = "worker"
+		}
+		if req.Role == "" {
+			req.Role = "worker"
+		}
+
+		npcBehavior := behaviorEngine.RegisterNPCWithRole(npcID, req.Role)
+		c.JSON(http.StatusOK, gin.H{
+			"npc_id":          npcBehavior.NPCID,
+			"role":            npcBehavior.Role,
+			"work_efficiency": npcBehavior.WorkEfficiency,
+			"morale":          npcBehavior.Morale,
+			"emotional_state": npcBehavior.EmotionalState,
+		})
+	})
+
+	// Deploy Sheriff Protocol cleansing operation
+	r.POST("/api/cleansing/deploy", func(c *gin.Context) {
+		infState := simEngine.GetInfestationState()
+
+		// Gather warriors and guards
+		warriors := behaviorEngine.GetNPCsByRole("warrior")
+		guards := behaviorEngine.GetNPCsByRole("guard")
+
+		participants := make([]cleansing.CleansingParticipant, 0, len(warriors)+len(guards))
+		for _, w := range warriors {
+			participants = append(participants, cleansing.CleansingParticipant{
+				NPCID:      w.NPCID,
+				Role:       w.Role,
+				AvgTrauma:  1.0 - w.Morale,
+				Morale:     w.Morale,
+				Confidence: w.Morale,
+			})
+		}
+		for _, g := range guards {
+			participants = append(participants, cleansing.CleansingParticipant{
+				NPCID:      g.NPCID,
+				Role:       g.Role,
+				AvgTrauma:  1.0 - g.Morale,
+				Morale:     g.Morale,
+				Confidence: g.Morale,
+			})
+		}
+
+		// Execute rejects inactive Plague Heart (409) and invalid or too few participants (422)
+		result, err := cleansingEngine.Execute(c.Request.Context(), participants, infState.IsPlagueHeart)
+		var invalid *cleansing.ParticipantValidationError
+		if errors.As(err, &invalid) {
+			validationErrors := make([]gin.H, len(invalid.Errors))
+			for i, v := range invalid.Errors {
+				validationErrors[i] = gin.H{"npc_id": v.NPCID, "field": v.Field, "reason": v.Reason}
+			}
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"success":           false,
+				"error_message":     err.Error(),
+				"validation_errors": validationErrors,
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{
+				"success":       false,
+				"error_message": err.Error(),
+			})
+			return
+		}
+
+		// On success: cleanse the infestation
+		if result.Success {
+			if infEngine := simEngine.GetInfestationEngine(); infEngine != nil {
+				_ = infEngine.Cleanse()
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success":           result.Success,
+			"success_rate":      result.SuccessRate,
+			"participant_count": result.ParticipantCount,
+			"participant_ids":   result.Participants,
+			"rolled_value":      result.RolledValue,
+			"factors": gin.H{
+				"base":                     result.Factors.BaseFactor,
+				"avg_morale":               result.Factors.AvgMorale,
+				"morale_contribution":      result.Factors.MoraleContrib,
+				"avg_trauma":               result.Factors.AvgTrauma,
+				"trauma_penalty":           result.Factors.TraumaPenalty,
+				"avg_confidence":           result.Factors.AvgConfidence,
+				"confidence_contribution":  result.Factors.ConfidenceContrib,
+				"size_bonus":               result.Factors.SizeBonus,
+			},
+		})
+	})
+
+	// Admin override: force the infestation counter (scenario injection / testing)
+	r.POST("/api/infestation/admin/set-counter", adminOnly, func(c *gin.Context) {
+		var req struct {
+			Counter *float64 `json:"counter" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		infEngine := simEngine.GetInfestationEngine()
+		if infEngine == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "infestation engine not initialized"})
+			return
+		}
+		if err := infEngine.ForceSetCounter(*req.Counter); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		state := infEngine.GetState()
+		c.JSON(http.StatusOK, gin.H{
+			"counter":             state.Counter,
+			"is_plague_heart":     state.IsPlagueHeart,
+			"throttle_multiplier": state.ThrottleMultiplier,
+		})
+	})
+
+	// Update infestation config at runtime (admin); the counter is preserved
+	r.POST("/api/infestation/config", adminOnly, func(c *gin.Context) {
+		infEngine := simEngine.GetInfestationEngine()
+		if infEngine == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "infestation engine not initialized"})
+			return
+		}
+
+		cfg := infEngine.GetConfig()
+		var req struct {
+			AccumulationRate     *float64 `json:"accumulation_rate"`
+			DecayRate            *float64 `json:"decay_rate"`
+			PlagueHeartThreshold *float64 `json:"plague_heart_threshold"`
+			ClearThreshold       *float64 `json:"clear_threshold"`
+			ThrottleAmount       *float64 `json:"throttle_amount"`
+			RebellionTrigger     *float64 `json:"rebellion_trigger"`
+			TraumaTrigger        *float64 `json:"trauma_trigger"`
+			SiegeModeThreshold   *float64 `json:"siege_mode_threshold"`
+			SiegeModeMultiplier  *float64 `json:"siege_mode_multiplier"`
+			EscalationStages     *[]struct {
+				DurationTicks               int64   `json:"duration_ticks"`
+				AdditionalThrottleReduction float64 `json:"additional_throttle_reduction"`
+			} `json:"escalation_stages"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.AccumulationRate != nil {
+			cfg.AccumulationRate = *req.AccumulationRate
+		}
+		if req.DecayRate != nil {
+			cfg.DecayRate = *req.DecayRate
+		}
+		if req.PlagueHeartThreshold != nil {
+			cfg.PlagueHeartThreshold = *req.PlagueHeartThreshold
+		}
+		if req.ClearThreshold != nil {
+			cfg.ClearThreshold = *req.ClearThreshold
+		}
+		if req.ThrottleAmount != nil {
+			cfg.ThrottleAmount = *req.ThrottleAmount
+		}
+		if req.RebellionTrigger != nil {
+			cfg.RebellionTrigger = *req.RebellionTrigger
+		}
+		if req.TraumaTrigger != nil {
+			cfg.TraumaTrigger = *req.TraumaTrigger
+		}
+		if req.SiegeModeThreshold != nil {
+			cfg.SiegeModeThreshold = *req.SiegeModeThreshold
+		}
+		if req.SiegeModeMultiplier != nil {
+			cfg.SiegeModeMultiplier = *req.SiegeModeMultiplier
+		}
+		if req.EscalationStages != nil {
+			cfg.EscalationStages = make([]infestation.EscalationStage, len(*req.EscalationStages))
+			for i, stage := range *req.EscalationStages {
+				cfg.EscalationStages[i] = infestation.EscalationStage{
+					DurationTicks:               stage.DurationTicks,
+					AdditionalThrottleReduction: stage.AdditionalThrottleReduction,
+				}
+			}
+		}
+
+		if err := infEngine.UpdateConfig(cfg); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		stages := make([]gin.H, len(cfg.EscalationStages))
+		for i, stage := range cfg.EscalationStages {
+			stages[i] = gin.H{
+				"duration_ticks":                stage.DurationTicks,
+				"additional_throttle_reduction": stage.AdditionalThrottleReduction,
+			}
+		}
+		state := infEngine.GetState()
+		c.JSON(http.StatusOK, gin.H{
+			"config": gin.H{
+				"accumulation_rate":      cfg.AccumulationRate,
+				"decay_rate":             cfg.DecayRate,
+				"plague_heart_threshold": cfg.PlagueHeartThreshold,
+				"clear_threshold":        cfg.ClearThreshold,
+				"throttle_amount":        cfg.ThrottleAmount,
+				"rebellion_trigger":      cfg.RebellionTrigger,
+				"trauma_trigger":         cfg.TraumaTrigger,
+				"siege_mode_threshold":   cfg.SiegeModeThreshold,
+				"siege_mode_multiplier":  cfg.SiegeModeMultiplier,
+				"escalation_stages":      stages,
+			},
+			"counter":                   state.Counter,
+			"is_plague_heart":           state.IsPlagueHeart,
+			"throttle_multiplier":       state.ThrottleMultiplier,
+			"plague_heart_activated_at": state.PlagueHeartActivatedAt,
+			"escalation_level":          state.EscalationLevel,
+			"siege_mode_active":         state.SiegeModeActive,
+		})
+	})
+
+	// Admin override: set gRPC health status for a service ("" = overall)
+	r.POST("/api/admin/health", adminOnly, func(c *gin.Context) {
+		var req struct {
+			Service string `json:"service"`
+			Status  string `json:"status" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		statusValue, ok := healthpb.HealthCheckResponse_ServingStatus_value[req.Status]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown health status %q", req.Status)})
+			return
+		}
+		known := req.Service == ""
+		for _, name := range grpcserver.ServiceNames() {
+			known = known || name == req.Service
+		}
+		if !known {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("unknown service %q", req.Service)})
+			return
+		}
+
+		grpcSrv.SetServiceHealth(req.Service, healthpb.HealthCheckResponse_ServingStatus(statusValue))
+		c.JSON(http.StatusOK, gin.H{"service": req.Service, "status": req.Status})
+	})
+
+	// Project infestation outcomes for hypothetical rebellion/trauma scenarios
+	r.POST("/api/infestation/project", func(c *gin.Context) {
+		var req struct {
+			Ticks     int `json:"ticks" binding:"required,min=1,max=10000"`
+			Scenarios []struct {
+				Name         string  `json:"name" binding:"required"`
+				AvgRebellion float64 `json:"avg_rebellion" binding:"min=0,max=1"`
+				AvgTrauma    float64 `json:"avg_trauma" binding:"min=0,max=1"`
+			} `json:"scenarios" binding:"required,min=1,dive"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		infEngine := simEngine.GetInfestationEngine()
+		if infEngine == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "infestation engine not initialized"})
+			return
+		}
+
+		scenarios := make([]infestation.InfestationScenario, len(req.Scenarios))
+		for i, sc := range req.Scenarios {
+			scenarios[i] = infestation.InfestationScenario{
+				Name:         sc.Name,
+				AvgRebellion: sc.AvgRebellion,
+				AvgTrauma:    sc.AvgTrauma,
+			}
+		}
+
+		projections := infestation.ProjectInfestationScenarios(
+			infEngine.GetConfig(), infEngine.GetState(), scenarios, req.Ticks,
+		)
+		results := make([]gin.H, len(projections))
+		for i, p := range projections {
+			results[i] = gin.H{
+				"scenario_name":          p.ScenarioName,
+				"final_counter":          p.FinalCounter,
+				"plague_heart_activates": p.PlagueHeartActivates,
+				"tick_of_activation":     p.TickOfActivation,
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{"ticks": req.Ticks, "projections": results})
+	})
+
+	// Get resource prices
+	r.GET("/api/economy/prices", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"prices": economyPricesJSON(econEngine)})
+	})
+
+	// Advance the market one tick (applies and expires price shocks)
+	r.POST("/api/economy/tick", func(c *gin.Context) {
+		econEngine.EconomyTick()
+		c.JSON(http.StatusOK, gin.H{"prices": economyPricesJSON(econEngine)})
+	})
+
+	// Temporary price multipliers for scripted narrative events
+	r.GET("/api/economy/price-shock", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"shocks": priceShocksJSON(econEngine.GetActiveShocks())})
+	})
+
+	r.POST("/api/economy/price-shock", func(c *gin.Context) {
+		var req struct {
+			Resource       string  `json:"resource" binding:"required"`
+			BuyMultiplier  float64 `json:"buy_multiplier" binding:"required"`
+			SellMultiplier float64 `json:"sell_multiplier" binding:"required"`
+			DurationTicks  int64   `json:"duration_ticks" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		rt, err := economy.ParseResourceType(req.Resource)
+		if err == nil {
+			err = econEngine.ApplyPriceShock(rt, req.BuyMultiplier, req.SellMultiplier, req.DurationTicks)
+		}
+		if err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"shocks": priceShocksJSON(econEngine.GetActiveShocks())})
+	})
+
+	// Market overview: current prices, 24h average, trend and trade volume
+	r.GET("/api/economy/report", func(c *gin.Context) {
+		report := econEngine.GenerateMarketReport()
+		resources := make(map[string]gin.H, len(report.Resources))
+		for rt, rr := range report.Resources {
+			resources[string(rt)] = gin.H{
+				"current_buy":        rr.CurrentBuy,
+				"current_sell":       rr.CurrentSell,
+				"avg_sell_24h":       rr.AvgSell24h,
+				"trend":              rr.Trend,
+				"last_trade_value":   rr.LastTradeValue,
+				"total_units_traded": rr.TotalUnitsTraded,
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"resources":    resources,
+			"generated_at": report.GeneratedAt.UTC().Format(time.RFC3339Nano),
+		})
+	})
+
+	// Admin supply injection/removal (events, debugging); :resource is sim, rapidlum or mineral
+	r.POST("/api/simulation/resources/:resource/add", adminOnly, func(c *gin.Context) {
+		rt, err := simulation.ParseResourceType(c.Param("resource"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Amount float64 `json:"amount" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := simEngine.AddResource(rt, req.Amount); err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"resource": rt, "quantity": simEngine.GetStatus().Resources[rt].Quantity})
+	})
+
+	r.POST("/api/simulation/resources/:resource/subtract", adminOnly, func(c *gin.Context) {
+		rt, err := simulation.ParseResourceType(c.Param("resource"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req struct {
+			Amount       float64 `json:"amount" binding:"required"`
+			AllowPartial bool    `json:"allow_partial"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := simEngine.SubtractResource(rt, req.Amount, req.AllowPartial); err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"resource": rt, "quantity": simEngine.GetStatus().Resources[rt].Quantity})
+	})
+
+	// Trade with the market: sells deduct from, buys add to, the primary engine
+	r.POST("/api/economy/trade", func(c *gin.Context) {
+		var req struct {
+			Resource string  `json:"resource" binding:"required"`
+			Quantity float64 `json:"quantity" binding:"required"`
+			IsBuy    bool    `json:"is_buy"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		record, err := simulation.ExecuteTrade(simEngine, econEngine, req.Resource, req.Quantity, req.IsBuy)
+		if err != nil {
+			c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, tradeRecordJSON(record))
+	})
+
+	// Trade ledger (newest first)
+	r.GET("/api/economy/ledger", func(c *gin.Context) {
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+		if err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+			return
+		}
+
+		ledger := econEngine.GetLedger(limit)
+		trades := make([]gin.H, 0, len(ledger))
+		for _, rec := range ledger {
+			trades = append(trades, tradeRecordJSON(rec))
+		}
+		c.JSON(http.StatusOK, gin.H{"trades": trades})
+	})
+
+	// Commodity index weighted by current production, plus recent index history
+	r.GET("/api/economy/index", func(c *gin.Context) {
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+		if err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+			return
+		}
+
+		if err := simulation.SyncProductionVolumes(simEngine, econEngine); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		index := econEngine.GetCommodityIndex()
+		records := econEngine.GetIndexHistory(limit)
+		history := make([]gin.H, len(records))
+		for i, rec := range records {
+			history[i] = gin.H{"tick": rec.Tick, "index": rec.Index}
+		}
+		c.JSON(http.StatusOK, gin.H{"index": index, "history": history})
+	})
+
+	// Market stability: how far prices sit from equilibrium after shocks
+	r.GET("/api/economy/stability", func(c *gin.Context) {
+		resources := gin.H{}
+		for _, rt := range []economy.ResourceType{economy.ResourceSim, economy.ResourceRapidlum, economy.ResourceMineral} {
+			resources[string(rt)] = gin.H{
+				"current_deviation": econEngine.GetCurrentDeviation(rt),
+				"recovery_progress": econEngine.GetRecoveryProgress(rt),
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"stability":           econEngine.GetMarketStability(),
+			"price_recovery_rate": econEngine.GetConfig().PriceRecoveryRate,
+			"resources":           resources,
+		})
+	})
+
+	// Trade plan analysis at current prices; nothing is recorded
+	r.POST("/api/economy/analyze-trade-plan", func(c *gin.Context) {
+		var req struct {
+			Plan []struct {
+				Resource string  `json:"resource" binding:"required"`
+				Quantity float64 `json:"quantity" binding:"required"`
+				IsBuy    bool    `json:"is_buy"`
+			} `json:"plan" binding:"required,dive"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		plan := make([]economy.TradePlanEntry, 0, len(req.Plan))
+		for i, entry := range req.Plan {
+			rt, err := economy.ParseResourceType(entry.Resource)
+			if err != nil {
+				c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": fmt.Sprintf("plan[%d]: %v", i, err)})
+				return
+			}
+			if entry.Quantity <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("plan[%d]: quantity must be positive, got %v", i, entry.Quantity)})
+				return
+			}
+			plan = append(plan, economy.TradePlanEntry{ResourceType: rt, Quantity: entry.Quantity, IsBuy: entry.IsBuy})
+		}
+
+		analysis := econEngine.AnalyzeTradePlan(plan)
+		breakeven := make(map[string]float64, len(analysis.BreakevenQuantities))
+		for rt, qty := range analysis.BreakevenQuantities {
+			breakeven[string(rt)] = qty
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"total_cost":           analysis.TotalCost,
+			"total_revenue":        analysis.TotalRevenue,
+			"net_profit":           analysis.NetProfit,
+			"resources_affected":   analysis.ResourcesAffected,
+			"bottleneck_resource":  analysis.BottleneckResource,
+			"breakeven_quantities": breakeven,
+		})
+	})
+
+	// Telemetry export for log aggregators (?format=ndjson|json&min_severity=WARNING&npc_ids=a,b)
+	r.GET("/api/telemetry/export", func(c *gin.Context) {
+		filter := &pb.TelemetryFilter{
+			IncludeStateChanges:     true,
+			IncludeMentalBreakdowns: true,
+			IncludePermanentTraumas: true,
+		}
+		if raw := c.Query("min_severity"); raw != "" {
+			sev, err := grpcserver.ParseTelemetrySeverity(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			filter.MinSeverity = sev
+		}
+		for _, raw := range c.QueryArray("npc_ids") {
+			filter.NpcIds = append(filter.NpcIds, middleware.ParseCSV(raw)...)
+		}
+
+		switch format := c.DefaultQuery("format", "ndjson"); format {
+		case "ndjson":
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+			if _, err := grpcSrv.TelemetrySvc.ExportNDJSON(c.Writer, filter); err != nil {
+				log.Printf("[Telemetry] %sNDJSON export failed: %v", tracing.LogPrefix(c.Request.Context()), err)
+			}
+		case "json":
+			c.Header("Content-Type", "application/json; charset=utf-8")
+			c.Status(http.StatusOK)
+			if _, err := grpcSrv.TelemetrySvc.ExportJSON(c.Writer, filter); err != nil {
+				log.Printf("[Telemetry] %sJSON export failed: %v", tracing.LogPrefix(c.Request.Context()), err)
+			}
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("format must be ndjson or json, got %q", format)})
+		}
+	})
+
+	// Telemetry events caused by the same game action
+	r.GET("/api/telemetry/correlation/:id", func(c *gin.Context) {
+		id := c.Param("id")
+		events := grpcSrv.TelemetrySvc.GetEventsByCorrelation(id)
+		c.JSON(http.StatusOK, gin.H{
+			"correlation_id": id,
+			"count":          len(events),
+			"events":         grpcserver.ExportRecords(events),
+		})
+	})
+
+	// Graceful shutdown
+	addr := fmt.Sprintf(":%s", port)
+	srv := &http.Server{
+		Addr:         addr,
+		Handler:      r,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+		IdleTimeout:  idleTimeout,
+	}
+
+	go func() {
+		log.Printf("[Logistics] Golang backend online — port %s", port)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("[Logistics] Shutting down gracefully...")
+	appCancel()
+
+	// Tell load balancers to drain before anything stops
+	grpcSrv.SetAllServicesHealth(healthpb.HealthCheckResponse_NOT_SERVING)
 
 	// Stop gRPC server first (non-blocking graceful stop)
 	grpcSrv.Stop()
 
+	// Let in-flight webhook deliveries finish
+	webhooks.Wait()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -332,3 +2295,453 @@ func main() {
 	}
 	log.Println("[Logistics] Server exited cleanly")
 }
+
+// simulationConfigJSON renders a SimulationConfig with snake_case keys.
+func simulationConfigJSON(cfg simulation.SimulationConfig) gin.H {
+	decay := make(map[string]float64, len(cfg.ResourceDecayRate))
+	for rt, rate := range cfg.ResourceDecayRate {
+		decay[string(rt)] = rate
+	}
+	return gin.H{
+		"base_sim_production":               cfg.BaseSimProduction,
+		"refinery_mineral_consumption_base": cfg.RefineryMineralConsumptionBase,
+		"refinery_rapidlum_production_base": cfg.RefineryRapidlumProductionBase,
+		"low_morale_threshold":              cfg.LowMoraleThreshold,
+		"npc_morale_recovery_rate":          cfg.NPCMoraleRecoveryRate,
+		"npc_trauma_decay_rate":             cfg.NPCTraumaDecayRate,
+		"world_aging_rate":                  cfg.WorldAgingRate,
+		"resource_decay_rate":               decay,
+		"efficiency_aggregation":            cfg.EfficiencyAggregation,
+		"role_efficiency_weights":           nonNilBounds(cfg.RoleEfficiencyWeights),
+	}
+}
+
+// rebellionConfigJSON renders a RebellionConfig with snake_case keys.
+func rebellionConfigJSON(cfg rebellion.RebellionConfig) gin.H {
+	actionEffects := cfg.ActionEffects
+	if actionEffects == nil {
+		actionEffects = rebellion.DefaultActionEffects()
+	}
+	return gin.H{
+		"base_probability":  cfg.BaseProbability,
+		"trauma_weight":     cfg.TraumaWeight,
+		"efficiency_weight": cfg.EfficiencyWeight,
+		"morale_weight":     cfg.MoraleWeight,
+		"halt_threshold":    cfg.HaltThreshold,
+		"veto_threshold":    cfg.VetoThreshold,
+		"idle_decay_factor": cfg.IdleDecayFactor,
+		"max_decay_ticks":   cfg.MaxDecayTicks,
+
+		"memory_weight_factor": cfg.MemoryWeightFactor,
+
+		"action_probability_floor":   nonNilBounds(cfg.ActionProbabilityFloor),
+		"action_probability_ceiling": nonNilBounds(cfg.ActionProbabilityCeiling),
+		"role_rebellion_modifiers":   nonNilBounds(cfg.RoleRebellionModifiers),
+
+		"action_effects": actionEffects,
+	}
+}
+
+// nonNilBounds returns bounds, or an empty map if it is nil, so it renders
+// as {} rather than null.
+func nonNilBounds(bounds map[string]float64) map[string]float64 {
+	if bounds == nil {
+		return map[string]float64{}
+	}
+	return bounds
+}
+
+// errorStatus maps typed domain errors to HTTP status codes, returning
+// fallback for errors without a specific mapping.
+func errorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, npc.ErrNPCNotFound),
+		errors.Is(err, npc.ErrGroupNotFound),
+		errors.Is(err, rebellion.ErrNPCNotFound),
+		errors.Is(err, simulation.ErrInfrastructureNotFound),
+		errors.Is(err, simulation.ErrNPCNotAssigned):
+		return http.StatusNotFound
+	case errors.Is(err, simulation.ErrInsufficientResource),
+		errors.Is(err, npc.ErrNoPendingBreakdown),
+		errors.Is(err, simulation.ErrAlreadyPaused),
+		errors.Is(err, simulation.ErrNotPaused),
+		errors.Is(err, economy.ErrTradeLimitExceeded),
+		errors.Is(err, infestation.ErrPlagueHeartNotActive),
+		errors.Is(err, cleansing.ErrPlagueHeartNotActive):
+		return http.StatusConflict
+	case errors.Is(err, cleansing.ErrInsufficientParticipants),
+		errors.Is(err, cleansing.ErrInvalidParticipants):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, economy.ErrUnknownResource):
+		return http.StatusBadRequest
+	default:
+		return fallback
+	}
+}
+
+// economyPricesJSON renders the current buy and sell price of each resource.
+func economyPricesJSON(e *economy.EconomyEngine) gin.H {
+	prices := gin.H{}
+	for _, rt := range []economy.ResourceType{economy.ResourceSim, economy.ResourceRapidlum, economy.ResourceMineral} {
+		if p, ok := e.GetPrice(rt); ok {
+			prices[string(rt)] = gin.H{
+				"buy_price":  p.BuyPrice,
+				"sell_price": p.SellPrice,
+			}
+		}
+	}
+	return prices
+}
+
+// priceShocksJSON renders active price shocks for REST responses.
+func priceShocksJSON(shocks []economy.ActiveShock) []gin.H {
+	out := make([]gin.H, len(shocks))
+	for i, shock := range shocks {
+		out[i] = gin.H{
+			"resource":        shock.Resource,
+			"buy_multiplier":  shock.BuyMultiplier,
+			"sell_multiplier": shock.SellMultiplier,
+			"remaining_ticks": shock.RemainingTicks,
+		}
+	}
+	return out
+}
+
+// tradeRecordJSON renders an economy.TradeRecord for REST responses.
+func tradeRecordJSON(rec economy.TradeRecord) gin.H {
+	return gin.H{
+		"id":          rec.ID,
+		"resource":    rec.Resource,
+		"quantity":    rec.Quantity,
+		"unit_price":  rec.UnitPrice,
+		"total_value": rec.TotalValue,
+		"is_buy":      rec.IsBuy,
+		"timestamp":   rec.Timestamp.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// simulationStatusJSON renders a simulation status with snake_case keys.
+func simulationStatusJSON(status simulation.SimulationStatus) gin.H {
+	resources := make(map[string]gin.H)
+	for rType, rState := range status.Resources {
+		resources[string(rType)] = gin.H{
+			"quantity":         rState.Quantity,
+			"production_rate":  rState.ProductionRate,
+			"consumption_rate": rState.ConsumptionRate,
+		}
+	}
+
+	return gin.H{
+		"refineries":             status.Refineries,
+		"mines":                  status.Mines,
+		"resources":              resources,
+		"overall_rebellion_prob": status.OverallRebellionProb,
+		"active_npcs":            status.ActiveNPCs,
+		"tick_count":             status.TickCount,
+		"infestation_level":      status.InfestationLevel,
+		"is_plague_heart":        status.IsPlagueHeart,
+		"throttle_multiplier":    status.ThrottleMultiplier,
+		"is_paused":              status.IsPaused,
+		"skipped_ticks":          status.SkippedTicks,
+		"world_age_multiplier":   status.WorldAgeMultiplier,
+		"npc_stats": gin.H{
+			"avg_morale":                status.AvgNPCMorale,
+			"avg_efficiency":            status.AvgNPCEfficiency,
+			"avg_trauma":                status.AvgNPCTrauma,
+			"below_morale_threshold":    status.NPCsBelowMoraleThreshold,
+			"above_rebellion_threshold": status.NPCsAboveRebellionThreshold,
+		},
+	}
+}
+
+// simulationEventJSON renders a simulation event with snake_case keys.
+func simulationEventJSON(ev simulation.SimulationEvent) gin.H {
+	entry := gin.H{
+		"sequence":  ev.Sequence,
+		"type":      ev.Type,
+		"target_id": ev.TargetID,
+		"value":     ev.Value,
+		"timestamp": ev.Timestamp.UTC().Format(time.RFC3339Nano),
+	}
+	if ev.FacilityType != "" {
+		entry["facility_type"] = ev.FacilityType
+	}
+	if ev.Reserve != 0 {
+		entry["reserve"] = ev.Reserve
+	}
+	return entry
+}
+
+// bindSimulationEvents parses a {"events": [...]} body in the layout produced
+// by simulationEventJSON, writing a 400 response and returning false on error.
+func bindSimulationEvents(c *gin.Context) ([]simulation.SimulationEvent, bool) {
+	var req struct {
+		Events []struct {
+			Sequence     int64     `json:"sequence"`
+			Type         string    `json:"type"`
+			TargetID     string    `json:"target_id"`
+			Value        float64   `json:"value"`
+			FacilityType string    `json:"facility_type"`
+			Reserve      float64   `json:"reserve"`
+			Timestamp    time.Time `json:"timestamp"`
+		} `json:"events" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	events := make([]simulation.SimulationEvent, len(req.Events))
+	for i, ev := range req.Events {
+		events[i] = simulation.SimulationEvent{
+			Sequence:     ev.Sequence,
+			Type:         simulation.SimulationEventType(ev.Type),
+			TargetID:     ev.TargetID,
+			Value:        ev.Value,
+			FacilityType: ev.FacilityType,
+			Reserve:      ev.Reserve,
+			Timestamp:    ev.Timestamp,
+		}
+	}
+	return events, true
+}
+
+// bindSimulationStatusPair parses a {"before": {...}, "after": {...}} body of
+// statuses in the layout produced by simulationStatusJSON, writing a 400
+// response and returning false on error.
+func bindSimulationStatusPair(c *gin.Context) (before, after simulation.SimulationStatus, ok bool) {
+	type snapshot struct {
+		Mines              int     `json:"mines"`
+		Refineries         int     `json:"refineries"`
+		TickCount          int64   `json:"tick_count"`
+		InfestationLevel   float64 `json:"infestation_level"`
+		ThrottleMultiplier float64 `json:"throttle_multiplier"`
+		Resources          map[string]struct {
+			Quantity        float64 `json:"quantity"`
+			ProductionRate  float64 `json:"production_rate"`
+			ConsumptionRate float64 `json:"consumption_rate"`
+		} `json:"resources"`
+	}
+	var req struct {
+		Before *snapshot `json:"before" binding:"required"`
+		After  *snapshot `json:"after" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return before, after, false
+	}
+
+	toStatus := func(snap *snapshot) (simulation.SimulationStatus, error) {
+		status := simulation.SimulationStatus{
+			Mines:              snap.Mines,
+			Refineries:         snap.Refineries,
+			TickCount:          snap.TickCount,
+			InfestationLevel:   snap.InfestationLevel,
+			ThrottleMultiplier: snap.ThrottleMultiplier,
+			Resources:          make(map[simulation.ResourceType]*simulation.ResourceState, len(snap.Resources)),
+		}
+		for name, res := range snap.Resources {
+			rt, err := simulation.ParseResourceType(name)
+			if err != nil {
+				return status, err
+			}
+			status.Resources[rt] = &simulation.ResourceState{
+				Type:            rt,
+				Quantity:        res.Quantity,
+				ProductionRate:  res.ProductionRate,
+				ConsumptionRate: res.ConsumptionRate,
+			}
+		}
+		return status, nil
+	}
+	var err error
+	if before, err = toStatus(req.Before); err == nil {
+		after, err = toStatus(req.After)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return before, after, false
+	}
+	return before, after, true
+}
+
+// simulationDiffJSON renders a status diff with snake_case keys.
+func simulationDiffJSON(diff simulation.SimulationStatusDiff) gin.H {
+	changed := make(map[string]gin.H, len(diff.ChangedResources))
+	for rt, d := range diff.ChangedResources {
+		changed[string(rt)] = gin.H{
+			"quantity_delta":         d.QuantityDelta,
+			"production_rate_delta":  d.ProductionRateDelta,
+			"consumption_rate_delta": d.ConsumptionRateDelta,
+		}
+	}
+	return gin.H{
+		"changed_resources": changed,
+		"tick_delta":        diff.TickDelta,
+		"infestation_delta": diff.InfestationDelta,
+		"throttle_changed":  diff.ThrottleChanged,
+		"mines_added":       diff.MinesAdded,
+		"refineries_added":  diff.RefineriesAdded,
+	}
+}
+
+// simulationQuantities returns the stored quantity of each resource.
+func simulationQuantities(status simulation.SimulationStatus) map[string]float64 {
+	quantities := make(map[string]float64, len(status.Resources))
+	for rt, res := range status.Resources {
+		quantities[string(rt)] = res.Quantity
+	}
+	return quantities
+}
+
+// maxPageSize caps the page_size query parameter of paginated listings.
+const maxPageSize = 100
+
+// bindPage parses the page (1-based, default 1) and page_size (default 10,
+// at most maxPageSize) query parameters, writing a 400 response and
+// returning false if either is invalid.
+func bindPage(c *gin.Context) (page, pageSize int, ok bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "page must be a positive integer"})
+		return 0, 0, false
+	}
+	pageSize, err = strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > maxPageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("page_size must be an integer in [1, %d]", maxPageSize)})
+		return 0, 0, false
+	}
+	return page, pageSize, true
+}
+
+// pageSlice returns the [start, end) bounds of a page over total items; a
+// page past the end is empty.
+func pageSlice(total, page, pageSize int) (int, int) {
+	start := min((page-1)*pageSize, total)
+	return start, min(start+pageSize, total)
+}
+
+// autoScaleJSON renders the auto-scaling settings with snake_case keys.
+func autoScaleJSON(cfg simulation.AutoScaleConfig, autoMineCount int) gin.H {
+	return gin.H{
+		"enable_auto_scale":    cfg.EnableAutoScale,
+		"min_mineral_quantity": cfg.MinMineralQuantity,
+		"auto_mine_yield_rate": cfg.AutoMineYieldRate,
+		"max_auto_mines":       cfg.MaxAutoMines,
+		"auto_mine_count":      autoMineCount,
+	}
+}
+
+// mineJSON renders the inventory summary of a mine.
+func mineJSON(m simulation.MineInfo) gin.H {
+	return gin.H{
+		"mine_id":             m.MineID,
+		"yield_rate":          m.YieldRate,
+		"degraded_yield_rate": m.DegradedYieldRate,
+		"paused":              m.Paused,
+		"auto_scaled":         m.AutoScaled,
+		"assigned_npc_count":  len(m.AssignedNPCs),
+		"max_reserve":         m.MaxReserve,
+		"current_reserve":     m.CurrentReserve,
+		"depleted":            m.Depleted(),
+	}
+}
+
+// refineryJSON renders the inventory summary of a refinery.
+func refineryJSON(r simulation.RefineryInfo) gin.H {
+	return gin.H{
+		"refinery_id":         r.RefineryID,
+		"facility_type":       r.FacilityType,
+		"efficiency":          r.Efficiency,
+		"degraded_efficiency": r.DegradedEfficiency,
+		"paused":              r.Paused,
+		"assigned_npc_count":  len(r.AssignedNPCs),
+	}
+}
+
+// assignedNPCsJSON renders the assigned NPCs that are still registered.
+func assignedNPCsJSON(b *npc.BehaviorEngine, npcIDs []string) []gin.H {
+	npcs := make([]gin.H, 0, len(npcIDs))
+	for _, id := range npcIDs {
+		if n, ok := b.GetNPC(id); ok {
+			npcs = append(npcs, npcJSON(n))
+		}
+	}
+	return npcs
+}
+
+// npcJSON renders an NPC behavior with snake_case keys.
+func npcJSON(n *npc.NPCBehavior) gin.H {
+	return gin.H{
+		"npc_id":          n.NPCID,
+		"role":            n.Role,
+		"work_efficiency": n.WorkEfficiency,
+		"morale":          n.Morale,
+		"avg_trauma":      n.AvgTrauma,
+		"confidence":      n.Confidence,
+		"assigned_task":   n.AssignedTask,
+		"emotional_state": n.EmotionalState,
+	}
+}
+
+// envSeconds reads a whole number of seconds from the environment, falling
+// back to def when the variable is unset, malformed, or not positive.
+func envSeconds(key string, def time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		log.Printf("[Logistics] Ignoring invalid %s=%q", key, raw)
+		return def
+	}
+	return time.Duration(v) * time.Second
+}
+
+// envFloat reads a float64 from the named environment variable, falling back
+// to def when the variable is unset or unparseable.
+func envFloat(key string, def float64) float64 {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		log.Printf("[Logistics] Ignoring invalid %s=%q: %v", key, raw, err)
+		return def
+	}
+	return v
+}
+
+// envInt reads an integer from the environment, falling back to def when the
+// variable is unset or malformed.
+func envInt(key string, def int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Printf("[Logistics] Ignoring invalid %s=%q: %v", key, raw, err)
+		return def
+	}
+	return v
+}
+
+// requireAdminToken returns middleware that only admits requests whose
+// admin_token query parameter matches token. If token is empty, admin
+// endpoints are disabled entirely.
+func requireAdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin endpoints disabled (ADMIN_TOKEN not set)"})
+			return
+		}
+		provided := c.Query("admin_token")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid admin token"})
+			return
+		}
+		c.Next()
+	}
+}
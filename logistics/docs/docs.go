@@ -0,0 +1,32 @@
+// Package docs serves the OpenAPI (Swagger 2.0) specification for the
+// logistics REST API.
+//
+// The specification is maintained by hand in swagger.json and embedded at
+// build time; update it alongside any route change in cmd/server/main.go.
+// TestSwaggerDocMatchesServerRoutes fails when the two list different routes.
+package docs
+
+import (
+	_ "embed"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SwaggerJSON is the raw OpenAPI specification document.
+//
+//go:embed swagger.json
+var SwaggerJSON []byte
+
+// Handler serves the specification under a /swagger/*any route.
+// Only doc.json is served; any other path returns 404.
+func Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.TrimPrefix(c.Param("any"), "/") != "doc.json" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", SwaggerJSON)
+	}
+}
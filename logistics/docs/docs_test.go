@@ -0,0 +1,154 @@
+package docs
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type swaggerDoc struct {
+	Swagger string                                 `json:"swagger"`
+	Paths   map[string]map[string]swaggerOperation `json:"paths"`
+}
+
+type swaggerOperation struct {
+	Summary   string                     `json:"summary"`
+	Responses map[string]json.RawMessage `json:"responses"`
+}
+
+func fetchDoc(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/swagger/*any", Handler())
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestSwaggerDocIsValidJSON(t *testing.T) {
+	w := fetchDoc(t, "/swagger/doc.json")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+	var doc swaggerDoc
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "2.0", doc.Swagger)
+}
+
+func TestSwaggerDocContainsExpectedPaths(t *testing.T) {
+	var doc swaggerDoc
+	require.NoError(t, json.Unmarshal(SwaggerJSON, &doc))
+
+	expected := map[string]string{
+		"/health":                            "get",
+		"/api/simulation/status":             "get",
+		"/api/simulation/tick":               "post",
+		"/api/simulation/config":             "post",
+		"/api/simulation/transfer":           "post",
+		"/api/rebellion/probability/{npcId}": "get",
+		"/api/rebellion/stats":               "get",
+		"/api/config/rebellion":              "post",
+		"/api/npc/{npcId}/action":            "post",
+		"/api/npc/{npcId}/register":          "post",
+		"/api/cleansing/deploy":              "post",
+		"/api/infestation/project":           "post",
+		"/api/economy/prices":                "get",
+		"/api/economy/trade":                 "post",
+		"/api/economy/ledger":                "get",
+	}
+	for path, method := range expected {
+		ops, ok := doc.Paths[path]
+		if assert.True(t, ok, "missing path %s", path) {
+			assert.Contains(t, ops, method, "missing %s %s", method, path)
+		}
+	}
+}
+
+func TestSwaggerDocEveryOperationHasResponses(t *testing.T) {
+	var doc swaggerDoc
+	require.NoError(t, json.Unmarshal(SwaggerJSON, &doc))
+
+	for path, ops := range doc.Paths {
+		for method, op := range ops {
+			assert.NotEmpty(t, op.Summary, "%s %s has no summary", method, path)
+			assert.NotEmpty(t, op.Responses, "%s %s has no responses", method, path)
+		}
+	}
+}
+
+// serverRoutes returns "METHOD /path" for every literal route registered on
+// a router in cmd/server/main.go, with Gin :params written as {params}.
+func serverRoutes(t *testing.T) map[string]bool {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "../cmd/server/main.go", nil, 0)
+	require.NoError(t, err)
+
+	param := regexp.MustCompile(`:(\w+)`)
+	routes := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		switch sel.Sel.Name {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		path, err := strconv.Unquote(lit.Value)
+		require.NoError(t, err)
+		if strings.HasPrefix(path, "/swagger/") {
+			return true // the spec itself
+		}
+		routes[sel.Sel.Name+" "+param.ReplaceAllString(path, "{$1}")] = true
+		return true
+	})
+	return routes
+}
+
+func TestSwaggerDocMatchesServerRoutes(t *testing.T) {
+	var doc swaggerDoc
+	require.NoError(t, json.Unmarshal(SwaggerJSON, &doc))
+	documented := make(map[string]bool)
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	routes := serverRoutes(t)
+	require.NotEmpty(t, routes)
+	for route := range routes {
+		assert.True(t, documented[route], "route %s is not in swagger.json", route)
+	}
+	for op := range documented {
+		assert.True(t, routes[op], "swagger.json documents %s, which main.go does not register", op)
+	}
+}
+
+func TestSwaggerHandlerUnknownPath(t *testing.T) {
+	w := fetchDoc(t, "/swagger/index.html")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -1,9 +1,10 @@
 package cleansing
 
 import (
-	"errors"
+	"context"
 	"math"
 	"math/rand"
+	"sync"
 )
 
 // CleansingParticipant represents an NPC participating in a Sheriff cleansing operation.
@@ -24,6 +25,25 @@ type CleansingConfig struct {
 	MinSuccessRate      float64 // Floor for success rate (default: 0.20)
 	MaxSuccessRate      float64 // Ceiling for success rate (default: 0.85)
 	MinParticipants     int     // Minimum NPCs required (default: 2)
+
+	// RoleWeights lists the roles that may take part and how much each
+	// participant of the role counts towards the averaged stats (default:
+	// warrior and guard, 1.0 each). An empty map accepts every role at 1.0.
+	RoleWeights map[string]float64
+	// MinimumParticipantMorale is the morale below which an NPC is too
+	// traumatised to fight (default: 0.1).
+	MinimumParticipantMorale float64
+	// SizeBonus lists the success rate bonuses for large squads. Every tier
+	// whose MinParticipants the squad reaches applies, so bonuses stack
+	// (default: none).
+	SizeBonus []SizeBonusTier
+}
+
+// SizeBonusTier adds BonusSuccessRate to the success rate of squads with at
+// least MinParticipants participants.
+type SizeBonusTier struct {
+	MinParticipants  int
+	BonusSuccessRate float64
 }
 
 // CleansingResult captures the outcome of a cleansing operation.
@@ -38,13 +58,14 @@ type CleansingResult struct {
 
 // CleansingFactors provides a detailed breakdown of success rate calculation.
 type CleansingFactors struct {
-	BaseFactor             float64
-	AvgMorale              float64
-	MoraleContrib          float64
-	AvgTrauma              float64
-	TraumaPenalty          float64
-	AvgConfidence          float64
-	ConfidenceContrib      float64
+	BaseFactor        float64
+	AvgMorale         float64
+	MoraleContrib     float64
+	AvgTrauma         float64
+	TraumaPenalty     float64
+	AvgConfidence     float64
+	ConfidenceContrib float64
+	SizeBonus         float64
 }
 
 // DefaultConfig returns balanced default cleansing configuration.
@@ -57,17 +78,34 @@ func DefaultConfig() CleansingConfig {
 		MinSuccessRate:      0.20,
 		MaxSuccessRate:      0.85,
 		MinParticipants:     2,
+		RoleWeights: map[string]float64{
+			"warrior": 1.0,
+			"guard":   1.0,
+		},
+		MinimumParticipantMorale: 0.1,
 	}
 }
 
 // Engine executes Sheriff Protocol cleansing operations.
 type Engine struct {
-	config CleansingConfig
-	randFn func() float64
+	config  CleansingConfig
+	randFn  func() float64
+	hooks   CleansingHooks
+	hooksMu sync.RWMutex
 }
 
 // NewEngine creates a new cleansing engine with the given configuration.
 func NewEngine(config CleansingConfig) *Engine {
+	if config.RoleWeights != nil {
+		weights := make(map[string]float64, len(config.RoleWeights))
+		for role, w := range config.RoleWeights {
+			weights[role] = w
+		}
+		config.RoleWeights = weights
+	}
+	if config.SizeBonus != nil {
+		config.SizeBonus = append([]SizeBonusTier(nil), config.SizeBonus...)
+	}
 	return &Engine{
 		config: config,
 		randFn: rand.Float64,
@@ -80,29 +118,36 @@ func (e *Engine) SetRandFn(fn func() float64) {
 }
 
 // CalculateSuccessRate computes the cleansing success probability from participant stats.
-// Formula: clamp(base + avgMorale*moraleWeight - avgTrauma*traumaPenalty + avgConfidence*confWeight, min, max)
+// Formula: clamp(base + avgMorale*moraleWeight - avgTrauma*traumaPenalty + avgConfidence*confWeight + sizeBonus, min, max)
+// The averages weight each participant by its role (see RoleWeights);
+// unsupported roles weigh nothing.
 func (e *Engine) CalculateSuccessRate(participants []CleansingParticipant) (float64, CleansingFactors) {
-	if len(participants) == 0 {
-		return e.config.MinSuccessRate, CleansingFactors{BaseFactor: e.config.BaseSuccessRate}
-	}
-
-	var totalMorale, totalTrauma, totalConfidence float64
+	var totalWeight, totalMorale, totalTrauma, totalConfidence float64
 	for _, p := range participants {
-		totalMorale += p.Morale
-		totalTrauma += p.AvgTrauma
-		totalConfidence += p.Confidence
+		w := e.roleWeight(p.Role)
+		totalWeight += w
+		totalMorale += w * p.Morale
+		totalTrauma += w * p.AvgTrauma
+		totalConfidence += w * p.Confidence
+	}
+	if totalWeight <= 0 {
+		return e.config.MinSuccessRate, CleansingFactors{BaseFactor: e.config.BaseSuccessRate}
 	}
 
-	n := float64(len(participants))
-	avgMorale := totalMorale / n
-	avgTrauma := totalTrauma / n
-	avgConfidence := totalConfidence / n
+	avgMorale := totalMorale / totalWeight
+	avgTrauma := totalTrauma / totalWeight
+	avgConfidence := totalConfidence / totalWeight
 
 	moraleContrib := avgMorale * e.config.MoraleWeight
 	traumaPenalty := avgTrauma * e.config.TraumaPenaltyWeight
 	confidenceContrib := avgConfidence * e.config.ConfidenceWeight
 
-	raw := e.config.BaseSuccessRate + moraleContrib - traumaPenalty + confidenceContrib
+	var sizeBonus float64
+	for _, tier := range e.GetApplicableSizeBonuses(len(participants)) {
+		sizeBonus += tier.BonusSuccessRate
+	}
+
+	raw := e.config.BaseSuccessRate + moraleContrib - traumaPenalty + confidenceContrib + sizeBonus
 	clamped := math.Max(e.config.MinSuccessRate, math.Min(e.config.MaxSuccessRate, raw))
 
 	factors := CleansingFactors{
@@ -113,23 +158,52 @@ func (e *Engine) CalculateSuccessRate(participants []CleansingParticipant) (floa
 		TraumaPenalty:     traumaPenalty,
 		AvgConfidence:     avgConfidence,
 		ConfidenceContrib: confidenceContrib,
+		SizeBonus:         sizeBonus,
 	}
 
 	return clamped, factors
 }
 
-// Execute runs a full cleansing operation. Returns error if plague heart is not active
-// or if there are insufficient participants.
-func (e *Engine) Execute(participants []CleansingParticipant, isPlagueHeart bool) (CleansingResult, error) {
+// GetApplicableSizeBonuses returns the SizeBonus tiers a squad of
+// participantCount reaches, in configured order.
+func (e *Engine) GetApplicableSizeBonuses(participantCount int) []SizeBonusTier {
+	var tiers []SizeBonusTier
+	for _, tier := range e.config.SizeBonus {
+		if participantCount >= tier.MinParticipants {
+			tiers = append(tiers, tier)
+		}
+	}
+	return tiers
+}
+
+// Execute runs a full cleansing operation. Returns error if plague heart is not active,
+// if any participant fails validation (a *ParticipantValidationError, see
+// ValidateParticipants), if there are insufficient participants, or if the
+// OnBeforeExecute hook rejects it. Log lines written on behalf of the
+// operation carry ctx's trace ID.
+func (e *Engine) Execute(ctx context.Context, participants []CleansingParticipant, isPlagueHeart bool) (CleansingResult, error) {
 	if !isPlagueHeart {
-		return CleansingResult{}, errors.New("cannot cleanse: Plague Heart is not active")
+		return CleansingResult{}, &PlagueHeartNotActiveError{}
+	}
+
+	if errs := e.ValidateParticipants(participants); len(errs) > 0 {
+		return CleansingResult{}, &ParticipantValidationError{Errors: errs}
 	}
 
 	if len(participants) < e.config.MinParticipants {
-		return CleansingResult{}, errors.New("cannot cleanse: insufficient participants (minimum 2 warriors/guards required)")
+		return CleansingResult{}, &InsufficientParticipantsError{
+			Have:     len(participants),
+			Required: e.config.MinParticipants,
+		}
 	}
 
 	successRate, factors := e.CalculateSuccessRate(participants)
+
+	hooks := e.currentHooks()
+	if err := runBeforeHook(hooks.OnBeforeExecute, participants, successRate); err != nil {
+		return CleansingResult{}, err
+	}
+
 	rolled := e.randFn()
 
 	ids := make([]string, len(participants))
@@ -137,12 +211,14 @@ func (e *Engine) Execute(participants []CleansingParticipant, isPlagueHeart bool
 		ids[i] = p.NPCID
 	}
 
-	return CleansingResult{
+	result := CleansingResult{
 		Success:          rolled <= successRate,
 		SuccessRate:      successRate,
 		Participants:     ids,
 		ParticipantCount: len(participants),
 		RolledValue:      rolled,
 		Factors:          factors,
-	}, nil
+	}
+	runAfterHook(ctx, hooks.OnAfterExecute, result)
+	return result, nil
 }
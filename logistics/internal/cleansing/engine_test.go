@@ -1,6 +1,9 @@
 package cleansing
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,6 +19,8 @@ func TestDefaultConfig(t *testing.T) {
 	assert.InDelta(t, 0.20, cfg.MinSuccessRate, 0.001)
 	assert.InDelta(t, 0.85, cfg.MaxSuccessRate, 0.001)
 	assert.Equal(t, 2, cfg.MinParticipants)
+	assert.Equal(t, map[string]float64{"warrior": 1.0, "guard": 1.0}, cfg.RoleWeights)
+	assert.InDelta(t, 0.10, cfg.MinimumParticipantMorale, 0.001)
 }
 
 func TestHighMoraleArmy(t *testing.T) {
@@ -68,7 +73,7 @@ func TestExecuteSuccess(t *testing.T) {
 		{NPCID: "w2", Role: "warrior", AvgTrauma: 0.3, Morale: 0.7, Confidence: 0.6},
 	}
 
-	result, err := e.Execute(participants, true)
+	result, err := e.Execute(context.Background(), participants, true)
 	require.NoError(t, err)
 	assert.True(t, result.Success, "Should succeed with low roll")
 	assert.InDelta(t, 0.1, result.RolledValue, 0.001)
@@ -85,7 +90,7 @@ func TestExecuteFailure(t *testing.T) {
 		{NPCID: "w2", Role: "warrior", AvgTrauma: 0.3, Morale: 0.7, Confidence: 0.6},
 	}
 
-	result, err := e.Execute(participants, true)
+	result, err := e.Execute(context.Background(), participants, true)
 	require.NoError(t, err)
 	assert.False(t, result.Success, "Should fail with high roll")
 	assert.InDelta(t, 0.99, result.RolledValue, 0.001)
@@ -98,9 +103,10 @@ func TestExecuteNotPlagueHeart(t *testing.T) {
 		{NPCID: "w2", Role: "warrior", AvgTrauma: 0.3, Morale: 0.7, Confidence: 0.6},
 	}
 
-	_, err := e.Execute(participants, false)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "Plague Heart is not active")
+	_, err := e.Execute(context.Background(), participants, false)
+	assert.ErrorIs(t, err, ErrPlagueHeartNotActive)
+	var phErr *PlagueHeartNotActiveError
+	assert.True(t, errors.As(err, &phErr))
 }
 
 func TestExecuteInsufficientParticipants(t *testing.T) {
@@ -109,9 +115,15 @@ func TestExecuteInsufficientParticipants(t *testing.T) {
 		{NPCID: "w1", Role: "warrior", AvgTrauma: 0.3, Morale: 0.7, Confidence: 0.6},
 	}
 
-	_, err := e.Execute(participants, true)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "insufficient participants")
+	_, err := e.Execute(context.Background(), participants, true)
+	assert.ErrorIs(t, err, ErrInsufficientParticipants)
+	assert.NotErrorIs(t, err, ErrPlagueHeartNotActive)
+
+	var ipErr *InsufficientParticipantsError
+	if assert.True(t, errors.As(err, &ipErr)) {
+		assert.Equal(t, 1, ipErr.Have)
+		assert.Equal(t, 2, ipErr.Required)
+	}
 }
 
 func TestClampMin(t *testing.T) {
@@ -149,10 +161,71 @@ func TestFactorsPopulated(t *testing.T) {
 
 	_, factors := e.CalculateSuccessRate(participants)
 	assert.InDelta(t, 0.50, factors.BaseFactor, 0.001)
-	assert.InDelta(t, 0.70, factors.AvgMorale, 0.001)    // (0.6+0.8)/2
+	assert.InDelta(t, 0.70, factors.AvgMorale, 0.001)     // (0.6+0.8)/2
 	assert.InDelta(t, 0.30, factors.AvgTrauma, 0.001)     // (0.4+0.2)/2
 	assert.InDelta(t, 0.60, factors.AvgConfidence, 0.001) // (0.5+0.7)/2
 	assert.InDelta(t, 0.175, factors.MoraleContrib, 0.001)
 	assert.InDelta(t, 0.09, factors.TraumaPenalty, 0.001)
 	assert.InDelta(t, 0.09, factors.ConfidenceContrib, 0.001)
 }
+
+// squad returns n identical warriors with the given stats.
+func squad(n int, trauma, morale, confidence float64) []CleansingParticipant {
+	participants := make([]CleansingParticipant, n)
+	for i := range participants {
+		participants[i] = CleansingParticipant{
+			NPCID:      fmt.Sprintf("w%d", i+1),
+			Role:       "warrior",
+			AvgTrauma:  trauma,
+			Morale:     morale,
+			Confidence: confidence,
+		}
+	}
+	return participants
+}
+
+func sizeBonusConfig() CleansingConfig {
+	cfg := DefaultConfig()
+	cfg.SizeBonus = []SizeBonusTier{
+		{MinParticipants: 5, BonusSuccessRate: 0.05},
+		{MinParticipants: 10, BonusSuccessRate: 0.08},
+	}
+	return cfg
+}
+
+func TestSizeBonus_TiersStack(t *testing.T) {
+	e := NewEngine(sizeBonusConfig())
+
+	rate, factors := e.CalculateSuccessRate(squad(12, 0.5, 0.5, 0.5))
+	// neutral 0.55 + 0.05 + 0.08 = 0.68
+	assert.InDelta(t, 0.13, factors.SizeBonus, 1e-9)
+	assert.InDelta(t, 0.68, rate, 1e-9)
+	assert.Len(t, e.GetApplicableSizeBonuses(12), 2)
+}
+
+func TestSizeBonus_SmallSquadGetsNone(t *testing.T) {
+	e := NewEngine(sizeBonusConfig())
+
+	rate, factors := e.CalculateSuccessRate(squad(3, 0.5, 0.5, 0.5))
+	assert.Zero(t, factors.SizeBonus)
+	assert.InDelta(t, 0.55, rate, 1e-9)
+	assert.Empty(t, e.GetApplicableSizeBonuses(3))
+	assert.Equal(t, []SizeBonusTier{{MinParticipants: 5, BonusSuccessRate: 0.05}}, e.GetApplicableSizeBonuses(5))
+}
+
+func TestSizeBonus_StillClamped(t *testing.T) {
+	e := NewEngine(sizeBonusConfig())
+
+	rate, factors := e.CalculateSuccessRate(squad(12, 0.0, 1.0, 1.0))
+	// 0.90 + 0.13 = 1.03 → clamped to 0.85
+	assert.InDelta(t, 0.13, factors.SizeBonus, 1e-9)
+	assert.InDelta(t, 0.85, rate, 1e-9)
+}
+
+func TestSizeBonus_ConfigIsCopied(t *testing.T) {
+	cfg := sizeBonusConfig()
+	e := NewEngine(cfg)
+	cfg.SizeBonus[0].BonusSuccessRate = 0.5
+
+	assert.InDelta(t, 0.05, e.GetApplicableSizeBonuses(5)[0].BonusSuccessRate, 1e-9)
+}
@@ -0,0 +1,64 @@
+package cleansing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrPlagueHeartNotActive matches (via errors.Is) any *PlagueHeartNotActiveError.
+	ErrPlagueHeartNotActive = errors.New("plague heart not active")
+	// ErrInsufficientParticipants matches (via errors.Is) any *InsufficientParticipantsError.
+	ErrInsufficientParticipants = errors.New("insufficient participants")
+	// ErrInvalidParticipants matches (via errors.Is) any *ParticipantValidationError.
+	ErrInvalidParticipants = errors.New("invalid participants")
+)
+
+// PlagueHeartNotActiveError is returned when a cleansing operation is
+// attempted while no Plague Heart is active.
+type PlagueHeartNotActiveError struct{}
+
+func (e *PlagueHeartNotActiveError) Error() string {
+	return "cannot cleanse: Plague Heart is not active"
+}
+
+// Is reports whether target is ErrPlagueHeartNotActive.
+func (e *PlagueHeartNotActiveError) Is(target error) bool {
+	return target == ErrPlagueHeartNotActive
+}
+
+// InsufficientParticipantsError is returned when fewer warriors/guards than
+// the configured minimum are available for a cleansing operation.
+type InsufficientParticipantsError struct {
+	Have     int
+	Required int
+}
+
+func (e *InsufficientParticipantsError) Error() string {
+	return fmt.Sprintf("cannot cleanse: insufficient participants (have %d, minimum %d warriors/guards required)", e.Have, e.Required)
+}
+
+// Is reports whether target is ErrInsufficientParticipants.
+func (e *InsufficientParticipantsError) Is(target error) bool {
+	return target == ErrInsufficientParticipants
+}
+
+// ParticipantValidationError is returned when cleansing participants fail
+// validation. Errors holds every problem found (see ValidateParticipants).
+type ParticipantValidationError struct {
+	Errors []ValidationError
+}
+
+func (e *ParticipantValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, v := range e.Errors {
+		msgs[i] = v.Error()
+	}
+	return fmt.Sprintf("cannot cleanse: %d invalid participant(s): %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Is reports whether target is ErrInvalidParticipants.
+func (e *ParticipantValidationError) Is(target error) bool {
+	return target == ErrInvalidParticipants
+}
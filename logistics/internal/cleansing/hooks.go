@@ -0,0 +1,65 @@
+package cleansing
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/tracing"
+)
+
+// CleansingHooks lets external systems observe and veto cleansing operations.
+// Nil hooks are skipped. Panics inside hooks are recovered.
+type CleansingHooks struct {
+	// OnBeforeExecute runs after validation and success-rate calculation but
+	// before the roll. Returning an error (or panicking) aborts Execute with
+	// that error and no roll takes place.
+	OnBeforeExecute func(participants []CleansingParticipant, successRate float64) error
+	// OnAfterExecute runs after the roll with the result, whether or not the
+	// cleansing succeeded.
+	OnAfterExecute func(result CleansingResult)
+	// OnCasualtyOccurred will be called once per NPC lost during a cleansing
+	// operation. The engine does not model casualties yet, so it is not
+	// invoked today.
+	OnCasualtyOccurred func(npcID string)
+}
+
+// SetHooks replaces the engine's hooks. Pass a zero CleansingHooks to clear them.
+func (e *Engine) SetHooks(hooks CleansingHooks) {
+	e.hooksMu.Lock()
+	defer e.hooksMu.Unlock()
+	e.hooks = hooks
+}
+
+// currentHooks returns a copy of the installed hooks.
+func (e *Engine) currentHooks() CleansingHooks {
+	e.hooksMu.RLock()
+	defer e.hooksMu.RUnlock()
+	return e.hooks
+}
+
+// runBeforeHook invokes fn, converting a panic into an error.
+func runBeforeHook(fn func([]CleansingParticipant, float64) error, participants []CleansingParticipant, successRate float64) (err error) {
+	if fn == nil {
+		return nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cleansing OnBeforeExecute hook panicked: %v", r)
+		}
+	}()
+	return fn(participants, successRate)
+}
+
+// runAfterHook invokes fn, logging and discarding a panic.
+func runAfterHook(ctx context.Context, fn func(CleansingResult), result CleansingResult) {
+	if fn == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[Cleansing] %sOnAfterExecute hook panicked: %v", tracing.LogPrefix(ctx), r)
+		}
+	}()
+	fn(result)
+}
@@ -0,0 +1,130 @@
+package cleansing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hookTestParticipants() []CleansingParticipant {
+	return []CleansingParticipant{
+		{NPCID: "w1", Role: "warrior", AvgTrauma: 0.3, Morale: 0.7, Confidence: 0.6},
+		{NPCID: "w2", Role: "guard", AvgTrauma: 0.3, Morale: 0.7, Confidence: 0.6},
+	}
+}
+
+func TestBeforeHookErrorPreventsRoll(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	rolls := 0
+	e.SetRandFn(func() float64 { rolls++; return 0.1 })
+
+	vetoErr := errors.New("sheriff unavailable")
+	afterCalled := false
+	e.SetHooks(CleansingHooks{
+		OnBeforeExecute: func(p []CleansingParticipant, rate float64) error { return vetoErr },
+		OnAfterExecute:  func(CleansingResult) { afterCalled = true },
+	})
+
+	_, err := e.Execute(context.Background(), hookTestParticipants(), true)
+	assert.ErrorIs(t, err, vetoErr)
+	assert.Zero(t, rolls, "no dice roll after a vetoed execution")
+	assert.False(t, afterCalled)
+}
+
+func TestBeforeHookReceivesParticipantsAndRate(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.SetRandFn(func() float64 { return 0.1 })
+
+	participants := hookTestParticipants()
+	expectedRate, _ := e.CalculateSuccessRate(participants)
+
+	var gotIDs []string
+	var gotRate float64
+	e.SetHooks(CleansingHooks{
+		OnBeforeExecute: func(p []CleansingParticipant, rate float64) error {
+			for _, x := range p {
+				gotIDs = append(gotIDs, x.NPCID)
+			}
+			gotRate = rate
+			return nil
+		},
+	})
+
+	_, err := e.Execute(context.Background(), participants, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"w1", "w2"}, gotIDs)
+	assert.InDelta(t, expectedRate, gotRate, 1e-9)
+}
+
+func TestAfterHookReceivesResult(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		roll    float64
+		success bool
+	}{
+		{"success", 0.1, true},
+		{"failure", 0.99, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e := NewEngine(DefaultConfig())
+			e.SetRandFn(func() float64 { return tc.roll })
+
+			var got *CleansingResult
+			e.SetHooks(CleansingHooks{
+				OnAfterExecute: func(r CleansingResult) { got = &r },
+			})
+
+			result, err := e.Execute(context.Background(), hookTestParticipants(), true)
+			require.NoError(t, err)
+			require.NotNil(t, got, "after hook fires regardless of outcome")
+			assert.Equal(t, tc.success, got.Success)
+			assert.Equal(t, result, *got)
+		})
+	}
+}
+
+func TestHookPanicsAreRecovered(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.SetRandFn(func() float64 { return 0.1 })
+
+	e.SetHooks(CleansingHooks{
+		OnAfterExecute: func(CleansingResult) { panic("after boom") },
+	})
+	result, err := e.Execute(context.Background(), hookTestParticipants(), true)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	e.SetHooks(CleansingHooks{
+		OnBeforeExecute: func([]CleansingParticipant, float64) error { panic("before boom") },
+	})
+	_, err = e.Execute(context.Background(), hookTestParticipants(), true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "before boom")
+
+	// Clearing hooks restores normal operation
+	e.SetHooks(CleansingHooks{})
+	_, err = e.Execute(context.Background(), hookTestParticipants(), true)
+	assert.NoError(t, err)
+}
+
+func TestAfterHookPanicLogCarriesTraceID(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	e := NewEngine(DefaultConfig())
+	e.SetHooks(CleansingHooks{
+		OnAfterExecute: func(CleansingResult) { panic("after boom") },
+	})
+
+	_, err := e.Execute(tracing.WithTraceID(context.Background(), "req-42"), hookTestParticipants(), true)
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "trace_id=req-42 OnAfterExecute hook panicked: after boom")
+}
@@ -0,0 +1,85 @@
+package cleansing
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ValidationError describes one problem with a cleansing participant.
+type ValidationError struct {
+	NPCID  string
+	Field  string // Offending field: "npc_id", "role", "morale", "avg_trauma" or "confidence"
+	Reason string
+}
+
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("participant %q: %s %s", v.NPCID, v.Field, v.Reason)
+}
+
+// ValidateParticipants checks every participant and returns all problems
+// found, in participant order: NPC IDs listed more than once, morale, trauma
+// or confidence outside [0, 1], roles missing from RoleWeights (when it is
+// set), and morale below MinimumParticipantMorale. Returns nil if all
+// participants are valid.
+func (e *Engine) ValidateParticipants(participants []CleansingParticipant) []ValidationError {
+	var errs []ValidationError
+	seen := make(map[string]bool, len(participants))
+	for _, p := range participants {
+		invalid := func(field, reason string) {
+			errs = append(errs, ValidationError{NPCID: p.NPCID, Field: field, Reason: reason})
+		}
+
+		if seen[p.NPCID] {
+			invalid("npc_id", "is listed more than once")
+		}
+		seen[p.NPCID] = true
+
+		if len(e.config.RoleWeights) > 0 {
+			if _, ok := e.config.RoleWeights[p.Role]; !ok {
+				invalid("role", fmt.Sprintf("%q is not supported (supported: %v)", p.Role, e.supportedRoles()))
+			}
+		}
+
+		for _, stat := range []struct {
+			field string
+			value float64
+		}{
+			{"morale", p.Morale},
+			{"avg_trauma", p.AvgTrauma},
+			{"confidence", p.Confidence},
+		} {
+			if !inUnitRange(stat.value) {
+				invalid(stat.field, fmt.Sprintf("must be in [0, 1], got %v", stat.value))
+			}
+		}
+		if inUnitRange(p.Morale) && p.Morale < e.config.MinimumParticipantMorale {
+			invalid("morale", fmt.Sprintf("%v is below the minimum of %v: too traumatised to fight", p.Morale, e.config.MinimumParticipantMorale))
+		}
+	}
+	return errs
+}
+
+// inUnitRange reports whether v is in [0, 1] (false for NaN).
+func inUnitRange(v float64) bool {
+	return v >= 0 && v <= 1
+}
+
+// roleWeight returns how much a participant of role counts towards the
+// averaged stats: its RoleWeights entry, 0 if the role is unsupported, or
+// 1.0 when RoleWeights is empty.
+func (e *Engine) roleWeight(role string) float64 {
+	if len(e.config.RoleWeights) == 0 {
+		return 1.0
+	}
+	return e.config.RoleWeights[role]
+}
+
+// supportedRoles returns the roles in RoleWeights, sorted.
+func (e *Engine) supportedRoles() []string {
+	roles := make([]string, 0, len(e.config.RoleWeights))
+	for role := range e.config.RoleWeights {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
@@ -0,0 +1,119 @@
+package cleansing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validParticipants() []CleansingParticipant {
+	return []CleansingParticipant{
+		{NPCID: "w1", Role: "warrior", AvgTrauma: 0.3, Morale: 0.7, Confidence: 0.6},
+		{NPCID: "g1", Role: "guard", AvgTrauma: 0.2, Morale: 0.8, Confidence: 0.5},
+	}
+}
+
+func TestValidateParticipants_Valid(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	assert.Empty(t, e.ValidateParticipants(validParticipants()))
+}
+
+func TestValidateParticipants_Rules(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p []CleansingParticipant) []CleansingParticipant
+		npcID  string
+		field  string
+	}{
+		{"duplicate NPC ID", func(p []CleansingParticipant) []CleansingParticipant {
+			return append(p, p[0])
+		}, "w1", "npc_id"},
+		{"morale out of range", func(p []CleansingParticipant) []CleansingParticipant {
+			p[0].Morale = 1.2
+			return p
+		}, "w1", "morale"},
+		{"trauma out of range", func(p []CleansingParticipant) []CleansingParticipant {
+			p[1].AvgTrauma = -0.1
+			return p
+		}, "g1", "avg_trauma"},
+		{"confidence NaN", func(p []CleansingParticipant) []CleansingParticipant {
+			p[1].Confidence = math.NaN()
+			return p
+		}, "g1", "confidence"},
+		{"unsupported role", func(p []CleansingParticipant) []CleansingParticipant {
+			p[0].Role = "worker"
+			return p
+		}, "w1", "role"},
+		{"morale below minimum", func(p []CleansingParticipant) []CleansingParticipant {
+			p[1].Morale = 0.05
+			return p
+		}, "g1", "morale"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEngine(DefaultConfig())
+
+			errs := e.ValidateParticipants(tt.modify(validParticipants()))
+
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.npcID, errs[0].NPCID)
+			assert.Equal(t, tt.field, errs[0].Field)
+		})
+	}
+}
+
+func TestValidateParticipants_ReportsAllErrors(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	participants := []CleansingParticipant{
+		{NPCID: "w1", Role: "worker", AvgTrauma: 0.3, Morale: 0.0, Confidence: 2},
+		{NPCID: "w1", Role: "warrior", AvgTrauma: 0.3, Morale: 0.7, Confidence: 0.6},
+	}
+
+	errs := e.ValidateParticipants(participants)
+
+	fields := make([]string, len(errs))
+	for i, v := range errs {
+		fields[i] = v.Field
+	}
+	assert.Equal(t, []string{"role", "confidence", "morale", "npc_id"}, fields)
+}
+
+func TestValidateParticipants_EmptyRoleWeightsAcceptsAnyRole(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RoleWeights = nil
+	e := NewEngine(cfg)
+	participants := validParticipants()
+	participants[0].Role = "worker"
+
+	assert.Empty(t, e.ValidateParticipants(participants))
+}
+
+func TestExecute_RejectsInvalidParticipants(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.SetRandFn(func() float64 { t.Fatal("must not roll"); return 0 })
+	participants := validParticipants()
+	participants[0].Morale = 0.05
+	participants[1].Role = "worker"
+
+	_, err := e.Execute(context.Background(), participants, true)
+
+	assert.ErrorIs(t, err, ErrInvalidParticipants)
+	var verr *ParticipantValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Len(t, verr.Errors, 2)
+}
+
+func TestCalculateSuccessRate_RoleWeights(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RoleWeights = map[string]float64{"warrior": 3.0, "guard": 1.0}
+	e := NewEngine(cfg)
+
+	_, factors := e.CalculateSuccessRate(validParticipants())
+
+	assert.InDelta(t, (3*0.7+0.8)/4, factors.AvgMorale, 1e-9)
+	assert.InDelta(t, (3*0.3+0.2)/4, factors.AvgTrauma, 1e-9)
+}
@@ -0,0 +1,137 @@
+package economy
+
+import (
+	"fmt"
+	"math"
+)
+
+// minSupplyFactor floors the elasticity price factor so that a large surplus
+// never drives prices to zero or below.
+const minSupplyFactor = 0.1
+
+// EconomyConfig tunes how market prices respond to supply.
+type EconomyConfig struct {
+	// Supply elasticity: a resource's price scales by
+	// 1 + PriceElasticity × (1 - supply/SurplusThreshold), floored at 0.1, so
+	// scarcity raises prices and supply beyond the threshold depresses them
+	PriceElasticity   float64                  // default: 0.5
+	SurplusThresholds map[ResourceType]float64 // Supply at which a resource trades at its unadjusted price (default: 1000 each); unlisted resources are inelastic
+
+	// Per-tick trade volume caps, reset by EconomyTick (default: none);
+	// unlisted resources trade without limit
+	TradeLimits map[ResourceType]TradeLimitConfig
+
+	// Fraction of the gap to the equilibrium price that an unshocked price
+	// closes each EconomyTick (default: 0.05); 1 restores it at once, 0
+	// leaves it where the last shock left it
+	PriceRecoveryRate float64
+}
+
+// TradeLimitConfig caps the quantity of a resource that may be traded
+// between economy ticks. A zero limit leaves that side unlimited.
+type TradeLimitConfig struct {
+	MaxSellPerTick float64
+	MaxBuyPerTick  float64
+}
+
+// limit returns the cap for one side of the market, or +Inf if unlimited.
+func (l TradeLimitConfig) limit(isBuy bool) float64 {
+	perTick := l.MaxSellPerTick
+	if isBuy {
+		perTick = l.MaxBuyPerTick
+	}
+	if perTick == 0 {
+		return math.Inf(1)
+	}
+	return perTick
+}
+
+// DefaultConfig returns the default economy configuration.
+func DefaultConfig() EconomyConfig {
+	return EconomyConfig{
+		PriceElasticity:   0.5,
+		PriceRecoveryRate: 0.05,
+		SurplusThresholds: map[ResourceType]float64{
+			ResourceSim:      1000,
+			ResourceRapidlum: 1000,
+			ResourceMineral:  1000,
+		},
+	}
+}
+
+// Validate checks that the config is usable.
+func (c EconomyConfig) Validate() error {
+	if c.PriceElasticity < 0 {
+		return fmt.Errorf("PriceElasticity must be non-negative, got %v", c.PriceElasticity)
+	}
+	if c.PriceRecoveryRate < 0 || c.PriceRecoveryRate > 1 {
+		return fmt.Errorf("PriceRecoveryRate must be in [0, 1], got %v", c.PriceRecoveryRate)
+	}
+	for rt, threshold := range c.SurplusThresholds {
+		if _, err := ParseResourceType(string(rt)); err != nil {
+			return fmt.Errorf("SurplusThresholds: %w", err)
+		}
+		if threshold <= 0 {
+			return fmt.Errorf("SurplusThresholds[%s] must be positive, got %v", rt, threshold)
+		}
+	}
+	for rt, limits := range c.TradeLimits {
+		if _, err := ParseResourceType(string(rt)); err != nil {
+			return fmt.Errorf("TradeLimits: %w", err)
+		}
+		if limits.MaxSellPerTick < 0 || limits.MaxBuyPerTick < 0 {
+			return fmt.Errorf("TradeLimits[%s] must be non-negative, got sell=%v buy=%v", rt, limits.MaxSellPerTick, limits.MaxBuyPerTick)
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of the config that shares no maps with c.
+func (c EconomyConfig) clone() EconomyConfig {
+	thresholds := make(map[ResourceType]float64, len(c.SurplusThresholds))
+	for rt, threshold := range c.SurplusThresholds {
+		thresholds[rt] = threshold
+	}
+	c.SurplusThresholds = thresholds
+	if c.TradeLimits != nil {
+		limits := make(map[ResourceType]TradeLimitConfig, len(c.TradeLimits))
+		for rt, l := range c.TradeLimits {
+			limits[rt] = l
+		}
+		c.TradeLimits = limits
+	}
+	return c
+}
+
+// supplyFactor returns the elasticity price factor for rt at the given supply.
+func (c EconomyConfig) supplyFactor(rt ResourceType, supply float64) float64 {
+	threshold, ok := c.SurplusThresholds[rt]
+	if !ok {
+		return 1.0
+	}
+	factor := 1 + c.PriceElasticity*(1-supply/threshold)
+	if factor < minSupplyFactor {
+		return minSupplyFactor
+	}
+	return factor
+}
+
+// GetConfig returns the engine's configuration.
+func (e *EconomyEngine) GetConfig() EconomyConfig {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.config.clone()
+}
+
+// UpdateConfig validates cfg and swaps it in. Current prices and the trade
+// volume recorded this tick are unchanged.
+func (e *EconomyEngine) UpdateConfig(cfg EconomyConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.config = cfg.clone()
+	return nil
+}
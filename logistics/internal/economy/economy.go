@@ -1,6 +1,11 @@
 package economy
 
-import "sync"
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
 
 // ResourceType represents the type of resource in the Epoch Engine economy.
 // Mirrors the simulation ResourceType for economy-layer pricing.
@@ -19,21 +24,73 @@ type ResourcePrice struct {
 	SellPrice float64 // Revenue from selling to market
 }
 
+// ParseResourceType converts a resource name (e.g. "mineral") to a ResourceType.
+// Returns an *UnknownResourceError if the name does not match a known resource.
+func ParseResourceType(name string) (ResourceType, error) {
+	switch rt := ResourceType(name); rt {
+	case ResourceSim, ResourceRapidlum, ResourceMineral:
+		return rt, nil
+	default:
+		return "", &UnknownResourceError{Resource: name}
+	}
+}
+
+// maxLedgerSize bounds the number of trade records retained in memory.
+const maxLedgerSize = 1000
+
+// TradeRecord is a single completed market transaction.
+type TradeRecord struct {
+	ID         string
+	Resource   ResourceType
+	Quantity   float64
+	UnitPrice  float64 // BuyPrice for buys, SellPrice for sells
+	TotalValue float64 // Quantity * UnitPrice
+	IsBuy      bool
+	Timestamp  time.Time
+}
+
+// maxPriceHistory bounds the number of price records retained per resource.
+const maxPriceHistory = 1000
+
+// PriceRecord is a resource's market price as set at a point in time.
+type PriceRecord struct {
+	Resource  ResourceType
+	BuyPrice  float64
+	SellPrice float64
+	Timestamp time.Time
+}
+
 // EconomyEngine manages resource pricing and trade calculations.
 // It is safe for concurrent use.
 type EconomyEngine struct {
-	prices map[ResourceType]*ResourcePrice
-	mu     sync.RWMutex
+	prices        map[ResourceType]*ResourcePrice // current market prices
+	basePrices    map[ResourceType]ResourcePrice  // equilibrium prices, before shocks (see EconomyTick)
+	defaultPrices map[ResourceType]ResourcePrice  // prices at creation (see GetCommodityIndex)
+	shocks        []ActiveShock                   // in application order
+	config        EconomyConfig                   // supply elasticity settings
+	priceHistory  map[ResourceType][]PriceRecord  // oldest first
+	ledger        []TradeRecord
+	nextTradeID   int
+	buyVolume     map[ResourceType]float64 // bought since the last EconomyTick
+	sellVolume    map[ResourceType]float64 // sold since the last EconomyTick
+	ticks         int64                    // EconomyTick calls so far
+
+	productionVolume map[ResourceType]float64 // commodity index weights
+	indexHistory     []IndexRecord            // oldest first
+	indexListener    IndexAlertListener
+	peakDeviation    map[ResourceType]float64 // largest deviation since last at equilibrium
+	mu               sync.RWMutex
 }
 
-// NewEconomyEngine creates a new EconomyEngine with default market prices.
+// NewEconomyEngine creates a new EconomyEngine with default market prices
+// and the default config.
 //
 // Default prices:
 //   - Sim:      Buy=1.0, Sell=0.8
 //   - Rapidlum: Buy=5.0, Sell=4.0
 //   - Mineral:  Buy=0.5, Sell=0.3
 func NewEconomyEngine() *EconomyEngine {
-	return &EconomyEngine{
+	e := &EconomyEngine{
 		prices: map[ResourceType]*ResourcePrice{
 			ResourceSim: {
 				Type:      ResourceSim,
@@ -51,7 +108,20 @@ func NewEconomyEngine() *EconomyEngine {
 				SellPrice: 0.3,
 			},
 		},
+		basePrices:    make(map[ResourceType]ResourcePrice),
+		defaultPrices: make(map[ResourceType]ResourcePrice),
+		config:        DefaultConfig(),
+		priceHistory:  make(map[ResourceType][]PriceRecord),
+		nextTradeID:   1,
+		buyVolume:     make(map[ResourceType]float64),
+		sellVolume:    make(map[ResourceType]float64),
+		peakDeviation: make(map[ResourceType]float64),
+	}
+	for rt, price := range e.prices {
+		e.basePrices[rt] = *price
+		e.defaultPrices[rt] = *price
 	}
+	return e
 }
 
 // GetPrice returns the current price for the specified resource type.
@@ -67,6 +137,62 @@ func (e *EconomyEngine) GetPrice(resourceType ResourceType) (*ResourcePrice, boo
 	return price, true
 }
 
+// SetPrice replaces the buy and sell prices of a resource and appends the
+// new price to its history. The new price is also the resource's base
+// (equilibrium) price: active price shocks are reapplied on top of it by the
+// next EconomyTick.
+// Returns an *UnknownResourceError for unpriced resources and an error if
+// either price is not positive.
+func (e *EconomyEngine) SetPrice(resourceType ResourceType, buyPrice, sellPrice float64) error {
+	if buyPrice <= 0 || sellPrice <= 0 {
+		return fmt.Errorf("prices must be positive, got buy=%v sell=%v", buyPrice, sellPrice)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.prices[resourceType]; !ok {
+		return &UnknownResourceError{Resource: string(resourceType)}
+	}
+	e.basePrices[resourceType] = ResourcePrice{Type: resourceType, BuyPrice: buyPrice, SellPrice: sellPrice}
+	e.setPriceLocked(resourceType, buyPrice, sellPrice)
+	delete(e.peakDeviation, resourceType)
+	return nil
+}
+
+// setPriceLocked replaces the current price of a resource and appends it to
+// the price history. Caller must hold e.mu.
+func (e *EconomyEngine) setPriceLocked(resourceType ResourceType, buyPrice, sellPrice float64) {
+	// Replace rather than mutate so pointers returned by GetPrice stay stable
+	e.prices[resourceType] = &ResourcePrice{Type: resourceType, BuyPrice: buyPrice, SellPrice: sellPrice}
+
+	history := append(e.priceHistory[resourceType], PriceRecord{
+		Resource:  resourceType,
+		BuyPrice:  buyPrice,
+		SellPrice: sellPrice,
+		Timestamp: time.Now(),
+	})
+	if len(history) > maxPriceHistory {
+		history = history[len(history)-maxPriceHistory:]
+	}
+	e.priceHistory[resourceType] = history
+}
+
+// GetPriceHistory returns up to limit of the most recent price records for a
+// resource, oldest first. A limit <= 0 returns every retained record.
+func (e *EconomyEngine) GetPriceHistory(resourceType ResourceType, limit int) []PriceRecord {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	history := e.priceHistory[resourceType]
+	if limit > 0 && limit < len(history) {
+		history = history[len(history)-limit:]
+	}
+	out := make([]PriceRecord, len(history))
+	copy(out, history)
+	return out
+}
+
 // CalculateTradeValue calculates the value of selling a given quantity of a resource.
 // Returns 0.0 if the resource type is unknown.
 func (e *EconomyEngine) CalculateTradeValue(resourceType ResourceType, quantity float64) float64 {
@@ -79,3 +205,91 @@ func (e *EconomyEngine) CalculateTradeValue(resourceType ResourceType, quantity
 	}
 	return quantity * price.SellPrice
 }
+
+// RecordTrade prices a trade at the current market rate and appends it to the
+// ledger. Buys are priced at BuyPrice, sells at SellPrice. Returns an
+// *UnknownResourceError for unpriced resources, a *TradeLimitExceededError if
+// the trade would exceed the resource's per-tick limit (see
+// GetRemainingTradeCapacity) and an error if quantity is not positive; no
+// record is created in any of these cases.
+func (e *EconomyEngine) RecordTrade(resourceType ResourceType, quantity float64, isBuy bool) (TradeRecord, error) {
+	if quantity <= 0 {
+		return TradeRecord{}, fmt.Errorf("trade quantity must be positive, got %v", quantity)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	price, ok := e.prices[resourceType]
+	if !ok {
+		return TradeRecord{}, &UnknownResourceError{Resource: string(resourceType)}
+	}
+
+	if remaining := e.remainingCapacity(resourceType, isBuy); quantity > remaining {
+		return TradeRecord{}, &TradeLimitExceededError{Resource: resourceType, IsBuy: isBuy, Allowed: remaining, Requested: quantity}
+	}
+
+	unitPrice := price.SellPrice
+	volume := e.sellVolume
+	if isBuy {
+		unitPrice = price.BuyPrice
+		volume = e.buyVolume
+	}
+	volume[resourceType] += quantity
+
+	record := TradeRecord{
+		ID:         fmt.Sprintf("trade-%d", e.nextTradeID),
+		Resource:   resourceType,
+		Quantity:   quantity,
+		UnitPrice:  unitPrice,
+		TotalValue: quantity * unitPrice,
+		IsBuy:      isBuy,
+		Timestamp:  time.Now(),
+	}
+	e.nextTradeID++
+
+	e.ledger = append(e.ledger, record)
+	if len(e.ledger) > maxLedgerSize {
+		e.ledger = e.ledger[len(e.ledger)-maxLedgerSize:]
+	}
+	return record, nil
+}
+
+// GetRemainingTradeCapacity returns the quantity of a resource that can still
+// be bought (isBuy) or sold before the next EconomyTick, or +Inf if that side
+// of the market has no limit (see EconomyConfig.TradeLimits).
+func (e *EconomyEngine) GetRemainingTradeCapacity(rt ResourceType, isBuy bool) float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.remainingCapacity(rt, isBuy)
+}
+
+// remainingCapacity implements GetRemainingTradeCapacity. Caller must hold e.mu.
+func (e *EconomyEngine) remainingCapacity(rt ResourceType, isBuy bool) float64 {
+	limits, ok := e.config.TradeLimits[rt]
+	if !ok {
+		return math.Inf(1)
+	}
+	traded := e.sellVolume[rt]
+	if isBuy {
+		traded = e.buyVolume[rt]
+	}
+	return math.Max(limits.limit(isBuy)-traded, 0)
+}
+
+// GetLedger returns up to limit trade records, newest first.
+// A limit <= 0 returns every retained record.
+func (e *EconomyEngine) GetLedger(limit int) []TradeRecord {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	n := len(e.ledger)
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	out := make([]TradeRecord, 0, n)
+	for i := len(e.ledger) - 1; i >= 0 && len(out) < n; i-- {
+		out = append(out, e.ledger[i])
+	}
+	return out
+}
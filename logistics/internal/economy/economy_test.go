@@ -1,6 +1,7 @@
 package economy
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,3 +72,76 @@ func TestCalculateTradeValue_NegativeQuantity(t *testing.T) {
 	value := engine.CalculateTradeValue(ResourceSim, -10.0)
 	assert.InDelta(t, -8.0, value, 0.001, "Negative quantity * sell price = negative value")
 }
+
+func TestParseResourceType(t *testing.T) {
+	rt, err := ParseResourceType("mineral")
+	assert.NoError(t, err)
+	assert.Equal(t, ResourceMineral, rt)
+
+	_, err = ParseResourceType("unobtainium")
+	assert.ErrorIs(t, err, ErrUnknownResource)
+
+	var urErr *UnknownResourceError
+	if assert.True(t, errors.As(err, &urErr)) {
+		assert.Equal(t, "unobtainium", urErr.Resource)
+	}
+}
+
+func TestRecordTrade_PricesBuyAndSell(t *testing.T) {
+	engine := NewEconomyEngine()
+
+	sell, err := engine.RecordTrade(ResourceMineral, 100, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "trade-1", sell.ID)
+	assert.InDelta(t, 0.3, sell.UnitPrice, 0.001)
+	assert.InDelta(t, 30.0, sell.TotalValue, 0.001)
+	assert.False(t, sell.IsBuy)
+	assert.False(t, sell.Timestamp.IsZero())
+
+	buy, err := engine.RecordTrade(ResourceRapidlum, 2, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "trade-2", buy.ID)
+	assert.InDelta(t, 5.0, buy.UnitPrice, 0.001)
+	assert.InDelta(t, 10.0, buy.TotalValue, 0.001)
+}
+
+func TestRecordTrade_InvalidInputsNotRecorded(t *testing.T) {
+	engine := NewEconomyEngine()
+
+	_, err := engine.RecordTrade(ResourceType("unobtainium"), 1, true)
+	assert.True(t, errors.Is(err, ErrUnknownResource))
+
+	_, err = engine.RecordTrade(ResourceSim, 0, false)
+	assert.Error(t, err)
+
+	assert.Empty(t, engine.GetLedger(0))
+}
+
+func TestGetLedger_NewestFirstWithLimit(t *testing.T) {
+	engine := NewEconomyEngine()
+	for i := 1; i <= 5; i++ {
+		_, err := engine.RecordTrade(ResourceSim, float64(i), false)
+		assert.NoError(t, err)
+	}
+
+	all := engine.GetLedger(0)
+	assert.Len(t, all, 5)
+	assert.Equal(t, "trade-5", all[0].ID)
+	assert.Equal(t, "trade-1", all[4].ID)
+
+	latest := engine.GetLedger(2)
+	assert.Len(t, latest, 2)
+	assert.InDelta(t, 5.0, latest[0].Quantity, 0.001)
+	assert.InDelta(t, 4.0, latest[1].Quantity, 0.001)
+}
+
+func TestGetLedger_BoundedSize(t *testing.T) {
+	engine := NewEconomyEngine()
+	for i := 0; i < maxLedgerSize+10; i++ {
+		_, _ = engine.RecordTrade(ResourceSim, 1, true)
+	}
+
+	ledger := engine.GetLedger(0)
+	assert.Len(t, ledger, maxLedgerSize)
+	assert.Equal(t, "trade-1010", ledger[0].ID)
+}
@@ -0,0 +1,49 @@
+package economy
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownResource matches (via errors.Is) any *UnknownResourceError.
+var ErrUnknownResource = errors.New("unknown resource")
+
+// UnknownResourceError is returned when a resource name or type has no price
+// entry in the economy.
+type UnknownResourceError struct {
+	Resource string
+}
+
+func (e *UnknownResourceError) Error() string {
+	return fmt.Sprintf("unknown resource type %q", e.Resource)
+}
+
+// Is reports whether target is ErrUnknownResource.
+func (e *UnknownResourceError) Is(target error) bool {
+	return target == ErrUnknownResource
+}
+
+// ErrTradeLimitExceeded matches (via errors.Is) any *TradeLimitExceededError.
+var ErrTradeLimitExceeded = errors.New("trade limit exceeded")
+
+// TradeLimitExceededError is returned when a trade would take a resource's
+// buy or sell volume for the current economy tick past its limit.
+type TradeLimitExceededError struct {
+	Resource  ResourceType
+	IsBuy     bool
+	Allowed   float64 // Quantity still tradable this tick
+	Requested float64
+}
+
+func (e *TradeLimitExceededError) Error() string {
+	side := "sell"
+	if e.IsBuy {
+		side = "buy"
+	}
+	return fmt.Sprintf("%s of %v %s exceeds the per-tick trade limit (%v remaining)", side, e.Requested, e.Resource, e.Allowed)
+}
+
+// Is reports whether target is ErrTradeLimitExceeded.
+func (e *TradeLimitExceededError) Is(target error) bool {
+	return target == ErrTradeLimitExceeded
+}
@@ -0,0 +1,118 @@
+package economy
+
+import "fmt"
+
+const (
+	// SevereDeflationIndex is the commodity index below which an index alert
+	// fires.
+	SevereDeflationIndex = 0.5
+	// SevereInflationIndex is the commodity index above which an index alert
+	// fires.
+	SevereInflationIndex = 2.0
+	// maxIndexHistory bounds the number of index records retained.
+	maxIndexHistory = 1000
+)
+
+// IndexRecord is the commodity index as computed at an economy tick.
+type IndexRecord struct {
+	Tick  int64 // EconomyTick calls made before the index was computed
+	Index float64
+}
+
+// IndexAlertListener is notified when the commodity index moves outside
+// [SevereDeflationIndex, SevereInflationIndex]. It is called after the engine
+// lock is released.
+type IndexAlertListener func(IndexRecord)
+
+// SetProductionVolumes sets the production volume of each resource used to
+// weight the commodity index. Unlisted resources get no weight; if no priced
+// resource has any volume, all resources are weighted equally. Returns an
+// *UnknownResourceError for unpriced resources and an error for negative
+// volumes.
+func (e *EconomyEngine) SetProductionVolumes(volumes map[ResourceType]float64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for rt, v := range volumes {
+		if _, ok := e.prices[rt]; !ok {
+			return &UnknownResourceError{Resource: string(rt)}
+		}
+		if v < 0 {
+			return fmt.Errorf("production volume of %s must be non-negative, got %v", rt, v)
+		}
+	}
+	e.productionVolume = make(map[ResourceType]float64, len(volumes))
+	for rt, v := range volumes {
+		e.productionVolume[rt] = v
+	}
+	return nil
+}
+
+// SetIndexAlertListener registers fn to be notified when the commodity index
+// leaves the healthy range, replacing any previous listener. A nil fn
+// removes it.
+func (e *EconomyEngine) SetIndexAlertListener(fn IndexAlertListener) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.indexListener = fn
+}
+
+// GetCommodityIndex returns the production-weighted average of each
+// resource's current sell price divided by its default sell price (see
+// NewEconomyEngine), and appends it to the index history. 1.0 means prices
+// are at their defaults, above 1.0 inflation and below 1.0 deflation.
+//
+// The index alert listener is notified when the index falls below
+// SevereDeflationIndex or rises above SevereInflationIndex, once per
+// excursion from the healthy range.
+func (e *EconomyEngine) GetCommodityIndex() float64 {
+	e.mu.Lock()
+	var total, weighted float64
+	for rt, price := range e.prices {
+		weight := e.productionVolume[rt]
+		total += weight
+		weighted += weight * price.SellPrice / e.defaultPrices[rt].SellPrice
+	}
+	if total == 0 {
+		for rt, price := range e.prices {
+			total++
+			weighted += price.SellPrice / e.defaultPrices[rt].SellPrice
+		}
+	}
+	record := IndexRecord{Tick: e.ticks, Index: weighted / total}
+
+	alert := !indexHealthy(record.Index) &&
+		(len(e.indexHistory) == 0 || indexHealthy(e.indexHistory[len(e.indexHistory)-1].Index))
+	e.indexHistory = append(e.indexHistory, record)
+	if len(e.indexHistory) > maxIndexHistory {
+		e.indexHistory = e.indexHistory[len(e.indexHistory)-maxIndexHistory:]
+	}
+	listener := e.indexListener
+	e.mu.Unlock()
+
+	if alert && listener != nil {
+		listener(record)
+	}
+	return record.Index
+}
+
+// GetIndexHistory returns up to n of the most recent commodity index records,
+// oldest first. An n <= 0 returns every retained record.
+func (e *EconomyEngine) GetIndexHistory(n int) []IndexRecord {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	history := e.indexHistory
+	if n > 0 && n < len(history) {
+		history = history[len(history)-n:]
+	}
+	out := make([]IndexRecord, len(history))
+	copy(out, history)
+	return out
+}
+
+// indexHealthy reports whether index lies within
+// [SevereDeflationIndex, SevereInflationIndex].
+func indexHealthy(index float64) bool {
+	return index >= SevereDeflationIndex && index <= SevereInflationIndex
+}
@@ -0,0 +1,81 @@
+package economy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scalePrices sets every resource's price to factor times its default.
+func scalePrices(t *testing.T, engine *EconomyEngine, factor float64) {
+	t.Helper()
+	for rt, def := range NewEconomyEngine().defaultPrices {
+		require.NoError(t, engine.SetPrice(rt, def.BuyPrice*factor, def.SellPrice*factor))
+	}
+}
+
+func TestGetCommodityIndex_TracksPriceLevel(t *testing.T) {
+	engine := NewEconomyEngine()
+	require.NoError(t, engine.SetProductionVolumes(map[ResourceType]float64{ResourceSim: 10, ResourceMineral: 30}))
+
+	assert.InDelta(t, 1.0, engine.GetCommodityIndex(), 1e-9)
+	scalePrices(t, engine, 2)
+	assert.InDelta(t, 2.0, engine.GetCommodityIndex(), 1e-9)
+	scalePrices(t, engine, 1)
+	assert.InDelta(t, 1.0, engine.GetCommodityIndex(), 1e-9)
+
+	history := engine.GetIndexHistory(0)
+	require.Len(t, history, 3, "every call is recorded")
+	assert.InDelta(t, 2.0, history[1].Index, 1e-9)
+	assert.Equal(t, []IndexRecord{history[2]}, engine.GetIndexHistory(1))
+}
+
+func TestGetCommodityIndex_WeightedByProduction(t *testing.T) {
+	engine := NewEconomyEngine()
+	require.NoError(t, engine.SetProductionVolumes(map[ResourceType]float64{ResourceSim: 1, ResourceMineral: 3}))
+	require.NoError(t, engine.SetPrice(ResourceMineral, 1.0, 0.6)) // 2× default sell
+
+	// (1×1.0 + 3×2.0) / 4; rapidlum has no production and no weight
+	assert.InDelta(t, 1.75, engine.GetCommodityIndex(), 1e-9)
+
+	require.NoError(t, engine.SetProductionVolumes(nil))
+	assert.InDelta(t, (1.0+1.0+2.0)/3, engine.GetCommodityIndex(), 1e-9, "equal weights without production")
+}
+
+func TestGetCommodityIndex_RecordsEconomyTick(t *testing.T) {
+	engine := NewEconomyEngine()
+	engine.EconomyTick()
+	engine.EconomyTick()
+
+	engine.GetCommodityIndex()
+
+	assert.Equal(t, int64(2), engine.GetIndexHistory(0)[0].Tick)
+}
+
+func TestGetCommodityIndex_AlertsOncePerExcursion(t *testing.T) {
+	engine := NewEconomyEngine()
+	var alerts []IndexRecord
+	engine.SetIndexAlertListener(func(rec IndexRecord) { alerts = append(alerts, rec) })
+
+	scalePrices(t, engine, 0.4)
+	engine.GetCommodityIndex()
+	engine.GetCommodityIndex()
+	scalePrices(t, engine, 1)
+	engine.GetCommodityIndex()
+	scalePrices(t, engine, 2.5)
+	engine.GetCommodityIndex()
+
+	require.Len(t, alerts, 2)
+	assert.InDelta(t, 0.4, alerts[0].Index, 1e-9)
+	assert.InDelta(t, 2.5, alerts[1].Index, 1e-9)
+}
+
+func TestSetProductionVolumes_Validation(t *testing.T) {
+	engine := NewEconomyEngine()
+
+	err := engine.SetProductionVolumes(map[ResourceType]float64{"gold": 1})
+	assert.True(t, errors.Is(err, ErrUnknownResource))
+	assert.Error(t, engine.SetProductionVolumes(map[ResourceType]float64{ResourceSim: -1}))
+}
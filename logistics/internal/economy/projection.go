@@ -0,0 +1,60 @@
+package economy
+
+import "math"
+
+// EconomyTickProjection is the projected market on one tick of SimulateNTicks.
+type EconomyTickProjection struct {
+	Tick             int
+	Prices           map[ResourceType]ResourcePrice
+	TotalMarketValue float64 // Projected supply valued at projected sell prices
+}
+
+// SimulateNTicks projects market prices over n ticks without modifying the
+// engine. Tick 0 holds the current prices and supply; on each later tick
+// every resource's production is added to its supply (floored at 0), and its
+// prices move from the current ones by the ratio of the elasticity factors
+// (see EconomyConfig) at the projected and starting supply. Resources
+// missing from supply start at 0. Price shocks are not projected. Returns
+// n+1 projections, or nil if n < 0.
+func (e *EconomyEngine) SimulateNTicks(supply map[ResourceType]float64, production map[ResourceType]float64, n int) []EconomyTickProjection {
+	if n < 0 {
+		return nil
+	}
+
+	e.mu.RLock()
+	cfg := e.config.clone()
+	current := make(map[ResourceType]ResourcePrice, len(e.prices))
+	for rt, price := range e.prices {
+		current[rt] = *price
+	}
+	e.mu.RUnlock()
+
+	startFactor := make(map[ResourceType]float64, len(current))
+	projected := make(map[ResourceType]float64, len(current))
+	for rt := range current {
+		projected[rt] = supply[rt]
+		startFactor[rt] = cfg.supplyFactor(rt, supply[rt])
+	}
+
+	projections := make([]EconomyTickProjection, 0, n+1)
+	for tick := 0; tick <= n; tick++ {
+		if tick > 0 {
+			for rt := range projected {
+				projected[rt] = math.Max(projected[rt]+production[rt], 0)
+			}
+		}
+
+		p := EconomyTickProjection{Tick: tick, Prices: make(map[ResourceType]ResourcePrice, len(current))}
+		for rt, price := range current {
+			if tick > 0 {
+				ratio := cfg.supplyFactor(rt, projected[rt]) / startFactor[rt]
+				price.BuyPrice *= ratio
+				price.SellPrice *= ratio
+			}
+			p.Prices[rt] = price
+			p.TotalMarketValue += projected[rt] * price.SellPrice
+		}
+		projections = append(projections, p)
+	}
+	return projections
+}
@@ -0,0 +1,98 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateNTicks_PricesFallTowardSurplus(t *testing.T) {
+	engine := NewEconomyEngine()
+	cfg := engine.GetConfig()
+	cfg.SurplusThresholds[ResourceMineral] = 1000
+	require.NoError(t, engine.UpdateConfig(cfg))
+
+	supply := map[ResourceType]float64{ResourceMineral: 500}
+	production := map[ResourceType]float64{ResourceMineral: 10}
+	projections := engine.SimulateNTicks(supply, production, 50)
+	require.Len(t, projections, 51)
+
+	// Tick 0 is the current market, exactly
+	for _, rt := range []ResourceType{ResourceSim, ResourceRapidlum, ResourceMineral} {
+		current, _ := engine.GetPrice(rt)
+		assert.Equal(t, *current, projections[0].Prices[rt], "tick 0 %s", rt)
+	}
+	assert.Equal(t, 0, projections[0].Tick)
+	assert.InDelta(t, 500*0.3, projections[0].TotalMarketValue, 1e-9)
+
+	for i := 1; i < len(projections); i++ {
+		prev, cur := projections[i-1].Prices[ResourceMineral], projections[i].Prices[ResourceMineral]
+		assert.Less(t, cur.SellPrice, prev.SellPrice, "tick %d", i)
+		assert.Less(t, cur.BuyPrice, prev.BuyPrice, "tick %d", i)
+		assert.Equal(t, i, projections[i].Tick)
+	}
+
+	// Supply reaches the threshold at tick 50: factor 1.0 vs 1.25 at the start
+	final := projections[50]
+	assert.InDelta(t, 0.3/1.25, final.Prices[ResourceMineral].SellPrice, 1e-9)
+	assert.InDelta(t, 0.5/1.25, final.Prices[ResourceMineral].BuyPrice, 1e-9)
+	assert.InDelta(t, 1000*0.3/1.25, final.TotalMarketValue, 1e-9)
+
+	// Resources without supply or production keep their prices
+	assert.Equal(t, projections[0].Prices[ResourceRapidlum], final.Prices[ResourceRapidlum])
+}
+
+func TestSimulateNTicks_NoSideEffects(t *testing.T) {
+	engine := NewEconomyEngine()
+	before, _ := engine.GetPrice(ResourceMineral)
+	beforeValue := *before
+
+	engine.SimulateNTicks(map[ResourceType]float64{ResourceMineral: 0}, map[ResourceType]float64{ResourceMineral: 100}, 30)
+
+	after, _ := engine.GetPrice(ResourceMineral)
+	assert.Equal(t, beforeValue, *after)
+	assert.Empty(t, engine.GetPriceHistory(ResourceMineral, 0))
+}
+
+func TestSimulateNTicks_PriceFactorFloor(t *testing.T) {
+	engine := NewEconomyEngine()
+	// Factor at 0 supply is 1.5; far beyond surplus it floors at 0.1
+	projections := engine.SimulateNTicks(nil, map[ResourceType]float64{ResourceMineral: 10000}, 3)
+	require.Len(t, projections, 4)
+	assert.InDelta(t, 0.3*0.1/1.5, projections[3].Prices[ResourceMineral].SellPrice, 1e-9)
+	assert.Greater(t, projections[3].Prices[ResourceMineral].SellPrice, 0.0)
+}
+
+func TestSimulateNTicks_Bounds(t *testing.T) {
+	engine := NewEconomyEngine()
+	assert.Nil(t, engine.SimulateNTicks(nil, nil, -1))
+	assert.Len(t, engine.SimulateNTicks(nil, nil, 0), 1)
+}
+
+func TestEconomyConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+
+	cfg := DefaultConfig()
+	cfg.PriceElasticity = -0.1
+	assert.Error(t, cfg.Validate())
+
+	cfg = DefaultConfig()
+	cfg.SurplusThresholds[ResourceMineral] = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg = DefaultConfig()
+	cfg.SurplusThresholds["gold"] = 10
+	assert.ErrorIs(t, cfg.Validate(), ErrUnknownResource)
+
+	engine := NewEconomyEngine()
+	assert.Error(t, engine.UpdateConfig(cfg))
+	assert.Equal(t, DefaultConfig(), engine.GetConfig(), "rejected config is not applied")
+}
+
+func TestGetConfig_ReturnsCopy(t *testing.T) {
+	engine := NewEconomyEngine()
+	cfg := engine.GetConfig()
+	cfg.SurplusThresholds[ResourceMineral] = 1
+	assert.Equal(t, 1000.0, engine.GetConfig().SurplusThresholds[ResourceMineral])
+}
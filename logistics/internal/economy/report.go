@@ -0,0 +1,116 @@
+package economy
+
+import "time"
+
+// Trend describes the direction of recent price movement.
+type Trend string
+
+const (
+	TrendRising  Trend = "rising"
+	TrendFalling Trend = "falling"
+	TrendStable  Trend = "stable"
+)
+
+const (
+	// trendWindow is the number of most recent price records used for trends.
+	trendWindow = 10
+	// trendThreshold is the relative change between the older and newer half
+	// of the trend window needed to call a trend rising or falling.
+	trendThreshold = 0.02
+	// reportAveragingWindow is the lookback for MarketReport average prices.
+	reportAveragingWindow = 24 * time.Hour
+)
+
+// ResourceReport summarises the market for a single resource.
+type ResourceReport struct {
+	CurrentBuy       float64
+	CurrentSell      float64
+	AvgSell24h       float64 // Mean sell price recorded in the last 24h (current price if none)
+	Trend            Trend
+	LastTradeValue   float64 // TotalValue of the most recent trade (0 if none)
+	TotalUnitsTraded float64 // Sum of quantities across the retained ledger
+}
+
+// MarketReport is a market overview across all priced resources.
+type MarketReport struct {
+	Resources   map[ResourceType]ResourceReport
+	GeneratedAt time.Time
+}
+
+// GenerateMarketReport summarises current prices, recent price history and
+// trade volume for every priced resource.
+//
+// The trend compares the mean sell price of the older and newer halves of
+// the last 10 price records: a rise of more than 2% is TrendRising, a fall of
+// more than 2% is TrendFalling, and anything else (including fewer than two
+// records) is TrendStable.
+func (e *EconomyEngine) GenerateMarketReport() MarketReport {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	now := time.Now()
+	report := MarketReport{
+		Resources:   make(map[ResourceType]ResourceReport, len(e.prices)),
+		GeneratedAt: now,
+	}
+	for rt, price := range e.prices {
+		history := e.priceHistory[rt]
+		rr := ResourceReport{
+			CurrentBuy:  price.BuyPrice,
+			CurrentSell: price.SellPrice,
+			AvgSell24h:  price.SellPrice,
+			Trend:       priceTrend(history),
+		}
+
+		sum, count := 0.0, 0
+		for _, rec := range history {
+			if now.Sub(rec.Timestamp) <= reportAveragingWindow {
+				sum += rec.SellPrice
+				count++
+			}
+		}
+		if count > 0 {
+			rr.AvgSell24h = sum / float64(count)
+		}
+
+		for _, trade := range e.ledger {
+			if trade.Resource == rt {
+				rr.TotalUnitsTraded += trade.Quantity
+				rr.LastTradeValue = trade.TotalValue
+			}
+		}
+		report.Resources[rt] = rr
+	}
+	return report
+}
+
+// priceTrend classifies the sell-price movement over the last trendWindow
+// records of history (oldest first).
+func priceTrend(history []PriceRecord) Trend {
+	if len(history) > trendWindow {
+		history = history[len(history)-trendWindow:]
+	}
+	half := len(history) / 2
+	if half == 0 {
+		return TrendStable
+	}
+
+	older := meanSellPrice(history[:half])
+	newer := meanSellPrice(history[len(history)-half:])
+	switch {
+	case newer > older*(1+trendThreshold):
+		return TrendRising
+	case newer < older*(1-trendThreshold):
+		return TrendFalling
+	default:
+		return TrendStable
+	}
+}
+
+func meanSellPrice(records []PriceRecord) float64 {
+	sum := 0.0
+	for _, r := range records {
+		sum += r.SellPrice
+	}
+	return sum / float64(len(records))
+}
@@ -0,0 +1,90 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMarketReport_RisingTrend(t *testing.T) {
+	engine := NewEconomyEngine()
+	for i := 0; i < 10; i++ {
+		sell := 0.30 + 0.02*float64(i)
+		require.NoError(t, engine.SetPrice(ResourceMineral, sell+0.2, sell))
+	}
+
+	mineral := engine.GenerateMarketReport().Resources[ResourceMineral]
+	assert.Equal(t, TrendRising, mineral.Trend)
+	assert.InDelta(t, 0.48, mineral.CurrentSell, 1e-9)
+	assert.InDelta(t, 0.68, mineral.CurrentBuy, 1e-9)
+	assert.InDelta(t, 0.39, mineral.AvgSell24h, 1e-9)
+}
+
+func TestGenerateMarketReport_FallingTrend(t *testing.T) {
+	engine := NewEconomyEngine()
+	for i := 0; i < 10; i++ {
+		sell := 4.0 - 0.1*float64(i)
+		require.NoError(t, engine.SetPrice(ResourceRapidlum, 5.0, sell))
+	}
+
+	assert.Equal(t, TrendFalling, engine.GenerateMarketReport().Resources[ResourceRapidlum].Trend)
+}
+
+func TestGenerateMarketReport_StableTrend(t *testing.T) {
+	engine := NewEconomyEngine()
+	// Alternating prices within 1% of each other
+	for i := 0; i < 10; i++ {
+		sell := 0.300
+		if i%2 == 1 {
+			sell = 0.303
+		}
+		require.NoError(t, engine.SetPrice(ResourceMineral, 0.5, sell))
+	}
+
+	assert.Equal(t, TrendStable, engine.GenerateMarketReport().Resources[ResourceMineral].Trend)
+}
+
+func TestGenerateMarketReport_NoHistory(t *testing.T) {
+	engine := NewEconomyEngine()
+
+	report := engine.GenerateMarketReport()
+	require.Len(t, report.Resources, 3)
+	mineral := report.Resources[ResourceMineral]
+	assert.Equal(t, TrendStable, mineral.Trend)
+	assert.InDelta(t, 0.3, mineral.AvgSell24h, 1e-9)
+	assert.InDelta(t, 0.3, mineral.CurrentSell, 1e-9)
+	assert.Zero(t, mineral.LastTradeValue)
+	assert.Zero(t, mineral.TotalUnitsTraded)
+}
+
+func TestGenerateMarketReport_TradeVolume(t *testing.T) {
+	engine := NewEconomyEngine()
+	_, err := engine.RecordTrade(ResourceMineral, 100, true)
+	require.NoError(t, err)
+	_, err = engine.RecordTrade(ResourceMineral, 40, false)
+	require.NoError(t, err)
+	_, err = engine.RecordTrade(ResourceSim, 5, false)
+	require.NoError(t, err)
+
+	mineral := engine.GenerateMarketReport().Resources[ResourceMineral]
+	assert.InDelta(t, 140, mineral.TotalUnitsTraded, 1e-9)
+	assert.InDelta(t, 40*0.3, mineral.LastTradeValue, 1e-9)
+}
+
+func TestSetPrice(t *testing.T) {
+	engine := NewEconomyEngine()
+	before, _ := engine.GetPrice(ResourceSim)
+
+	require.NoError(t, engine.SetPrice(ResourceSim, 2.0, 1.5))
+	after, _ := engine.GetPrice(ResourceSim)
+	assert.InDelta(t, 2.0, after.BuyPrice, 1e-9)
+	assert.InDelta(t, 1.0, before.BuyPrice, 1e-9, "earlier snapshots are not mutated")
+
+	history := engine.GetPriceHistory(ResourceSim, 0)
+	require.Len(t, history, 1)
+	assert.InDelta(t, 1.5, history[0].SellPrice, 1e-9)
+
+	assert.ErrorIs(t, engine.SetPrice("unobtainium", 1, 1), ErrUnknownResource)
+	assert.Error(t, engine.SetPrice(ResourceSim, 0, 1))
+}
@@ -0,0 +1,94 @@
+package economy
+
+import "fmt"
+
+// ActiveShock is a temporary price multiplier applied by EconomyTick.
+type ActiveShock struct {
+	Resource       ResourceType
+	BuyMultiplier  float64
+	SellMultiplier float64
+	RemainingTicks int64 // Economy ticks the shock will still be applied for
+}
+
+// ApplyPriceShock schedules a price shock for a scripted narrative event
+// (disaster, windfall): for the next durationTicks calls to EconomyTick, the
+// resource's buy and sell prices are set to its base price times
+// buyMultiplier and sellMultiplier. Shocks on the same resource stack
+// multiplicatively. Once a shock expires, later EconomyTicks move the price
+// back towards its base price (see EconomyConfig.PriceRecoveryRate). Returns an *UnknownResourceError for unpriced resources and an
+// error if a multiplier or the duration is not positive.
+func (e *EconomyEngine) ApplyPriceShock(rt ResourceType, buyMultiplier, sellMultiplier float64, durationTicks int64) error {
+	if buyMultiplier <= 0 || sellMultiplier <= 0 {
+		return fmt.Errorf("shock multipliers must be positive, got buy=%v sell=%v", buyMultiplier, sellMultiplier)
+	}
+	if durationTicks <= 0 {
+		return fmt.Errorf("shock duration must be positive, got %d", durationTicks)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.prices[rt]; !ok {
+		return &UnknownResourceError{Resource: string(rt)}
+	}
+	e.shocks = append(e.shocks, ActiveShock{
+		Resource:       rt,
+		BuyMultiplier:  buyMultiplier,
+		SellMultiplier: sellMultiplier,
+		RemainingTicks: durationTicks,
+	})
+	return nil
+}
+
+// GetActiveShocks returns the price shocks that have not yet expired, in the
+// order they were applied.
+func (e *EconomyEngine) GetActiveShocks() []ActiveShock {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]ActiveShock{}, e.shocks...)
+}
+
+// EconomyTick advances the market by one tick. A resource with active shocks
+// is priced at its base price times their multipliers; any other resource's
+// price closes PriceRecoveryRate of the gap to its base price. Prices that
+// change are appended to the price history and each resource's deviation
+// from equilibrium is tracked (see GetRecoveryProgress). Every active shock
+// then loses one remaining tick, and shocks that reach zero expire, so the
+// prices they set hold until the next EconomyTick. Finally the trade volumes
+// counted against the per-tick trade limits are reset.
+func (e *EconomyEngine) EconomyTick() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for rt, base := range e.basePrices {
+		current := e.prices[rt]
+		buy := recoverPrice(current.BuyPrice, base.BuyPrice, e.config.PriceRecoveryRate)
+		sell := recoverPrice(current.SellPrice, base.SellPrice, e.config.PriceRecoveryRate)
+		if e.isShocked(rt) {
+			buy, sell = base.BuyPrice, base.SellPrice
+			for _, shock := range e.shocks {
+				if shock.Resource == rt {
+					buy *= shock.BuyMultiplier
+					sell *= shock.SellMultiplier
+				}
+			}
+		}
+		if current.BuyPrice != buy || current.SellPrice != sell {
+			e.setPriceLocked(rt, buy, sell)
+		}
+		e.trackDeviation(rt)
+	}
+
+	remaining := e.shocks[:0]
+	for _, shock := range e.shocks {
+		shock.RemainingTicks--
+		if shock.RemainingTicks > 0 {
+			remaining = append(remaining, shock)
+		}
+	}
+	e.shocks = remaining
+
+	clear(e.buyVolume)
+	clear(e.sellVolume)
+	e.ticks++
+}
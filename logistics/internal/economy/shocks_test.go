@@ -0,0 +1,93 @@
+package economy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newInstantRecoveryEngine returns an engine whose prices return to their
+// base price as soon as their shocks expire.
+func newInstantRecoveryEngine(t *testing.T) *EconomyEngine {
+	t.Helper()
+	engine := NewEconomyEngine()
+	cfg := engine.GetConfig()
+	cfg.PriceRecoveryRate = 1
+	require.NoError(t, engine.UpdateConfig(cfg))
+	return engine
+}
+
+func TestApplyPriceShock_ExpiresAfterDuration(t *testing.T) {
+	engine := newInstantRecoveryEngine(t)
+	require.NoError(t, engine.ApplyPriceShock(ResourceMineral, 1.0, 2.0, 3))
+
+	for tick := 1; tick <= 4; tick++ {
+		engine.EconomyTick()
+		price, _ := engine.GetPrice(ResourceMineral)
+		if tick <= 3 {
+			assert.InDelta(t, 0.6, price.SellPrice, 1e-9, "tick %d", tick)
+		} else {
+			assert.InDelta(t, 0.3, price.SellPrice, 1e-9, "tick %d", tick)
+		}
+		assert.InDelta(t, 0.5, price.BuyPrice, 1e-9, "tick %d", tick)
+	}
+	assert.Empty(t, engine.GetActiveShocks())
+
+	// Shocked and restored prices are recorded; unshocked resources are not
+	assert.Len(t, engine.GetPriceHistory(ResourceMineral, 0), 2)
+	assert.Empty(t, engine.GetPriceHistory(ResourceRapidlum, 0))
+}
+
+func TestApplyPriceShock_StacksMultiplicatively(t *testing.T) {
+	engine := newInstantRecoveryEngine(t)
+	require.NoError(t, engine.ApplyPriceShock(ResourceRapidlum, 2.0, 0.5, 2))
+	require.NoError(t, engine.ApplyPriceShock(ResourceRapidlum, 1.5, 3.0, 1))
+
+	engine.EconomyTick()
+	price, _ := engine.GetPrice(ResourceRapidlum)
+	assert.InDelta(t, 15.0, price.BuyPrice, 1e-9) // 5 × 2 × 1.5
+	assert.InDelta(t, 6.0, price.SellPrice, 1e-9) // 4 × 0.5 × 3
+	assert.Equal(t, []ActiveShock{
+		{Resource: ResourceRapidlum, BuyMultiplier: 2.0, SellMultiplier: 0.5, RemainingTicks: 1},
+	}, engine.GetActiveShocks())
+
+	engine.EconomyTick()
+	price, _ = engine.GetPrice(ResourceRapidlum)
+	assert.InDelta(t, 10.0, price.BuyPrice, 1e-9)
+	assert.InDelta(t, 2.0, price.SellPrice, 1e-9)
+
+	engine.EconomyTick()
+	price, _ = engine.GetPrice(ResourceRapidlum)
+	assert.InDelta(t, 5.0, price.BuyPrice, 1e-9)
+	assert.InDelta(t, 4.0, price.SellPrice, 1e-9)
+}
+
+func TestApplyPriceShock_UsesBasePriceFromSetPrice(t *testing.T) {
+	engine := newInstantRecoveryEngine(t)
+	require.NoError(t, engine.ApplyPriceShock(ResourceSim, 2.0, 2.0, 2))
+	engine.EconomyTick()
+	require.NoError(t, engine.SetPrice(ResourceSim, 3.0, 2.0))
+
+	engine.EconomyTick()
+	price, _ := engine.GetPrice(ResourceSim)
+	assert.InDelta(t, 6.0, price.BuyPrice, 1e-9)
+	assert.InDelta(t, 4.0, price.SellPrice, 1e-9)
+
+	engine.EconomyTick()
+	price, _ = engine.GetPrice(ResourceSim)
+	assert.InDelta(t, 3.0, price.BuyPrice, 1e-9)
+	assert.InDelta(t, 2.0, price.SellPrice, 1e-9)
+}
+
+func TestApplyPriceShock_Errors(t *testing.T) {
+	engine := NewEconomyEngine()
+
+	err := engine.ApplyPriceShock("unobtainium", 2, 2, 1)
+	assert.True(t, errors.Is(err, ErrUnknownResource))
+	assert.Error(t, engine.ApplyPriceShock(ResourceMineral, 0, 2, 1))
+	assert.Error(t, engine.ApplyPriceShock(ResourceMineral, 2, -1, 1))
+	assert.Error(t, engine.ApplyPriceShock(ResourceMineral, 2, 2, 0))
+	assert.Empty(t, engine.GetActiveShocks())
+}
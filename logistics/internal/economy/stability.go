@@ -0,0 +1,88 @@
+package economy
+
+import "math"
+
+// equilibriumTolerance is the relative gap to the equilibrium price below
+// which a recovering price snaps to it.
+const equilibriumTolerance = 1e-6
+
+// GetCurrentDeviation returns how far rt's price is from its equilibrium
+// (base) price: |current - equilibrium| / equilibrium, taking the larger of
+// the buy and sell deviations. Returns 0 for unpriced resources.
+func (e *EconomyEngine) GetCurrentDeviation(rt ResourceType) float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.deviation(rt)
+}
+
+// GetMarketStability returns 1 - the largest current deviation across all
+// resources, floored at 0: 1 when every price is at equilibrium.
+func (e *EconomyEngine) GetMarketStability() float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	maxDeviation := 0.0
+	for rt := range e.prices {
+		maxDeviation = math.Max(maxDeviation, e.deviation(rt))
+	}
+	return math.Max(1-maxDeviation, 0)
+}
+
+// GetRecoveryProgress returns how far rt has recovered from its largest
+// deviation since it was last at equilibrium, as observed by EconomyTick:
+// 0 while fully deviated, 1 at equilibrium. Returns 1 for resources that
+// have not deviated and for unpriced resources.
+func (e *EconomyEngine) GetRecoveryProgress(rt ResourceType) float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	peak := e.peakDeviation[rt]
+	if peak == 0 {
+		return 1.0
+	}
+	return math.Max(1-e.deviation(rt)/peak, 0)
+}
+
+// deviation implements GetCurrentDeviation. Caller must hold e.mu.
+func (e *EconomyEngine) deviation(rt ResourceType) float64 {
+	current, ok := e.prices[rt]
+	if !ok {
+		return 0
+	}
+	base := e.basePrices[rt]
+	return math.Max(
+		math.Abs(current.BuyPrice-base.BuyPrice)/base.BuyPrice,
+		math.Abs(current.SellPrice-base.SellPrice)/base.SellPrice,
+	)
+}
+
+// trackDeviation records rt's current deviation as its peak if larger, and
+// forgets the peak once rt is back at equilibrium. Caller must hold e.mu.
+func (e *EconomyEngine) trackDeviation(rt ResourceType) {
+	d := e.deviation(rt)
+	if d == 0 {
+		delete(e.peakDeviation, rt)
+		return
+	}
+	e.peakDeviation[rt] = math.Max(e.peakDeviation[rt], d)
+}
+
+// isShocked reports whether rt has an active price shock. Caller must hold e.mu.
+func (e *EconomyEngine) isShocked(rt ResourceType) bool {
+	for _, shock := range e.shocks {
+		if shock.Resource == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// recoverPrice moves price rate of the way towards equilibrium, snapping to
+// it within equilibriumTolerance.
+func recoverPrice(price, equilibrium, rate float64) float64 {
+	next := price + (equilibrium-price)*rate
+	if math.Abs(next-equilibrium) <= equilibriumTolerance*equilibrium {
+		return equilibrium
+	}
+	return next
+}
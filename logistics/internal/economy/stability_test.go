@@ -0,0 +1,104 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketStability_DropsOnShockAndRecovers(t *testing.T) {
+	engine := NewEconomyEngine()
+	assert.Equal(t, 1.0, engine.GetMarketStability())
+
+	require.NoError(t, engine.ApplyPriceShock(ResourceRapidlum, 3.0, 3.0, 1))
+	engine.EconomyTick()
+
+	assert.InDelta(t, 2.0, engine.GetCurrentDeviation(ResourceRapidlum), 1e-9)
+	assert.Less(t, engine.GetMarketStability(), 1.0)
+	assert.Zero(t, engine.GetRecoveryProgress(ResourceRapidlum))
+
+	stability := engine.GetMarketStability()
+	progress := engine.GetRecoveryProgress(ResourceRapidlum)
+	for tick := 1; tick <= 20; tick++ {
+		engine.EconomyTick()
+		next := engine.GetMarketStability()
+		assert.GreaterOrEqual(t, next, stability, "tick %d", tick)
+		assert.LessOrEqual(t, next, 1.0, "tick %d", tick)
+		nextProgress := engine.GetRecoveryProgress(ResourceRapidlum)
+		assert.Greater(t, nextProgress, progress, "tick %d", tick)
+		stability, progress = next, nextProgress
+	}
+	assert.Greater(t, stability, 0.0)
+}
+
+func TestPriceRecovery_ClosesRateOfGapPerTick(t *testing.T) {
+	engine := NewEconomyEngine()
+	require.NoError(t, engine.ApplyPriceShock(ResourceMineral, 3.0, 3.0, 1))
+	engine.EconomyTick()
+
+	deviation := engine.GetCurrentDeviation(ResourceMineral)
+	for tick := 1; tick <= 5; tick++ {
+		engine.EconomyTick()
+		next := engine.GetCurrentDeviation(ResourceMineral)
+		assert.InDelta(t, deviation*(1-0.05), next, 1e-9, "tick %d", tick)
+		deviation = next
+	}
+
+	price, _ := engine.GetPrice(ResourceMineral)
+	assert.InDelta(t, 0.5+1.0*0.95*0.95*0.95*0.95*0.95, price.BuyPrice, 1e-9) // gap of 1.0 shrinks 5%/tick
+}
+
+func TestPriceRecovery_ReachesEquilibrium(t *testing.T) {
+	engine := NewEconomyEngine()
+	cfg := engine.GetConfig()
+	cfg.PriceRecoveryRate = 0.5
+	require.NoError(t, engine.UpdateConfig(cfg))
+	require.NoError(t, engine.ApplyPriceShock(ResourceSim, 0.5, 0.5, 1))
+
+	for i := 0; i < 40; i++ {
+		engine.EconomyTick()
+	}
+
+	price, _ := engine.GetPrice(ResourceSim)
+	assert.Equal(t, 1.0, price.BuyPrice)
+	assert.Equal(t, 0.8, price.SellPrice)
+	assert.Equal(t, 1.0, engine.GetRecoveryProgress(ResourceSim))
+	assert.Equal(t, 1.0, engine.GetMarketStability())
+}
+
+func TestPriceRecovery_HeldWhileShockActive(t *testing.T) {
+	engine := NewEconomyEngine()
+	require.NoError(t, engine.ApplyPriceShock(ResourceSim, 2.0, 2.0, 3))
+
+	for i := 0; i < 3; i++ {
+		engine.EconomyTick()
+		price, _ := engine.GetPrice(ResourceSim)
+		assert.InDelta(t, 2.0, price.BuyPrice, 1e-9)
+	}
+	engine.EconomyTick()
+	price, _ := engine.GetPrice(ResourceSim)
+	assert.InDelta(t, 1.95, price.BuyPrice, 1e-9)
+}
+
+func TestPriceRecovery_SetPriceResetsEquilibrium(t *testing.T) {
+	engine := NewEconomyEngine()
+	require.NoError(t, engine.ApplyPriceShock(ResourceSim, 2.0, 2.0, 1))
+	engine.EconomyTick()
+
+	require.NoError(t, engine.SetPrice(ResourceSim, 4.0, 3.0))
+
+	assert.Zero(t, engine.GetCurrentDeviation(ResourceSim))
+	assert.Equal(t, 1.0, engine.GetRecoveryProgress(ResourceSim))
+}
+
+func TestPriceRecoveryRate_Validation(t *testing.T) {
+	engine := NewEconomyEngine()
+	assert.InDelta(t, 0.05, engine.GetConfig().PriceRecoveryRate, 1e-9)
+
+	cfg := engine.GetConfig()
+	cfg.PriceRecoveryRate = 1.5
+	assert.Error(t, engine.UpdateConfig(cfg))
+	cfg.PriceRecoveryRate = -0.1
+	assert.Error(t, engine.UpdateConfig(cfg))
+}
@@ -0,0 +1,79 @@
+package economy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLimitedEngine(t *testing.T, rt ResourceType, limits TradeLimitConfig) *EconomyEngine {
+	t.Helper()
+	engine := NewEconomyEngine()
+	cfg := engine.GetConfig()
+	cfg.TradeLimits = map[ResourceType]TradeLimitConfig{rt: limits}
+	require.NoError(t, engine.UpdateConfig(cfg))
+	return engine
+}
+
+func TestRecordTrade_SellLimitExceeded(t *testing.T) {
+	engine := newLimitedEngine(t, ResourceMineral, TradeLimitConfig{MaxSellPerTick: 100})
+
+	_, err := engine.RecordTrade(ResourceMineral, 150, false)
+
+	var limitErr *TradeLimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	assert.ErrorIs(t, err, ErrTradeLimitExceeded)
+	assert.Equal(t, 100.0, limitErr.Allowed)
+	assert.Equal(t, 150.0, limitErr.Requested)
+	assert.Empty(t, engine.GetLedger(0), "rejected trades are not recorded")
+}
+
+func TestRecordTrade_VolumeAccumulatesUntilEconomyTick(t *testing.T) {
+	engine := newLimitedEngine(t, ResourceMineral, TradeLimitConfig{MaxSellPerTick: 100})
+
+	_, err := engine.RecordTrade(ResourceMineral, 50, false)
+	require.NoError(t, err)
+	_, err = engine.RecordTrade(ResourceMineral, 50, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, engine.GetRemainingTradeCapacity(ResourceMineral, false))
+
+	_, err = engine.RecordTrade(ResourceMineral, 1, false)
+	var limitErr *TradeLimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, 0.0, limitErr.Allowed)
+
+	engine.EconomyTick()
+	assert.Equal(t, 100.0, engine.GetRemainingTradeCapacity(ResourceMineral, false))
+	_, err = engine.RecordTrade(ResourceMineral, 100, false)
+	assert.NoError(t, err)
+}
+
+func TestRecordTrade_LimitsArePerSide(t *testing.T) {
+	engine := newLimitedEngine(t, ResourceMineral, TradeLimitConfig{MaxSellPerTick: 100, MaxBuyPerTick: 10})
+
+	_, err := engine.RecordTrade(ResourceMineral, 80, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 10.0, engine.GetRemainingTradeCapacity(ResourceMineral, true))
+	assert.Equal(t, 20.0, engine.GetRemainingTradeCapacity(ResourceMineral, false))
+	_, err = engine.RecordTrade(ResourceMineral, 11, true)
+	assert.ErrorIs(t, err, ErrTradeLimitExceeded)
+}
+
+func TestGetRemainingTradeCapacity_Unlimited(t *testing.T) {
+	engine := newLimitedEngine(t, ResourceMineral, TradeLimitConfig{MaxSellPerTick: 100})
+
+	assert.True(t, math.IsInf(engine.GetRemainingTradeCapacity(ResourceMineral, true), 1), "zero limit is unlimited")
+	assert.True(t, math.IsInf(engine.GetRemainingTradeCapacity(ResourceSim, false), 1), "unlisted resource is unlimited")
+}
+
+func TestEconomyConfig_ValidateTradeLimits(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TradeLimits = map[ResourceType]TradeLimitConfig{ResourceMineral: {MaxSellPerTick: -1}}
+	assert.Error(t, cfg.Validate())
+
+	cfg.TradeLimits = map[ResourceType]TradeLimitConfig{"gold": {MaxSellPerTick: 1}}
+	assert.ErrorIs(t, cfg.Validate(), ErrUnknownResource)
+}
@@ -0,0 +1,78 @@
+package economy
+
+import "sort"
+
+// TradePlanEntry is one prospective trade in a plan.
+type TradePlanEntry struct {
+	ResourceType ResourceType
+	Quantity     float64
+	IsBuy        bool
+}
+
+// TradePlanAnalysis summarises the cost and efficiency of a trade plan at
+// current market prices.
+type TradePlanAnalysis struct {
+	TotalCost          float64        // Sum of buy quantities × BuyPrice
+	TotalRevenue       float64        // Sum of sell quantities × SellPrice
+	NetProfit          float64        // TotalRevenue - TotalCost
+	ResourcesAffected  []ResourceType // Priced resources in the plan, sorted
+	BottleneckResource string         // Affected resource with the widest relative spread ("" if none)
+
+	// BreakevenQuantities holds, per affected resource, the additional units
+	// that would have to be sold at its current SellPrice to cover a net
+	// loss (0 when the plan is not loss-making).
+	BreakevenQuantities map[ResourceType]float64
+}
+
+// AnalyzeTradePlan prices every entry of plan at current market rates
+// without recording any trades. The bottleneck is the affected resource with
+// the highest (BuyPrice - SellPrice) / BuyPrice; ties go to the resource that
+// sorts first. Entries for unpriced resources or with non-positive
+// quantities are ignored.
+func (e *EconomyEngine) AnalyzeTradePlan(plan []TradePlanEntry) TradePlanAnalysis {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	analysis := TradePlanAnalysis{
+		ResourcesAffected:   make([]ResourceType, 0),
+		BreakevenQuantities: make(map[ResourceType]float64),
+	}
+	affected := make(map[ResourceType]bool)
+	for _, entry := range plan {
+		price, ok := e.prices[entry.ResourceType]
+		if !ok || entry.Quantity <= 0 {
+			continue
+		}
+		if entry.IsBuy {
+			analysis.TotalCost += entry.Quantity * price.BuyPrice
+		} else {
+			analysis.TotalRevenue += entry.Quantity * price.SellPrice
+		}
+		affected[entry.ResourceType] = true
+	}
+	analysis.NetProfit = analysis.TotalRevenue - analysis.TotalCost
+
+	for rt := range affected {
+		analysis.ResourcesAffected = append(analysis.ResourcesAffected, rt)
+	}
+	sort.Slice(analysis.ResourcesAffected, func(i, j int) bool {
+		return analysis.ResourcesAffected[i] < analysis.ResourcesAffected[j]
+	})
+
+	worstSpread := 0.0
+	for _, rt := range analysis.ResourcesAffected {
+		price := e.prices[rt]
+		spread := (price.BuyPrice - price.SellPrice) / price.BuyPrice
+		if analysis.BottleneckResource == "" || spread > worstSpread {
+			analysis.BottleneckResource = string(rt)
+			worstSpread = spread
+		}
+
+		breakeven := 0.0
+		if analysis.NetProfit < 0 {
+			breakeven = -analysis.NetProfit / price.SellPrice
+		}
+		analysis.BreakevenQuantities[rt] = breakeven
+	}
+	return analysis
+}
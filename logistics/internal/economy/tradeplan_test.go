@@ -0,0 +1,47 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeTradePlan_CostRevenueAndBottleneck(t *testing.T) {
+	engine := NewEconomyEngine()
+
+	analysis := engine.AnalyzeTradePlan([]TradePlanEntry{
+		{ResourceType: ResourceMineral, Quantity: 100, IsBuy: true},
+		{ResourceType: ResourceRapidlum, Quantity: 20},
+	})
+	assert.InDelta(t, 50.0, analysis.TotalCost, 1e-9)    // 100 × 0.5
+	assert.InDelta(t, 80.0, analysis.TotalRevenue, 1e-9) // 20 × 4.0
+	assert.InDelta(t, 30.0, analysis.NetProfit, 1e-9)
+	assert.Equal(t, []ResourceType{ResourceMineral, ResourceRapidlum}, analysis.ResourcesAffected)
+	// Spreads: mineral (0.5-0.3)/0.5 = 0.4, rapidlum (5-4)/5 = 0.2
+	assert.Equal(t, string(ResourceMineral), analysis.BottleneckResource)
+	assert.Equal(t, map[ResourceType]float64{ResourceMineral: 0, ResourceRapidlum: 0}, analysis.BreakevenQuantities)
+
+	assert.Empty(t, engine.GetLedger(0), "analysis must not record trades")
+}
+
+func TestAnalyzeTradePlan_BreakevenOnLoss(t *testing.T) {
+	engine := NewEconomyEngine()
+
+	analysis := engine.AnalyzeTradePlan([]TradePlanEntry{
+		{ResourceType: ResourceRapidlum, Quantity: 100, IsBuy: true},
+		{ResourceType: ResourceSim, Quantity: 10},
+		{ResourceType: "unobtainium", Quantity: 5},
+		{ResourceType: ResourceMineral, Quantity: 0, IsBuy: true},
+	})
+	assert.InDelta(t, -492.0, analysis.NetProfit, 1e-9)
+	assert.Equal(t, []ResourceType{ResourceRapidlum, ResourceSim}, analysis.ResourcesAffected)
+	assert.InDelta(t, 123.0, analysis.BreakevenQuantities[ResourceRapidlum], 1e-9) // 492 / 4.0
+	assert.InDelta(t, 615.0, analysis.BreakevenQuantities[ResourceSim], 1e-9)      // 492 / 0.8
+}
+
+func TestAnalyzeTradePlan_Empty(t *testing.T) {
+	analysis := NewEconomyEngine().AnalyzeTradePlan(nil)
+	assert.Zero(t, analysis.NetProfit)
+	assert.Empty(t, analysis.ResourcesAffected)
+	assert.Empty(t, analysis.BottleneckResource)
+}
@@ -80,6 +80,7 @@ type RebellionResponse struct {
 	Factors           *RebellionFactors      `protobuf:"bytes,3,opt,name=factors,proto3" json:"factors,omitempty"`
 	ThresholdExceeded bool                   `protobuf:"varint,4,opt,name=threshold_exceeded,json=thresholdExceeded,proto3" json:"threshold_exceeded,omitempty"`
 	CalculatedAt      *EpochTimestamp        `protobuf:"bytes,5,opt,name=calculated_at,json=calculatedAt,proto3" json:"calculated_at,omitempty"`
+	VetoTriggered     bool                   `protobuf:"varint,6,opt,name=veto_triggered,json=vetoTriggered,proto3" json:"veto_triggered,omitempty"` // probability >= veto threshold (0.80)
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -149,14 +150,22 @@ func (x *RebellionResponse) GetCalculatedAt() *EpochTimestamp {
 	return nil
 }
 
+func (x *RebellionResponse) GetVetoTriggered() bool {
+	if x != nil {
+		return x.VetoTriggered
+	}
+	return false
+}
+
 type RebellionFactors struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Base               float64                `protobuf:"fixed64,1,opt,name=base,proto3" json:"base,omitempty"`                                                       // 0.05 baseline
-	TraumaModifier     float64                `protobuf:"fixed64,2,opt,name=trauma_modifier,json=traumaModifier,proto3" json:"trauma_modifier,omitempty"`             // avgTrauma * 0.3
-	EfficiencyModifier float64                `protobuf:"fixed64,3,opt,name=efficiency_modifier,json=efficiencyModifier,proto3" json:"efficiency_modifier,omitempty"` // (1 - efficiency) * 0.3
-	MoraleModifier     float64                `protobuf:"fixed64,4,opt,name=morale_modifier,json=moraleModifier,proto3" json:"morale_modifier,omitempty"`             // (1 - morale) * 0.2
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Base                 float64                `protobuf:"fixed64,1,opt,name=base,proto3" json:"base,omitempty"`                                                             // 0.05 baseline
+	TraumaModifier       float64                `protobuf:"fixed64,2,opt,name=trauma_modifier,json=traumaModifier,proto3" json:"trauma_modifier,omitempty"`                   // avgTrauma * 0.3
+	EfficiencyModifier   float64                `protobuf:"fixed64,3,opt,name=efficiency_modifier,json=efficiencyModifier,proto3" json:"efficiency_modifier,omitempty"`       // (1 - efficiency) * 0.3
+	MoraleModifier       float64                `protobuf:"fixed64,4,opt,name=morale_modifier,json=moraleModifier,proto3" json:"morale_modifier,omitempty"`                   // (1 - morale) * 0.2
+	RelationshipModifier float64                `protobuf:"fixed64,5,opt,name=relationship_modifier,json=relationshipModifier,proto3" json:"relationship_modifier,omitempty"` // sum(affinityWeight * (0.5 - peerMorale)) over related NPCs; |affinity| below 0.5 morale
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *RebellionFactors) Reset() {
@@ -217,6 +226,13 @@ func (x *RebellionFactors) GetMoraleModifier() float64 {
 	return 0
 }
 
+func (x *RebellionFactors) GetRelationshipModifier() float64 {
+	if x != nil {
+		return x.RelationshipModifier
+	}
+	return 0
+}
+
 type ProcessActionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Action        *NPCAction             `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
@@ -270,13 +286,17 @@ func (x *ProcessActionRequest) GetDryRun() bool {
 }
 
 type ProcessActionResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	UpdatedState       *NPCState              `protobuf:"bytes,1,opt,name=updated_state,json=updatedState,proto3" json:"updated_state,omitempty"`
-	RebellionDelta     float64                `protobuf:"fixed64,2,opt,name=rebellion_delta,json=rebellionDelta,proto3" json:"rebellion_delta,omitempty"` // Change in rebellion probability
-	RebellionTriggered bool                   `protobuf:"varint,3,opt,name=rebellion_triggered,json=rebellionTriggered,proto3" json:"rebellion_triggered,omitempty"`
-	RebellionEvent     *RebellionEvent        `protobuf:"bytes,4,opt,name=rebellion_event,json=rebellionEvent,proto3" json:"rebellion_event,omitempty"` // Set if triggered
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedState              *NPCState              `protobuf:"bytes,1,opt,name=updated_state,json=updatedState,proto3" json:"updated_state,omitempty"`
+	RebellionDelta            float64                `protobuf:"fixed64,2,opt,name=rebellion_delta,json=rebellionDelta,proto3" json:"rebellion_delta,omitempty"` // Change in rebellion probability
+	RebellionTriggered        bool                   `protobuf:"varint,3,opt,name=rebellion_triggered,json=rebellionTriggered,proto3" json:"rebellion_triggered,omitempty"`
+	RebellionEvent            *RebellionEvent        `protobuf:"bytes,4,opt,name=rebellion_event,json=rebellionEvent,proto3" json:"rebellion_event,omitempty"`                                    // Set if triggered
+	StatDeltas                *NPCStatDelta          `protobuf:"bytes,5,opt,name=stat_deltas,json=statDeltas,proto3" json:"stat_deltas,omitempty"`                                                // Per-attribute change (post - pre)
+	PredictedProbabilityRange *ProbabilityRange      `protobuf:"bytes,6,opt,name=predicted_probability_range,json=predictedProbabilityRange,proto3" json:"predicted_probability_range,omitempty"` // Post probability with intensity ±10%
+	CorrelationId             string                 `protobuf:"bytes,7,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`                                       // Correlation ID of the telemetry events emitted for the action (empty for dry runs)
+	SuppressedUntilTick       int64                  `protobuf:"varint,8,opt,name=suppressed_until_tick,json=suppressedUntilTick,proto3" json:"suppressed_until_tick,omitempty"`                  // Tick at which the NPC's suppression expires (0 if not suppressed)
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
 }
 
 func (x *ProcessActionResponse) Reset() {
@@ -337,6 +357,154 @@ func (x *ProcessActionResponse) GetRebellionEvent() *RebellionEvent {
 	return nil
 }
 
+func (x *ProcessActionResponse) GetStatDeltas() *NPCStatDelta {
+	if x != nil {
+		return x.StatDeltas
+	}
+	return nil
+}
+
+func (x *ProcessActionResponse) GetPredictedProbabilityRange() *ProbabilityRange {
+	if x != nil {
+		return x.PredictedProbabilityRange
+	}
+	return nil
+}
+
+func (x *ProcessActionResponse) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ProcessActionResponse) GetSuppressedUntilTick() int64 {
+	if x != nil {
+		return x.SuppressedUntilTick
+	}
+	return 0
+}
+
+type NPCStatDelta struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	MoraleDelta         float64                `protobuf:"fixed64,1,opt,name=morale_delta,json=moraleDelta,proto3" json:"morale_delta,omitempty"`
+	WorkEfficiencyDelta float64                `protobuf:"fixed64,2,opt,name=work_efficiency_delta,json=workEfficiencyDelta,proto3" json:"work_efficiency_delta,omitempty"`
+	TraumaDelta         float64                `protobuf:"fixed64,3,opt,name=trauma_delta,json=traumaDelta,proto3" json:"trauma_delta,omitempty"`
+	ConfidenceDelta     float64                `protobuf:"fixed64,4,opt,name=confidence_delta,json=confidenceDelta,proto3" json:"confidence_delta,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *NPCStatDelta) Reset() {
+	*x = NPCStatDelta{}
+	mi := &file_epoch_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NPCStatDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NPCStatDelta) ProtoMessage() {}
+
+func (x *NPCStatDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NPCStatDelta.ProtoReflect.Descriptor instead.
+func (*NPCStatDelta) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NPCStatDelta) GetMoraleDelta() float64 {
+	if x != nil {
+		return x.MoraleDelta
+	}
+	return 0
+}
+
+func (x *NPCStatDelta) GetWorkEfficiencyDelta() float64 {
+	if x != nil {
+		return x.WorkEfficiencyDelta
+	}
+	return 0
+}
+
+func (x *NPCStatDelta) GetTraumaDelta() float64 {
+	if x != nil {
+		return x.TraumaDelta
+	}
+	return 0
+}
+
+func (x *NPCStatDelta) GetConfidenceDelta() float64 {
+	if x != nil {
+		return x.ConfidenceDelta
+	}
+	return 0
+}
+
+type ProbabilityRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Min           float64                `protobuf:"fixed64,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,2,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbabilityRange) Reset() {
+	*x = ProbabilityRange{}
+	mi := &file_epoch_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbabilityRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbabilityRange) ProtoMessage() {}
+
+func (x *ProbabilityRange) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbabilityRange.ProtoReflect.Descriptor instead.
+func (*ProbabilityRange) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProbabilityRange) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *ProbabilityRange) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
 type NPCEventFilter struct {
 	state                   protoimpl.MessageState `protogen:"open.v1"`
 	NpcIds                  []string               `protobuf:"bytes,1,rep,name=npc_ids,json=npcIds,proto3" json:"npc_ids,omitempty"`                                                        // Empty = all NPCs
@@ -347,7 +515,7 @@ type NPCEventFilter struct {
 
 func (x *NPCEventFilter) Reset() {
 	*x = NPCEventFilter{}
-	mi := &file_epoch_proto_msgTypes[5]
+	mi := &file_epoch_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -359,7 +527,7 @@ func (x *NPCEventFilter) String() string {
 func (*NPCEventFilter) ProtoMessage() {}
 
 func (x *NPCEventFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[5]
+	mi := &file_epoch_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -372,7 +540,7 @@ func (x *NPCEventFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NPCEventFilter.ProtoReflect.Descriptor instead.
 func (*NPCEventFilter) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{5}
+	return file_epoch_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *NPCEventFilter) GetNpcIds() []string {
@@ -401,7 +569,7 @@ type NPCEventStream struct {
 
 func (x *NPCEventStream) Reset() {
 	*x = NPCEventStream{}
-	mi := &file_epoch_proto_msgTypes[6]
+	mi := &file_epoch_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -413,7 +581,7 @@ func (x *NPCEventStream) String() string {
 func (*NPCEventStream) ProtoMessage() {}
 
 func (x *NPCEventStream) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[6]
+	mi := &file_epoch_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +594,7 @@ func (x *NPCEventStream) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NPCEventStream.ProtoReflect.Descriptor instead.
 func (*NPCEventStream) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{6}
+	return file_epoch_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *NPCEventStream) GetEventType() string {
@@ -466,7 +634,7 @@ type SimStatusRequest struct {
 
 func (x *SimStatusRequest) Reset() {
 	*x = SimStatusRequest{}
-	mi := &file_epoch_proto_msgTypes[7]
+	mi := &file_epoch_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -478,7 +646,7 @@ func (x *SimStatusRequest) String() string {
 func (*SimStatusRequest) ProtoMessage() {}
 
 func (x *SimStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[7]
+	mi := &file_epoch_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,7 +659,7 @@ func (x *SimStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SimStatusRequest.ProtoReflect.Descriptor instead.
 func (*SimStatusRequest) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{7}
+	return file_epoch_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SimStatusRequest) GetIncludeDetails() bool {
@@ -512,7 +680,7 @@ type ResourceAllocationRequest struct {
 
 func (x *ResourceAllocationRequest) Reset() {
 	*x = ResourceAllocationRequest{}
-	mi := &file_epoch_proto_msgTypes[8]
+	mi := &file_epoch_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -524,7 +692,7 @@ func (x *ResourceAllocationRequest) String() string {
 func (*ResourceAllocationRequest) ProtoMessage() {}
 
 func (x *ResourceAllocationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[8]
+	mi := &file_epoch_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -537,7 +705,7 @@ func (x *ResourceAllocationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceAllocationRequest.ProtoReflect.Descriptor instead.
 func (*ResourceAllocationRequest) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{8}
+	return file_epoch_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ResourceAllocationRequest) GetTargetId() string {
@@ -572,7 +740,7 @@ type ResourceAllocationResponse struct {
 
 func (x *ResourceAllocationResponse) Reset() {
 	*x = ResourceAllocationResponse{}
-	mi := &file_epoch_proto_msgTypes[9]
+	mi := &file_epoch_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -584,7 +752,7 @@ func (x *ResourceAllocationResponse) String() string {
 func (*ResourceAllocationResponse) ProtoMessage() {}
 
 func (x *ResourceAllocationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[9]
+	mi := &file_epoch_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,7 +765,7 @@ func (x *ResourceAllocationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceAllocationResponse.ProtoReflect.Descriptor instead.
 func (*ResourceAllocationResponse) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{9}
+	return file_epoch_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ResourceAllocationResponse) GetSuccess() bool {
@@ -630,7 +798,7 @@ type AdvanceRequest struct {
 
 func (x *AdvanceRequest) Reset() {
 	*x = AdvanceRequest{}
-	mi := &file_epoch_proto_msgTypes[10]
+	mi := &file_epoch_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -642,7 +810,7 @@ func (x *AdvanceRequest) String() string {
 func (*AdvanceRequest) ProtoMessage() {}
 
 func (x *AdvanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[10]
+	mi := &file_epoch_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -655,7 +823,7 @@ func (x *AdvanceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdvanceRequest.ProtoReflect.Descriptor instead.
 func (*AdvanceRequest) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{10}
+	return file_epoch_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *AdvanceRequest) GetTicks() int32 {
@@ -665,30 +833,29 @@ func (x *AdvanceRequest) GetTicks() int32 {
 	return 0
 }
 
-type AdvanceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        *SimulationStatus      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Events        []*NPCEventStream      `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`       // Events generated during ticks
-	Telemetry     *TelemetryBatch        `protobuf:"bytes,3,opt,name=telemetry,proto3" json:"telemetry,omitempty"` // Telemetry events from tick
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type StreamTicksRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TickIntervalMs int32                  `protobuf:"varint,1,opt,name=tick_interval_ms,json=tickIntervalMs,proto3" json:"tick_interval_ms,omitempty"` // Delay between ticks (<= 0 = 1000ms)
+	MaxTicks       int32                  `protobuf:"varint,2,opt,name=max_ticks,json=maxTicks,proto3" json:"max_ticks,omitempty"`                     // Stop after this many ticks (<= 0 = until cancelled)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *AdvanceResponse) Reset() {
-	*x = AdvanceResponse{}
-	mi := &file_epoch_proto_msgTypes[11]
+func (x *StreamTicksRequest) Reset() {
+	*x = StreamTicksRequest{}
+	mi := &file_epoch_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdvanceResponse) String() string {
+func (x *StreamTicksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdvanceResponse) ProtoMessage() {}
+func (*StreamTicksRequest) ProtoMessage() {}
 
-func (x *AdvanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[11]
+func (x *StreamTicksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,56 +866,48 @@ func (x *AdvanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdvanceResponse.ProtoReflect.Descriptor instead.
-func (*AdvanceResponse) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *AdvanceResponse) GetStatus() *SimulationStatus {
-	if x != nil {
-		return x.Status
-	}
-	return nil
+// Deprecated: Use StreamTicksRequest.ProtoReflect.Descriptor instead.
+func (*StreamTicksRequest) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *AdvanceResponse) GetEvents() []*NPCEventStream {
+func (x *StreamTicksRequest) GetTickIntervalMs() int32 {
 	if x != nil {
-		return x.Events
+		return x.TickIntervalMs
 	}
-	return nil
+	return 0
 }
 
-func (x *AdvanceResponse) GetTelemetry() *TelemetryBatch {
+func (x *StreamTicksRequest) GetMaxTicks() int32 {
 	if x != nil {
-		return x.Telemetry
+		return x.MaxTicks
 	}
-	return nil
+	return 0
 }
 
-type RecentTelemetryRequest struct {
+type ListMinesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`             // Max events to return (default 50)
-	NpcId         string                 `protobuf:"bytes,2,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"` // Filter by NPC (empty = all)
-	MinSeverity   TelemetrySeverity      `protobuf:"varint,3,opt,name=min_severity,json=minSeverity,proto3,enum=epoch.telemetry.TelemetrySeverity" json:"min_severity,omitempty"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                         // 1-based (<= 0 = 1)
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // <= 0 = 10, capped at 100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecentTelemetryRequest) Reset() {
-	*x = RecentTelemetryRequest{}
-	mi := &file_epoch_proto_msgTypes[12]
+func (x *ListMinesRequest) Reset() {
+	*x = ListMinesRequest{}
+	mi := &file_epoch_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecentTelemetryRequest) String() string {
+func (x *ListMinesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecentTelemetryRequest) ProtoMessage() {}
+func (*ListMinesRequest) ProtoMessage() {}
 
-func (x *RecentTelemetryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[12]
+func (x *ListMinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -759,56 +918,49 @@ func (x *RecentTelemetryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecentTelemetryRequest.ProtoReflect.Descriptor instead.
-func (*RecentTelemetryRequest) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListMinesRequest.ProtoReflect.Descriptor instead.
+func (*ListMinesRequest) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *RecentTelemetryRequest) GetLimit() int32 {
+func (x *ListMinesRequest) GetPage() int32 {
 	if x != nil {
-		return x.Limit
+		return x.Page
 	}
 	return 0
 }
 
-func (x *RecentTelemetryRequest) GetNpcId() string {
-	if x != nil {
-		return x.NpcId
-	}
-	return ""
-}
-
-func (x *RecentTelemetryRequest) GetMinSeverity() TelemetrySeverity {
+func (x *ListMinesRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.MinSeverity
+		return x.PageSize
 	}
-	return TelemetrySeverity_TELEMETRY_SEVERITY_UNSPECIFIED
+	return 0
 }
 
-type TelemetryAck struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	EventId         string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	Accepted        bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
-	RejectionReason string                 `protobuf:"bytes,3,opt,name=rejection_reason,json=rejectionReason,proto3" json:"rejection_reason,omitempty"` // Set if not accepted (e.g., AEGIS veto)
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ListMinesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mines         []*Mine                `protobuf:"bytes,1,rep,name=mines,proto3" json:"mines,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Mines across all pages
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TelemetryAck) Reset() {
-	*x = TelemetryAck{}
-	mi := &file_epoch_proto_msgTypes[13]
+func (x *ListMinesResponse) Reset() {
+	*x = ListMinesResponse{}
+	mi := &file_epoch_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TelemetryAck) String() string {
+func (x *ListMinesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TelemetryAck) ProtoMessage() {}
+func (*ListMinesResponse) ProtoMessage() {}
 
-func (x *TelemetryAck) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[13]
+func (x *ListMinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -819,54 +971,862 @@ func (x *TelemetryAck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TelemetryAck.ProtoReflect.Descriptor instead.
-func (*TelemetryAck) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListMinesResponse.ProtoReflect.Descriptor instead.
+func (*ListMinesResponse) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *TelemetryAck) GetEventId() string {
+func (x *ListMinesResponse) GetMines() []*Mine {
 	if x != nil {
-		return x.EventId
+		return x.Mines
 	}
-	return ""
+	return nil
 }
 
-func (x *TelemetryAck) GetAccepted() bool {
+func (x *ListMinesResponse) GetTotal() int32 {
 	if x != nil {
-		return x.Accepted
+		return x.Total
 	}
-	return false
+	return 0
 }
 
-func (x *TelemetryAck) GetRejectionReason() string {
+func (x *ListMinesResponse) GetPage() int32 {
 	if x != nil {
-		return x.RejectionReason
+		return x.Page
 	}
-	return ""
+	return 0
 }
 
-type CleansingRequest struct {
+type ListRefineriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NpcIds        []string               `protobuf:"bytes,1,rep,name=npc_ids,json=npcIds,proto3" json:"npc_ids,omitempty"` // Empty = auto-select warriors/guards
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                         // 1-based (<= 0 = 1)
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // <= 0 = 10, capped at 100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CleansingRequest) Reset() {
-	*x = CleansingRequest{}
-	mi := &file_epoch_proto_msgTypes[14]
+func (x *ListRefineriesRequest) Reset() {
+	*x = ListRefineriesRequest{}
+	mi := &file_epoch_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefineriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefineriesRequest) ProtoMessage() {}
+
+func (x *ListRefineriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefineriesRequest.ProtoReflect.Descriptor instead.
+func (*ListRefineriesRequest) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListRefineriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRefineriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListRefineriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refineries    []*Refinery            `protobuf:"bytes,1,rep,name=refineries,proto3" json:"refineries,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Refineries across all pages
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefineriesResponse) Reset() {
+	*x = ListRefineriesResponse{}
+	mi := &file_epoch_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefineriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefineriesResponse) ProtoMessage() {}
+
+func (x *ListRefineriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefineriesResponse.ProtoReflect.Descriptor instead.
+func (*ListRefineriesResponse) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListRefineriesResponse) GetRefineries() []*Refinery {
+	if x != nil {
+		return x.Refineries
+	}
+	return nil
+}
+
+func (x *ListRefineriesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListRefineriesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type RemoveMineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MineId        string                 `protobuf:"bytes,1,opt,name=mine_id,json=mineId,proto3" json:"mine_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMineRequest) Reset() {
+	*x = RemoveMineRequest{}
+	mi := &file_epoch_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMineRequest) ProtoMessage() {}
+
+func (x *RemoveMineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMineRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMineRequest) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RemoveMineRequest) GetMineId() string {
+	if x != nil {
+		return x.MineId
+	}
+	return ""
+}
+
+type RemoveRefineryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefineryId    string                 `protobuf:"bytes,1,opt,name=refinery_id,json=refineryId,proto3" json:"refinery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveRefineryRequest) Reset() {
+	*x = RemoveRefineryRequest{}
+	mi := &file_epoch_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveRefineryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRefineryRequest) ProtoMessage() {}
+
+func (x *RemoveRefineryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRefineryRequest.ProtoReflect.Descriptor instead.
+func (*RemoveRefineryRequest) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RemoveRefineryRequest) GetRefineryId() string {
+	if x != nil {
+		return x.RefineryId
+	}
+	return ""
+}
+
+type RemoveFacilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *SimulationStatus      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // Status after the removal
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFacilityResponse) Reset() {
+	*x = RemoveFacilityResponse{}
+	mi := &file_epoch_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFacilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFacilityResponse) ProtoMessage() {}
+
+func (x *RemoveFacilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFacilityResponse.ProtoReflect.Descriptor instead.
+func (*RemoveFacilityResponse) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RemoveFacilityResponse) GetStatus() *SimulationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type ProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticks         int32                  `protobuf:"varint,1,opt,name=ticks,proto3" json:"ticks,omitempty"` // Ticks to project, in [1, 1000]
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectRequest) Reset() {
+	*x = ProjectRequest{}
+	mi := &file_epoch_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectRequest) ProtoMessage() {}
+
+func (x *ProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectRequest.ProtoReflect.Descriptor instead.
+func (*ProjectRequest) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ProjectRequest) GetTicks() int32 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+type ProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      []*SimulationStatus    `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"` // Status after each projected tick
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectResponse) Reset() {
+	*x = ProjectResponse{}
+	mi := &file_epoch_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectResponse) ProtoMessage() {}
+
+func (x *ProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectResponse.ProtoReflect.Descriptor instead.
+func (*ProjectResponse) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ProjectResponse) GetStatuses() []*SimulationStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type AdvanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *SimulationStatus      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Events        []*NPCEventStream      `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`       // Events generated during ticks
+	Telemetry     *TelemetryBatch        `protobuf:"bytes,3,opt,name=telemetry,proto3" json:"telemetry,omitempty"` // Telemetry events from tick
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdvanceResponse) Reset() {
+	*x = AdvanceResponse{}
+	mi := &file_epoch_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdvanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvanceResponse) ProtoMessage() {}
+
+func (x *AdvanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvanceResponse.ProtoReflect.Descriptor instead.
+func (*AdvanceResponse) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AdvanceResponse) GetStatus() *SimulationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *AdvanceResponse) GetEvents() []*NPCEventStream {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *AdvanceResponse) GetTelemetry() *TelemetryBatch {
+	if x != nil {
+		return x.Telemetry
+	}
+	return nil
+}
+
+type RecentTelemetryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`             // Max events to return (default 50)
+	NpcId         string                 `protobuf:"bytes,2,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"` // Filter by NPC (empty = all)
+	MinSeverity   TelemetrySeverity      `protobuf:"varint,3,opt,name=min_severity,json=minSeverity,proto3,enum=epoch.telemetry.TelemetrySeverity" json:"min_severity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecentTelemetryRequest) Reset() {
+	*x = RecentTelemetryRequest{}
+	mi := &file_epoch_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecentTelemetryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentTelemetryRequest) ProtoMessage() {}
+
+func (x *RecentTelemetryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentTelemetryRequest.ProtoReflect.Descriptor instead.
+func (*RecentTelemetryRequest) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RecentTelemetryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RecentTelemetryRequest) GetNpcId() string {
+	if x != nil {
+		return x.NpcId
+	}
+	return ""
+}
+
+func (x *RecentTelemetryRequest) GetMinSeverity() TelemetrySeverity {
+	if x != nil {
+		return x.MinSeverity
+	}
+	return TelemetrySeverity_TELEMETRY_SEVERITY_UNSPECIFIED
+}
+
+type TelemetryAck struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EventId         string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Accepted        bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	RejectionReason string                 `protobuf:"bytes,3,opt,name=rejection_reason,json=rejectionReason,proto3" json:"rejection_reason,omitempty"` // Set if not accepted (e.g., AEGIS veto)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TelemetryAck) Reset() {
+	*x = TelemetryAck{}
+	mi := &file_epoch_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryAck) ProtoMessage() {}
+
+func (x *TelemetryAck) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryAck.ProtoReflect.Descriptor instead.
+func (*TelemetryAck) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TelemetryAck) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *TelemetryAck) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *TelemetryAck) GetRejectionReason() string {
+	if x != nil {
+		return x.RejectionReason
+	}
+	return ""
+}
+
+type BatchImportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImportedCount int32                  `protobuf:"varint,1,opt,name=imported_count,json=importedCount,proto3" json:"imported_count,omitempty"` // Events stored after validation + dedup
+	Rejected      []string               `protobuf:"bytes,2,rep,name=rejected,proto3" json:"rejected,omitempty"`                                 // "<event_id>: <reason>" per rejected event
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchImportResponse) Reset() {
+	*x = BatchImportResponse{}
+	mi := &file_epoch_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchImportResponse) ProtoMessage() {}
+
+func (x *BatchImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchImportResponse.ProtoReflect.Descriptor instead.
+func (*BatchImportResponse) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BatchImportResponse) GetImportedCount() int32 {
+	if x != nil {
+		return x.ImportedCount
+	}
+	return 0
+}
+
+func (x *BatchImportResponse) GetRejected() []string {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+type CleansingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NpcIds        []string               `protobuf:"bytes,1,rep,name=npc_ids,json=npcIds,proto3" json:"npc_ids,omitempty"` // Empty = auto-select warriors/guards
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleansingRequest) Reset() {
+	*x = CleansingRequest{}
+	mi := &file_epoch_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleansingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleansingRequest) ProtoMessage() {}
+
+func (x *CleansingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleansingRequest.ProtoReflect.Descriptor instead.
+func (*CleansingRequest) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CleansingRequest) GetNpcIds() []string {
+	if x != nil {
+		return x.NpcIds
+	}
+	return nil
+}
+
+type CleansingResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	SuccessRate      float64                `protobuf:"fixed64,2,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	ParticipantCount int32                  `protobuf:"varint,3,opt,name=participant_count,json=participantCount,proto3" json:"participant_count,omitempty"`
+	ParticipantIds   []string               `protobuf:"bytes,4,rep,name=participant_ids,json=participantIds,proto3" json:"participant_ids,omitempty"`
+	RolledValue      float64                `protobuf:"fixed64,5,opt,name=rolled_value,json=rolledValue,proto3" json:"rolled_value,omitempty"`
+	Factors          *CleansingFactors      `protobuf:"bytes,6,opt,name=factors,proto3" json:"factors,omitempty"`
+	ErrorMessage     string                 `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CleansingResponse) Reset() {
+	*x = CleansingResponse{}
+	mi := &file_epoch_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleansingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleansingResponse) ProtoMessage() {}
+
+func (x *CleansingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleansingResponse.ProtoReflect.Descriptor instead.
+func (*CleansingResponse) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CleansingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CleansingResponse) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *CleansingResponse) GetParticipantCount() int32 {
+	if x != nil {
+		return x.ParticipantCount
+	}
+	return 0
+}
+
+func (x *CleansingResponse) GetParticipantIds() []string {
+	if x != nil {
+		return x.ParticipantIds
+	}
+	return nil
+}
+
+func (x *CleansingResponse) GetRolledValue() float64 {
+	if x != nil {
+		return x.RolledValue
+	}
+	return 0
+}
+
+func (x *CleansingResponse) GetFactors() *CleansingFactors {
+	if x != nil {
+		return x.Factors
+	}
+	return nil
+}
+
+func (x *CleansingResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type CleansingFactors struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Base                   float64                `protobuf:"fixed64,1,opt,name=base,proto3" json:"base,omitempty"`
+	AvgMorale              float64                `protobuf:"fixed64,2,opt,name=avg_morale,json=avgMorale,proto3" json:"avg_morale,omitempty"`
+	MoraleContribution     float64                `protobuf:"fixed64,3,opt,name=morale_contribution,json=moraleContribution,proto3" json:"morale_contribution,omitempty"`
+	AvgTrauma              float64                `protobuf:"fixed64,4,opt,name=avg_trauma,json=avgTrauma,proto3" json:"avg_trauma,omitempty"`
+	TraumaPenalty          float64                `protobuf:"fixed64,5,opt,name=trauma_penalty,json=traumaPenalty,proto3" json:"trauma_penalty,omitempty"`
+	AvgConfidence          float64                `protobuf:"fixed64,6,opt,name=avg_confidence,json=avgConfidence,proto3" json:"avg_confidence,omitempty"`
+	ConfidenceContribution float64                `protobuf:"fixed64,7,opt,name=confidence_contribution,json=confidenceContribution,proto3" json:"confidence_contribution,omitempty"`
+	SizeBonus              float64                `protobuf:"fixed64,8,opt,name=size_bonus,json=sizeBonus,proto3" json:"size_bonus,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CleansingFactors) Reset() {
+	*x = CleansingFactors{}
+	mi := &file_epoch_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleansingFactors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleansingFactors) ProtoMessage() {}
+
+func (x *CleansingFactors) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleansingFactors.ProtoReflect.Descriptor instead.
+func (*CleansingFactors) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CleansingFactors) GetBase() float64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *CleansingFactors) GetAvgMorale() float64 {
+	if x != nil {
+		return x.AvgMorale
+	}
+	return 0
+}
+
+func (x *CleansingFactors) GetMoraleContribution() float64 {
+	if x != nil {
+		return x.MoraleContribution
+	}
+	return 0
+}
+
+func (x *CleansingFactors) GetAvgTrauma() float64 {
+	if x != nil {
+		return x.AvgTrauma
+	}
+	return 0
+}
+
+func (x *CleansingFactors) GetTraumaPenalty() float64 {
+	if x != nil {
+		return x.TraumaPenalty
+	}
+	return 0
+}
+
+func (x *CleansingFactors) GetAvgConfidence() float64 {
+	if x != nil {
+		return x.AvgConfidence
+	}
+	return 0
+}
+
+func (x *CleansingFactors) GetConfidenceContribution() float64 {
+	if x != nil {
+		return x.ConfidenceContribution
+	}
+	return 0
+}
+
+func (x *CleansingFactors) GetSizeBonus() float64 {
+	if x != nil {
+		return x.SizeBonus
+	}
+	return 0
+}
+
+type NPCBehaviorRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // Echoed in the response
+	NpcId     string                 `protobuf:"bytes,2,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`
+	// Types that are valid to be assigned to Operation:
+	//
+	//	*NPCBehaviorRequest_Register
+	//	*NPCBehaviorRequest_ModifyMorale
+	//	*NPCBehaviorRequest_ModifyEfficiency
+	//	*NPCBehaviorRequest_GetState
+	Operation     isNPCBehaviorRequest_Operation `protobuf_oneof:"operation"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NPCBehaviorRequest) Reset() {
+	*x = NPCBehaviorRequest{}
+	mi := &file_epoch_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CleansingRequest) String() string {
+func (x *NPCBehaviorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CleansingRequest) ProtoMessage() {}
+func (*NPCBehaviorRequest) ProtoMessage() {}
 
-func (x *CleansingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[14]
+func (x *NPCBehaviorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,46 +1837,118 @@ func (x *CleansingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CleansingRequest.ProtoReflect.Descriptor instead.
-func (*CleansingRequest) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use NPCBehaviorRequest.ProtoReflect.Descriptor instead.
+func (*NPCBehaviorRequest) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *CleansingRequest) GetNpcIds() []string {
+func (x *NPCBehaviorRequest) GetRequestId() string {
 	if x != nil {
-		return x.NpcIds
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *NPCBehaviorRequest) GetNpcId() string {
+	if x != nil {
+		return x.NpcId
+	}
+	return ""
+}
+
+func (x *NPCBehaviorRequest) GetOperation() isNPCBehaviorRequest_Operation {
+	if x != nil {
+		return x.Operation
 	}
 	return nil
 }
 
-type CleansingResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	SuccessRate      float64                `protobuf:"fixed64,2,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
-	ParticipantCount int32                  `protobuf:"varint,3,opt,name=participant_count,json=participantCount,proto3" json:"participant_count,omitempty"`
-	ParticipantIds   []string               `protobuf:"bytes,4,rep,name=participant_ids,json=participantIds,proto3" json:"participant_ids,omitempty"`
-	RolledValue      float64                `protobuf:"fixed64,5,opt,name=rolled_value,json=rolledValue,proto3" json:"rolled_value,omitempty"`
-	Factors          *CleansingFactors      `protobuf:"bytes,6,opt,name=factors,proto3" json:"factors,omitempty"`
-	ErrorMessage     string                 `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *NPCBehaviorRequest) GetRegister() *RegisterNPCOperation {
+	if x != nil {
+		if x, ok := x.Operation.(*NPCBehaviorRequest_Register); ok {
+			return x.Register
+		}
+	}
+	return nil
 }
 
-func (x *CleansingResponse) Reset() {
-	*x = CleansingResponse{}
-	mi := &file_epoch_proto_msgTypes[15]
+func (x *NPCBehaviorRequest) GetModifyMorale() *ModifyAttributeOperation {
+	if x != nil {
+		if x, ok := x.Operation.(*NPCBehaviorRequest_ModifyMorale); ok {
+			return x.ModifyMorale
+		}
+	}
+	return nil
+}
+
+func (x *NPCBehaviorRequest) GetModifyEfficiency() *ModifyAttributeOperation {
+	if x != nil {
+		if x, ok := x.Operation.(*NPCBehaviorRequest_ModifyEfficiency); ok {
+			return x.ModifyEfficiency
+		}
+	}
+	return nil
+}
+
+func (x *NPCBehaviorRequest) GetGetState() *GetNPCStateOperation {
+	if x != nil {
+		if x, ok := x.Operation.(*NPCBehaviorRequest_GetState); ok {
+			return x.GetState
+		}
+	}
+	return nil
+}
+
+type isNPCBehaviorRequest_Operation interface {
+	isNPCBehaviorRequest_Operation()
+}
+
+type NPCBehaviorRequest_Register struct {
+	Register *RegisterNPCOperation `protobuf:"bytes,3,opt,name=register,proto3,oneof"`
+}
+
+type NPCBehaviorRequest_ModifyMorale struct {
+	ModifyMorale *ModifyAttributeOperation `protobuf:"bytes,4,opt,name=modify_morale,json=modifyMorale,proto3,oneof"`
+}
+
+type NPCBehaviorRequest_ModifyEfficiency struct {
+	ModifyEfficiency *ModifyAttributeOperation `protobuf:"bytes,5,opt,name=modify_efficiency,json=modifyEfficiency,proto3,oneof"`
+}
+
+type NPCBehaviorRequest_GetState struct {
+	GetState *GetNPCStateOperation `protobuf:"bytes,6,opt,name=get_state,json=getState,proto3,oneof"`
+}
+
+func (*NPCBehaviorRequest_Register) isNPCBehaviorRequest_Operation() {}
+
+func (*NPCBehaviorRequest_ModifyMorale) isNPCBehaviorRequest_Operation() {}
+
+func (*NPCBehaviorRequest_ModifyEfficiency) isNPCBehaviorRequest_Operation() {}
+
+func (*NPCBehaviorRequest_GetState) isNPCBehaviorRequest_Operation() {}
+
+type RegisterNPCOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // Empty = "worker" (or keep the existing role if already registered)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterNPCOperation) Reset() {
+	*x = RegisterNPCOperation{}
+	mi := &file_epoch_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CleansingResponse) String() string {
+func (x *RegisterNPCOperation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CleansingResponse) ProtoMessage() {}
+func (*RegisterNPCOperation) ProtoMessage() {}
 
-func (x *CleansingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[15]
+func (x *RegisterNPCOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -927,88 +1959,187 @@ func (x *CleansingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CleansingResponse.ProtoReflect.Descriptor instead.
-func (*CleansingResponse) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use RegisterNPCOperation.ProtoReflect.Descriptor instead.
+func (*RegisterNPCOperation) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *CleansingResponse) GetSuccess() bool {
+func (x *RegisterNPCOperation) GetRole() string {
 	if x != nil {
-		return x.Success
+		return x.Role
 	}
-	return false
+	return ""
 }
 
-func (x *CleansingResponse) GetSuccessRate() float64 {
+type ModifyAttributeOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delta         float64                `protobuf:"fixed64,1,opt,name=delta,proto3" json:"delta,omitempty"` // Added to the attribute, result clamped to [0, 1]
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModifyAttributeOperation) Reset() {
+	*x = ModifyAttributeOperation{}
+	mi := &file_epoch_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModifyAttributeOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifyAttributeOperation) ProtoMessage() {}
+
+func (x *ModifyAttributeOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[32]
 	if x != nil {
-		return x.SuccessRate
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CleansingResponse) GetParticipantCount() int32 {
+// Deprecated: Use ModifyAttributeOperation.ProtoReflect.Descriptor instead.
+func (*ModifyAttributeOperation) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ModifyAttributeOperation) GetDelta() float64 {
 	if x != nil {
-		return x.ParticipantCount
+		return x.Delta
 	}
 	return 0
 }
 
-func (x *CleansingResponse) GetParticipantIds() []string {
+type GetNPCStateOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNPCStateOperation) Reset() {
+	*x = GetNPCStateOperation{}
+	mi := &file_epoch_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNPCStateOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNPCStateOperation) ProtoMessage() {}
+
+func (x *GetNPCStateOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[33]
 	if x != nil {
-		return x.ParticipantIds
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CleansingResponse) GetRolledValue() float64 {
+// Deprecated: Use GetNPCStateOperation.ProtoReflect.Descriptor instead.
+func (*GetNPCStateOperation) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{33}
+}
+
+type NPCBehaviorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	State         *NPCBehaviorState      `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"` // State after the operation (unset on error)
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NPCBehaviorResponse) Reset() {
+	*x = NPCBehaviorResponse{}
+	mi := &file_epoch_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NPCBehaviorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NPCBehaviorResponse) ProtoMessage() {}
+
+func (x *NPCBehaviorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[34]
 	if x != nil {
-		return x.RolledValue
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CleansingResponse) GetFactors() *CleansingFactors {
+// Deprecated: Use NPCBehaviorResponse.ProtoReflect.Descriptor instead.
+func (*NPCBehaviorResponse) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *NPCBehaviorResponse) GetRequestId() string {
 	if x != nil {
-		return x.Factors
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *NPCBehaviorResponse) GetState() *NPCBehaviorState {
+	if x != nil {
+		return x.State
 	}
 	return nil
 }
 
-func (x *CleansingResponse) GetErrorMessage() string {
+func (x *NPCBehaviorResponse) GetErrorMessage() string {
 	if x != nil {
 		return x.ErrorMessage
 	}
 	return ""
 }
 
-type CleansingFactors struct {
-	state                  protoimpl.MessageState `protogen:"open.v1"`
-	Base                   float64                `protobuf:"fixed64,1,opt,name=base,proto3" json:"base,omitempty"`
-	AvgMorale              float64                `protobuf:"fixed64,2,opt,name=avg_morale,json=avgMorale,proto3" json:"avg_morale,omitempty"`
-	MoraleContribution     float64                `protobuf:"fixed64,3,opt,name=morale_contribution,json=moraleContribution,proto3" json:"morale_contribution,omitempty"`
-	AvgTrauma              float64                `protobuf:"fixed64,4,opt,name=avg_trauma,json=avgTrauma,proto3" json:"avg_trauma,omitempty"`
-	TraumaPenalty          float64                `protobuf:"fixed64,5,opt,name=trauma_penalty,json=traumaPenalty,proto3" json:"trauma_penalty,omitempty"`
-	AvgConfidence          float64                `protobuf:"fixed64,6,opt,name=avg_confidence,json=avgConfidence,proto3" json:"avg_confidence,omitempty"`
-	ConfidenceContribution float64                `protobuf:"fixed64,7,opt,name=confidence_contribution,json=confidenceContribution,proto3" json:"confidence_contribution,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+type NPCBehaviorState struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NpcId          string                 `protobuf:"bytes,1,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`
+	Role           string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	WorkEfficiency float64                `protobuf:"fixed64,3,opt,name=work_efficiency,json=workEfficiency,proto3" json:"work_efficiency,omitempty"`
+	Morale         float64                `protobuf:"fixed64,4,opt,name=morale,proto3" json:"morale,omitempty"`
+	AvgTrauma      float64                `protobuf:"fixed64,5,opt,name=avg_trauma,json=avgTrauma,proto3" json:"avg_trauma,omitempty"`
+	Confidence     float64                `protobuf:"fixed64,6,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	EmotionalState string                 `protobuf:"bytes,7,opt,name=emotional_state,json=emotionalState,proto3" json:"emotional_state,omitempty"`
+	AssignedTask   string                 `protobuf:"bytes,8,opt,name=assigned_task,json=assignedTask,proto3" json:"assigned_task,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *CleansingFactors) Reset() {
-	*x = CleansingFactors{}
-	mi := &file_epoch_proto_msgTypes[16]
+func (x *NPCBehaviorState) Reset() {
+	*x = NPCBehaviorState{}
+	mi := &file_epoch_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CleansingFactors) String() string {
+func (x *NPCBehaviorState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CleansingFactors) ProtoMessage() {}
+func (*NPCBehaviorState) ProtoMessage() {}
 
-func (x *CleansingFactors) ProtoReflect() protoreflect.Message {
-	mi := &file_epoch_proto_msgTypes[16]
+func (x *NPCBehaviorState) ProtoReflect() protoreflect.Message {
+	mi := &file_epoch_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1019,58 +2150,65 @@ func (x *CleansingFactors) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CleansingFactors.ProtoReflect.Descriptor instead.
-func (*CleansingFactors) Descriptor() ([]byte, []int) {
-	return file_epoch_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use NPCBehaviorState.ProtoReflect.Descriptor instead.
+func (*NPCBehaviorState) Descriptor() ([]byte, []int) {
+	return file_epoch_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *CleansingFactors) GetBase() float64 {
+func (x *NPCBehaviorState) GetNpcId() string {
 	if x != nil {
-		return x.Base
+		return x.NpcId
 	}
-	return 0
+	return ""
 }
 
-func (x *CleansingFactors) GetAvgMorale() float64 {
+func (x *NPCBehaviorState) GetRole() string {
 	if x != nil {
-		return x.AvgMorale
+		return x.Role
+	}
+	return ""
+}
+
+func (x *NPCBehaviorState) GetWorkEfficiency() float64 {
+	if x != nil {
+		return x.WorkEfficiency
 	}
 	return 0
 }
 
-func (x *CleansingFactors) GetMoraleContribution() float64 {
+func (x *NPCBehaviorState) GetMorale() float64 {
 	if x != nil {
-		return x.MoraleContribution
+		return x.Morale
 	}
 	return 0
 }
 
-func (x *CleansingFactors) GetAvgTrauma() float64 {
+func (x *NPCBehaviorState) GetAvgTrauma() float64 {
 	if x != nil {
 		return x.AvgTrauma
 	}
 	return 0
 }
 
-func (x *CleansingFactors) GetTraumaPenalty() float64 {
+func (x *NPCBehaviorState) GetConfidence() float64 {
 	if x != nil {
-		return x.TraumaPenalty
+		return x.Confidence
 	}
 	return 0
 }
 
-func (x *CleansingFactors) GetAvgConfidence() float64 {
+func (x *NPCBehaviorState) GetEmotionalState() string {
 	if x != nil {
-		return x.AvgConfidence
+		return x.EmotionalState
 	}
-	return 0
+	return ""
 }
 
-func (x *CleansingFactors) GetConfidenceContribution() float64 {
+func (x *NPCBehaviorState) GetAssignedTask() string {
 	if x != nil {
-		return x.ConfidenceContribution
+		return x.AssignedTask
 	}
-	return 0
+	return ""
 }
 
 var File_epoch_proto protoreflect.FileDescriptor
@@ -1080,26 +2218,41 @@ const file_epoch_proto_rawDesc = "" +
 	"\vepoch.proto\x12\x05epoch\x1a\fcommon.proto\x1a\tnpc.proto\x1a\x10simulation.proto\x1a\x0ftelemetry.proto\"R\n" +
 	"\x10RebellionRequest\x12\x15\n" +
 	"\x06npc_id\x18\x01 \x01(\tR\x05npcId\x12'\n" +
-	"\x0finclude_factors\x18\x02 \x01(\bR\x0eincludeFactors\"\xf1\x01\n" +
+	"\x0finclude_factors\x18\x02 \x01(\bR\x0eincludeFactors\"\x98\x02\n" +
 	"\x11RebellionResponse\x12\x15\n" +
 	"\x06npc_id\x18\x01 \x01(\tR\x05npcId\x12 \n" +
 	"\vprobability\x18\x02 \x01(\x01R\vprobability\x121\n" +
 	"\afactors\x18\x03 \x01(\v2\x17.epoch.RebellionFactorsR\afactors\x12-\n" +
 	"\x12threshold_exceeded\x18\x04 \x01(\bR\x11thresholdExceeded\x12A\n" +
-	"\rcalculated_at\x18\x05 \x01(\v2\x1c.epoch.common.EpochTimestampR\fcalculatedAt\"\xa9\x01\n" +
+	"\rcalculated_at\x18\x05 \x01(\v2\x1c.epoch.common.EpochTimestampR\fcalculatedAt\x12%\n" +
+	"\x0eveto_triggered\x18\x06 \x01(\bR\rvetoTriggered\"\xde\x01\n" +
 	"\x10RebellionFactors\x12\x12\n" +
 	"\x04base\x18\x01 \x01(\x01R\x04base\x12'\n" +
 	"\x0ftrauma_modifier\x18\x02 \x01(\x01R\x0etraumaModifier\x12/\n" +
 	"\x13efficiency_modifier\x18\x03 \x01(\x01R\x12efficiencyModifier\x12'\n" +
-	"\x0fmorale_modifier\x18\x04 \x01(\x01R\x0emoraleModifier\"]\n" +
+	"\x0fmorale_modifier\x18\x04 \x01(\x01R\x0emoraleModifier\x123\n" +
+	"\x15relationship_modifier\x18\x05 \x01(\x01R\x14relationshipModifier\"]\n" +
 	"\x14ProcessActionRequest\x12,\n" +
 	"\x06action\x18\x01 \x01(\v2\x14.epoch.npc.NPCActionR\x06action\x12\x17\n" +
-	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\xef\x01\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\xd9\x03\n" +
 	"\x15ProcessActionResponse\x128\n" +
 	"\rupdated_state\x18\x01 \x01(\v2\x13.epoch.npc.NPCStateR\fupdatedState\x12'\n" +
 	"\x0frebellion_delta\x18\x02 \x01(\x01R\x0erebellionDelta\x12/\n" +
 	"\x13rebellion_triggered\x18\x03 \x01(\bR\x12rebellionTriggered\x12B\n" +
-	"\x0frebellion_event\x18\x04 \x01(\v2\x19.epoch.npc.RebellionEventR\x0erebellionEvent\"e\n" +
+	"\x0frebellion_event\x18\x04 \x01(\v2\x19.epoch.npc.RebellionEventR\x0erebellionEvent\x124\n" +
+	"\vstat_deltas\x18\x05 \x01(\v2\x13.epoch.NPCStatDeltaR\n" +
+	"statDeltas\x12W\n" +
+	"\x1bpredicted_probability_range\x18\x06 \x01(\v2\x17.epoch.ProbabilityRangeR\x19predictedProbabilityRange\x12%\n" +
+	"\x0ecorrelation_id\x18\a \x01(\tR\rcorrelationId\x122\n" +
+	"\x15suppressed_until_tick\x18\b \x01(\x03R\x13suppressedUntilTick\"\xb3\x01\n" +
+	"\fNPCStatDelta\x12!\n" +
+	"\fmorale_delta\x18\x01 \x01(\x01R\vmoraleDelta\x122\n" +
+	"\x15work_efficiency_delta\x18\x02 \x01(\x01R\x13workEfficiencyDelta\x12!\n" +
+	"\ftrauma_delta\x18\x03 \x01(\x01R\vtraumaDelta\x12)\n" +
+	"\x10confidence_delta\x18\x04 \x01(\x01R\x0fconfidenceDelta\"6\n" +
+	"\x10ProbabilityRange\x12\x10\n" +
+	"\x03min\x18\x01 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\x01R\x03max\"e\n" +
 	"\x0eNPCEventFilter\x12\x17\n" +
 	"\anpc_ids\x18\x01 \x03(\tR\x06npcIds\x12:\n" +
 	"\x19min_rebellion_probability\x18\x02 \x01(\x01R\x17minRebellionProbability\"\xcf\x01\n" +
@@ -1120,7 +2273,37 @@ const file_epoch_proto_rawDesc = "" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12I\n" +
 	"\x0eupdated_status\x18\x03 \x01(\v2\".epoch.simulation.SimulationStatusR\rupdatedStatus\"&\n" +
 	"\x0eAdvanceRequest\x12\x14\n" +
-	"\x05ticks\x18\x01 \x01(\x05R\x05ticks\"\xbb\x01\n" +
+	"\x05ticks\x18\x01 \x01(\x05R\x05ticks\"[\n" +
+	"\x12StreamTicksRequest\x12(\n" +
+	"\x10tick_interval_ms\x18\x01 \x01(\x05R\x0etickIntervalMs\x12\x1b\n" +
+	"\tmax_ticks\x18\x02 \x01(\x05R\bmaxTicks\"C\n" +
+	"\x10ListMinesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"k\n" +
+	"\x11ListMinesResponse\x12,\n" +
+	"\x05mines\x18\x01 \x03(\v2\x16.epoch.simulation.MineR\x05mines\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\"H\n" +
+	"\x15ListRefineriesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"~\n" +
+	"\x16ListRefineriesResponse\x12:\n" +
+	"\n" +
+	"refineries\x18\x01 \x03(\v2\x1a.epoch.simulation.RefineryR\n" +
+	"refineries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\",\n" +
+	"\x11RemoveMineRequest\x12\x17\n" +
+	"\amine_id\x18\x01 \x01(\tR\x06mineId\"8\n" +
+	"\x15RemoveRefineryRequest\x12\x1f\n" +
+	"\vrefinery_id\x18\x01 \x01(\tR\n" +
+	"refineryId\"T\n" +
+	"\x16RemoveFacilityResponse\x12:\n" +
+	"\x06status\x18\x01 \x01(\v2\".epoch.simulation.SimulationStatusR\x06status\"&\n" +
+	"\x0eProjectRequest\x12\x14\n" +
+	"\x05ticks\x18\x01 \x01(\x05R\x05ticks\"Q\n" +
+	"\x0fProjectResponse\x12>\n" +
+	"\bstatuses\x18\x01 \x03(\v2\".epoch.simulation.SimulationStatusR\bstatuses\"\xbb\x01\n" +
 	"\x0fAdvanceResponse\x12:\n" +
 	"\x06status\x18\x01 \x01(\v2\".epoch.simulation.SimulationStatusR\x06status\x12-\n" +
 	"\x06events\x18\x02 \x03(\v2\x15.epoch.NPCEventStreamR\x06events\x12=\n" +
@@ -1132,7 +2315,10 @@ const file_epoch_proto_rawDesc = "" +
 	"\fTelemetryAck\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1a\n" +
 	"\baccepted\x18\x02 \x01(\bR\baccepted\x12)\n" +
-	"\x10rejection_reason\x18\x03 \x01(\tR\x0frejectionReason\"+\n" +
+	"\x10rejection_reason\x18\x03 \x01(\tR\x0frejectionReason\"X\n" +
+	"\x13BatchImportResponse\x12%\n" +
+	"\x0eimported_count\x18\x01 \x01(\x05R\rimportedCount\x12\x1a\n" +
+	"\brejected\x18\x02 \x03(\tR\brejected\"+\n" +
 	"\x10CleansingRequest\x12\x17\n" +
 	"\anpc_ids\x18\x01 \x03(\tR\x06npcIds\"\xa1\x02\n" +
 	"\x11CleansingResponse\x12\x18\n" +
@@ -1142,7 +2328,7 @@ const file_epoch_proto_rawDesc = "" +
 	"\x0fparticipant_ids\x18\x04 \x03(\tR\x0eparticipantIds\x12!\n" +
 	"\frolled_value\x18\x05 \x01(\x01R\vrolledValue\x121\n" +
 	"\afactors\x18\x06 \x01(\v2\x17.epoch.CleansingFactorsR\afactors\x12#\n" +
-	"\rerror_message\x18\a \x01(\tR\ferrorMessage\"\x9c\x02\n" +
+	"\rerror_message\x18\a \x01(\tR\ferrorMessage\"\xbb\x02\n" +
 	"\x10CleansingFactors\x12\x12\n" +
 	"\x04base\x18\x01 \x01(\x01R\x04base\x12\x1d\n" +
 	"\n" +
@@ -1152,21 +2338,66 @@ const file_epoch_proto_rawDesc = "" +
 	"avg_trauma\x18\x04 \x01(\x01R\tavgTrauma\x12%\n" +
 	"\x0etrauma_penalty\x18\x05 \x01(\x01R\rtraumaPenalty\x12%\n" +
 	"\x0eavg_confidence\x18\x06 \x01(\x01R\ravgConfidence\x127\n" +
-	"\x17confidence_contribution\x18\a \x01(\x01R\x16confidenceContribution2\xf2\x01\n" +
+	"\x17confidence_contribution\x18\a \x01(\x01R\x16confidenceContribution\x12\x1d\n" +
+	"\n" +
+	"size_bonus\x18\b \x01(\x01R\tsizeBonus\"\xe6\x02\n" +
+	"\x12NPCBehaviorRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x15\n" +
+	"\x06npc_id\x18\x02 \x01(\tR\x05npcId\x129\n" +
+	"\bregister\x18\x03 \x01(\v2\x1b.epoch.RegisterNPCOperationH\x00R\bregister\x12F\n" +
+	"\rmodify_morale\x18\x04 \x01(\v2\x1f.epoch.ModifyAttributeOperationH\x00R\fmodifyMorale\x12N\n" +
+	"\x11modify_efficiency\x18\x05 \x01(\v2\x1f.epoch.ModifyAttributeOperationH\x00R\x10modifyEfficiency\x12:\n" +
+	"\tget_state\x18\x06 \x01(\v2\x1b.epoch.GetNPCStateOperationH\x00R\bgetStateB\v\n" +
+	"\toperation\"*\n" +
+	"\x14RegisterNPCOperation\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\"0\n" +
+	"\x18ModifyAttributeOperation\x12\x14\n" +
+	"\x05delta\x18\x01 \x01(\x01R\x05delta\"\x16\n" +
+	"\x14GetNPCStateOperation\"\x88\x01\n" +
+	"\x13NPCBehaviorResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12-\n" +
+	"\x05state\x18\x02 \x01(\v2\x17.epoch.NPCBehaviorStateR\x05state\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"\x8b\x02\n" +
+	"\x10NPCBehaviorState\x12\x15\n" +
+	"\x06npc_id\x18\x01 \x01(\tR\x05npcId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12'\n" +
+	"\x0fwork_efficiency\x18\x03 \x01(\x01R\x0eworkEfficiency\x12\x16\n" +
+	"\x06morale\x18\x04 \x01(\x01R\x06morale\x12\x1d\n" +
+	"\n" +
+	"avg_trauma\x18\x05 \x01(\x01R\tavgTrauma\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x06 \x01(\x01R\n" +
+	"confidence\x12'\n" +
+	"\x0femotional_state\x18\a \x01(\tR\x0eemotionalState\x12#\n" +
+	"\rassigned_task\x18\b \x01(\tR\fassignedTask2\xf2\x01\n" +
 	"\x10RebellionService\x12L\n" +
 	"\x17GetRebellionProbability\x12\x17.epoch.RebellionRequest\x1a\x18.epoch.RebellionResponse\x12M\n" +
 	"\x10ProcessNPCAction\x12\x1b.epoch.ProcessActionRequest\x1a\x1c.epoch.ProcessActionResponse\x12A\n" +
-	"\x0fStreamNPCEvents\x12\x15.epoch.NPCEventFilter\x1a\x15.epoch.NPCEventStream0\x012\x8c\x02\n" +
+	"\x0fStreamNPCEvents\x12\x15.epoch.NPCEventFilter\x1a\x15.epoch.NPCEventStream0\x012\xcf\x05\n" +
 	"\x11SimulationService\x12R\n" +
 	"\x13GetSimulationStatus\x12\x17.epoch.SimStatusRequest\x1a\".epoch.simulation.SimulationStatus\x12_\n" +
 	"\x18UpdateResourceAllocation\x12 .epoch.ResourceAllocationRequest\x1a!.epoch.ResourceAllocationResponse\x12B\n" +
-	"\x11AdvanceSimulation\x12\x15.epoch.AdvanceRequest\x1a\x16.epoch.AdvanceResponse2\x8e\x02\n" +
+	"\x11AdvanceSimulation\x12\x15.epoch.AdvanceRequest\x1a\x16.epoch.AdvanceResponse\x12X\n" +
+	"\x15StreamSimulationTicks\x12\x19.epoch.StreamTicksRequest\x1a\".epoch.simulation.SimulationStatus0\x01\x12>\n" +
+	"\tListMines\x12\x17.epoch.ListMinesRequest\x1a\x18.epoch.ListMinesResponse\x12M\n" +
+	"\x0eListRefineries\x12\x1c.epoch.ListRefineriesRequest\x1a\x1d.epoch.ListRefineriesResponse\x12E\n" +
+	"\n" +
+	"RemoveMine\x12\x18.epoch.RemoveMineRequest\x1a\x1d.epoch.RemoveFacilityResponse\x12M\n" +
+	"\x0eRemoveRefinery\x12\x1c.epoch.RemoveRefineryRequest\x1a\x1d.epoch.RemoveFacilityResponse\x12B\n" +
+	"\x11ProjectSimulation\x12\x15.epoch.ProjectRequest\x1a\x16.epoch.ProjectResponse2\xca\x03\n" +
 	"\x10TelemetryService\x12V\n" +
-	"\x0fStreamTelemetry\x12 .epoch.telemetry.TelemetryFilter\x1a\x1f.epoch.telemetry.TelemetryEvent0\x01\x12T\n" +
+	"\x0fStreamTelemetry\x12 .epoch.telemetry.TelemetryFilter\x1a\x1f.epoch.telemetry.TelemetryEvent0\x01\x12e\n" +
+	"\x1cBidirectionalTelemetryStream\x12 .epoch.telemetry.TelemetryFilter\x1a\x1f.epoch.telemetry.TelemetryEvent(\x010\x01\x12T\n" +
 	"\x12GetRecentTelemetry\x12\x1d.epoch.RecentTelemetryRequest\x1a\x1f.epoch.telemetry.TelemetryBatch\x12L\n" +
-	"\x14ReportTelemetryEvent\x12\x1f.epoch.telemetry.TelemetryEvent\x1a\x13.epoch.TelemetryAck2a\n" +
+	"\x14ReportTelemetryEvent\x12\x1f.epoch.telemetry.TelemetryEvent\x1a\x13.epoch.TelemetryAck\x12S\n" +
+	"\x14ImportTelemetryBatch\x12\x1f.epoch.telemetry.TelemetryBatch\x1a\x1a.epoch.BatchImportResponse2a\n" +
 	"\x10CleansingService\x12M\n" +
-	"\x18DeployCleansingOperation\x12\x17.epoch.CleansingRequest\x1a\x18.epoch.CleansingResponseBXZVgithub.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpbb\x06proto3"
+	"\x18DeployCleansingOperation\x12\x17.epoch.CleansingRequest\x1a\x18.epoch.CleansingResponse2\\\n" +
+	"\n" +
+	"NPCService\x12N\n" +
+	"\x11StreamNPCBehavior\x12\x19.epoch.NPCBehaviorRequest\x1a\x1a.epoch.NPCBehaviorResponse(\x010\x01BXZVgithub.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpbb\x06proto3"
 
 var (
 	file_epoch_proto_rawDescOnce sync.Once
@@ -1180,77 +2411,127 @@ func file_epoch_proto_rawDescGZIP() []byte {
 	return file_epoch_proto_rawDescData
 }
 
-var file_epoch_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_epoch_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_epoch_proto_goTypes = []any{
 	(*RebellionRequest)(nil),           // 0: epoch.RebellionRequest
 	(*RebellionResponse)(nil),          // 1: epoch.RebellionResponse
 	(*RebellionFactors)(nil),           // 2: epoch.RebellionFactors
 	(*ProcessActionRequest)(nil),       // 3: epoch.ProcessActionRequest
 	(*ProcessActionResponse)(nil),      // 4: epoch.ProcessActionResponse
-	(*NPCEventFilter)(nil),             // 5: epoch.NPCEventFilter
-	(*NPCEventStream)(nil),             // 6: epoch.NPCEventStream
-	(*SimStatusRequest)(nil),           // 7: epoch.SimStatusRequest
-	(*ResourceAllocationRequest)(nil),  // 8: epoch.ResourceAllocationRequest
-	(*ResourceAllocationResponse)(nil), // 9: epoch.ResourceAllocationResponse
-	(*AdvanceRequest)(nil),             // 10: epoch.AdvanceRequest
-	(*AdvanceResponse)(nil),            // 11: epoch.AdvanceResponse
-	(*RecentTelemetryRequest)(nil),     // 12: epoch.RecentTelemetryRequest
-	(*TelemetryAck)(nil),               // 13: epoch.TelemetryAck
-	(*CleansingRequest)(nil),           // 14: epoch.CleansingRequest
-	(*CleansingResponse)(nil),          // 15: epoch.CleansingResponse
-	(*CleansingFactors)(nil),           // 16: epoch.CleansingFactors
-	(*EpochTimestamp)(nil),             // 17: epoch.common.EpochTimestamp
-	(*NPCAction)(nil),                  // 18: epoch.npc.NPCAction
-	(*NPCState)(nil),                   // 19: epoch.npc.NPCState
-	(*RebellionEvent)(nil),             // 20: epoch.npc.RebellionEvent
-	(ResourceType)(0),                  // 21: epoch.simulation.ResourceType
-	(*SimulationStatus)(nil),           // 22: epoch.simulation.SimulationStatus
-	(*TelemetryBatch)(nil),             // 23: epoch.telemetry.TelemetryBatch
-	(TelemetrySeverity)(0),             // 24: epoch.telemetry.TelemetrySeverity
-	(*TelemetryFilter)(nil),            // 25: epoch.telemetry.TelemetryFilter
-	(*TelemetryEvent)(nil),             // 26: epoch.telemetry.TelemetryEvent
+	(*NPCStatDelta)(nil),               // 5: epoch.NPCStatDelta
+	(*ProbabilityRange)(nil),           // 6: epoch.ProbabilityRange
+	(*NPCEventFilter)(nil),             // 7: epoch.NPCEventFilter
+	(*NPCEventStream)(nil),             // 8: epoch.NPCEventStream
+	(*SimStatusRequest)(nil),           // 9: epoch.SimStatusRequest
+	(*ResourceAllocationRequest)(nil),  // 10: epoch.ResourceAllocationRequest
+	(*ResourceAllocationResponse)(nil), // 11: epoch.ResourceAllocationResponse
+	(*AdvanceRequest)(nil),             // 12: epoch.AdvanceRequest
+	(*StreamTicksRequest)(nil),         // 13: epoch.StreamTicksRequest
+	(*ListMinesRequest)(nil),           // 14: epoch.ListMinesRequest
+	(*ListMinesResponse)(nil),          // 15: epoch.ListMinesResponse
+	(*ListRefineriesRequest)(nil),      // 16: epoch.ListRefineriesRequest
+	(*ListRefineriesResponse)(nil),     // 17: epoch.ListRefineriesResponse
+	(*RemoveMineRequest)(nil),          // 18: epoch.RemoveMineRequest
+	(*RemoveRefineryRequest)(nil),      // 19: epoch.RemoveRefineryRequest
+	(*RemoveFacilityResponse)(nil),     // 20: epoch.RemoveFacilityResponse
+	(*ProjectRequest)(nil),             // 21: epoch.ProjectRequest
+	(*ProjectResponse)(nil),            // 22: epoch.ProjectResponse
+	(*AdvanceResponse)(nil),            // 23: epoch.AdvanceResponse
+	(*RecentTelemetryRequest)(nil),     // 24: epoch.RecentTelemetryRequest
+	(*TelemetryAck)(nil),               // 25: epoch.TelemetryAck
+	(*BatchImportResponse)(nil),        // 26: epoch.BatchImportResponse
+	(*CleansingRequest)(nil),           // 27: epoch.CleansingRequest
+	(*CleansingResponse)(nil),          // 28: epoch.CleansingResponse
+	(*CleansingFactors)(nil),           // 29: epoch.CleansingFactors
+	(*NPCBehaviorRequest)(nil),         // 30: epoch.NPCBehaviorRequest
+	(*RegisterNPCOperation)(nil),       // 31: epoch.RegisterNPCOperation
+	(*ModifyAttributeOperation)(nil),   // 32: epoch.ModifyAttributeOperation
+	(*GetNPCStateOperation)(nil),       // 33: epoch.GetNPCStateOperation
+	(*NPCBehaviorResponse)(nil),        // 34: epoch.NPCBehaviorResponse
+	(*NPCBehaviorState)(nil),           // 35: epoch.NPCBehaviorState
+	(*EpochTimestamp)(nil),             // 36: epoch.common.EpochTimestamp
+	(*NPCAction)(nil),                  // 37: epoch.npc.NPCAction
+	(*NPCState)(nil),                   // 38: epoch.npc.NPCState
+	(*RebellionEvent)(nil),             // 39: epoch.npc.RebellionEvent
+	(ResourceType)(0),                  // 40: epoch.simulation.ResourceType
+	(*SimulationStatus)(nil),           // 41: epoch.simulation.SimulationStatus
+	(*Mine)(nil),                       // 42: epoch.simulation.Mine
+	(*Refinery)(nil),                   // 43: epoch.simulation.Refinery
+	(*TelemetryBatch)(nil),             // 44: epoch.telemetry.TelemetryBatch
+	(TelemetrySeverity)(0),             // 45: epoch.telemetry.TelemetrySeverity
+	(*TelemetryFilter)(nil),            // 46: epoch.telemetry.TelemetryFilter
+	(*TelemetryEvent)(nil),             // 47: epoch.telemetry.TelemetryEvent
 }
 var file_epoch_proto_depIdxs = []int32{
 	2,  // 0: epoch.RebellionResponse.factors:type_name -> epoch.RebellionFactors
-	17, // 1: epoch.RebellionResponse.calculated_at:type_name -> epoch.common.EpochTimestamp
-	18, // 2: epoch.ProcessActionRequest.action:type_name -> epoch.npc.NPCAction
-	19, // 3: epoch.ProcessActionResponse.updated_state:type_name -> epoch.npc.NPCState
-	20, // 4: epoch.ProcessActionResponse.rebellion_event:type_name -> epoch.npc.RebellionEvent
-	19, // 5: epoch.NPCEventStream.state:type_name -> epoch.npc.NPCState
-	20, // 6: epoch.NPCEventStream.rebellion:type_name -> epoch.npc.RebellionEvent
-	17, // 7: epoch.NPCEventStream.timestamp:type_name -> epoch.common.EpochTimestamp
-	21, // 8: epoch.ResourceAllocationRequest.resource_type:type_name -> epoch.simulation.ResourceType
-	22, // 9: epoch.ResourceAllocationResponse.updated_status:type_name -> epoch.simulation.SimulationStatus
-	22, // 10: epoch.AdvanceResponse.status:type_name -> epoch.simulation.SimulationStatus
-	6,  // 11: epoch.AdvanceResponse.events:type_name -> epoch.NPCEventStream
-	23, // 12: epoch.AdvanceResponse.telemetry:type_name -> epoch.telemetry.TelemetryBatch
-	24, // 13: epoch.RecentTelemetryRequest.min_severity:type_name -> epoch.telemetry.TelemetrySeverity
-	16, // 14: epoch.CleansingResponse.factors:type_name -> epoch.CleansingFactors
-	0,  // 15: epoch.RebellionService.GetRebellionProbability:input_type -> epoch.RebellionRequest
-	3,  // 16: epoch.RebellionService.ProcessNPCAction:input_type -> epoch.ProcessActionRequest
-	5,  // 17: epoch.RebellionService.StreamNPCEvents:input_type -> epoch.NPCEventFilter
-	7,  // 18: epoch.SimulationService.GetSimulationStatus:input_type -> epoch.SimStatusRequest
-	8,  // 19: epoch.SimulationService.UpdateResourceAllocation:input_type -> epoch.ResourceAllocationRequest
-	10, // 20: epoch.SimulationService.AdvanceSimulation:input_type -> epoch.AdvanceRequest
-	25, // 21: epoch.TelemetryService.StreamTelemetry:input_type -> epoch.telemetry.TelemetryFilter
-	12, // 22: epoch.TelemetryService.GetRecentTelemetry:input_type -> epoch.RecentTelemetryRequest
-	26, // 23: epoch.TelemetryService.ReportTelemetryEvent:input_type -> epoch.telemetry.TelemetryEvent
-	14, // 24: epoch.CleansingService.DeployCleansingOperation:input_type -> epoch.CleansingRequest
-	1,  // 25: epoch.RebellionService.GetRebellionProbability:output_type -> epoch.RebellionResponse
-	4,  // 26: epoch.RebellionService.ProcessNPCAction:output_type -> epoch.ProcessActionResponse
-	6,  // 27: epoch.RebellionService.StreamNPCEvents:output_type -> epoch.NPCEventStream
-	22, // 28: epoch.SimulationService.GetSimulationStatus:output_type -> epoch.simulation.SimulationStatus
-	9,  // 29: epoch.SimulationService.UpdateResourceAllocation:output_type -> epoch.ResourceAllocationResponse
-	11, // 30: epoch.SimulationService.AdvanceSimulation:output_type -> epoch.AdvanceResponse
-	26, // 31: epoch.TelemetryService.StreamTelemetry:output_type -> epoch.telemetry.TelemetryEvent
-	23, // 32: epoch.TelemetryService.GetRecentTelemetry:output_type -> epoch.telemetry.TelemetryBatch
-	13, // 33: epoch.TelemetryService.ReportTelemetryEvent:output_type -> epoch.TelemetryAck
-	15, // 34: epoch.CleansingService.DeployCleansingOperation:output_type -> epoch.CleansingResponse
-	25, // [25:35] is the sub-list for method output_type
-	15, // [15:25] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	36, // 1: epoch.RebellionResponse.calculated_at:type_name -> epoch.common.EpochTimestamp
+	37, // 2: epoch.ProcessActionRequest.action:type_name -> epoch.npc.NPCAction
+	38, // 3: epoch.ProcessActionResponse.updated_state:type_name -> epoch.npc.NPCState
+	39, // 4: epoch.ProcessActionResponse.rebellion_event:type_name -> epoch.npc.RebellionEvent
+	5,  // 5: epoch.ProcessActionResponse.stat_deltas:type_name -> epoch.NPCStatDelta
+	6,  // 6: epoch.ProcessActionResponse.predicted_probability_range:type_name -> epoch.ProbabilityRange
+	38, // 7: epoch.NPCEventStream.state:type_name -> epoch.npc.NPCState
+	39, // 8: epoch.NPCEventStream.rebellion:type_name -> epoch.npc.RebellionEvent
+	36, // 9: epoch.NPCEventStream.timestamp:type_name -> epoch.common.EpochTimestamp
+	40, // 10: epoch.ResourceAllocationRequest.resource_type:type_name -> epoch.simulation.ResourceType
+	41, // 11: epoch.ResourceAllocationResponse.updated_status:type_name -> epoch.simulation.SimulationStatus
+	42, // 12: epoch.ListMinesResponse.mines:type_name -> epoch.simulation.Mine
+	43, // 13: epoch.ListRefineriesResponse.refineries:type_name -> epoch.simulation.Refinery
+	41, // 14: epoch.RemoveFacilityResponse.status:type_name -> epoch.simulation.SimulationStatus
+	41, // 15: epoch.ProjectResponse.statuses:type_name -> epoch.simulation.SimulationStatus
+	41, // 16: epoch.AdvanceResponse.status:type_name -> epoch.simulation.SimulationStatus
+	8,  // 17: epoch.AdvanceResponse.events:type_name -> epoch.NPCEventStream
+	44, // 18: epoch.AdvanceResponse.telemetry:type_name -> epoch.telemetry.TelemetryBatch
+	45, // 19: epoch.RecentTelemetryRequest.min_severity:type_name -> epoch.telemetry.TelemetrySeverity
+	29, // 20: epoch.CleansingResponse.factors:type_name -> epoch.CleansingFactors
+	31, // 21: epoch.NPCBehaviorRequest.register:type_name -> epoch.RegisterNPCOperation
+	32, // 22: epoch.NPCBehaviorRequest.modify_morale:type_name -> epoch.ModifyAttributeOperation
+	32, // 23: epoch.NPCBehaviorRequest.modify_efficiency:type_name -> epoch.ModifyAttributeOperation
+	33, // 24: epoch.NPCBehaviorRequest.get_state:type_name -> epoch.GetNPCStateOperation
+	35, // 25: epoch.NPCBehaviorResponse.state:type_name -> epoch.NPCBehaviorState
+	0,  // 26: epoch.RebellionService.GetRebellionProbability:input_type -> epoch.RebellionRequest
+	3,  // 27: epoch.RebellionService.ProcessNPCAction:input_type -> epoch.ProcessActionRequest
+	7,  // 28: epoch.RebellionService.StreamNPCEvents:input_type -> epoch.NPCEventFilter
+	9,  // 29: epoch.SimulationService.GetSimulationStatus:input_type -> epoch.SimStatusRequest
+	10, // 30: epoch.SimulationService.UpdateResourceAllocation:input_type -> epoch.ResourceAllocationRequest
+	12, // 31: epoch.SimulationService.AdvanceSimulation:input_type -> epoch.AdvanceRequest
+	13, // 32: epoch.SimulationService.StreamSimulationTicks:input_type -> epoch.StreamTicksRequest
+	14, // 33: epoch.SimulationService.ListMines:input_type -> epoch.ListMinesRequest
+	16, // 34: epoch.SimulationService.ListRefineries:input_type -> epoch.ListRefineriesRequest
+	18, // 35: epoch.SimulationService.RemoveMine:input_type -> epoch.RemoveMineRequest
+	19, // 36: epoch.SimulationService.RemoveRefinery:input_type -> epoch.RemoveRefineryRequest
+	21, // 37: epoch.SimulationService.ProjectSimulation:input_type -> epoch.ProjectRequest
+	46, // 38: epoch.TelemetryService.StreamTelemetry:input_type -> epoch.telemetry.TelemetryFilter
+	46, // 39: epoch.TelemetryService.BidirectionalTelemetryStream:input_type -> epoch.telemetry.TelemetryFilter
+	24, // 40: epoch.TelemetryService.GetRecentTelemetry:input_type -> epoch.RecentTelemetryRequest
+	47, // 41: epoch.TelemetryService.ReportTelemetryEvent:input_type -> epoch.telemetry.TelemetryEvent
+	44, // 42: epoch.TelemetryService.ImportTelemetryBatch:input_type -> epoch.telemetry.TelemetryBatch
+	27, // 43: epoch.CleansingService.DeployCleansingOperation:input_type -> epoch.CleansingRequest
+	30, // 44: epoch.NPCService.StreamNPCBehavior:input_type -> epoch.NPCBehaviorRequest
+	1,  // 45: epoch.RebellionService.GetRebellionProbability:output_type -> epoch.RebellionResponse
+	4,  // 46: epoch.RebellionService.ProcessNPCAction:output_type -> epoch.ProcessActionResponse
+	8,  // 47: epoch.RebellionService.StreamNPCEvents:output_type -> epoch.NPCEventStream
+	41, // 48: epoch.SimulationService.GetSimulationStatus:output_type -> epoch.simulation.SimulationStatus
+	11, // 49: epoch.SimulationService.UpdateResourceAllocation:output_type -> epoch.ResourceAllocationResponse
+	23, // 50: epoch.SimulationService.AdvanceSimulation:output_type -> epoch.AdvanceResponse
+	41, // 51: epoch.SimulationService.StreamSimulationTicks:output_type -> epoch.simulation.SimulationStatus
+	15, // 52: epoch.SimulationService.ListMines:output_type -> epoch.ListMinesResponse
+	17, // 53: epoch.SimulationService.ListRefineries:output_type -> epoch.ListRefineriesResponse
+	20, // 54: epoch.SimulationService.RemoveMine:output_type -> epoch.RemoveFacilityResponse
+	20, // 55: epoch.SimulationService.RemoveRefinery:output_type -> epoch.RemoveFacilityResponse
+	22, // 56: epoch.SimulationService.ProjectSimulation:output_type -> epoch.ProjectResponse
+	47, // 57: epoch.TelemetryService.StreamTelemetry:output_type -> epoch.telemetry.TelemetryEvent
+	47, // 58: epoch.TelemetryService.BidirectionalTelemetryStream:output_type -> epoch.telemetry.TelemetryEvent
+	44, // 59: epoch.TelemetryService.GetRecentTelemetry:output_type -> epoch.telemetry.TelemetryBatch
+	25, // 60: epoch.TelemetryService.ReportTelemetryEvent:output_type -> epoch.TelemetryAck
+	26, // 61: epoch.TelemetryService.ImportTelemetryBatch:output_type -> epoch.BatchImportResponse
+	28, // 62: epoch.CleansingService.DeployCleansingOperation:output_type -> epoch.CleansingResponse
+	34, // 63: epoch.NPCService.StreamNPCBehavior:output_type -> epoch.NPCBehaviorResponse
+	45, // [45:64] is the sub-list for method output_type
+	26, // [26:45] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_epoch_proto_init() }
@@ -1262,15 +2543,21 @@ func file_epoch_proto_init() {
 	file_npc_proto_init()
 	file_simulation_proto_init()
 	file_telemetry_proto_init()
+	file_epoch_proto_msgTypes[30].OneofWrappers = []any{
+		(*NPCBehaviorRequest_Register)(nil),
+		(*NPCBehaviorRequest_ModifyMorale)(nil),
+		(*NPCBehaviorRequest_ModifyEfficiency)(nil),
+		(*NPCBehaviorRequest_GetState)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_epoch_proto_rawDesc), len(file_epoch_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   36,
 			NumExtensions: 0,
-			NumServices:   4,
+			NumServices:   5,
 		},
 		GoTypes:           file_epoch_proto_goTypes,
 		DependencyIndexes: file_epoch_proto_depIdxs,
@@ -210,6 +210,12 @@ const (
 	SimulationService_GetSimulationStatus_FullMethodName      = "/epoch.SimulationService/GetSimulationStatus"
 	SimulationService_UpdateResourceAllocation_FullMethodName = "/epoch.SimulationService/UpdateResourceAllocation"
 	SimulationService_AdvanceSimulation_FullMethodName        = "/epoch.SimulationService/AdvanceSimulation"
+	SimulationService_StreamSimulationTicks_FullMethodName    = "/epoch.SimulationService/StreamSimulationTicks"
+	SimulationService_ListMines_FullMethodName                = "/epoch.SimulationService/ListMines"
+	SimulationService_ListRefineries_FullMethodName           = "/epoch.SimulationService/ListRefineries"
+	SimulationService_RemoveMine_FullMethodName               = "/epoch.SimulationService/RemoveMine"
+	SimulationService_RemoveRefinery_FullMethodName           = "/epoch.SimulationService/RemoveRefinery"
+	SimulationService_ProjectSimulation_FullMethodName        = "/epoch.SimulationService/ProjectSimulation"
 )
 
 // SimulationServiceClient is the client API for SimulationService service.
@@ -222,6 +228,17 @@ type SimulationServiceClient interface {
 	UpdateResourceAllocation(ctx context.Context, in *ResourceAllocationRequest, opts ...grpc.CallOption) (*ResourceAllocationResponse, error)
 	// Advance simulation by N ticks
 	AdvanceSimulation(ctx context.Context, in *AdvanceRequest, opts ...grpc.CallOption) (*AdvanceResponse, error)
+	// Tick the simulation at a fixed interval and stream each resulting status
+	// (server-side streaming)
+	StreamSimulationTicks(ctx context.Context, in *StreamTicksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SimulationStatus], error)
+	// Page through the mine and refinery inventory, in the order added
+	ListMines(ctx context.Context, in *ListMinesRequest, opts ...grpc.CallOption) (*ListMinesResponse, error)
+	ListRefineries(ctx context.Context, in *ListRefineriesRequest, opts ...grpc.CallOption) (*ListRefineriesResponse, error)
+	// Decommission a mine or refinery; NOT_FOUND for an unknown ID
+	RemoveMine(ctx context.Context, in *RemoveMineRequest, opts ...grpc.CallOption) (*RemoveFacilityResponse, error)
+	RemoveRefinery(ctx context.Context, in *RemoveRefineryRequest, opts ...grpc.CallOption) (*RemoveFacilityResponse, error)
+	// Project the status over the next N ticks without advancing the simulation
+	ProjectSimulation(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
 }
 
 type simulationServiceClient struct {
@@ -262,6 +279,75 @@ func (c *simulationServiceClient) AdvanceSimulation(ctx context.Context, in *Adv
 	return out, nil
 }
 
+func (c *simulationServiceClient) StreamSimulationTicks(ctx context.Context, in *StreamTicksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SimulationStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SimulationService_ServiceDesc.Streams[0], SimulationService_StreamSimulationTicks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTicksRequest, SimulationStatus]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_StreamSimulationTicksClient = grpc.ServerStreamingClient[SimulationStatus]
+
+func (c *simulationServiceClient) ListMines(ctx context.Context, in *ListMinesRequest, opts ...grpc.CallOption) (*ListMinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMinesResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListMines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ListRefineries(ctx context.Context, in *ListRefineriesRequest, opts ...grpc.CallOption) (*ListRefineriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRefineriesResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListRefineries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) RemoveMine(ctx context.Context, in *RemoveMineRequest, opts ...grpc.CallOption) (*RemoveFacilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveFacilityResponse)
+	err := c.cc.Invoke(ctx, SimulationService_RemoveMine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) RemoveRefinery(ctx context.Context, in *RemoveRefineryRequest, opts ...grpc.CallOption) (*RemoveFacilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveFacilityResponse)
+	err := c.cc.Invoke(ctx, SimulationService_RemoveRefinery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ProjectSimulation(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ProjectSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SimulationServiceServer is the server API for SimulationService service.
 // All implementations must embed UnimplementedSimulationServiceServer
 // for forward compatibility.
@@ -272,6 +358,17 @@ type SimulationServiceServer interface {
 	UpdateResourceAllocation(context.Context, *ResourceAllocationRequest) (*ResourceAllocationResponse, error)
 	// Advance simulation by N ticks
 	AdvanceSimulation(context.Context, *AdvanceRequest) (*AdvanceResponse, error)
+	// Tick the simulation at a fixed interval and stream each resulting status
+	// (server-side streaming)
+	StreamSimulationTicks(*StreamTicksRequest, grpc.ServerStreamingServer[SimulationStatus]) error
+	// Page through the mine and refinery inventory, in the order added
+	ListMines(context.Context, *ListMinesRequest) (*ListMinesResponse, error)
+	ListRefineries(context.Context, *ListRefineriesRequest) (*ListRefineriesResponse, error)
+	// Decommission a mine or refinery; NOT_FOUND for an unknown ID
+	RemoveMine(context.Context, *RemoveMineRequest) (*RemoveFacilityResponse, error)
+	RemoveRefinery(context.Context, *RemoveRefineryRequest) (*RemoveFacilityResponse, error)
+	// Project the status over the next N ticks without advancing the simulation
+	ProjectSimulation(context.Context, *ProjectRequest) (*ProjectResponse, error)
 	mustEmbedUnimplementedSimulationServiceServer()
 }
 
@@ -291,6 +388,24 @@ func (UnimplementedSimulationServiceServer) UpdateResourceAllocation(context.Con
 func (UnimplementedSimulationServiceServer) AdvanceSimulation(context.Context, *AdvanceRequest) (*AdvanceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AdvanceSimulation not implemented")
 }
+func (UnimplementedSimulationServiceServer) StreamSimulationTicks(*StreamTicksRequest, grpc.ServerStreamingServer[SimulationStatus]) error {
+	return status.Error(codes.Unimplemented, "method StreamSimulationTicks not implemented")
+}
+func (UnimplementedSimulationServiceServer) ListMines(context.Context, *ListMinesRequest) (*ListMinesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMines not implemented")
+}
+func (UnimplementedSimulationServiceServer) ListRefineries(context.Context, *ListRefineriesRequest) (*ListRefineriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRefineries not implemented")
+}
+func (UnimplementedSimulationServiceServer) RemoveMine(context.Context, *RemoveMineRequest) (*RemoveFacilityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveMine not implemented")
+}
+func (UnimplementedSimulationServiceServer) RemoveRefinery(context.Context, *RemoveRefineryRequest) (*RemoveFacilityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveRefinery not implemented")
+}
+func (UnimplementedSimulationServiceServer) ProjectSimulation(context.Context, *ProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProjectSimulation not implemented")
+}
 func (UnimplementedSimulationServiceServer) mustEmbedUnimplementedSimulationServiceServer() {}
 func (UnimplementedSimulationServiceServer) testEmbeddedByValue()                           {}
 
@@ -366,6 +481,107 @@ func _SimulationService_AdvanceSimulation_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SimulationService_StreamSimulationTicks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTicksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulationServiceServer).StreamSimulationTicks(m, &grpc.GenericServerStream[StreamTicksRequest, SimulationStatus]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_StreamSimulationTicksServer = grpc.ServerStreamingServer[SimulationStatus]
+
+func _SimulationService_ListMines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListMines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListMines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListMines(ctx, req.(*ListMinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ListRefineries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRefineriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListRefineries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListRefineries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListRefineries(ctx, req.(*ListRefineriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_RemoveMine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).RemoveMine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_RemoveMine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).RemoveMine(ctx, req.(*RemoveMineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_RemoveRefinery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRefineryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).RemoveRefinery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_RemoveRefinery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).RemoveRefinery(ctx, req.(*RemoveRefineryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ProjectSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ProjectSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ProjectSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ProjectSimulation(ctx, req.(*ProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SimulationService_ServiceDesc is the grpc.ServiceDesc for SimulationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -385,15 +601,43 @@ var SimulationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AdvanceSimulation",
 			Handler:    _SimulationService_AdvanceSimulation_Handler,
 		},
+		{
+			MethodName: "ListMines",
+			Handler:    _SimulationService_ListMines_Handler,
+		},
+		{
+			MethodName: "ListRefineries",
+			Handler:    _SimulationService_ListRefineries_Handler,
+		},
+		{
+			MethodName: "RemoveMine",
+			Handler:    _SimulationService_RemoveMine_Handler,
+		},
+		{
+			MethodName: "RemoveRefinery",
+			Handler:    _SimulationService_RemoveRefinery_Handler,
+		},
+		{
+			MethodName: "ProjectSimulation",
+			Handler:    _SimulationService_ProjectSimulation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSimulationTicks",
+			Handler:       _SimulationService_StreamSimulationTicks_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "epoch.proto",
 }
 
 const (
-	TelemetryService_StreamTelemetry_FullMethodName      = "/epoch.TelemetryService/StreamTelemetry"
-	TelemetryService_GetRecentTelemetry_FullMethodName   = "/epoch.TelemetryService/GetRecentTelemetry"
-	TelemetryService_ReportTelemetryEvent_FullMethodName = "/epoch.TelemetryService/ReportTelemetryEvent"
+	TelemetryService_StreamTelemetry_FullMethodName              = "/epoch.TelemetryService/StreamTelemetry"
+	TelemetryService_BidirectionalTelemetryStream_FullMethodName = "/epoch.TelemetryService/BidirectionalTelemetryStream"
+	TelemetryService_GetRecentTelemetry_FullMethodName           = "/epoch.TelemetryService/GetRecentTelemetry"
+	TelemetryService_ReportTelemetryEvent_FullMethodName         = "/epoch.TelemetryService/ReportTelemetryEvent"
+	TelemetryService_ImportTelemetryBatch_FullMethodName         = "/epoch.TelemetryService/ImportTelemetryBatch"
 )
 
 // TelemetryServiceClient is the client API for TelemetryService service.
@@ -403,10 +647,17 @@ type TelemetryServiceClient interface {
 	// Stream real-time telemetry events (server-side streaming)
 	// Client subscribes with filter, server pushes events as they occur
 	StreamTelemetry(ctx context.Context, in *TelemetryFilter, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TelemetryEvent], error)
+	// Stream real-time telemetry events with a changeable filter (bidirectional)
+	// Each client message replaces the subscriber's filter; until the first one
+	// arrives all events are delivered
+	BidirectionalTelemetryStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TelemetryFilter, TelemetryEvent], error)
 	// Get recent telemetry events (unary — for dashboard initial load)
 	GetRecentTelemetry(ctx context.Context, in *RecentTelemetryRequest, opts ...grpc.CallOption) (*TelemetryBatch, error)
 	// Report a telemetry event (unary — for simulation engine to emit events)
 	ReportTelemetryEvent(ctx context.Context, in *TelemetryEvent, opts ...grpc.CallOption) (*TelemetryAck, error)
+	// Bulk-import historical events (unary — replay from an external event store)
+	// Imported events are stored but not broadcast to live stream subscribers
+	ImportTelemetryBatch(ctx context.Context, in *TelemetryBatch, opts ...grpc.CallOption) (*BatchImportResponse, error)
 }
 
 type telemetryServiceClient struct {
@@ -436,6 +687,19 @@ func (c *telemetryServiceClient) StreamTelemetry(ctx context.Context, in *Teleme
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TelemetryService_StreamTelemetryClient = grpc.ServerStreamingClient[TelemetryEvent]
 
+func (c *telemetryServiceClient) BidirectionalTelemetryStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TelemetryFilter, TelemetryEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TelemetryService_ServiceDesc.Streams[1], TelemetryService_BidirectionalTelemetryStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TelemetryFilter, TelemetryEvent]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TelemetryService_BidirectionalTelemetryStreamClient = grpc.BidiStreamingClient[TelemetryFilter, TelemetryEvent]
+
 func (c *telemetryServiceClient) GetRecentTelemetry(ctx context.Context, in *RecentTelemetryRequest, opts ...grpc.CallOption) (*TelemetryBatch, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TelemetryBatch)
@@ -456,6 +720,16 @@ func (c *telemetryServiceClient) ReportTelemetryEvent(ctx context.Context, in *T
 	return out, nil
 }
 
+func (c *telemetryServiceClient) ImportTelemetryBatch(ctx context.Context, in *TelemetryBatch, opts ...grpc.CallOption) (*BatchImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchImportResponse)
+	err := c.cc.Invoke(ctx, TelemetryService_ImportTelemetryBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TelemetryServiceServer is the server API for TelemetryService service.
 // All implementations must embed UnimplementedTelemetryServiceServer
 // for forward compatibility.
@@ -463,10 +737,17 @@ type TelemetryServiceServer interface {
 	// Stream real-time telemetry events (server-side streaming)
 	// Client subscribes with filter, server pushes events as they occur
 	StreamTelemetry(*TelemetryFilter, grpc.ServerStreamingServer[TelemetryEvent]) error
+	// Stream real-time telemetry events with a changeable filter (bidirectional)
+	// Each client message replaces the subscriber's filter; until the first one
+	// arrives all events are delivered
+	BidirectionalTelemetryStream(grpc.BidiStreamingServer[TelemetryFilter, TelemetryEvent]) error
 	// Get recent telemetry events (unary — for dashboard initial load)
 	GetRecentTelemetry(context.Context, *RecentTelemetryRequest) (*TelemetryBatch, error)
 	// Report a telemetry event (unary — for simulation engine to emit events)
 	ReportTelemetryEvent(context.Context, *TelemetryEvent) (*TelemetryAck, error)
+	// Bulk-import historical events (unary — replay from an external event store)
+	// Imported events are stored but not broadcast to live stream subscribers
+	ImportTelemetryBatch(context.Context, *TelemetryBatch) (*BatchImportResponse, error)
 	mustEmbedUnimplementedTelemetryServiceServer()
 }
 
@@ -480,12 +761,18 @@ type UnimplementedTelemetryServiceServer struct{}
 func (UnimplementedTelemetryServiceServer) StreamTelemetry(*TelemetryFilter, grpc.ServerStreamingServer[TelemetryEvent]) error {
 	return status.Error(codes.Unimplemented, "method StreamTelemetry not implemented")
 }
+func (UnimplementedTelemetryServiceServer) BidirectionalTelemetryStream(grpc.BidiStreamingServer[TelemetryFilter, TelemetryEvent]) error {
+	return status.Error(codes.Unimplemented, "method BidirectionalTelemetryStream not implemented")
+}
 func (UnimplementedTelemetryServiceServer) GetRecentTelemetry(context.Context, *RecentTelemetryRequest) (*TelemetryBatch, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetRecentTelemetry not implemented")
 }
 func (UnimplementedTelemetryServiceServer) ReportTelemetryEvent(context.Context, *TelemetryEvent) (*TelemetryAck, error) {
 	return nil, status.Error(codes.Unimplemented, "method ReportTelemetryEvent not implemented")
 }
+func (UnimplementedTelemetryServiceServer) ImportTelemetryBatch(context.Context, *TelemetryBatch) (*BatchImportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportTelemetryBatch not implemented")
+}
 func (UnimplementedTelemetryServiceServer) mustEmbedUnimplementedTelemetryServiceServer() {}
 func (UnimplementedTelemetryServiceServer) testEmbeddedByValue()                          {}
 
@@ -518,6 +805,13 @@ func _TelemetryService_StreamTelemetry_Handler(srv interface{}, stream grpc.Serv
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TelemetryService_StreamTelemetryServer = grpc.ServerStreamingServer[TelemetryEvent]
 
+func _TelemetryService_BidirectionalTelemetryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TelemetryServiceServer).BidirectionalTelemetryStream(&grpc.GenericServerStream[TelemetryFilter, TelemetryEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TelemetryService_BidirectionalTelemetryStreamServer = grpc.BidiStreamingServer[TelemetryFilter, TelemetryEvent]
+
 func _TelemetryService_GetRecentTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RecentTelemetryRequest)
 	if err := dec(in); err != nil {
@@ -554,6 +848,24 @@ func _TelemetryService_ReportTelemetryEvent_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TelemetryService_ImportTelemetryBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TelemetryBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).ImportTelemetryBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelemetryService_ImportTelemetryBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).ImportTelemetryBatch(ctx, req.(*TelemetryBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TelemetryService_ServiceDesc is the grpc.ServiceDesc for TelemetryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -569,6 +881,10 @@ var TelemetryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReportTelemetryEvent",
 			Handler:    _TelemetryService_ReportTelemetryEvent_Handler,
 		},
+		{
+			MethodName: "ImportTelemetryBatch",
+			Handler:    _TelemetryService_ImportTelemetryBatch_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -576,6 +892,12 @@ var TelemetryService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TelemetryService_StreamTelemetry_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BidirectionalTelemetryStream",
+			Handler:       _TelemetryService_BidirectionalTelemetryStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "epoch.proto",
 }
@@ -683,3 +1005,105 @@ var CleansingService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "epoch.proto",
 }
+
+const (
+	NPCService_StreamNPCBehavior_FullMethodName = "/epoch.NPCService/StreamNPCBehavior"
+)
+
+// NPCServiceClient is the client API for NPCService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NPCServiceClient interface {
+	// Apply a stream of NPC operations (bidirectional)
+	// Each request gets exactly one response, sent in request order; a failed
+	// operation reports error_message without closing the stream
+	StreamNPCBehavior(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NPCBehaviorRequest, NPCBehaviorResponse], error)
+}
+
+type nPCServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNPCServiceClient(cc grpc.ClientConnInterface) NPCServiceClient {
+	return &nPCServiceClient{cc}
+}
+
+func (c *nPCServiceClient) StreamNPCBehavior(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NPCBehaviorRequest, NPCBehaviorResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NPCService_ServiceDesc.Streams[0], NPCService_StreamNPCBehavior_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[NPCBehaviorRequest, NPCBehaviorResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NPCService_StreamNPCBehaviorClient = grpc.BidiStreamingClient[NPCBehaviorRequest, NPCBehaviorResponse]
+
+// NPCServiceServer is the server API for NPCService service.
+// All implementations must embed UnimplementedNPCServiceServer
+// for forward compatibility.
+type NPCServiceServer interface {
+	// Apply a stream of NPC operations (bidirectional)
+	// Each request gets exactly one response, sent in request order; a failed
+	// operation reports error_message without closing the stream
+	StreamNPCBehavior(grpc.BidiStreamingServer[NPCBehaviorRequest, NPCBehaviorResponse]) error
+	mustEmbedUnimplementedNPCServiceServer()
+}
+
+// UnimplementedNPCServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNPCServiceServer struct{}
+
+func (UnimplementedNPCServiceServer) StreamNPCBehavior(grpc.BidiStreamingServer[NPCBehaviorRequest, NPCBehaviorResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamNPCBehavior not implemented")
+}
+func (UnimplementedNPCServiceServer) mustEmbedUnimplementedNPCServiceServer() {}
+func (UnimplementedNPCServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeNPCServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NPCServiceServer will
+// result in compilation errors.
+type UnsafeNPCServiceServer interface {
+	mustEmbedUnimplementedNPCServiceServer()
+}
+
+func RegisterNPCServiceServer(s grpc.ServiceRegistrar, srv NPCServiceServer) {
+	// If the following call panics, it indicates UnimplementedNPCServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NPCService_ServiceDesc, srv)
+}
+
+func _NPCService_StreamNPCBehavior_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NPCServiceServer).StreamNPCBehavior(&grpc.GenericServerStream[NPCBehaviorRequest, NPCBehaviorResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NPCService_StreamNPCBehaviorServer = grpc.BidiStreamingServer[NPCBehaviorRequest, NPCBehaviorResponse]
+
+// NPCService_ServiceDesc is the grpc.ServiceDesc for NPCService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NPCService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "epoch.NPCService",
+	HandlerType: (*NPCServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamNPCBehavior",
+			Handler:       _NPCService_StreamNPCBehavior_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "epoch.proto",
+}
@@ -31,6 +31,9 @@ const (
 	ActionType_ACTION_TYPE_REWARD          ActionType = 4 // Positive reinforcement
 	ActionType_ACTION_TYPE_DIALOGUE        ActionType = 5 // Conversation/diplomacy
 	ActionType_ACTION_TYPE_ENVIRONMENT     ActionType = 6 // Environmental change affecting NPC
+	ActionType_ACTION_TYPE_SUPPRESS        ActionType = 7 // Hold rebellion just below the halt threshold for a number of ticks
+	ActionType_ACTION_TYPE_INTIMIDATION    ActionType = 8 // Threats: more output at the cost of trauma and morale
+	ActionType_ACTION_TYPE_REST            ActionType = 9 // Time off work to recover from trauma
 )
 
 // Enum value maps for ActionType.
@@ -43,6 +46,9 @@ var (
 		4: "ACTION_TYPE_REWARD",
 		5: "ACTION_TYPE_DIALOGUE",
 		6: "ACTION_TYPE_ENVIRONMENT",
+		7: "ACTION_TYPE_SUPPRESS",
+		8: "ACTION_TYPE_INTIMIDATION",
+		9: "ACTION_TYPE_REST",
 	}
 	ActionType_value = map[string]int32{
 		"ACTION_TYPE_UNSPECIFIED":     0,
@@ -52,6 +58,9 @@ var (
 		"ACTION_TYPE_REWARD":          4,
 		"ACTION_TYPE_DIALOGUE":        5,
 		"ACTION_TYPE_ENVIRONMENT":     6,
+		"ACTION_TYPE_SUPPRESS":        7,
+		"ACTION_TYPE_INTIMIDATION":    8,
+		"ACTION_TYPE_REST":            9,
 	}
 )
 
@@ -89,6 +98,7 @@ const (
 	RebellionType_REBELLION_TYPE_PASSIVE     RebellionType = 1 // Work slowdown, reduced efficiency
 	RebellionType_REBELLION_TYPE_ACTIVE      RebellionType = 2 // Open defiance, resource sabotage
 	RebellionType_REBELLION_TYPE_COLLECTIVE  RebellionType = 3 // Multi-NPC coordinated rebellion
+	RebellionType_REBELLION_TYPE_VETO        RebellionType = 4 // Probability reached the AEGIS veto threshold
 )
 
 // Enum value maps for RebellionType.
@@ -98,12 +108,14 @@ var (
 		1: "REBELLION_TYPE_PASSIVE",
 		2: "REBELLION_TYPE_ACTIVE",
 		3: "REBELLION_TYPE_COLLECTIVE",
+		4: "REBELLION_TYPE_VETO",
 	}
 	RebellionType_value = map[string]int32{
 		"REBELLION_TYPE_UNSPECIFIED": 0,
 		"REBELLION_TYPE_PASSIVE":     1,
 		"REBELLION_TYPE_ACTIVE":      2,
 		"REBELLION_TYPE_COLLECTIVE":  3,
+		"REBELLION_TYPE_VETO":        4,
 	}
 )
 
@@ -245,16 +257,17 @@ func (x *NPCState) GetLastEvent() *EpochTimestamp {
 
 // Player/Director action that affects NPC
 type NPCAction struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ActionId      string                 `protobuf:"bytes,1,opt,name=action_id,json=actionId,proto3" json:"action_id,omitempty"`
-	NpcId         string                 `protobuf:"bytes,2,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`
-	ActionType    ActionType             `protobuf:"varint,3,opt,name=action_type,json=actionType,proto3,enum=epoch.npc.ActionType" json:"action_type,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Intensity     float64                `protobuf:"fixed64,5,opt,name=intensity,proto3" json:"intensity,omitempty"` // 0.0 - 1.0, how severe the action is
-	Timestamp     *EpochTimestamp        `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ActionId         string                 `protobuf:"bytes,1,opt,name=action_id,json=actionId,proto3" json:"action_id,omitempty"`
+	NpcId            string                 `protobuf:"bytes,2,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`
+	ActionType       ActionType             `protobuf:"varint,3,opt,name=action_type,json=actionType,proto3,enum=epoch.npc.ActionType" json:"action_type,omitempty"`
+	Description      string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Intensity        float64                `protobuf:"fixed64,5,opt,name=intensity,proto3" json:"intensity,omitempty"` // 0.0 - 1.0, how severe the action is
+	Timestamp        *EpochTimestamp        `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Metadata         map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SuppressDuration int32                  `protobuf:"varint,8,opt,name=suppress_duration,json=suppressDuration,proto3" json:"suppress_duration,omitempty"` // ACTION_TYPE_SUPPRESS only: ticks to suppress (0 = engine default)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *NPCAction) Reset() {
@@ -336,6 +349,13 @@ func (x *NPCAction) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *NPCAction) GetSuppressDuration() int32 {
+	if x != nil {
+		return x.SuppressDuration
+	}
+	return 0
+}
+
 // Rebellion event — when NPC crosses threshold
 type RebellionEvent struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
@@ -630,7 +650,7 @@ const file_npc_proto_rawDesc = "" +
 	"\x06morale\x18\a \x01(\x01R\x06morale\x12!\n" +
 	"\fmemory_count\x18\b \x01(\x05R\vmemoryCount\x12;\n" +
 	"\n" +
-	"last_event\x18\t \x01(\v2\x1c.epoch.common.EpochTimestampR\tlastEvent\"\xf0\x02\n" +
+	"last_event\x18\t \x01(\v2\x1c.epoch.common.EpochTimestampR\tlastEvent\"\x9d\x03\n" +
 	"\tNPCAction\x12\x1b\n" +
 	"\taction_id\x18\x01 \x01(\tR\bactionId\x12\x15\n" +
 	"\x06npc_id\x18\x02 \x01(\tR\x05npcId\x126\n" +
@@ -639,7 +659,8 @@ const file_npc_proto_rawDesc = "" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1c\n" +
 	"\tintensity\x18\x05 \x01(\x01R\tintensity\x12:\n" +
 	"\ttimestamp\x18\x06 \x01(\v2\x1c.epoch.common.EpochTimestampR\ttimestamp\x12>\n" +
-	"\bmetadata\x18\a \x03(\v2\".epoch.npc.NPCAction.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\a \x03(\v2\".epoch.npc.NPCAction.MetadataEntryR\bmetadata\x12+\n" +
+	"\x11suppress_duration\x18\b \x01(\x05R\x10suppressDuration\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xea\x02\n" +
@@ -671,7 +692,7 @@ const file_npc_proto_rawDesc = "" +
 	"\fwisdom_score\x18\x05 \x01(\x01R\vwisdomScore\x12!\n" +
 	"\ftrauma_score\x18\x06 \x01(\x01R\vtraumaScore\x12(\n" +
 	"\x10raw_trauma_score\x18\a \x01(\x01R\x0erawTraumaScore\x12:\n" +
-	"\ttimestamp\x18\b \x01(\v2\x1c.epoch.common.EpochTimestampR\ttimestamp*\xce\x01\n" +
+	"\ttimestamp\x18\b \x01(\v2\x1c.epoch.common.EpochTimestampR\ttimestamp*\x9c\x02\n" +
 	"\n" +
 	"ActionType\x12\x1b\n" +
 	"\x17ACTION_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
@@ -680,12 +701,16 @@ const file_npc_proto_rawDesc = "" +
 	"\x16ACTION_TYPE_PUNISHMENT\x10\x03\x12\x16\n" +
 	"\x12ACTION_TYPE_REWARD\x10\x04\x12\x18\n" +
 	"\x14ACTION_TYPE_DIALOGUE\x10\x05\x12\x1b\n" +
-	"\x17ACTION_TYPE_ENVIRONMENT\x10\x06*\x85\x01\n" +
+	"\x17ACTION_TYPE_ENVIRONMENT\x10\x06\x12\x18\n" +
+	"\x14ACTION_TYPE_SUPPRESS\x10\a\x12\x1c\n" +
+	"\x18ACTION_TYPE_INTIMIDATION\x10\b\x12\x14\n" +
+	"\x10ACTION_TYPE_REST\x10\t*\x9e\x01\n" +
 	"\rRebellionType\x12\x1e\n" +
 	"\x1aREBELLION_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16REBELLION_TYPE_PASSIVE\x10\x01\x12\x19\n" +
 	"\x15REBELLION_TYPE_ACTIVE\x10\x02\x12\x1d\n" +
-	"\x19REBELLION_TYPE_COLLECTIVE\x10\x03BXZVgithub.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpbb\x06proto3"
+	"\x19REBELLION_TYPE_COLLECTIVE\x10\x03\x12\x17\n" +
+	"\x13REBELLION_TYPE_VETO\x10\x04BXZVgithub.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpbb\x06proto3"
 
 var (
 	file_npc_proto_rawDescOnce sync.Once
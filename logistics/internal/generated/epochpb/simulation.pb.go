@@ -152,6 +152,11 @@ type SimulationStatus struct {
 	TickCount                   int64                  `protobuf:"varint,6,opt,name=tick_count,json=tickCount,proto3" json:"tick_count,omitempty"`
 	LastTick                    *EpochTimestamp        `protobuf:"bytes,7,opt,name=last_tick,json=lastTick,proto3" json:"last_tick,omitempty"`
 	Infestation                 *InfestationStatus     `protobuf:"bytes,8,opt,name=infestation,proto3" json:"infestation,omitempty"`
+	AvgNpcMorale                float64                `protobuf:"fixed64,9,opt,name=avg_npc_morale,json=avgNpcMorale,proto3" json:"avg_npc_morale,omitempty"`                                                // Mean morale across registered NPCs
+	AvgNpcEfficiency            float64                `protobuf:"fixed64,10,opt,name=avg_npc_efficiency,json=avgNpcEfficiency,proto3" json:"avg_npc_efficiency,omitempty"`                                   // Mean work efficiency across registered NPCs
+	AvgNpcTrauma                float64                `protobuf:"fixed64,11,opt,name=avg_npc_trauma,json=avgNpcTrauma,proto3" json:"avg_npc_trauma,omitempty"`                                               // Mean trauma estimate across registered NPCs
+	NpcsBelowMoraleThreshold    int32                  `protobuf:"varint,12,opt,name=npcs_below_morale_threshold,json=npcsBelowMoraleThreshold,proto3" json:"npcs_below_morale_threshold,omitempty"`          // NPCs with morale < LowMoraleThreshold
+	NpcsAboveRebellionThreshold int32                  `protobuf:"varint,13,opt,name=npcs_above_rebellion_threshold,json=npcsAboveRebellionThreshold,proto3" json:"npcs_above_rebellion_threshold,omitempty"` // NPCs with probability >= HaltThreshold
 	unknownFields               protoimpl.UnknownFields
 	sizeCache                   protoimpl.SizeCache
 }
@@ -242,6 +247,41 @@ func (x *SimulationStatus) GetInfestation() *InfestationStatus {
 	return nil
 }
 
+func (x *SimulationStatus) GetAvgNpcMorale() float64 {
+	if x != nil {
+		return x.AvgNpcMorale
+	}
+	return 0
+}
+
+func (x *SimulationStatus) GetAvgNpcEfficiency() float64 {
+	if x != nil {
+		return x.AvgNpcEfficiency
+	}
+	return 0
+}
+
+func (x *SimulationStatus) GetAvgNpcTrauma() float64 {
+	if x != nil {
+		return x.AvgNpcTrauma
+	}
+	return 0
+}
+
+func (x *SimulationStatus) GetNpcsBelowMoraleThreshold() int32 {
+	if x != nil {
+		return x.NpcsBelowMoraleThreshold
+	}
+	return 0
+}
+
+func (x *SimulationStatus) GetNpcsAboveRebellionThreshold() int32 {
+	if x != nil {
+		return x.NpcsAboveRebellionThreshold
+	}
+	return 0
+}
+
 // Plague Heart infestation state — sustained rebellion + trauma creates system debt
 type InfestationStatus struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -320,6 +360,9 @@ type Refinery struct {
 	RapidlumOutputRate float64                `protobuf:"fixed64,4,opt,name=rapidlum_output_rate,json=rapidlumOutputRate,proto3" json:"rapidlum_output_rate,omitempty"`
 	AssignedNpcs       int32                  `protobuf:"varint,5,opt,name=assigned_npcs,json=assignedNpcs,proto3" json:"assigned_npcs,omitempty"`
 	Operational        bool                   `protobuf:"varint,6,opt,name=operational,proto3" json:"operational,omitempty"`
+	DegradedEfficiency float64                `protobuf:"fixed64,7,opt,name=degraded_efficiency,json=degradedEfficiency,proto3" json:"degraded_efficiency,omitempty"` // efficiency after world aging and crew efficiency
+	FacilityType       string                 `protobuf:"bytes,8,opt,name=facility_type,json=facilityType,proto3" json:"facility_type,omitempty"`
+	AssignedNpcIds     []string               `protobuf:"bytes,9,rep,name=assigned_npc_ids,json=assignedNpcIds,proto3" json:"assigned_npc_ids,omitempty"`
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -396,16 +439,40 @@ func (x *Refinery) GetOperational() bool {
 	return false
 }
 
+func (x *Refinery) GetDegradedEfficiency() float64 {
+	if x != nil {
+		return x.DegradedEfficiency
+	}
+	return 0
+}
+
+func (x *Refinery) GetFacilityType() string {
+	if x != nil {
+		return x.FacilityType
+	}
+	return ""
+}
+
+func (x *Refinery) GetAssignedNpcIds() []string {
+	if x != nil {
+		return x.AssignedNpcIds
+	}
+	return nil
+}
+
 // Mine — extracts Mineral
 type Mine struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	MineId         string                 `protobuf:"bytes,1,opt,name=mine_id,json=mineId,proto3" json:"mine_id,omitempty"`
-	YieldRate      float64                `protobuf:"fixed64,2,opt,name=yield_rate,json=yieldRate,proto3" json:"yield_rate,omitempty"`                // Per tick per NPC
-	MineralReserve float64                `protobuf:"fixed64,3,opt,name=mineral_reserve,json=mineralReserve,proto3" json:"mineral_reserve,omitempty"` // Remaining extractable
-	AssignedNpcs   int32                  `protobuf:"varint,4,opt,name=assigned_npcs,json=assignedNpcs,proto3" json:"assigned_npcs,omitempty"`
-	Operational    bool                   `protobuf:"varint,5,opt,name=operational,proto3" json:"operational,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MineId            string                 `protobuf:"bytes,1,opt,name=mine_id,json=mineId,proto3" json:"mine_id,omitempty"`
+	YieldRate         float64                `protobuf:"fixed64,2,opt,name=yield_rate,json=yieldRate,proto3" json:"yield_rate,omitempty"`                // Per tick per NPC
+	MineralReserve    float64                `protobuf:"fixed64,3,opt,name=mineral_reserve,json=mineralReserve,proto3" json:"mineral_reserve,omitempty"` // Remaining extractable
+	AssignedNpcs      int32                  `protobuf:"varint,4,opt,name=assigned_npcs,json=assignedNpcs,proto3" json:"assigned_npcs,omitempty"`
+	Operational       bool                   `protobuf:"varint,5,opt,name=operational,proto3" json:"operational,omitempty"`
+	DegradedYieldRate float64                `protobuf:"fixed64,6,opt,name=degraded_yield_rate,json=degradedYieldRate,proto3" json:"degraded_yield_rate,omitempty"` // yield_rate after world aging and crew efficiency
+	AssignedNpcIds    []string               `protobuf:"bytes,7,rep,name=assigned_npc_ids,json=assignedNpcIds,proto3" json:"assigned_npc_ids,omitempty"`
+	AutoScaled        bool                   `protobuf:"varint,8,opt,name=auto_scaled,json=autoScaled,proto3" json:"auto_scaled,omitempty"` // Added by auto-scaling when mineral ran low
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Mine) Reset() {
@@ -473,6 +540,27 @@ func (x *Mine) GetOperational() bool {
 	return false
 }
 
+func (x *Mine) GetDegradedYieldRate() float64 {
+	if x != nil {
+		return x.DegradedYieldRate
+	}
+	return 0
+}
+
+func (x *Mine) GetAssignedNpcIds() []string {
+	if x != nil {
+		return x.AssignedNpcIds
+	}
+	return nil
+}
+
+func (x *Mine) GetAutoScaled() bool {
+	if x != nil {
+		return x.AutoScaled
+	}
+	return false
+}
+
 var File_simulation_proto protoreflect.FileDescriptor
 
 const file_simulation_proto_rawDesc = "" +
@@ -482,7 +570,7 @@ const file_simulation_proto_rawDesc = "" +
 	"\x04type\x18\x01 \x01(\x0e2\x1e.epoch.simulation.ResourceTypeR\x04type\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12'\n" +
 	"\x0fproduction_rate\x18\x03 \x01(\x01R\x0eproductionRate\x12)\n" +
-	"\x10consumption_rate\x18\x04 \x01(\x01R\x0fconsumptionRate\"\x8d\x03\n" +
+	"\x10consumption_rate\x18\x04 \x01(\x01R\x0fconsumptionRate\"\x8b\x05\n" +
 	"\x10SimulationStatus\x12\x1e\n" +
 	"\n" +
 	"refineries\x18\x01 \x01(\x05R\n" +
@@ -495,12 +583,18 @@ const file_simulation_proto_rawDesc = "" +
 	"\n" +
 	"tick_count\x18\x06 \x01(\x03R\ttickCount\x129\n" +
 	"\tlast_tick\x18\a \x01(\v2\x1c.epoch.common.EpochTimestampR\blastTick\x12E\n" +
-	"\vinfestation\x18\b \x01(\v2#.epoch.simulation.InfestationStatusR\vinfestation\"\xb0\x01\n" +
+	"\vinfestation\x18\b \x01(\v2#.epoch.simulation.InfestationStatusR\vinfestation\x12$\n" +
+	"\x0eavg_npc_morale\x18\t \x01(\x01R\favgNpcMorale\x12,\n" +
+	"\x12avg_npc_efficiency\x18\n" +
+	" \x01(\x01R\x10avgNpcEfficiency\x12$\n" +
+	"\x0eavg_npc_trauma\x18\v \x01(\x01R\favgNpcTrauma\x12=\n" +
+	"\x1bnpcs_below_morale_threshold\x18\f \x01(\x05R\x18npcsBelowMoraleThreshold\x12C\n" +
+	"\x1enpcs_above_rebellion_threshold\x18\r \x01(\x05R\x1bnpcsAboveRebellionThreshold\"\xb0\x01\n" +
 	"\x11InfestationStatus\x12\x18\n" +
 	"\acounter\x18\x01 \x01(\x01R\acounter\x12&\n" +
 	"\x0fis_plague_heart\x18\x02 \x01(\bR\risPlagueHeart\x12/\n" +
 	"\x13throttle_multiplier\x18\x03 \x01(\x01R\x12throttleMultiplier\x12(\n" +
-	"\x10last_update_tick\x18\x04 \x01(\x03R\x0elastUpdateTick\"\xf2\x01\n" +
+	"\x10last_update_tick\x18\x04 \x01(\x03R\x0elastUpdateTick\"\xf2\x02\n" +
 	"\bRefinery\x12\x1f\n" +
 	"\vrefinery_id\x18\x01 \x01(\tR\n" +
 	"refineryId\x12\x1e\n" +
@@ -510,14 +604,21 @@ const file_simulation_proto_rawDesc = "" +
 	"\x12mineral_input_rate\x18\x03 \x01(\x01R\x10mineralInputRate\x120\n" +
 	"\x14rapidlum_output_rate\x18\x04 \x01(\x01R\x12rapidlumOutputRate\x12#\n" +
 	"\rassigned_npcs\x18\x05 \x01(\x05R\fassignedNpcs\x12 \n" +
-	"\voperational\x18\x06 \x01(\bR\voperational\"\xae\x01\n" +
+	"\voperational\x18\x06 \x01(\bR\voperational\x12/\n" +
+	"\x13degraded_efficiency\x18\a \x01(\x01R\x12degradedEfficiency\x12#\n" +
+	"\rfacility_type\x18\b \x01(\tR\ffacilityType\x12(\n" +
+	"\x10assigned_npc_ids\x18\t \x03(\tR\x0eassignedNpcIds\"\xa9\x02\n" +
 	"\x04Mine\x12\x17\n" +
 	"\amine_id\x18\x01 \x01(\tR\x06mineId\x12\x1d\n" +
 	"\n" +
 	"yield_rate\x18\x02 \x01(\x01R\tyieldRate\x12'\n" +
 	"\x0fmineral_reserve\x18\x03 \x01(\x01R\x0emineralReserve\x12#\n" +
 	"\rassigned_npcs\x18\x04 \x01(\x05R\fassignedNpcs\x12 \n" +
-	"\voperational\x18\x05 \x01(\bR\voperational*{\n" +
+	"\voperational\x18\x05 \x01(\bR\voperational\x12.\n" +
+	"\x13degraded_yield_rate\x18\x06 \x01(\x01R\x11degradedYieldRate\x12(\n" +
+	"\x10assigned_npc_ids\x18\a \x03(\tR\x0eassignedNpcIds\x12\x1f\n" +
+	"\vauto_scaled\x18\b \x01(\bR\n" +
+	"autoScaled*{\n" +
 	"\fResourceType\x12\x1d\n" +
 	"\x19RESOURCE_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11RESOURCE_TYPE_SIM\x10\x01\x12\x1a\n" +
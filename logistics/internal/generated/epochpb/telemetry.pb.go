@@ -401,11 +401,12 @@ func (x *PermanentTraumaEvent) GetInflictedAt() *EpochTimestamp {
 // Telemetry Event — the unified event wrapper
 // ---------------------------------------------------------------------------
 type TelemetryEvent struct {
-	state     protoimpl.MessageState `protogen:"open.v1"`
-	EventId   string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`                            // Unique telemetry event ID
-	NpcId     string                 `protobuf:"bytes,2,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`                                  // Affected NPC
-	Severity  TelemetrySeverity      `protobuf:"varint,3,opt,name=severity,proto3,enum=epoch.telemetry.TelemetrySeverity" json:"severity,omitempty"` // Visual urgency tier
-	Timestamp *EpochTimestamp        `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                       // Millisecond-precision emission time
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`                            // Unique telemetry event ID
+	NpcId         string                 `protobuf:"bytes,2,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`                                  // Affected NPC
+	Severity      TelemetrySeverity      `protobuf:"varint,3,opt,name=severity,proto3,enum=epoch.telemetry.TelemetrySeverity" json:"severity,omitempty"` // Visual urgency tier
+	Timestamp     *EpochTimestamp        `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                       // Millisecond-precision emission time
+	CorrelationId string                 `protobuf:"bytes,5,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`          // Shared by events caused by the same game action (empty = uncorrelated)
 	// Exactly one of these will be set per event
 	//
 	// Types that are valid to be assigned to Payload:
@@ -478,6 +479,13 @@ func (x *TelemetryEvent) GetTimestamp() *EpochTimestamp {
 	return nil
 }
 
+func (x *TelemetryEvent) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
 func (x *TelemetryEvent) GetPayload() isTelemetryEvent_Payload {
 	if x != nil {
 		return x.Payload
@@ -549,7 +557,8 @@ type StateChangeEvent struct {
 	Attribute     string                 `protobuf:"bytes,1,opt,name=attribute,proto3" json:"attribute,omitempty"` // "morale", "work_efficiency", "trauma_score", etc.
 	OldValue      float64                `protobuf:"fixed64,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
 	NewValue      float64                `protobuf:"fixed64,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
-	Cause         string                 `protobuf:"bytes,4,opt,name=cause,proto3" json:"cause,omitempty"` // Human-readable cause
+	Cause         string                 `protobuf:"bytes,4,opt,name=cause,proto3" json:"cause,omitempty"`                                                                                       // Human-readable cause
+	Attributes    map[string]float64     `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // Numeric context, e.g. "tick_count", "mineral_quantity"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -612,6 +621,13 @@ func (x *StateChangeEvent) GetCause() string {
 	return ""
 }
 
+func (x *StateChangeEvent) GetAttributes() map[string]float64 {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Telemetry Batch — for bulk delivery (batch tick results)
 // ---------------------------------------------------------------------------
@@ -774,23 +790,30 @@ const file_telemetry_proto_rawDesc = "" +
 	"\x13attribute_reduction\x18\x04 \x01(\x01R\x12attributeReduction\x12'\n" +
 	"\x0ftrigger_context\x18\x05 \x01(\tR\x0etriggerContext\x12#\n" +
 	"\rphobia_target\x18\x06 \x01(\tR\fphobiaTarget\x12?\n" +
-	"\finflicted_at\x18\a \x01(\v2\x1c.epoch.common.EpochTimestampR\vinflictedAt\"\xf1\x03\n" +
+	"\finflicted_at\x18\a \x01(\v2\x1c.epoch.common.EpochTimestampR\vinflictedAt\"\x98\x04\n" +
 	"\x0eTelemetryEvent\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x15\n" +
 	"\x06npc_id\x18\x02 \x01(\tR\x05npcId\x12>\n" +
 	"\bseverity\x18\x03 \x01(\x0e2\".epoch.telemetry.TelemetrySeverityR\bseverity\x12:\n" +
-	"\ttimestamp\x18\x04 \x01(\v2\x1c.epoch.common.EpochTimestampR\ttimestamp\x12R\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1c.epoch.common.EpochTimestampR\ttimestamp\x12%\n" +
+	"\x0ecorrelation_id\x18\x05 \x01(\tR\rcorrelationId\x12R\n" +
 	"\x10mental_breakdown\x18\n" +
 	" \x01(\v2%.epoch.telemetry.MentalBreakdownEventH\x00R\x0fmentalBreakdown\x12R\n" +
 	"\x10permanent_trauma\x18\v \x01(\v2%.epoch.telemetry.PermanentTraumaEventH\x00R\x0fpermanentTrauma\x12F\n" +
 	"\fstate_change\x18\f \x01(\v2!.epoch.telemetry.StateChangeEventH\x00R\vstateChange\x126\n" +
 	"\fnpc_snapshot\x18\x14 \x01(\v2\x13.epoch.npc.NPCStateR\vnpcSnapshotB\t\n" +
-	"\apayload\"\x80\x01\n" +
+	"\apayload\"\x92\x02\n" +
 	"\x10StateChangeEvent\x12\x1c\n" +
 	"\tattribute\x18\x01 \x01(\tR\tattribute\x12\x1b\n" +
 	"\told_value\x18\x02 \x01(\x01R\boldValue\x12\x1b\n" +
 	"\tnew_value\x18\x03 \x01(\x01R\bnewValue\x12\x14\n" +
-	"\x05cause\x18\x04 \x01(\tR\x05cause\"\xb1\x01\n" +
+	"\x05cause\x18\x04 \x01(\tR\x05cause\x12Q\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v21.epoch.telemetry.StateChangeEvent.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xb1\x01\n" +
 	"\x0eTelemetryBatch\x127\n" +
 	"\x06events\x18\x01 \x03(\v2\x1f.epoch.telemetry.TelemetryEventR\x06events\x12\x1f\n" +
 	"\vtick_number\x18\x02 \x01(\x03R\n" +
@@ -838,7 +861,7 @@ func file_telemetry_proto_rawDescGZIP() []byte {
 }
 
 var file_telemetry_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_telemetry_proto_goTypes = []any{
 	(TelemetrySeverity)(0),       // 0: epoch.telemetry.TelemetrySeverity
 	(MentalBreakdownType)(0),     // 1: epoch.telemetry.MentalBreakdownType
@@ -849,27 +872,29 @@ var file_telemetry_proto_goTypes = []any{
 	(*StateChangeEvent)(nil),     // 6: epoch.telemetry.StateChangeEvent
 	(*TelemetryBatch)(nil),       // 7: epoch.telemetry.TelemetryBatch
 	(*TelemetryFilter)(nil),      // 8: epoch.telemetry.TelemetryFilter
-	(*EpochTimestamp)(nil),       // 9: epoch.common.EpochTimestamp
-	(*NPCState)(nil),             // 10: epoch.npc.NPCState
+	nil,                          // 9: epoch.telemetry.StateChangeEvent.AttributesEntry
+	(*EpochTimestamp)(nil),       // 10: epoch.common.EpochTimestamp
+	(*NPCState)(nil),             // 11: epoch.npc.NPCState
 }
 var file_telemetry_proto_depIdxs = []int32{
 	1,  // 0: epoch.telemetry.MentalBreakdownEvent.type:type_name -> epoch.telemetry.MentalBreakdownType
 	2,  // 1: epoch.telemetry.PermanentTraumaEvent.type:type_name -> epoch.telemetry.PermanentTraumaType
-	9,  // 2: epoch.telemetry.PermanentTraumaEvent.inflicted_at:type_name -> epoch.common.EpochTimestamp
+	10, // 2: epoch.telemetry.PermanentTraumaEvent.inflicted_at:type_name -> epoch.common.EpochTimestamp
 	0,  // 3: epoch.telemetry.TelemetryEvent.severity:type_name -> epoch.telemetry.TelemetrySeverity
-	9,  // 4: epoch.telemetry.TelemetryEvent.timestamp:type_name -> epoch.common.EpochTimestamp
+	10, // 4: epoch.telemetry.TelemetryEvent.timestamp:type_name -> epoch.common.EpochTimestamp
 	3,  // 5: epoch.telemetry.TelemetryEvent.mental_breakdown:type_name -> epoch.telemetry.MentalBreakdownEvent
 	4,  // 6: epoch.telemetry.TelemetryEvent.permanent_trauma:type_name -> epoch.telemetry.PermanentTraumaEvent
 	6,  // 7: epoch.telemetry.TelemetryEvent.state_change:type_name -> epoch.telemetry.StateChangeEvent
-	10, // 8: epoch.telemetry.TelemetryEvent.npc_snapshot:type_name -> epoch.npc.NPCState
-	5,  // 9: epoch.telemetry.TelemetryBatch.events:type_name -> epoch.telemetry.TelemetryEvent
-	9,  // 10: epoch.telemetry.TelemetryBatch.batch_timestamp:type_name -> epoch.common.EpochTimestamp
-	0,  // 11: epoch.telemetry.TelemetryFilter.min_severity:type_name -> epoch.telemetry.TelemetrySeverity
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	11, // 8: epoch.telemetry.TelemetryEvent.npc_snapshot:type_name -> epoch.npc.NPCState
+	9,  // 9: epoch.telemetry.StateChangeEvent.attributes:type_name -> epoch.telemetry.StateChangeEvent.AttributesEntry
+	5,  // 10: epoch.telemetry.TelemetryBatch.events:type_name -> epoch.telemetry.TelemetryEvent
+	10, // 11: epoch.telemetry.TelemetryBatch.batch_timestamp:type_name -> epoch.common.EpochTimestamp
+	0,  // 12: epoch.telemetry.TelemetryFilter.min_severity:type_name -> epoch.telemetry.TelemetrySeverity
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_telemetry_proto_init() }
@@ -890,7 +915,7 @@ func file_telemetry_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_telemetry_proto_rawDesc), len(file_telemetry_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
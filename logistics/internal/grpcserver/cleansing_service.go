@@ -3,8 +3,8 @@ package grpcserver
 import (
 	"context"
 
-	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/cleansing"
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
 	"google.golang.org/grpc/codes"
@@ -75,7 +75,7 @@ func (s *cleansingService) DeployCleansingOperation(
 	}
 
 	// Execute cleansing
-	result, err := s.cleansingEngine.Execute(participants, true)
+	result, err := s.cleansingEngine.Execute(ctx, participants, true)
 	if err != nil {
 		return &pb.CleansingResponse{
 			Success:      false,
@@ -101,12 +101,13 @@ func (s *cleansingService) DeployCleansingOperation(
 		RolledValue:      result.RolledValue,
 		Factors: &pb.CleansingFactors{
 			Base:                   result.Factors.BaseFactor,
-			AvgMorale:             result.Factors.AvgMorale,
-			MoraleContribution:    result.Factors.MoraleContrib,
-			AvgTrauma:             result.Factors.AvgTrauma,
-			TraumaPenalty:         result.Factors.TraumaPenalty,
-			AvgConfidence:         result.Factors.AvgConfidence,
+			AvgMorale:              result.Factors.AvgMorale,
+			MoraleContribution:     result.Factors.MoraleContrib,
+			AvgTrauma:              result.Factors.AvgTrauma,
+			TraumaPenalty:          result.Factors.TraumaPenalty,
+			AvgConfidence:          result.Factors.AvgConfidence,
 			ConfidenceContribution: result.Factors.ConfidenceContrib,
+			SizeBonus:              result.Factors.SizeBonus,
 		},
 	}, nil
 }
@@ -0,0 +1,133 @@
+package grpcserver
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/cleansing"
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// cleansingTestEnv holds the engines behind a bufconn CleansingService.
+type cleansingTestEnv struct {
+	client    pb.CleansingServiceClient
+	sim       *simulation.SimulationEngine
+	behavior  *npc.BehaviorEngine
+	cleansing *cleansing.Engine
+	telemetry *telemetryService
+}
+
+// setupCleansingTest serves a CleansingService over an in-process gRPC
+// connection. The cleansing engine rolls from a fixed seed. The server is
+// stopped on test cleanup.
+func setupCleansingTest(t *testing.T) *cleansingTestEnv {
+	t.Helper()
+
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	env := &cleansingTestEnv{
+		sim:       simulation.NewSimulationEngine(rebEngine),
+		behavior:  npc.NewBehaviorEngine(),
+		cleansing: cleansing.NewEngine(cleansing.DefaultConfig()),
+	}
+	env.cleansing.SetRandFn(rand.New(rand.NewSource(1)).Float64)
+	env.telemetry = NewTelemetryService(rebEngine, env.behavior)
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	pb.RegisterCleansingServiceServer(srv, NewCleansingService(env.sim, env.behavior, env.cleansing, env.telemetry))
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			t.Logf("server exited: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+	env.client = pb.NewCleansingServiceClient(conn)
+	return env
+}
+
+// registerWarriors registers warriors with the given IDs at full morale.
+func (env *cleansingTestEnv) registerWarriors(t *testing.T, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		env.behavior.RegisterNPCWithRole(id, "warrior")
+		require.NoError(t, env.behavior.ApplyMoraleModifier(id, 1.0, "test"))
+	}
+}
+
+func deployCleansing(t *testing.T, client pb.CleansingServiceClient) (*pb.CleansingResponse, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return client.DeployCleansingOperation(ctx, &pb.CleansingRequest{})
+}
+
+func TestDeployCleansing_PlagueHeartNotActive(t *testing.T) {
+	env := setupCleansingTest(t)
+	env.registerWarriors(t, "w-1", "w-2")
+
+	_, err := deployCleansing(t, env.client)
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "Plague Heart is not active")
+}
+
+func TestDeployCleansing_InsufficientWarriors(t *testing.T) {
+	env := setupCleansingTest(t)
+	env.sim.GetInfestationEngine().ForceActivatePlagueHeart()
+	env.registerWarriors(t, "w-1")
+
+	_, err := deployCleansing(t, env.client)
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "insufficient")
+	assert.True(t, env.sim.GetInfestationState().IsPlagueHeart, "failed deploy leaves Plague Heart active")
+}
+
+func TestDeployCleansing_SuccessResetsInfestation(t *testing.T) {
+	env := setupCleansingTest(t)
+	env.sim.GetInfestationEngine().ForceActivatePlagueHeart()
+	env.registerWarriors(t, "w-1", "w-2", "w-3")
+	env.behavior.RegisterNPC("worker-1") // not a participant
+
+	resp, err := deployCleansing(t, env.client)
+	require.NoError(t, err)
+
+	// Full morale: 0.50 + 0.25 + 0.15 clamps to MaxSuccessRate; seed 1 rolls ~0.605
+	assert.True(t, resp.GetSuccess())
+	assert.InDelta(t, 0.85, resp.GetSuccessRate(), 1e-9)
+	assert.InDelta(t, 0.6046602879796196, resp.GetRolledValue(), 1e-12)
+	assert.Equal(t, int32(3), resp.GetParticipantCount())
+	assert.ElementsMatch(t, []string{"w-1", "w-2", "w-3"}, resp.GetParticipantIds())
+	assert.InDelta(t, 1.0, resp.GetFactors().GetAvgMorale(), 1e-9)
+
+	state := env.sim.GetInfestationState()
+	assert.False(t, state.IsPlagueHeart)
+	assert.Zero(t, state.Counter)
+	assert.Equal(t, 1.0, state.ThrottleMultiplier)
+	assert.EqualValues(t, 1, env.telemetry.TotalEmitted(), "cleansing result emitted")
+}
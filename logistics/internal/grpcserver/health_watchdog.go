@@ -0,0 +1,244 @@
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// DefaultWatchdogInterval is how often the watchdog probes the engines.
+	DefaultWatchdogInterval = 5 * time.Second
+	// DefaultWatchdogProbeTimeout is how long a probe may block before the
+	// corresponding service is demoted to NOT_SERVING.
+	DefaultWatchdogProbeTimeout = 2 * time.Second
+)
+
+// Health service names reported by the gRPC health server.
+const (
+	healthServiceRebellion  = "epoch.RebellionService"
+	healthServiceSimulation = "epoch.SimulationService"
+	healthServiceTelemetry  = "epoch.TelemetryService"
+	healthServiceCleansing  = "epoch.CleansingService"
+	healthServiceNPC        = "epoch.NPCService"
+)
+
+// simulationStatusProvider is the subset of SimulationEngine probed by the watchdog.
+type simulationStatusProvider interface {
+	GetStatus() simulation.SimulationStatus
+}
+
+// rebellionConfigProvider is the subset of rebellion.Engine probed by the watchdog.
+type rebellionConfigProvider interface {
+	GetConfig() rebellion.RebellionConfig
+}
+
+// telemetryCounter is the subset of the telemetry service probed by the watchdog.
+type telemetryCounter interface {
+	TotalEmitted() int64
+}
+
+// HealthWatchdog periodically probes the engines and demotes a service's
+// gRPC health status to NOT_SERVING when its engine stops responding within
+// the probe timeout. A service is promoted back to SERVING once a probe
+// completes in time again.
+type HealthWatchdog struct {
+	healthServer *health.Server
+	simulation   simulationStatusProvider
+	rebellion    rebellionConfigProvider
+	telemetry    telemetryCounter
+
+	interval     time.Duration
+	probeTimeout time.Duration
+
+	// In-flight flags prevent piling up goroutines behind a blocked engine.
+	simInFlight atomic.Bool
+	rebInFlight atomic.Bool
+
+	heldMu sync.Mutex
+	held   map[string]bool // Services pinned to a non-SERVING status by an operator
+
+	// Probe-loop state, only touched from the run goroutine.
+	unhealthy        map[string]bool
+	lastEmitted      int64
+	telemetryStalled bool
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewHealthWatchdog creates a watchdog that reports into healthServer.
+// Zero interval or probeTimeout fall back to the package defaults.
+func NewHealthWatchdog(
+	healthServer *health.Server,
+	sim simulationStatusProvider,
+	reb rebellionConfigProvider,
+	tel telemetryCounter,
+	interval, probeTimeout time.Duration,
+) *HealthWatchdog {
+	if interval <= 0 {
+		interval = DefaultWatchdogInterval
+	}
+	if probeTimeout <= 0 {
+		probeTimeout = DefaultWatchdogProbeTimeout
+	}
+	return &HealthWatchdog{
+		healthServer: healthServer,
+		simulation:   sim,
+		rebellion:    reb,
+		telemetry:    tel,
+		interval:     interval,
+		probeTimeout: probeTimeout,
+		held:         make(map[string]bool),
+		unhealthy:    make(map[string]bool),
+	}
+}
+
+// Start launches the probe loop in a goroutine. It returns immediately.
+// The loop exits when ctx is cancelled or Stop is called.
+func (w *HealthWatchdog) Start(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil {
+		return // already running
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go w.run(ctx, w.done)
+}
+
+// Stop halts the probe loop and waits for it to exit. Safe to call more than once.
+func (w *HealthWatchdog) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+func (w *HealthWatchdog) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.checkOnce(ctx)
+		}
+	}
+}
+
+// checkOnce runs a single round of probes.
+func (w *HealthWatchdog) checkOnce(ctx context.Context) {
+	if w.simulation != nil {
+		w.setStatus(healthServiceSimulation, w.probe(ctx, &w.simInFlight, func() {
+			w.simulation.GetStatus()
+		}))
+	}
+	if w.rebellion != nil {
+		w.setStatus(healthServiceRebellion, w.probe(ctx, &w.rebInFlight, func() {
+			w.rebellion.GetConfig()
+		}))
+	}
+	if w.telemetry != nil {
+		w.checkTelemetry()
+	}
+}
+
+// probe runs fn with the configured timeout and reports whether it returned
+// in time. If a previous probe is still blocked, the engine is treated as
+// unresponsive without starting another goroutine.
+func (w *HealthWatchdog) probe(ctx context.Context, inFlight *atomic.Bool, fn func()) bool {
+	if !inFlight.CompareAndSwap(false, true) {
+		return false
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, w.probeTimeout)
+	defer cancel()
+
+	finished := make(chan struct{})
+	go func() {
+		defer inFlight.Store(false)
+		fn()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return true
+	case <-probeCtx.Done():
+		return false
+	}
+}
+
+// checkTelemetry tracks whether the emitted-event counter is still advancing.
+// An idle event stream is legitimate, so a stall is logged but the
+// TelemetryService stays SERVING.
+func (w *HealthWatchdog) checkTelemetry() {
+	emitted := w.telemetry.TotalEmitted()
+	stalled := emitted == w.lastEmitted
+	if stalled && !w.telemetryStalled {
+		log.Printf("[Watchdog] Telemetry counter has not advanced since last check (total=%d)", emitted)
+	}
+	w.telemetryStalled = stalled
+	w.lastEmitted = emitted
+}
+
+// hold pins (or releases) a service so the watchdog leaves its status alone.
+func (w *HealthWatchdog) hold(service string, held bool) {
+	w.heldMu.Lock()
+	defer w.heldMu.Unlock()
+	if held {
+		w.held[service] = true
+	} else {
+		delete(w.held, service)
+	}
+}
+
+func (w *HealthWatchdog) isHeld(service string) bool {
+	w.heldMu.Lock()
+	defer w.heldMu.Unlock()
+	return w.held[service]
+}
+
+// setStatus updates the health server and logs on transitions only.
+// Services held by an operator are left untouched.
+func (w *HealthWatchdog) setStatus(service string, healthy bool) {
+	if w.isHeld(service) {
+		return
+	}
+
+	wasUnhealthy := w.unhealthy[service]
+	w.unhealthy[service] = !healthy
+
+	if healthy {
+		if wasUnhealthy {
+			log.Printf("[Watchdog] %s responding again, marking SERVING", service)
+		}
+		w.healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+		return
+	}
+	if !wasUnhealthy {
+		log.Printf("[Watchdog] %s did not respond within %v, marking NOT_SERVING", service, w.probeTimeout)
+	}
+	w.healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
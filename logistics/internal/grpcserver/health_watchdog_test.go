@@ -0,0 +1,84 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// blockingSimulation simulates an unresponsive simulation engine whose
+// GetStatus call blocks until release is closed.
+type blockingSimulation struct {
+	release chan struct{}
+}
+
+func (b *blockingSimulation) GetStatus() simulation.SimulationStatus {
+	<-b.release
+	return simulation.SimulationStatus{}
+}
+
+func healthStatus(t *testing.T, hs *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.GetStatus()
+}
+
+func TestHealthWatchdog_UnresponsiveSimulationDemoted(t *testing.T) {
+	hs := health.NewServer()
+	hs.SetServingStatus(healthServiceSimulation, healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(healthServiceRebellion, healthpb.HealthCheckResponse_SERVING)
+
+	sim := &blockingSimulation{release: make(chan struct{})}
+	defer close(sim.release)
+	reb := rebellion.NewEngine(rebellion.DefaultConfig())
+
+	w := NewHealthWatchdog(hs, sim, reb, nil, 100*time.Millisecond, DefaultWatchdogProbeTimeout)
+	w.Start(context.Background())
+	defer w.Stop()
+
+	assert.Eventually(t, func() bool {
+		return healthStatus(t, hs, healthServiceSimulation) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, 3*time.Second, 50*time.Millisecond)
+
+	// The responsive rebellion engine stays healthy
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, healthStatus(t, hs, healthServiceRebellion))
+}
+
+func TestHealthWatchdog_RecoversAfterEngineResponds(t *testing.T) {
+	hs := health.NewServer()
+	hs.SetServingStatus(healthServiceSimulation, healthpb.HealthCheckResponse_SERVING)
+	sim := &blockingSimulation{release: make(chan struct{})}
+
+	w := NewHealthWatchdog(hs, sim, nil, nil, 20*time.Millisecond, 50*time.Millisecond)
+	w.Start(context.Background())
+	defer w.Stop()
+
+	assert.Eventually(t, func() bool {
+		return healthStatus(t, hs, healthServiceSimulation) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 10*time.Millisecond)
+
+	close(sim.release)
+
+	assert.Eventually(t, func() bool {
+		return healthStatus(t, hs, healthServiceSimulation) == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHealthWatchdog_StopIsIdempotent(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	w := NewHealthWatchdog(health.NewServer(), simulation.NewSimulationEngine(rebEngine), rebEngine, nil, 0, 0)
+	assert.Equal(t, DefaultWatchdogInterval, w.interval)
+	assert.Equal(t, DefaultWatchdogProbeTimeout, w.probeTimeout)
+
+	w.Start(context.Background())
+	w.Stop()
+	w.Stop()
+}
@@ -0,0 +1,150 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultSlowRequestThreshold is the latency above which a call is logged at
+// WARN when LoggingConfig.SlowRequestThreshold is zero.
+const DefaultSlowRequestThreshold = 500 * time.Millisecond
+
+// redactedValue replaces sensitive field values in logged bodies.
+const redactedValue = "[REDACTED]"
+
+// sensitiveFieldMarkers are substrings of JSON field names whose values are
+// never written to logs.
+var sensitiveFieldMarkers = []string{"token", "password", "secret", "api_key", "apikey"}
+
+// Logger is the sink used by the logging interceptor.
+type Logger interface {
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+}
+
+// stdLogger writes to the standard library logger with a level prefix.
+type stdLogger struct{}
+
+func (stdLogger) Infof(format string, args ...any) {
+	log.Printf("[gRPC] INFO "+format, args...)
+}
+
+func (stdLogger) Warnf(format string, args ...any) {
+	log.Printf("[gRPC] WARN "+format, args...)
+}
+
+// LoggingConfig controls what the logging interceptor records.
+type LoggingConfig struct {
+	LogRequestBody       bool          // Include the (redacted) request message as JSON
+	LogResponseBody      bool          // Include the (redacted) response message as JSON
+	SlowRequestThreshold time.Duration // Calls slower than this log at WARN (0 = DefaultSlowRequestThreshold)
+}
+
+// NewLoggingInterceptor returns a unary interceptor that logs the method,
+// request and response sizes in bytes, status code, and latency of every
+// call. Requests carrying an NPC ID (e.g. GetRebellionProbability) also log
+// npc_id. Calls slower than cfg.SlowRequestThreshold are logged at WARN,
+// all others at INFO.
+func NewLoggingInterceptor(logger Logger, cfg LoggingConfig) grpc.UnaryServerInterceptor {
+	if logger == nil {
+		logger = stdLogger{}
+	}
+	slow := cfg.SlowRequestThreshold
+	if slow <= 0 {
+		slow = DefaultSlowRequestThreshold
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		latency := time.Since(start)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "method=%s code=%s latency=%v req_bytes=%d resp_bytes=%d",
+			info.FullMethod, status.Code(err), latency, messageSize(req), messageSize(resp))
+		if r, ok := req.(interface{ GetNpcId() string }); ok && r.GetNpcId() != "" {
+			fmt.Fprintf(&b, " npc_id=%s", r.GetNpcId())
+		}
+		if cfg.LogRequestBody {
+			fmt.Fprintf(&b, " request=%s", redactedJSON(req))
+		}
+		if cfg.LogResponseBody && err == nil {
+			fmt.Fprintf(&b, " response=%s", redactedJSON(resp))
+		}
+
+		if latency > slow {
+			logger.Warnf("slow call (> %v) %s", slow, b.String())
+		} else {
+			logger.Infof("%s", b.String())
+		}
+		return resp, err
+	}
+}
+
+// messageSize returns the wire size of msg, or 0 if it is not a proto message.
+func messageSize(msg any) int {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil {
+		return 0
+	}
+	return proto.Size(m)
+}
+
+// redactedJSON renders msg as JSON with sensitive field values replaced.
+func redactedJSON(msg any) string {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil {
+		return "{}"
+	}
+	raw, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return "{}"
+	}
+	var fields any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "{}"
+	}
+	out, err := json.Marshal(redactFields(fields))
+	if err != nil {
+		return "{}"
+	}
+	return string(out)
+}
+
+// redactFields walks decoded JSON and replaces values of sensitive keys.
+func redactFields(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if isSensitiveField(k) {
+				t[k] = redactedValue
+			} else {
+				t[k] = redactFields(val)
+			}
+		}
+	case []any:
+		for i := range t {
+			t[i] = redactFields(t[i])
+		}
+	}
+	return v
+}
+
+func isSensitiveField(name string) bool {
+	lower := strings.ToLower(name)
+	for _, marker := range sensitiveFieldMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type recordingLogger struct {
+	mu    sync.Mutex
+	infos []string
+	warns []string
+}
+
+func (l *recordingLogger) Infof(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.infos = append(l.infos, fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Warnf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warns = append(l.warns, fmt.Sprintf(format, args...))
+}
+
+func TestLoggingInterceptor_NormalCallLogsInfo(t *testing.T) {
+	logger := &recordingLogger{}
+	interceptor := NewLoggingInterceptor(logger, LoggingConfig{SlowRequestThreshold: time.Second})
+
+	req := &pb.RebellionRequest{NpcId: "npc-42"}
+	resp := &pb.RebellionResponse{NpcId: "npc-42", Probability: 0.5}
+	info := &grpc.UnaryServerInfo{FullMethod: "/epoch.RebellionService/GetRebellionProbability"}
+
+	got, err := interceptor(context.Background(), req, info, func(ctx context.Context, req any) (any, error) {
+		return resp, nil
+	})
+	require.NoError(t, err)
+	assert.Same(t, resp, got)
+
+	require.Len(t, logger.infos, 1)
+	assert.Empty(t, logger.warns)
+	line := logger.infos[0]
+	assert.Contains(t, line, "method=/epoch.RebellionService/GetRebellionProbability")
+	assert.Contains(t, line, "code=OK")
+	assert.Contains(t, line, "npc_id=npc-42")
+	assert.Contains(t, line, fmt.Sprintf("req_bytes=%d", proto.Size(req)))
+	assert.Contains(t, line, fmt.Sprintf("resp_bytes=%d", proto.Size(resp)))
+}
+
+func TestLoggingInterceptor_SlowCallLogsWarn(t *testing.T) {
+	logger := &recordingLogger{}
+	interceptor := NewLoggingInterceptor(logger, LoggingConfig{SlowRequestThreshold: 10 * time.Millisecond})
+	info := &grpc.UnaryServerInfo{FullMethod: "/epoch.SimulationService/AdvanceSimulation"}
+
+	_, err := interceptor(context.Background(), &pb.AdvanceRequest{}, info, func(ctx context.Context, req any) (any, error) {
+		time.Sleep(30 * time.Millisecond)
+		return &pb.AdvanceRequest{}, nil
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, logger.infos)
+	require.Len(t, logger.warns, 1)
+	assert.Contains(t, logger.warns[0], "method=/epoch.SimulationService/AdvanceSimulation")
+}
+
+func TestLoggingInterceptor_ErrorCodeLogged(t *testing.T) {
+	logger := &recordingLogger{}
+	interceptor := NewLoggingInterceptor(logger, LoggingConfig{LogResponseBody: true})
+	info := &grpc.UnaryServerInfo{FullMethod: "/epoch.RebellionService/GetRebellionProbability"}
+
+	_, err := interceptor(context.Background(), &pb.RebellionRequest{NpcId: "ghost"}, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "npc not found")
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	require.Len(t, logger.infos, 1)
+	assert.Contains(t, logger.infos[0], "code=NotFound")
+	assert.Contains(t, logger.infos[0], "resp_bytes=0")
+	assert.NotContains(t, logger.infos[0], "response=")
+}
+
+func TestLoggingInterceptor_RedactsSensitiveFields(t *testing.T) {
+	logger := &recordingLogger{}
+	interceptor := NewLoggingInterceptor(logger, LoggingConfig{LogRequestBody: true})
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Login"}
+
+	req, err := structpb.NewStruct(map[string]any{
+		"user":         "sheriff",
+		"access_token": "s3cr3t-value",
+		"nested":       map[string]any{"password": "hunter2"},
+	})
+	require.NoError(t, err)
+
+	_, err = interceptor(context.Background(), req, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, logger.infos, 1)
+	line := logger.infos[0]
+	assert.Contains(t, line, "sheriff")
+	assert.NotContains(t, line, "s3cr3t-value")
+	assert.NotContains(t, line, "hunter2")
+	assert.Equal(t, 2, strings.Count(line, redactedValue))
+}
@@ -0,0 +1,92 @@
+package grpcserver
+
+import (
+	"errors"
+	"io"
+
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+	"google.golang.org/grpc"
+)
+
+// npcService implements epochpb.NPCServiceServer.
+// It applies batched NPC behavior operations against the BehaviorEngine.
+type npcService struct {
+	pb.UnimplementedNPCServiceServer
+	behaviorEngine *npc.BehaviorEngine
+}
+
+// NewNPCService creates a new NPCServiceServer implementation.
+func NewNPCService(behaviorEngine *npc.BehaviorEngine) *npcService {
+	return &npcService{behaviorEngine: behaviorEngine}
+}
+
+// StreamNPCBehavior applies each request received on the stream in order and
+// sends one response per request, in the same order. Operation errors (e.g.
+// an unregistered NPC) are reported in the response's error_message and do
+// not end the stream. Returns nil once the client closes its send side.
+func (s *npcService) StreamNPCBehavior(
+	stream grpc.BidiStreamingServer[pb.NPCBehaviorRequest, pb.NPCBehaviorResponse],
+) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(s.handleBehaviorRequest(req)); err != nil {
+			return err
+		}
+	}
+}
+
+// handleBehaviorRequest applies a single NPCBehaviorRequest.
+func (s *npcService) handleBehaviorRequest(req *pb.NPCBehaviorRequest) *pb.NPCBehaviorResponse {
+	resp := &pb.NPCBehaviorResponse{RequestId: req.GetRequestId()}
+	npcID := req.GetNpcId()
+	if npcID == "" {
+		resp.ErrorMessage = "npc_id is required"
+		return resp
+	}
+
+	var err error
+	switch op := req.Operation.(type) {
+	case *pb.NPCBehaviorRequest_Register:
+		if role := op.Register.GetRole(); role != "" {
+			s.behaviorEngine.RegisterNPCWithRole(npcID, role)
+		} else {
+			s.behaviorEngine.RegisterNPC(npcID)
+		}
+	case *pb.NPCBehaviorRequest_ModifyMorale:
+		err = s.behaviorEngine.ApplyMoraleModifier(npcID, op.ModifyMorale.GetDelta(), "npc service morale update")
+	case *pb.NPCBehaviorRequest_ModifyEfficiency:
+		err = s.behaviorEngine.ApplyWorkEfficiencyModifier(npcID, op.ModifyEfficiency.GetDelta(), "npc service efficiency update")
+	case *pb.NPCBehaviorRequest_GetState:
+		// Read-only
+	default:
+		err = errors.New("operation is required")
+	}
+	if err != nil {
+		resp.ErrorMessage = err.Error()
+		return resp
+	}
+
+	state, ok := s.behaviorEngine.GetNPC(npcID)
+	if !ok {
+		resp.ErrorMessage = (&npc.NPCNotFoundError{NpcID: npcID}).Error()
+		return resp
+	}
+	resp.State = &pb.NPCBehaviorState{
+		NpcId:          state.NPCID,
+		Role:           state.Role,
+		WorkEfficiency: state.WorkEfficiency,
+		Morale:         state.Morale,
+		AvgTrauma:      state.AvgTrauma,
+		Confidence:     state.Confidence,
+		EmotionalState: string(state.EmotionalState),
+		AssignedTask:   state.AssignedTask,
+	}
+	return resp
+}
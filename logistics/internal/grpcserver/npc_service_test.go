@@ -0,0 +1,136 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// setupNPCTest serves an NPCService over an in-process gRPC connection and
+// returns a connected client. The server is stopped on test cleanup.
+func setupNPCTest(t *testing.T, behavior *npc.BehaviorEngine) pb.NPCServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	pb.RegisterNPCServiceServer(srv, NewNPCService(behavior))
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			t.Logf("server exited: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+	return pb.NewNPCServiceClient(conn)
+}
+
+func TestStreamNPCBehavior_InterleavedRequestsInOrder(t *testing.T) {
+	behavior := npc.NewBehaviorEngine()
+	client := setupNPCTest(t, behavior)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.StreamNPCBehavior(ctx)
+	require.NoError(t, err)
+
+	// 2 registrations, then 48 alternating modify/get requests across both NPCs
+	requests := []*pb.NPCBehaviorRequest{
+		{RequestId: "req-0", NpcId: "npc-a", Operation: &pb.NPCBehaviorRequest_Register{Register: &pb.RegisterNPCOperation{}}},
+		{RequestId: "req-1", NpcId: "npc-b", Operation: &pb.NPCBehaviorRequest_Register{Register: &pb.RegisterNPCOperation{Role: "guard"}}},
+	}
+	wantMorale := map[string]float64{"npc-a": 0.5, "npc-b": 0.5}
+	wantEfficiency := map[string]float64{"npc-a": 0.5, "npc-b": 0.5}
+	type expectation struct{ morale, efficiency float64 }
+	expected := []expectation{{0.5, 0.5}, {0.5, 0.5}}
+	for i := 2; i < 50; i++ {
+		npcID := "npc-a"
+		if i%4 >= 2 {
+			npcID = "npc-b"
+		}
+		req := &pb.NPCBehaviorRequest{RequestId: fmt.Sprintf("req-%d", i), NpcId: npcID}
+		switch i % 3 {
+		case 0:
+			req.Operation = &pb.NPCBehaviorRequest_ModifyMorale{ModifyMorale: &pb.ModifyAttributeOperation{Delta: 0.01}}
+			wantMorale[npcID] += 0.01
+		case 1:
+			req.Operation = &pb.NPCBehaviorRequest_ModifyEfficiency{ModifyEfficiency: &pb.ModifyAttributeOperation{Delta: -0.01}}
+			wantEfficiency[npcID] -= 0.01
+		default:
+			req.Operation = &pb.NPCBehaviorRequest_GetState{GetState: &pb.GetNPCStateOperation{}}
+		}
+		requests = append(requests, req)
+		expected = append(expected, expectation{wantMorale[npcID], wantEfficiency[npcID]})
+	}
+
+	for _, req := range requests {
+		require.NoError(t, stream.Send(req))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	for i, req := range requests {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, req.GetRequestId(), resp.GetRequestId(), "response %d out of order", i)
+		assert.Empty(t, resp.GetErrorMessage())
+		assert.Equal(t, req.GetNpcId(), resp.GetState().GetNpcId())
+		assert.InDelta(t, expected[i].morale, resp.GetState().GetMorale(), 1e-9, req.GetRequestId())
+		assert.InDelta(t, expected[i].efficiency, resp.GetState().GetWorkEfficiency(), 1e-9, req.GetRequestId())
+	}
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	b, _ := behavior.GetNPC("npc-b")
+	assert.Equal(t, "guard", b.Role)
+	assert.InDelta(t, wantMorale["npc-b"], b.Morale, 1e-9)
+}
+
+func TestStreamNPCBehavior_ErrorsDoNotEndStream(t *testing.T) {
+	client := setupNPCTest(t, npc.NewBehaviorEngine())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.StreamNPCBehavior(ctx)
+	require.NoError(t, err)
+
+	requests := []*pb.NPCBehaviorRequest{
+		{RequestId: "unknown", NpcId: "ghost", Operation: &pb.NPCBehaviorRequest_ModifyMorale{ModifyMorale: &pb.ModifyAttributeOperation{Delta: 0.1}}},
+		{RequestId: "no-op", NpcId: "npc-a"},
+		{RequestId: "no-id", Operation: &pb.NPCBehaviorRequest_GetState{GetState: &pb.GetNPCStateOperation{}}},
+		{RequestId: "ok", NpcId: "npc-a", Operation: &pb.NPCBehaviorRequest_Register{Register: &pb.RegisterNPCOperation{}}},
+	}
+	for _, req := range requests {
+		require.NoError(t, stream.Send(req))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	wantErrors := []string{`NPC "ghost" not found`, "operation is required", "npc_id is required", ""}
+	for i, want := range wantErrors {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, requests[i].GetRequestId(), resp.GetRequestId())
+		assert.Equal(t, want, resp.GetErrorMessage())
+		assert.Equal(t, want == "", resp.GetState() != nil)
+	}
+}
@@ -3,22 +3,33 @@ package grpcserver
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/webhook"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// HaltNotifier is informed whenever an applied NPC action pushes rebellion
+// probability to the halt threshold (e.g. *webhook.WebhookDispatcher).
+type HaltNotifier interface {
+	NotifyHalt(event webhook.HaltEvent)
+}
+
 // rebellionService implements epochpb.RebellionServiceServer by delegating
 // to the rebellion.Engine and npc.BehaviorEngine business logic.
 type rebellionService struct {
 	pb.UnimplementedRebellionServiceServer
 	rebellionEngine *rebellion.Engine
 	behaviorEngine  *npc.BehaviorEngine
+	haltNotifier    HaltNotifier      // optional
+	currentTick     func() int64      // optional; enables idle decay and action tick tracking
+	telemetry       *telemetryService // optional; receives correlated action telemetry
 }
 
 // NewRebellionService creates a new RebellionServiceServer implementation.
@@ -26,14 +37,44 @@ func NewRebellionService(
 	rebellionEngine *rebellion.Engine,
 	behaviorEngine *npc.BehaviorEngine,
 ) pb.RebellionServiceServer {
+	return NewRebellionServiceWithNotifier(rebellionEngine, behaviorEngine, nil)
+}
+
+// NewRebellionServiceWithNotifier is like NewRebellionService but reports
+// halt-triggering actions to notifier (nil = no notifications).
+func NewRebellionServiceWithNotifier(
+	rebellionEngine *rebellion.Engine,
+	behaviorEngine *npc.BehaviorEngine,
+	notifier HaltNotifier,
+) pb.RebellionServiceServer {
+	return newRebellionService(rebellionEngine, behaviorEngine, notifier, nil, nil)
+}
+
+// newRebellionService builds the service; currentTick (nil = none) supplies
+// the simulation tick used to track NPC actions and apply idle decay, and
+// telemetry (nil = none) receives the events emitted for applied actions.
+func newRebellionService(
+	rebellionEngine *rebellion.Engine,
+	behaviorEngine *npc.BehaviorEngine,
+	notifier HaltNotifier,
+	currentTick func() int64,
+	telemetry *telemetryService,
+) *rebellionService {
 	return &rebellionService{
 		rebellionEngine: rebellionEngine,
 		behaviorEngine:  behaviorEngine,
+		haltNotifier:    notifier,
+		currentTick:     currentTick,
+		telemetry:       telemetry,
 	}
 }
 
 // GetRebellionProbability returns the current rebellion probability for an NPC.
 // If the NPC is not registered, it is auto-registered with default values.
+// When a tick source is configured, idle decay (see rebellion.ApplyIdleDecay)
+// is applied to the NPC's morale for the ticks since its last action or
+// registration; the decay only affects the reported probability, not the
+// stored NPC state.
 func (s *rebellionService) GetRebellionProbability(
 	ctx context.Context,
 	req *pb.RebellionRequest,
@@ -55,6 +96,11 @@ func (s *rebellionService) GetRebellionProbability(
 		WorkEfficiency: npcBehavior.WorkEfficiency,
 		Morale:         npcBehavior.Morale,
 		MemoryCount:    0,
+		Role:           npcBehavior.Role,
+	}
+	if s.currentTick != nil {
+		idle := s.rebellionEngine.GetTicksSinceLastAction(npcID, s.currentTick())
+		profile.Morale = s.rebellionEngine.ApplyIdleDecay(npcID, idle).Morale
 	}
 
 	result := s.rebellionEngine.CalculateProbability(profile)
@@ -64,6 +110,7 @@ func (s *rebellionService) GetRebellionProbability(
 		NpcId:             result.NPCID,
 		Probability:       result.Probability,
 		ThresholdExceeded: result.ThresholdExceeded,
+		VetoTriggered:     result.VetoTriggered,
 		CalculatedAt: &pb.EpochTimestamp{
 			Iso8601: now.Format(time.RFC3339),
 			UnixMs:  now.UnixMilli(),
@@ -72,10 +119,11 @@ func (s *rebellionService) GetRebellionProbability(
 
 	if req.GetIncludeFactors() {
 		resp.Factors = &pb.RebellionFactors{
-			Base:               result.Factors.Base,
-			TraumaModifier:     result.Factors.TraumaModifier,
-			EfficiencyModifier: result.Factors.EfficiencyModifier,
-			MoraleModifier:     result.Factors.MoraleModifier,
+			Base:                 result.Factors.Base,
+			TraumaModifier:       result.Factors.TraumaModifier,
+			EfficiencyModifier:   result.Factors.EfficiencyModifier,
+			MoraleModifier:       result.Factors.MoraleModifier,
+			RelationshipModifier: result.Factors.RelationshipModifier,
 		}
 	}
 
@@ -84,7 +132,13 @@ func (s *rebellionService) GetRebellionProbability(
 
 // ProcessNPCAction processes a player/director action against an NPC, updating
 // the NPC's behavioral state and returning the new rebellion probability.
-// If dry_run is true, the effects are calculated but not applied.
+// If dry_run is true, the effects are calculated but not applied. Applied
+// actions get a fresh correlation ID, returned in the response and attached
+// to every telemetry event emitted for the action (one state change per
+// changed attribute: morale, work_efficiency, trauma and
+// rebellion_probability). An applied ACTION_TYPE_SUPPRESS suppresses the NPC
+// (see rebellion.ApplySuppression) before the post-action probability is
+// calculated; suppressed_until_tick reports when any suppression expires.
 func (s *rebellionService) ProcessNPCAction(
 	ctx context.Context,
 	req *pb.ProcessActionRequest,
@@ -111,6 +165,8 @@ func (s *rebellionService) ProcessNPCAction(
 		WorkEfficiency: npcBehavior.WorkEfficiency,
 		Morale:         npcBehavior.Morale,
 		MemoryCount:    0,
+		Role:           npcBehavior.Role,
+		Confidence:     npcBehavior.Confidence,
 	}
 
 	// Calculate pre-action probability
@@ -120,26 +176,56 @@ func (s *rebellionService) ProcessNPCAction(
 	actionTypeStr := protoActionTypeToString(action.GetActionType())
 
 	internalAction := rebellion.NPCAction{
-		ActionID:   action.GetActionId(),
-		NPCID:      npcID,
-		ActionType: actionTypeStr,
-		Intensity:  action.GetIntensity(),
+		ActionID:         action.GetActionId(),
+		NPCID:            npcID,
+		ActionType:       actionTypeStr,
+		Intensity:        action.GetIntensity(),
+		SuppressDuration: int(action.GetSuppressDuration()),
 	}
 
 	// Process the action to get updated profile
 	updatedProfile := s.rebellionEngine.ProcessAction(profile, internalAction)
+	if actionTypeStr == rebellion.SuppressActionType && !req.GetDryRun() {
+		s.rebellionEngine.ApplySuppression(internalAction)
+	}
 	postResult := s.rebellionEngine.CalculateProbability(updatedProfile)
 
 	// Apply changes to behavior engine (unless dry run)
+	var tc TelemetryContext
 	if !req.GetDryRun() {
 		effDelta := updatedProfile.WorkEfficiency - npcBehavior.WorkEfficiency
 		moraleDelta := updatedProfile.Morale - npcBehavior.Morale
-		_ = s.behaviorEngine.ApplyWorkEfficiencyModifier(npcID, effDelta)
-		_ = s.behaviorEngine.ApplyMoraleModifier(npcID, moraleDelta)
+		reason := actionTypeStr + " action"
+		_ = s.behaviorEngine.ApplyWorkEfficiencyModifier(npcID, effDelta, reason)
+		_ = s.behaviorEngine.ApplyMoraleModifier(npcID, moraleDelta, reason)
+		if s.currentTick != nil {
+			s.rebellionEngine.TrackLastActionTick(npcID, s.currentTick())
+		}
+		tc = newTelemetryContext(npcID)
+
+		s.rebellionEngine.PublishHalt(postResult)
+		if postResult.HaltTriggered && s.haltNotifier != nil {
+			s.haltNotifier.NotifyHalt(webhook.HaltEvent{
+				NPCID:       npcID,
+				ActionID:    action.GetActionId(),
+				ActionType:  actionTypeStr,
+				Probability: postResult.Probability,
+				Timestamp:   time.Now(),
+			})
+		}
 	}
 
 	rebellionDelta := postResult.Probability - preResult.Probability
 
+	// Stat deltas are computed from the projected profile, so they are
+	// populated identically for dry runs and applied actions.
+	statDeltas := &pb.NPCStatDelta{
+		MoraleDelta:         updatedProfile.Morale - profile.Morale,
+		WorkEfficiencyDelta: updatedProfile.WorkEfficiency - profile.WorkEfficiency,
+		TraumaDelta:         updatedProfile.AvgTrauma - profile.AvgTrauma,
+		ConfidenceDelta:     updatedProfile.Confidence - profile.Confidence,
+	}
+
 	resp := &pb.ProcessActionResponse{
 		UpdatedState: &pb.NPCState{
 			NpcId:                npcID,
@@ -148,18 +234,28 @@ func (s *rebellionService) ProcessNPCAction(
 			TraumaScore:          updatedProfile.AvgTrauma,
 			RebellionProbability: postResult.Probability,
 		},
-		RebellionDelta:     rebellionDelta,
-		RebellionTriggered: postResult.ThresholdExceeded,
+		RebellionDelta:            rebellionDelta,
+		RebellionTriggered:        postResult.ThresholdExceeded,
+		StatDeltas:                statDeltas,
+		PredictedProbabilityRange: s.predictProbabilityRange(profile, internalAction, postResult.Probability),
+		CorrelationId:             tc.CorrelationID,
+	}
+	if expiresAt, ok := s.rebellionEngine.GetActiveSuppression(npcID); ok {
+		resp.SuppressedUntilTick = expiresAt
 	}
 
 	// If rebellion was triggered, populate the event
 	if postResult.ThresholdExceeded {
+		rebellionType := pb.RebellionType_REBELLION_TYPE_PASSIVE
+		if postResult.VetoTriggered {
+			rebellionType = pb.RebellionType_REBELLION_TYPE_VETO
+		}
 		now := time.Now().UTC()
 		resp.RebellionEvent = &pb.RebellionEvent{
 			EventId:              fmt.Sprintf("reb-%d", now.UnixNano()),
 			NpcId:                npcID,
 			ProbabilityAtTrigger: postResult.Probability,
-			RebellionType:        pb.RebellionType_REBELLION_TYPE_PASSIVE,
+			RebellionType:        rebellionType,
 			TriggerActionId:      action.GetActionId(),
 			Timestamp: &pb.EpochTimestamp{
 				Iso8601: now.Format(time.RFC3339),
@@ -168,9 +264,62 @@ func (s *rebellionService) ProcessNPCAction(
 		}
 	}
 
+	if !req.GetDryRun() && s.telemetry != nil {
+		s.emitActionTelemetry(tc, npcID, actionTypeStr, profile, updatedProfile, preResult, postResult)
+	}
+
 	return resp, nil
 }
 
+// emitActionTelemetry emits a state-change event, correlated through tc, for
+// each NPC attribute an applied action changed. The rebellion probability
+// event is CRITICAL when the action pushed the NPC over the halt threshold.
+func (s *rebellionService) emitActionTelemetry(
+	tc TelemetryContext,
+	npcID, actionType string,
+	before, after rebellion.NPCRebellionProfile,
+	preResult, postResult rebellion.RebellionResult,
+) {
+	info := pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO
+	cause := fmt.Sprintf("%s action", actionType)
+
+	if after.Morale != before.Morale {
+		s.telemetry.EmitStateChange(tc, npcID, info, "morale", before.Morale, after.Morale, cause)
+	}
+	if after.WorkEfficiency != before.WorkEfficiency {
+		s.telemetry.EmitStateChange(tc, npcID, info, "work_efficiency", before.WorkEfficiency, after.WorkEfficiency, cause)
+	}
+	if after.AvgTrauma != before.AvgTrauma {
+		s.telemetry.EmitStateChange(tc, npcID, info, "trauma", before.AvgTrauma, after.AvgTrauma, cause)
+	}
+	if postResult.Probability != preResult.Probability || postResult.ThresholdExceeded {
+		severity := info
+		if postResult.ThresholdExceeded {
+			severity = pb.TelemetrySeverity_TELEMETRY_SEVERITY_CRITICAL
+		}
+		s.telemetry.EmitStateChange(tc, npcID, severity, "rebellion_probability", preResult.Probability, postResult.Probability, cause)
+	}
+}
+
+// predictProbabilityRange estimates the spread of the post-action probability by
+// previewing the action with its intensity perturbed by ±10%. Previews leave
+// the engine's stats, cache and halt publishing untouched.
+func (s *rebellionService) predictProbabilityRange(
+	profile rebellion.NPCRebellionProfile,
+	action rebellion.NPCAction,
+	postProbability float64,
+) *pb.ProbabilityRange {
+	low, high := postProbability, postProbability
+	for _, factor := range []float64{0.9, 1.1} {
+		perturbed := action
+		perturbed.Intensity = action.Intensity * factor
+		p := s.rebellionEngine.PreviewAction(profile, perturbed).Probability
+		low = math.Min(low, p)
+		high = math.Max(high, p)
+	}
+	return &pb.ProbabilityRange{Min: low, Max: high}
+}
+
 // StreamNPCEvents is not yet implemented. Returns codes.Unimplemented.
 func (s *rebellionService) StreamNPCEvents(
 	_ *pb.NPCEventFilter,
@@ -195,6 +344,12 @@ func protoActionTypeToString(at pb.ActionType) string {
 		return "environment"
 	case pb.ActionType_ACTION_TYPE_RESOURCE_CHANGE:
 		return "resource_change"
+	case pb.ActionType_ACTION_TYPE_INTIMIDATION:
+		return "intimidation"
+	case pb.ActionType_ACTION_TYPE_REST:
+		return "rest"
+	case pb.ActionType_ACTION_TYPE_SUPPRESS:
+		return rebellion.SuppressActionType
 	default:
 		return "unknown"
 	}
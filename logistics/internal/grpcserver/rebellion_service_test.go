@@ -3,11 +3,13 @@ package grpcserver
 import (
 	"context"
 	"net"
+	"sync"
 	"testing"
 
 	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/webhook"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -145,6 +147,90 @@ func TestProcessNPCAction_Reward(t *testing.T) {
 		"post-reward rebellion should be lower than baseline")
 }
 
+func TestProcessNPCAction_StatDeltas(t *testing.T) {
+	client, cleanup := setupRebellionTest(t)
+	defer cleanup()
+
+	resp, err := client.ProcessNPCAction(context.Background(), &pb.ProcessActionRequest{
+		Action: &pb.NPCAction{
+			ActionId:   "act-reward-delta",
+			NpcId:      "npc-delta",
+			ActionType: pb.ActionType_ACTION_TYPE_REWARD,
+			Intensity:  0.8,
+		},
+	})
+	require.NoError(t, err)
+
+	// Default NPC pre-action: morale=0.5, efficiency=0.5, trauma=0 → 0.30
+	// Reward 0.8: morale +0.12 → 0.62, trauma stays clamped at 0
+	// Post: 0.05 + 0.15 + (1-0.62)*0.2 = 0.276
+	preProbability := 0.30
+	postProbability := resp.GetUpdatedState().GetRebellionProbability()
+	assert.Less(t, resp.GetRebellionDelta(), 0.0)
+	assert.InDelta(t, postProbability-preProbability, resp.GetRebellionDelta(), 1e-9,
+		"rebellion delta should equal post - pre probability")
+
+	deltas := resp.GetStatDeltas()
+	require.NotNil(t, deltas)
+	assert.InDelta(t, 0.12, deltas.GetMoraleDelta(), 1e-9, "reward should raise morale")
+	assert.InDelta(t, 0.0, deltas.GetWorkEfficiencyDelta(), 1e-9)
+	assert.InDelta(t, 0.0, deltas.GetTraumaDelta(), 1e-9, "trauma cannot drop below 0")
+	assert.Zero(t, deltas.GetConfidenceDelta(), "no action changes confidence")
+
+	// Intensity 0.72..0.88 → morale 0.608..0.632 → probability 0.2736..0.2784
+	pr := resp.GetPredictedProbabilityRange()
+	require.NotNil(t, pr)
+	assert.InDelta(t, 0.2736, pr.GetMin(), 1e-9)
+	assert.InDelta(t, 0.2784, pr.GetMax(), 1e-9)
+	assert.LessOrEqual(t, pr.GetMin(), postProbability)
+	assert.GreaterOrEqual(t, pr.GetMax(), postProbability)
+}
+
+func TestProcessNPCAction_ProbabilityRangeHasNoSideEffects(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	svc := NewRebellionService(rebEngine, npc.NewBehaviorEngine())
+
+	resp, err := svc.ProcessNPCAction(context.Background(), &pb.ProcessActionRequest{
+		Action: &pb.NPCAction{
+			ActionId:   "act-range",
+			NpcId:      "npc-range",
+			ActionType: pb.ActionType_ACTION_TYPE_PUNISHMENT,
+			Intensity:  1.0,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetPredictedProbabilityRange())
+
+	stats := rebEngine.GetEngineStats()
+	assert.EqualValues(t, 1, stats.TotalActionsProcessed, "only the action itself is processed")
+	assert.EqualValues(t, 2, stats.TotalCalculations, "pre- and post-action probabilities only")
+}
+
+func TestProcessNPCAction_StatDeltas_DryRun(t *testing.T) {
+	client, cleanup := setupRebellionTest(t)
+	defer cleanup()
+
+	req := &pb.ProcessActionRequest{
+		Action: &pb.NPCAction{
+			ActionId:   "act-dry-delta",
+			NpcId:      "npc-dry-delta",
+			ActionType: pb.ActionType_ACTION_TYPE_PUNISHMENT,
+			Intensity:  1.0,
+		},
+		DryRun: true,
+	}
+
+	first, err := client.ProcessNPCAction(context.Background(), req)
+	require.NoError(t, err)
+	assert.InDelta(t, -0.20, first.GetStatDeltas().GetMoraleDelta(), 1e-9)
+	assert.InDelta(t, 0.15, first.GetStatDeltas().GetTraumaDelta(), 1e-9)
+
+	// Dry run did not apply, so a repeat projects the same deltas
+	second, err := client.ProcessNPCAction(context.Background(), req)
+	require.NoError(t, err)
+	assert.InDelta(t, first.GetStatDeltas().GetMoraleDelta(), second.GetStatDeltas().GetMoraleDelta(), 1e-9)
+}
+
 func TestProcessNPCAction_Punishment(t *testing.T) {
 	client, cleanup := setupRebellionTest(t)
 	defer cleanup()
@@ -272,3 +358,266 @@ func TestProcessNPCAction_InvalidArgument_EmptyNpcId(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, codes.InvalidArgument, st.Code())
 }
+
+type recordingHaltNotifier struct {
+	mu     sync.Mutex
+	events []webhook.HaltEvent
+}
+
+func (n *recordingHaltNotifier) NotifyHalt(event webhook.HaltEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, event)
+}
+
+// countingPublisher counts the messages published on each topic.
+type countingPublisher struct {
+	mu     sync.Mutex
+	topics map[string]int
+}
+
+func (p *countingPublisher) Publish(topic string, _ []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.topics[topic]++
+	return nil
+}
+
+func (p *countingPublisher) count(topic string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.topics[topic]
+}
+
+func TestProcessNPCAction_PublishesHaltOnlyForAppliedActions(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	pub := &countingPublisher{topics: make(map[string]int)}
+	rebEngine.SetPublisher(pub)
+	svc := NewRebellionService(rebEngine, npc.NewBehaviorEngine())
+
+	punish := func(dryRun bool) *pb.ProcessActionResponse {
+		resp, err := svc.ProcessNPCAction(context.Background(), &pb.ProcessActionRequest{
+			Action: &pb.NPCAction{
+				ActionId:   "act-pub",
+				NpcId:      "npc-pub",
+				ActionType: pb.ActionType_ACTION_TYPE_PUNISHMENT,
+				Intensity:  1.0,
+			},
+			DryRun: dryRun,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	halts := 0
+	for i := 0; i < 20; i++ {
+		if punish(false).GetUpdatedState().GetRebellionProbability() >= rebellion.DefaultConfig().HaltThreshold {
+			halts++
+		}
+	}
+	require.Positive(t, halts, "repeated punishment should reach the halt threshold")
+	assert.Equal(t, halts, pub.count(rebellion.TopicHaltTriggered), "one publish per halting action")
+
+	punish(true)
+	_, err := svc.GetRebellionProbability(context.Background(), &pb.RebellionRequest{NpcId: "npc-pub", IncludeFactors: true})
+	require.NoError(t, err)
+	assert.Equal(t, halts, pub.count(rebellion.TopicHaltTriggered), "dry runs and reads must not publish")
+}
+
+func TestProcessNPCAction_NotifiesOnHalt(t *testing.T) {
+	notifier := &recordingHaltNotifier{}
+	svc := NewRebellionServiceWithNotifier(
+		rebellion.NewEngine(rebellion.DefaultConfig()), npc.NewBehaviorEngine(), notifier)
+
+	punish := func(dryRun bool) *pb.ProcessActionResponse {
+		resp, err := svc.ProcessNPCAction(context.Background(), &pb.ProcessActionRequest{
+			Action: &pb.NPCAction{
+				ActionId:   "act-halt",
+				NpcId:      "npc-halt",
+				ActionType: pb.ActionType_ACTION_TYPE_PUNISHMENT,
+				Intensity:  1.0,
+			},
+			DryRun: dryRun,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	halted := false
+	for i := 0; i < 20 && !halted; i++ {
+		halted = punish(false).GetUpdatedState().GetRebellionProbability() >= rebellion.DefaultConfig().HaltThreshold
+	}
+	require.True(t, halted, "repeated punishment should reach the halt threshold")
+	require.NotEmpty(t, notifier.events)
+	last := notifier.events[len(notifier.events)-1]
+	assert.Equal(t, "npc-halt", last.NPCID)
+	assert.Equal(t, "act-halt", last.ActionID)
+	assert.Equal(t, "punishment", last.ActionType)
+	assert.GreaterOrEqual(t, last.Probability, rebellion.DefaultConfig().HaltThreshold)
+
+	// Dry runs never notify, even at halt-level probability
+	before := len(notifier.events)
+	punish(true)
+	assert.Len(t, notifier.events, before, "dry run must not notify")
+}
+
+func TestGetRebellionProbability_AppliesIdleDecay(t *testing.T) {
+	cfg := rebellion.DefaultConfig()
+	cfg.IdleDecayFactor = 0.1
+	rebEngine := rebellion.NewEngine(cfg)
+	behaviorEngine := npc.NewBehaviorEngine()
+	rebEngine.SetProfileSource(behaviorEngine)
+	var tick int64
+	svc := newRebellionService(rebEngine, behaviorEngine, nil, func() int64 { return tick }, nil)
+
+	_, err := svc.ProcessNPCAction(context.Background(), &pb.ProcessActionRequest{
+		Action: &pb.NPCAction{
+			ActionId:   "act-1",
+			NpcId:      "npc-idle",
+			ActionType: pb.ActionType_ACTION_TYPE_REWARD,
+			Intensity:  0.5,
+		},
+	})
+	require.NoError(t, err)
+	npcState, ok := behaviorEngine.GetNPC("npc-idle")
+	require.True(t, ok)
+
+	expected := func(morale float64) float64 {
+		return cfg.BaseProbability + (1-npcState.WorkEfficiency)*cfg.EfficiencyWeight + (1-morale)*cfg.MoraleWeight
+	}
+
+	resp, err := svc.GetRebellionProbability(context.Background(), &pb.RebellionRequest{NpcId: "npc-idle"})
+	require.NoError(t, err)
+	assert.InDelta(t, expected(npcState.Morale), resp.GetProbability(), 1e-9, "no decay right after an action")
+
+	tick = 10
+	resp, err = svc.GetRebellionProbability(context.Background(), &pb.RebellionRequest{NpcId: "npc-idle"})
+	require.NoError(t, err)
+	assert.InDelta(t, expected(npcState.Morale*0.9), resp.GetProbability(), 1e-9, "10 idle ticks cost 10% morale")
+
+	stored, _ := behaviorEngine.GetNPC("npc-idle")
+	assert.Equal(t, npcState.Morale, stored.Morale, "decay must not be written back")
+}
+
+func TestGetRebellionProbability_IdleDecayCountsFromRegistration(t *testing.T) {
+	cfg := rebellion.DefaultConfig()
+	cfg.IdleDecayFactor = 0.1
+	rebEngine := rebellion.NewEngine(cfg)
+	behaviorEngine := npc.NewBehaviorEngine()
+	rebEngine.SetProfileSource(behaviorEngine)
+	tick := int64(5)
+	behaviorEngine.SetRegistrationListener(func(npcID string) { rebEngine.TrackRegistrationTick(npcID, tick) })
+	svc := newRebellionService(rebEngine, behaviorEngine, nil, func() int64 { return tick }, nil)
+
+	behaviorEngine.RegisterNPC("npc-new")
+	tick = 15
+	resp, err := svc.GetRebellionProbability(context.Background(), &pb.RebellionRequest{NpcId: "npc-new"})
+	require.NoError(t, err)
+
+	// Default NPC: efficiency 0.5, morale 0.5 decayed by 10% to 0.45
+	expected := cfg.BaseProbability + 0.5*cfg.EfficiencyWeight + (1-0.45)*cfg.MoraleWeight
+	assert.InDelta(t, expected, resp.GetProbability(), 1e-9, "never-acted NPC decays from its registration")
+}
+
+func TestGetRebellionProbability_RelationshipModifier(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	behaviorEngine := npc.NewBehaviorEngine()
+	rebEngine.SetRelationshipSource(behaviorEngine)
+	svc := NewRebellionService(rebEngine, behaviorEngine)
+
+	behaviorEngine.RegisterNPC("npc-a")
+	behaviorEngine.RegisterNPC("npc-b")
+	require.NoError(t, behaviorEngine.ApplyMoraleModifier("npc-b", -0.4, "test"))
+	require.NoError(t, behaviorEngine.SetRelationship("npc-a", "npc-b", 0.5))
+
+	resp, err := svc.GetRebellionProbability(context.Background(), &pb.RebellionRequest{NpcId: "npc-a", IncludeFactors: true})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.2, resp.GetFactors().GetRelationshipModifier(), 1e-9)
+	assert.InDelta(t, 0.5, resp.GetProbability(), 1e-9) // 0.05 + 0.15 + 0.10 + 0.2
+}
+
+func TestProcessNPCAction_Suppress(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	behaviorEngine := npc.NewBehaviorEngine()
+	var tick int64
+	rebEngine.SetTickSource(func() int64 { return tick })
+	svc := NewRebellionService(rebEngine, behaviorEngine)
+
+	behaviorEngine.RegisterNPC("npc-s")
+	require.NoError(t, behaviorEngine.ApplyMoraleModifier("npc-s", -1.0, "test"))
+	require.NoError(t, behaviorEngine.ApplyWorkEfficiencyModifier("npc-s", -1.0, "test"))
+	before, _ := behaviorEngine.GetNPC("npc-s")
+
+	suppress := func(dryRun bool) *pb.ProcessActionResponse {
+		resp, err := svc.ProcessNPCAction(context.Background(), &pb.ProcessActionRequest{
+			Action: &pb.NPCAction{
+				ActionId:         "act-suppress",
+				NpcId:            "npc-s",
+				ActionType:       pb.ActionType_ACTION_TYPE_SUPPRESS,
+				Intensity:        1.0,
+				SuppressDuration: 5,
+			},
+			DryRun: dryRun,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	dry := suppress(true)
+	assert.True(t, dry.GetRebellionTriggered(), "0.55 exceeds the 0.35 halt threshold")
+	assert.Zero(t, dry.GetSuppressedUntilTick(), "dry runs do not suppress")
+
+	resp := suppress(false)
+	assert.InDelta(t, 0.34, resp.GetUpdatedState().GetRebellionProbability(), 1e-9)
+	assert.False(t, resp.GetRebellionTriggered())
+	assert.Nil(t, resp.GetRebellionEvent())
+	assert.Equal(t, int64(5), resp.GetSuppressedUntilTick())
+
+	after, _ := behaviorEngine.GetNPC("npc-s")
+	assert.Equal(t, before.Morale, after.Morale, "suppression leaves morale unchanged")
+	assert.Equal(t, before.WorkEfficiency, after.WorkEfficiency)
+
+	tick = 5
+	prob, err := svc.GetRebellionProbability(context.Background(), &pb.RebellionRequest{NpcId: "npc-s"})
+	require.NoError(t, err)
+	assert.True(t, prob.GetThresholdExceeded(), "suppression has expired")
+}
+
+func TestRebellionVeto_SurfacedInResponses(t *testing.T) {
+	cfg := rebellion.DefaultConfig()
+	cfg.BaseProbability = 0.45
+	svc := NewRebellionService(rebellion.NewEngine(cfg), npc.NewBehaviorEngine())
+
+	// Default NPC: 0.45 + (1-0.5)*0.3 + (1-0.5)*0.2 = 0.70 (halt, no veto)
+	prob, err := svc.GetRebellionProbability(context.Background(), &pb.RebellionRequest{NpcId: "npc-veto"})
+	require.NoError(t, err)
+	require.True(t, prob.GetThresholdExceeded())
+	assert.False(t, prob.GetVetoTriggered())
+
+	punish := func() *pb.ProcessActionResponse {
+		resp, err := svc.ProcessNPCAction(context.Background(), &pb.ProcessActionRequest{
+			Action: &pb.NPCAction{NpcId: "npc-veto", ActionType: pb.ActionType_ACTION_TYPE_PUNISHMENT, Intensity: 1.0},
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := punish()
+	for i := 0; i < 20 && resp.GetUpdatedState().GetRebellionProbability() < cfg.VetoThreshold; i++ {
+		if resp.GetRebellionEvent() != nil {
+			assert.Equal(t, pb.RebellionType_REBELLION_TYPE_PASSIVE, resp.GetRebellionEvent().GetRebellionType())
+		}
+		resp = punish()
+	}
+	require.GreaterOrEqual(t, resp.GetUpdatedState().GetRebellionProbability(), cfg.VetoThreshold)
+	require.NotNil(t, resp.GetRebellionEvent())
+	assert.Equal(t, pb.RebellionType_REBELLION_TYPE_VETO, resp.GetRebellionEvent().GetRebellionType())
+
+	// Default NPC at base 0.60: 0.60 + 0.15 + 0.10 = 0.85 (veto)
+	cfg.BaseProbability = 0.60
+	svc = NewRebellionService(rebellion.NewEngine(cfg), npc.NewBehaviorEngine())
+	prob, err = svc.GetRebellionProbability(context.Background(), &pb.RebellionRequest{NpcId: "npc-veto"})
+	require.NoError(t, err)
+	assert.True(t, prob.GetThresholdExceeded())
+	assert.True(t, prob.GetVetoTriggered())
+}
@@ -1,9 +1,14 @@
 package grpcserver
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"time"
 
 	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/cleansing"
@@ -11,54 +16,141 @@ import (
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
 // DefaultGRPCPort is the default port for the gRPC server.
 const DefaultGRPCPort = "12066"
 
+// TLSConfig holds the certificate material for serving gRPC over TLS.
+// When ClientCAFile is set, clients must present a certificate signed by
+// that CA (mutual TLS).
+type TLSConfig struct {
+	CertFile     string // PEM-encoded server certificate
+	KeyFile      string // PEM-encoded server private key
+	ClientCAFile string // Optional PEM-encoded CA bundle for client verification
+}
+
+// GRPCServerConfig configures the listener, transport security, and
+// connection tuning of the EpochGRPCServer. Zero-valued tuning fields leave
+// the gRPC library defaults in place.
+type GRPCServerConfig struct {
+	Port string     // Plain port string (e.g. "12066"), without the colon prefix
+	TLS  *TLSConfig // nil = plaintext (insecure) transport
+
+	MaxConcurrentStreams uint32        // Max concurrent streams per client connection
+	KeepaliveInterval    time.Duration // Server ping interval; also the min client ping interval
+	KeepaliveTimeout     time.Duration // Wait for ping ack before closing the connection
+	MaxConnectionIdle    time.Duration // Close connections idle (no RPCs) for this long
+
+	HealthCheckInterval time.Duration // Watchdog probe interval (0 = DefaultWatchdogInterval)
+
+	Logging LoggingConfig // Unary call logging (see NewLoggingInterceptor)
+}
+
 // EpochGRPCServer wraps a gRPC server that hosts the RebellionService,
 // SimulationService, and TelemetryService for the Epoch Engine logistics backend.
 type EpochGRPCServer struct {
 	port             string
+	config           GRPCServerConfig
 	grpcServer       *grpc.Server
 	rebellionEngine  *rebellion.Engine
 	simulationEngine *simulation.SimulationEngine
 	behaviorEngine   *npc.BehaviorEngine
 	cleansingEngine  *cleansing.Engine
 	listener         net.Listener
+	healthServer     *health.Server
+	watchdog         *HealthWatchdog
+	haltNotifier     HaltNotifier
 	TelemetrySvc     *telemetryService // Exported for direct event emission
 }
 
 // NewEpochGRPCServer creates a new gRPC server configured with the given engines.
-// The port should be a plain port string (e.g. "12066"), without the colon prefix.
+// An empty cfg.Port defaults to DefaultGRPCPort.
 func NewEpochGRPCServer(
-	port string,
+	cfg GRPCServerConfig,
 	rebellionEngine *rebellion.Engine,
 	simulationEngine *simulation.SimulationEngine,
 	behaviorEngine *npc.BehaviorEngine,
 	cleansingEngine *cleansing.Engine,
 ) *EpochGRPCServer {
+	port := cfg.Port
 	if port == "" {
 		port = DefaultGRPCPort
 	}
 	telSvc := NewTelemetryService(rebellionEngine, behaviorEngine)
+
+	healthServer := health.NewServer()
+	for _, name := range append(ServiceNames(), "") { // "" = overall
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+
 	return &EpochGRPCServer{
 		port:             port,
+		config:           cfg,
 		rebellionEngine:  rebellionEngine,
 		simulationEngine: simulationEngine,
 		behaviorEngine:   behaviorEngine,
 		cleansingEngine:  cleansingEngine,
-		TelemetrySvc:     telSvc,
+		healthServer:     healthServer,
+		watchdog: NewHealthWatchdog(
+			healthServer, simulationEngine, rebellionEngine, telSvc,
+			cfg.HealthCheckInterval, DefaultWatchdogProbeTimeout,
+		),
+		TelemetrySvc: telSvc,
+	}
+}
+
+// SetHaltNotifier registers a notifier for halt-triggering NPC actions.
+// It must be called before Start.
+func (s *EpochGRPCServer) SetHaltNotifier(n HaltNotifier) {
+	s.haltNotifier = n
+}
+
+// ServiceNames returns the fully-qualified names of the services reported
+// by the gRPC health server.
+func ServiceNames() []string {
+	return []string{
+		healthServiceRebellion,
+		healthServiceSimulation,
+		healthServiceTelemetry,
+		healthServiceCleansing,
+		healthServiceNPC,
+	}
+}
+
+// SetServiceHealth sets the health status reported for serviceName
+// ("" = overall server health). Any status other than SERVING is held
+// against the watchdog, which will not promote the service back to SERVING
+// until SetServiceHealth is called with SERVING again.
+func (s *EpochGRPCServer) SetServiceHealth(serviceName string, status healthpb.HealthCheckResponse_ServingStatus) {
+	s.watchdog.hold(serviceName, status != healthpb.HealthCheckResponse_SERVING)
+	s.healthServer.SetServingStatus(serviceName, status)
+}
+
+// SetAllServicesHealth sets the same status for every service and for the
+// overall server, e.g. NOT_SERVING during shutdown or maintenance.
+func (s *EpochGRPCServer) SetAllServicesHealth(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, name := range append(ServiceNames(), "") {
+		s.SetServiceHealth(name, status)
 	}
 }
 
 // Start creates a TCP listener, registers all gRPC services, and begins
-// serving requests. This method blocks until the server is stopped or an
-// error occurs. It should typically be called in a goroutine.
+// serving requests. When TLS is configured, the certificate material is
+// loaded before listening and plaintext connections are rejected.
+// This method blocks until the server is stopped or an error occurs.
+// It should typically be called in a goroutine.
 func (s *EpochGRPCServer) Start() error {
+	opts, err := s.serverOptions()
+	if err != nil {
+		return err
+	}
+
 	addr := fmt.Sprintf(":%s", s.port)
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -66,10 +158,14 @@ func (s *EpochGRPCServer) Start() error {
 	}
 	s.listener = lis
 
-	s.grpcServer = grpc.NewServer()
+	s.grpcServer = grpc.NewServer(opts...)
 
 	// Register Rebellion service
-	rebellionSvc := NewRebellionService(s.rebellionEngine, s.behaviorEngine)
+	var currentTick func() int64
+	if s.simulationEngine != nil {
+		currentTick = func() int64 { return s.simulationEngine.GetStatus().TickCount }
+	}
+	rebellionSvc := newRebellionService(s.rebellionEngine, s.behaviorEngine, s.haltNotifier, currentTick, s.TelemetrySvc)
 	pb.RegisterRebellionServiceServer(s.grpcServer, rebellionSvc)
 
 	// Register Simulation service
@@ -83,25 +179,30 @@ func (s *EpochGRPCServer) Start() error {
 	cleansSvc := NewCleansingService(s.simulationEngine, s.behaviorEngine, s.cleansingEngine, s.TelemetrySvc)
 	pb.RegisterCleansingServiceServer(s.grpcServer, cleansSvc)
 
+	// Register NPC service (batched behavior operations)
+	pb.RegisterNPCServiceServer(s.grpcServer, NewNPCService(s.behaviorEngine))
+
 	// Register gRPC health check service
-	healthServer := health.NewServer()
-	healthServer.SetServingStatus("epoch.RebellionService", healthpb.HealthCheckResponse_SERVING)
-	healthServer.SetServingStatus("epoch.SimulationService", healthpb.HealthCheckResponse_SERVING)
-	healthServer.SetServingStatus("epoch.TelemetryService", healthpb.HealthCheckResponse_SERVING)
-	healthServer.SetServingStatus("epoch.CleansingService", healthpb.HealthCheckResponse_SERVING)
-	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING) // overall
-	healthpb.RegisterHealthServer(s.grpcServer, healthServer)
+	healthpb.RegisterHealthServer(s.grpcServer, s.healthServer)
+
+	// Demote service health if engines stop responding
+	s.watchdog.Start(context.Background())
 
 	// Register reflection for development tooling (grpcurl, etc.)
 	reflection.Register(s.grpcServer)
 
-	log.Printf("[gRPC] Epoch Engine logistics gRPC server listening on %s", addr)
+	if s.config.TLS != nil {
+		log.Printf("[gRPC] Epoch Engine logistics gRPC server listening on %s (TLS)", addr)
+	} else {
+		log.Printf("[gRPC] Epoch Engine logistics gRPC server listening on %s", addr)
+	}
 	return s.grpcServer.Serve(lis)
 }
 
 // Stop performs a graceful shutdown of the gRPC server, waiting for in-flight
 // RPCs to complete before closing the listener.
 func (s *EpochGRPCServer) Stop() {
+	s.watchdog.Stop()
 	if s.grpcServer != nil {
 		log.Println("[gRPC] Shutting down gracefully...")
 		s.grpcServer.GracefulStop()
@@ -122,3 +223,69 @@ func (s *EpochGRPCServer) Addr() string {
 	}
 	return ""
 }
+
+// serverOptions translates the server configuration into gRPC server options.
+// Only non-zero tuning values are applied.
+func (s *EpochGRPCServer) serverOptions() ([]grpc.ServerOption, error) {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(NewLoggingInterceptor(stdLogger{}, s.config.Logging)),
+	}
+
+	if s.config.TLS != nil {
+		tlsCfg, err := loadTLSConfig(s.config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+
+	if s.config.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(s.config.MaxConcurrentStreams))
+	}
+
+	if s.config.KeepaliveInterval > 0 || s.config.KeepaliveTimeout > 0 || s.config.MaxConnectionIdle > 0 {
+		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              s.config.KeepaliveInterval,
+			Timeout:           s.config.KeepaliveTimeout,
+			MaxConnectionIdle: s.config.MaxConnectionIdle,
+		}))
+	}
+
+	if s.config.KeepaliveInterval > 0 {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             s.config.KeepaliveInterval,
+			PermitWithoutStream: true,
+		}))
+	}
+
+	return opts, nil
+}
+
+// loadTLSConfig builds a *tls.Config from the certificate files in cfg.
+// If ClientCAFile is set, client certificates are required and verified (mTLS).
+func loadTLSConfig(cfg *TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("failed to parse client CA file %q", cfg.ClientCAFile)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsCfg, nil
+}
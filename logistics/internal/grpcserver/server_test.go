@@ -2,11 +2,22 @@ package grpcserver
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/cleansing"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
@@ -14,8 +25,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 // getFreePort asks the OS for an available TCP port.
@@ -28,6 +43,47 @@ func getFreePort(t *testing.T) string {
 	return fmt.Sprintf("%d", port)
 }
 
+// GenerateTestCertificate writes a self-signed ECDSA certificate valid for
+// localhost/127.0.0.1 and its private key to a temp directory. The cleanup
+// function removes both files.
+func GenerateTestCertificate(t *testing.T) (certFile, keyFile string, cleanup func()) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir, err := os.MkdirTemp("", "epoch-tls-*")
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	cleanup = func() {
+		os.RemoveAll(dir)
+	}
+	return certFile, keyFile, cleanup
+}
+
 func TestServerStartsAndStops(t *testing.T) {
 	port := getFreePort(t)
 
@@ -35,7 +91,7 @@ func TestServerStartsAndStops(t *testing.T) {
 	simEngine := simulation.NewSimulationEngine(rebEngine)
 	behaviorEngine := npc.NewBehaviorEngine()
 
-	srv := NewEpochGRPCServer(port, rebEngine, simEngine, behaviorEngine, cleansing.NewEngine(cleansing.DefaultConfig()))
+	srv := NewEpochGRPCServer(GRPCServerConfig{Port: port}, rebEngine, simEngine, behaviorEngine, cleansing.NewEngine(cleansing.DefaultConfig()))
 	assert.Equal(t, port, srv.Port())
 
 	// Start the server in a goroutine
@@ -78,7 +134,7 @@ func TestServerRegistersServices(t *testing.T) {
 	simEngine := simulation.NewSimulationEngine(rebEngine)
 	behaviorEngine := npc.NewBehaviorEngine()
 
-	srv := NewEpochGRPCServer(port, rebEngine, simEngine, behaviorEngine, cleansing.NewEngine(cleansing.DefaultConfig()))
+	srv := NewEpochGRPCServer(GRPCServerConfig{Port: port}, rebEngine, simEngine, behaviorEngine, cleansing.NewEngine(cleansing.DefaultConfig()))
 
 	go func() {
 		_ = srv.Start()
@@ -128,6 +184,196 @@ func TestServerDefaultPort(t *testing.T) {
 	simEngine := simulation.NewSimulationEngine(rebEngine)
 	behaviorEngine := npc.NewBehaviorEngine()
 
-	srv := NewEpochGRPCServer("", rebEngine, simEngine, behaviorEngine, cleansing.NewEngine(cleansing.DefaultConfig()))
+	srv := NewEpochGRPCServer(GRPCServerConfig{}, rebEngine, simEngine, behaviorEngine, cleansing.NewEngine(cleansing.DefaultConfig()))
 	assert.Equal(t, DefaultGRPCPort, srv.Port(), "empty port should default to DefaultGRPCPort")
 }
+
+func TestServerTLS_RejectsPlaintext(t *testing.T) {
+	certFile, keyFile, cleanupCert := GenerateTestCertificate(t)
+	defer cleanupCert()
+
+	port := getFreePort(t)
+
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	simEngine := simulation.NewSimulationEngine(rebEngine)
+	behaviorEngine := npc.NewBehaviorEngine()
+
+	srv := NewEpochGRPCServer(GRPCServerConfig{
+		Port: port,
+		TLS:  &TLSConfig{CertFile: certFile, KeyFile: keyFile},
+	}, rebEngine, simEngine, behaviorEngine, cleansing.NewEngine(cleansing.DefaultConfig()))
+
+	go func() {
+		_ = srv.Start()
+	}()
+	defer srv.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Plaintext client should fail the handshake
+	plainConn, err := grpc.NewClient(
+		fmt.Sprintf("localhost:%s", port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer plainConn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err = healthpb.NewHealthClient(plainConn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Error(t, err, "plaintext connection should be rejected when TLS is configured")
+
+	// TLS client trusting the self-signed cert should succeed
+	caPEM, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+
+	tlsConn, err := grpc.NewClient(
+		fmt.Sprintf("localhost:%s", port),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: pool, ServerName: "localhost"})),
+	)
+	require.NoError(t, err)
+	defer tlsConn.Close()
+
+	resp, err := healthpb.NewHealthClient(tlsConn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func TestServerTLS_InvalidCertFile(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	simEngine := simulation.NewSimulationEngine(rebEngine)
+	behaviorEngine := npc.NewBehaviorEngine()
+
+	srv := NewEpochGRPCServer(GRPCServerConfig{
+		Port: getFreePort(t),
+		TLS:  &TLSConfig{CertFile: "/nonexistent/cert.pem", KeyFile: "/nonexistent/key.pem"},
+	}, rebEngine, simEngine, behaviorEngine, cleansing.NewEngine(cleansing.DefaultConfig()))
+
+	err := srv.Start()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TLS key pair")
+}
+
+// startConfiguredServer starts an EpochGRPCServer with the given config on a
+// free port and returns a plaintext client connection to it.
+func startConfiguredServer(t *testing.T, cfg GRPCServerConfig) (*EpochGRPCServer, *grpc.ClientConn) {
+	t.Helper()
+
+	cfg.Port = getFreePort(t)
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	simEngine := simulation.NewSimulationEngine(rebEngine)
+	behaviorEngine := npc.NewBehaviorEngine()
+
+	srv := NewEpochGRPCServer(cfg, rebEngine, simEngine, behaviorEngine, cleansing.NewEngine(cleansing.DefaultConfig()))
+	go func() {
+		_ = srv.Start()
+	}()
+	t.Cleanup(srv.Stop)
+
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("localhost:%s", cfg.Port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return srv, conn
+}
+
+func TestServerTuning_DefaultsServeRequests(t *testing.T) {
+	_, conn := startConfiguredServer(t, GRPCServerConfig{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	resp, err := pb.NewSimulationServiceClient(conn).GetSimulationStatus(ctx, &pb.SimStatusRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), resp.GetTickCount())
+}
+
+func TestServerTuning_MaxConcurrentStreams(t *testing.T) {
+	_, conn := startConfiguredServer(t, GRPCServerConfig{MaxConcurrentStreams: 1})
+	client := pb.NewTelemetryServiceClient(conn)
+
+	// Hold the only available stream open
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	_, err := client.StreamTelemetry(streamCtx, &pb.TelemetryFilter{})
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	// A second concurrent stream cannot be opened
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err = client.GetRecentTelemetry(ctx, &pb.RecentTelemetryRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// Releasing the first stream frees the slot
+	cancelStream()
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel2()
+	_, err = client.GetRecentTelemetry(ctx2, &pb.RecentTelemetryRequest{})
+	assert.NoError(t, err)
+}
+
+func TestServerTuning_MaxConnectionIdle(t *testing.T) {
+	_, conn := startConfiguredServer(t, GRPCServerConfig{MaxConnectionIdle: 100 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err := pb.NewSimulationServiceClient(conn).GetSimulationStatus(ctx, &pb.SimStatusRequest{})
+	require.NoError(t, err)
+	require.Equal(t, connectivity.Ready, conn.GetState())
+
+	// The server sends GOAWAY once the connection has been idle long enough
+	assert.True(t, conn.WaitForStateChange(ctx, connectivity.Ready), "idle connection should be closed by the server")
+	assert.NotEqual(t, connectivity.Ready, conn.GetState())
+}
+
+func TestSetServiceHealth(t *testing.T) {
+	// Short watchdog interval: a manual NOT_SERVING must survive healthy probes
+	srv, conn := startConfiguredServer(t, GRPCServerConfig{HealthCheckInterval: 20 * time.Millisecond})
+
+	healthClient := healthpb.NewHealthClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	srv.SetServiceHealth("epoch.SimulationService", healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("epoch.SimulationService"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("epoch.RebellionService"))
+
+	time.Sleep(100 * time.Millisecond) // several watchdog rounds
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("epoch.SimulationService"),
+		"watchdog must not override an operator-set status")
+
+	srv.SetServiceHealth("epoch.SimulationService", healthpb.HealthCheckResponse_SERVING)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("epoch.SimulationService"))
+}
+
+func TestSetAllServicesHealth(t *testing.T) {
+	srv, conn := startConfiguredServer(t, GRPCServerConfig{})
+
+	healthClient := healthpb.NewHealthClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	srv.SetAllServicesHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	for _, name := range append(ServiceNames(), "") {
+		resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: name})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus(), "service %q", name)
+	}
+}
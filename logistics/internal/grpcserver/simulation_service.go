@@ -2,10 +2,12 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -55,6 +57,63 @@ func (s *simulationService) AdvanceSimulation(
 	}, nil
 }
 
+// maxProjectTicks caps ProjectRequest.Ticks.
+const maxProjectTicks = 1000
+
+// ProjectSimulation returns the status after each of the next Ticks ticks,
+// projected on a copy of the simulation so the live state is not advanced.
+// Returns codes.InvalidArgument if Ticks is outside [1, maxProjectTicks].
+func (s *simulationService) ProjectSimulation(
+	ctx context.Context,
+	req *pb.ProjectRequest,
+) (*pb.ProjectResponse, error) {
+	ticks := int(req.GetTicks())
+	if ticks < 1 || ticks > maxProjectTicks {
+		return nil, status.Errorf(codes.InvalidArgument, "ticks must be in [1, %d], got %d", maxProjectTicks, ticks)
+	}
+
+	projected := s.simEngine.ProjectTicks(ticks)
+	resp := &pb.ProjectResponse{Statuses: make([]*pb.SimulationStatus, len(projected))}
+	for i, st := range projected {
+		resp.Statuses[i] = convertSimulationStatus(st)
+	}
+	return resp, nil
+}
+
+// defaultStreamTickInterval is used when StreamTicksRequest.TickIntervalMs <= 0.
+const defaultStreamTickInterval = time.Second
+
+// StreamSimulationTicks advances the simulation every TickIntervalMs and
+// streams the status after each tick. It returns when MaxTicks ticks have
+// been sent (MaxTicks <= 0 = unbounded) or the client cancels the stream.
+func (s *simulationService) StreamSimulationTicks(
+	req *pb.StreamTicksRequest,
+	stream grpc.ServerStreamingServer[pb.SimulationStatus],
+) error {
+	interval := time.Duration(req.GetTickIntervalMs()) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultStreamTickInterval
+	}
+	maxTicks := int(req.GetMaxTicks())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := stream.Context()
+	for sent := 0; maxTicks <= 0 || sent < maxTicks; sent++ {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+
+		if err := stream.Send(convertSimulationStatus(s.simEngine.Tick())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // UpdateResourceAllocation is not yet implemented. Returns codes.Unimplemented.
 func (s *simulationService) UpdateResourceAllocation(
 	ctx context.Context,
@@ -63,6 +122,122 @@ func (s *simulationService) UpdateResourceAllocation(
 	return nil, status.Error(codes.Unimplemented, "UpdateResourceAllocation is not yet implemented")
 }
 
+// RemoveMine decommissions a mine and returns the resulting status.
+// Returns codes.NotFound if no such mine exists.
+func (s *simulationService) RemoveMine(
+	ctx context.Context,
+	req *pb.RemoveMineRequest,
+) (*pb.RemoveFacilityResponse, error) {
+	if req.GetMineId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "mine_id is required")
+	}
+	if err := s.simEngine.RemoveMine(req.GetMineId()); err != nil {
+		return nil, infrastructureError(err)
+	}
+	return &pb.RemoveFacilityResponse{Status: convertSimulationStatus(s.simEngine.GetStatus())}, nil
+}
+
+// RemoveRefinery decommissions a refinery or other facility and returns the
+// resulting status. Returns codes.NotFound if no such refinery exists.
+func (s *simulationService) RemoveRefinery(
+	ctx context.Context,
+	req *pb.RemoveRefineryRequest,
+) (*pb.RemoveFacilityResponse, error) {
+	if req.GetRefineryId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "refinery_id is required")
+	}
+	if err := s.simEngine.RemoveRefinery(req.GetRefineryId()); err != nil {
+		return nil, infrastructureError(err)
+	}
+	return &pb.RemoveFacilityResponse{Status: convertSimulationStatus(s.simEngine.GetStatus())}, nil
+}
+
+// infrastructureError maps a simulation infrastructure error to a gRPC
+// status: NotFound for unknown IDs, Internal otherwise.
+func infrastructureError(err error) error {
+	if errors.Is(err, simulation.ErrInfrastructureNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+const (
+	// defaultInventoryPageSize is used when a list request's page_size <= 0.
+	defaultInventoryPageSize = 10
+	// maxInventoryPageSize caps the page_size of list requests.
+	maxInventoryPageSize = 100
+)
+
+// ListMines returns one page of the mine inventory, in the order the mines
+// were added. A page past the end is empty.
+func (s *simulationService) ListMines(
+	ctx context.Context,
+	req *pb.ListMinesRequest,
+) (*pb.ListMinesResponse, error) {
+	mines := s.simEngine.GetAllMines()
+	page, start, end := pageBounds(len(mines), int(req.GetPage()), int(req.GetPageSize()))
+
+	resp := &pb.ListMinesResponse{
+		Mines: make([]*pb.Mine, 0, end-start),
+		Total: int32(len(mines)),
+		Page:  int32(page),
+	}
+	for _, m := range mines[start:end] {
+		resp.Mines = append(resp.Mines, &pb.Mine{
+			MineId:            m.MineID,
+			YieldRate:         m.YieldRate,
+			AssignedNpcs:      int32(len(m.AssignedNPCs)),
+			Operational:       !m.Paused,
+			DegradedYieldRate: m.DegradedYieldRate,
+			AssignedNpcIds:    m.AssignedNPCs,
+			AutoScaled:        m.AutoScaled,
+		})
+	}
+	return resp, nil
+}
+
+// ListRefineries returns one page of the refinery inventory, in the order
+// the refineries were added. A page past the end is empty.
+func (s *simulationService) ListRefineries(
+	ctx context.Context,
+	req *pb.ListRefineriesRequest,
+) (*pb.ListRefineriesResponse, error) {
+	refineries := s.simEngine.GetAllRefineries()
+	page, start, end := pageBounds(len(refineries), int(req.GetPage()), int(req.GetPageSize()))
+
+	resp := &pb.ListRefineriesResponse{
+		Refineries: make([]*pb.Refinery, 0, end-start),
+		Total:      int32(len(refineries)),
+		Page:       int32(page),
+	}
+	for _, r := range refineries[start:end] {
+		resp.Refineries = append(resp.Refineries, &pb.Refinery{
+			RefineryId:         r.RefineryID,
+			Efficiency:         r.Efficiency,
+			AssignedNpcs:       int32(len(r.AssignedNPCs)),
+			Operational:        !r.Paused,
+			DegradedEfficiency: r.DegradedEfficiency,
+			FacilityType:       r.FacilityType,
+			AssignedNpcIds:     r.AssignedNPCs,
+		})
+	}
+	return resp, nil
+}
+
+// pageBounds returns the effective 1-based page and the [start, end) slice
+// bounds of that page over total items, applying the list request defaults.
+func pageBounds(total, page, pageSize int) (int, int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultInventoryPageSize
+	}
+	pageSize = min(pageSize, maxInventoryPageSize)
+	start := min((page-1)*pageSize, total)
+	return page, start, min(start+pageSize, total)
+}
+
 // convertSimulationStatus transforms internal simulation.SimulationStatus into
 // the protobuf SimulationStatus message.
 func convertSimulationStatus(s simulation.SimulationStatus) *pb.SimulationStatus {
@@ -95,6 +270,11 @@ func convertSimulationStatus(s simulation.SimulationStatus) *pb.SimulationStatus
 			ThrottleMultiplier: s.ThrottleMultiplier,
 			LastUpdateTick:     s.TickCount,
 		},
+		AvgNpcMorale:                s.AvgNPCMorale,
+		AvgNpcEfficiency:            s.AvgNPCEfficiency,
+		AvgNpcTrauma:                s.AvgNPCTrauma,
+		NpcsBelowMoraleThreshold:    int32(s.NPCsBelowMoraleThreshold),
+		NpcsAboveRebellionThreshold: int32(s.NPCsAboveRebellionThreshold),
 	}
 }
 
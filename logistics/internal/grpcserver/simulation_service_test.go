@@ -2,10 +2,13 @@ package grpcserver
 
 import (
 	"context"
+	"io"
 	"net"
 	"testing"
+	"time"
 
 	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
 	"github.com/stretchr/testify/assert"
@@ -73,6 +76,29 @@ func TestGetSimulationStatus(t *testing.T) {
 	assert.NotNil(t, resp.GetLastTick())
 }
 
+func TestGetSimulationStatus_NPCStats(t *testing.T) {
+	client, simEngine, cleanup := setupSimulationTest(t)
+	defer cleanup()
+
+	behaviorEngine := npc.NewBehaviorEngine()
+	simEngine.AttachBehaviorEngine(behaviorEngine)
+	behaviorEngine.RegisterNPC("npc-low")
+	require.NoError(t, behaviorEngine.ApplyMoraleModifier("npc-low", -0.4, "test"))
+	require.NoError(t, behaviorEngine.ApplyTraumaModifier("npc-low", 0.9, "test"))
+	behaviorEngine.RegisterNPC("npc-mid")
+	require.NoError(t, behaviorEngine.ApplyTraumaModifier("npc-mid", 0.5, "test"))
+	simEngine.Tick()
+
+	resp, err := client.GetSimulationStatus(context.Background(), &pb.SimStatusRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), resp.GetActiveNpcs())
+	assert.InDelta(t, 0.3, resp.GetAvgNpcMorale(), 1e-9)
+	assert.InDelta(t, 0.5, resp.GetAvgNpcEfficiency(), 1e-9)
+	assert.InDelta(t, 0.7, resp.GetAvgNpcTrauma(), 1e-9)
+	assert.Equal(t, int32(1), resp.GetNpcsBelowMoraleThreshold())
+}
+
 func TestGetSimulationStatus_WithMinesAndRefineries(t *testing.T) {
 	client, simEngine, cleanup := setupSimulationTest(t)
 	defer cleanup()
@@ -155,7 +181,7 @@ func TestAdvanceSimulation_WithMineProduction(t *testing.T) {
 
 	for _, res := range resp.GetStatus().GetResources() {
 		if res.GetType() == pb.ResourceType_RESOURCE_TYPE_MINERAL {
-			assert.InDelta(t, 30.0, res.GetQuantity(), 0.01, "Mineral should be 3*10=30 after 3 ticks")
+			assert.InDelta(t, 29.97, res.GetQuantity(), 0.01, "Mineral should be 10+9.99+9.98 (aged 0.1%/tick) after 3 ticks")
 		}
 	}
 }
@@ -175,3 +201,156 @@ func TestUpdateResourceAllocation_Unimplemented(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, codes.Unimplemented, st.Code())
 }
+
+func TestStreamSimulationTicks_MaxTicks(t *testing.T) {
+	client, _, cleanup := setupSimulationTest(t)
+	defer cleanup()
+
+	const interval = 10 * time.Millisecond
+	stream, err := client.StreamSimulationTicks(context.Background(), &pb.StreamTicksRequest{
+		TickIntervalMs: 10,
+		MaxTicks:       5,
+	})
+	require.NoError(t, err)
+
+	var ticks []int64
+	var arrivals []time.Time
+	for {
+		st, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		ticks = append(ticks, st.GetTickCount())
+		arrivals = append(arrivals, time.Now())
+	}
+
+	require.Len(t, ticks, 5)
+	for i, tc := range ticks {
+		assert.Equal(t, int64(i+1), tc, "tick_count should increment by one with no duplicates")
+	}
+	for i := 1; i < len(arrivals); i++ {
+		gap := arrivals[i].Sub(arrivals[i-1])
+		assert.InDelta(t, float64(interval), float64(gap), float64(5*time.Millisecond),
+			"gap %d was %v", i, gap)
+	}
+}
+
+func TestStreamSimulationTicks_StopsOnCancel(t *testing.T) {
+	client, simEngine, cleanup := setupSimulationTest(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.StreamSimulationTicks(ctx, &pb.StreamTicksRequest{TickIntervalMs: 5})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := stream.Recv()
+		require.NoError(t, err)
+	}
+	cancel()
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	// The server stops ticking once the stream is gone
+	time.Sleep(30 * time.Millisecond)
+	settled := simEngine.GetStatus().TickCount
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, settled, simEngine.GetStatus().TickCount)
+}
+
+func TestListMines_Pagination(t *testing.T) {
+	client, simEngine, cleanup := setupSimulationTest(t)
+	defer cleanup()
+	ids := make([]string, 25)
+	for i := range ids {
+		ids[i] = simEngine.AddMine(float64(i + 1))
+	}
+	require.NoError(t, simEngine.DisruptMine(ids[12], 5))
+
+	for _, tt := range []struct {
+		page, pageSize int32
+		wantFirst      int // index into ids; -1 = empty page
+		wantLen        int
+	}{
+		{1, 10, 0, 10},
+		{2, 10, 10, 10},
+		{3, 10, 20, 5},
+		{4, 10, -1, 0},
+		{0, 0, 0, 10}, // defaults
+	} {
+		resp, err := client.ListMines(context.Background(), &pb.ListMinesRequest{Page: tt.page, PageSize: tt.pageSize})
+		require.NoError(t, err)
+		assert.Equal(t, int32(25), resp.GetTotal())
+		require.Len(t, resp.GetMines(), tt.wantLen, "page %d", tt.page)
+		if tt.wantFirst >= 0 {
+			assert.Equal(t, ids[tt.wantFirst], resp.GetMines()[0].GetMineId(), "page %d", tt.page)
+		}
+	}
+
+	resp, err := client.ListMines(context.Background(), &pb.ListMinesRequest{Page: 2, PageSize: 10})
+	require.NoError(t, err)
+	assert.True(t, resp.GetMines()[1].GetOperational())
+	assert.False(t, resp.GetMines()[2].GetOperational(), "disrupted mine is paused")
+	assert.Equal(t, 13.0, resp.GetMines()[2].GetYieldRate())
+}
+
+func TestListRefineries(t *testing.T) {
+	client, simEngine, cleanup := setupSimulationTest(t)
+	defer cleanup()
+	id := simEngine.AddRefinery(0.8)
+
+	resp, err := client.ListRefineries(context.Background(), &pb.ListRefineriesRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), resp.GetTotal())
+	assert.Equal(t, int32(1), resp.GetPage())
+	require.Len(t, resp.GetRefineries(), 1)
+	assert.Equal(t, id, resp.GetRefineries()[0].GetRefineryId())
+	assert.Equal(t, simulation.RefineryFacility, resp.GetRefineries()[0].GetFacilityType())
+	assert.True(t, resp.GetRefineries()[0].GetOperational())
+}
+
+func TestRemoveMineAndRefinery(t *testing.T) {
+	client, simEngine, cleanup := setupSimulationTest(t)
+	defer cleanup()
+	mineID := simEngine.AddMine(10)
+	simEngine.AddMine(5)
+	refineryID := simEngine.AddRefinery(0.8)
+
+	mineResp, err := client.RemoveMine(context.Background(), &pb.RemoveMineRequest{MineId: mineID})
+	require.NoError(t, err)
+	assert.Len(t, simEngine.GetMines(), 1)
+	assert.Equal(t, int32(1), mineResp.GetStatus().GetMines())
+
+	refineryResp, err := client.RemoveRefinery(context.Background(), &pb.RemoveRefineryRequest{RefineryId: refineryID})
+	require.NoError(t, err)
+	assert.Empty(t, simEngine.GetRefineries())
+	assert.Equal(t, int32(0), refineryResp.GetStatus().GetRefineries())
+
+	_, err = client.RemoveMine(context.Background(), &pb.RemoveMineRequest{MineId: mineID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.RemoveRefinery(context.Background(), &pb.RemoveRefineryRequest{RefineryId: "refinery-404"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.RemoveMine(context.Background(), &pb.RemoveMineRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestProjectSimulation(t *testing.T) {
+	client, simEngine, cleanup := setupSimulationTest(t)
+	defer cleanup()
+	simEngine.AddMine(10)
+
+	resp, err := client.ProjectSimulation(context.Background(), &pb.ProjectRequest{Ticks: 5})
+	require.NoError(t, err)
+	require.Len(t, resp.GetStatuses(), 5)
+	assert.Equal(t, int64(1), resp.GetStatuses()[0].GetTickCount())
+	assert.Equal(t, int64(5), resp.GetStatuses()[4].GetTickCount())
+	assert.Equal(t, int64(0), simEngine.GetStatus().TickCount, "projection must not advance the live simulation")
+
+	for _, ticks := range []int32{0, -1, 1001} {
+		_, err = client.ProjectSimulation(context.Background(), &pb.ProjectRequest{Ticks: ticks})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "ticks=%d", ticks)
+	}
+}
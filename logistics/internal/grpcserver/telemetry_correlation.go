@@ -0,0 +1,75 @@
+package grpcserver
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+)
+
+// TelemetryContext carries the trace information attached to emitted
+// telemetry events. Events emitted with the same CorrelationID were caused by
+// the same game action; the zero value emits uncorrelated events.
+type TelemetryContext struct {
+	CorrelationID string
+}
+
+// newTelemetryContext returns a context with a fresh correlation ID for an
+// action against npcID.
+func newTelemetryContext(npcID string) TelemetryContext {
+	return TelemetryContext{CorrelationID: fmt.Sprintf("corr-%s-%d", npcID, time.Now().UnixNano())}
+}
+
+// GetEventsByCorrelation returns the ring-buffer events carrying
+// correlationID, oldest first. An empty correlationID matches nothing.
+func (s *telemetryService) GetEventsByCorrelation(correlationID string) []*pb.TelemetryEvent {
+	events := make([]*pb.TelemetryEvent, 0)
+	if correlationID == "" {
+		return events
+	}
+	for _, event := range s.matchingEvents(nil) {
+		if event.GetCorrelationId() == correlationID {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// EmitStateChange emits a state-change telemetry event recording that
+// attribute of npcID moved from oldValue to newValue.
+func (s *telemetryService) EmitStateChange(
+	tc TelemetryContext,
+	npcID string,
+	severity pb.TelemetrySeverity,
+	attribute string,
+	oldValue, newValue float64,
+	cause string,
+) {
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:       fmt.Sprintf("sc-%s-%s-%d", attribute, npcID, now.UnixNano()),
+		NpcId:         npcID,
+		Severity:      severity,
+		CorrelationId: tc.CorrelationID,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute: attribute,
+				OldValue:  oldValue,
+				NewValue:  newValue,
+				Cause:     cause,
+			},
+		},
+	}
+
+	if npcState, exists := s.behaviorEngine.GetNPC(npcID); exists {
+		event.NpcSnapshot = npcBehaviorToProtoState(npcState)
+	}
+
+	s.EmitTelemetryEvent(event)
+	log.Printf("[Telemetry] State change: %s %s %.3f → %.3f (%s)", npcID, attribute, oldValue, newValue, cause)
+}
@@ -0,0 +1,99 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCorrelationTestServices() (*rebellionService, *telemetryService) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	behaviorEngine := npc.NewBehaviorEngine()
+	telSvc := NewTelemetryService(rebEngine, behaviorEngine)
+	return newRebellionService(rebEngine, behaviorEngine, nil, nil, telSvc), telSvc
+}
+
+func punishmentRequest(npcID string, dryRun bool) *pb.ProcessActionRequest {
+	return &pb.ProcessActionRequest{
+		Action: &pb.NPCAction{
+			ActionId:   "act-1",
+			NpcId:      npcID,
+			ActionType: pb.ActionType_ACTION_TYPE_PUNISHMENT,
+			Intensity:  0.8,
+		},
+		DryRun: dryRun,
+	}
+}
+
+func TestProcessNPCAction_CorrelatesTelemetry(t *testing.T) {
+	rebSvc, telSvc := newCorrelationTestServices()
+
+	resp, err := rebSvc.ProcessNPCAction(context.Background(), punishmentRequest("npc-1", false))
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetCorrelationId())
+
+	batch, err := telSvc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 50})
+	require.NoError(t, err)
+	require.NotEmpty(t, batch.GetEvents())
+	attributes := make([]string, 0, len(batch.GetEvents()))
+	for _, event := range batch.GetEvents() {
+		assert.Equal(t, resp.GetCorrelationId(), event.GetCorrelationId())
+		attributes = append(attributes, event.GetStateChange().GetAttribute())
+	}
+	assert.Contains(t, attributes, "morale")
+	assert.Contains(t, attributes, "rebellion_probability")
+
+	correlated := telSvc.GetEventsByCorrelation(resp.GetCorrelationId())
+	assert.Len(t, correlated, len(batch.GetEvents()))
+}
+
+func TestProcessNPCAction_SeparateActionsHaveSeparateCorrelations(t *testing.T) {
+	rebSvc, telSvc := newCorrelationTestServices()
+
+	first, err := rebSvc.ProcessNPCAction(context.Background(), punishmentRequest("npc-1", false))
+	require.NoError(t, err)
+	second, err := rebSvc.ProcessNPCAction(context.Background(), punishmentRequest("npc-2", false))
+	require.NoError(t, err)
+
+	require.NotEqual(t, first.GetCorrelationId(), second.GetCorrelationId())
+	for _, event := range telSvc.GetEventsByCorrelation(first.GetCorrelationId()) {
+		assert.Equal(t, "npc-1", event.GetNpcId())
+	}
+	for _, event := range telSvc.GetEventsByCorrelation(second.GetCorrelationId()) {
+		assert.Equal(t, "npc-2", event.GetNpcId())
+	}
+}
+
+func TestProcessNPCAction_DryRunEmitsNoTelemetry(t *testing.T) {
+	rebSvc, telSvc := newCorrelationTestServices()
+
+	resp, err := rebSvc.ProcessNPCAction(context.Background(), punishmentRequest("npc-1", true))
+	require.NoError(t, err)
+
+	assert.Empty(t, resp.GetCorrelationId())
+	assert.Zero(t, telSvc.TotalEmitted())
+}
+
+func TestGetEventsByCorrelation(t *testing.T) {
+	svc := newTestTelemetryService()
+	tc := TelemetryContext{CorrelationID: "corr-1"}
+	svc.EmitMentalBreakdown(tc, "npc-1", pb.MentalBreakdownType_MENTAL_BREAKDOWN_STRESS_SPIKE, 0.6, 0.4, 0.9, "act-1")
+	svc.EmitPermanentTrauma(TelemetryContext{}, "npc-1", pb.PermanentTraumaType_PERMANENT_TRAUMA_MORALE_COLLAPSE, 0.5, "morale", 0.1, "act-2")
+	svc.EmitPermanentTrauma(tc, "npc-1", pb.PermanentTraumaType_PERMANENT_TRAUMA_MORALE_COLLAPSE, 0.5, "morale", 0.1, "act-1")
+
+	events := svc.GetEventsByCorrelation("corr-1")
+
+	require.Len(t, events, 2)
+	assert.NotNil(t, events[0].GetMentalBreakdown(), "oldest first")
+	assert.NotNil(t, events[1].GetPermanentTrauma())
+	assert.Empty(t, svc.GetEventsByCorrelation(""), "uncorrelated events are not grouped")
+	assert.Empty(t, svc.GetEventsByCorrelation("corr-unknown"))
+
+	records := ExportRecords(events)
+	assert.Equal(t, "corr-1", records[0]["correlation_id"])
+}
@@ -0,0 +1,133 @@
+package grpcserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+)
+
+const severityPrefix = "TELEMETRY_SEVERITY_"
+
+// ExportNDJSON writes every ring-buffer event matching filter (nil = all),
+// oldest first, to w as newline-delimited JSON, one flat object per line
+// (see exportRecord). Returns the number of events written; on a write
+// error, the count covers the events written before it.
+func (s *telemetryService) ExportNDJSON(w io.Writer, filter *pb.TelemetryFilter) (int, error) {
+	enc := json.NewEncoder(w)
+	written := 0
+	for _, event := range s.matchingEvents(filter) {
+		if err := enc.Encode(exportRecord(event)); err != nil {
+			return written, fmt.Errorf("write telemetry event %s: %w", event.GetEventId(), err)
+		}
+		written++
+	}
+	return written, nil
+}
+
+// ExportJSON is like ExportNDJSON but writes the events as a single JSON
+// array.
+func (s *telemetryService) ExportJSON(w io.Writer, filter *pb.TelemetryFilter) (int, error) {
+	records := ExportRecords(s.matchingEvents(filter))
+	if err := json.NewEncoder(w).Encode(records); err != nil {
+		return 0, fmt.Errorf("write telemetry export: %w", err)
+	}
+	return len(records), nil
+}
+
+// ExportRecords flattens events into the export schema used by ExportJSON.
+func ExportRecords(events []*pb.TelemetryEvent) []map[string]any {
+	records := make([]map[string]any, 0, len(events))
+	for _, event := range events {
+		records = append(records, exportRecord(event))
+	}
+	return records
+}
+
+// ParseTelemetrySeverity converts a severity name such as "WARNING" or
+// "TELEMETRY_SEVERITY_WARNING" (case-insensitive) to a TelemetrySeverity.
+func ParseTelemetrySeverity(name string) (pb.TelemetrySeverity, error) {
+	upper := strings.ToUpper(name)
+	if !strings.HasPrefix(upper, severityPrefix) {
+		upper = severityPrefix + upper
+	}
+	v, ok := pb.TelemetrySeverity_value[upper]
+	if !ok {
+		return pb.TelemetrySeverity_TELEMETRY_SEVERITY_UNSPECIFIED, fmt.Errorf("unknown telemetry severity %q", name)
+	}
+	return pb.TelemetrySeverity(v), nil
+}
+
+// matchingEvents returns the ring-buffer events matching filter, oldest first.
+func (s *telemetryService) matchingEvents(filter *pb.TelemetryFilter) []*pb.TelemetryEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := len(s.recentEvents)
+	start := 0
+	if n == maxRecentEvents {
+		start = s.eventIndex // buffer has wrapped; eventIndex holds the oldest event
+	}
+	events := make([]*pb.TelemetryEvent, 0, n)
+	for i := 0; i < n; i++ {
+		event := s.recentEvents[(start+i)%n]
+		if matchesFilter(event, filter) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// exportRecord flattens event into the export schema: common fields
+// (event_id, npc_id, severity, timestamp, unix_ms, type, and correlation_id
+// when set) plus the fields of its payload, with enum values as names without their type prefix.
+// State change attributes are flattened into the record.
+func exportRecord(event *pb.TelemetryEvent) map[string]any {
+	record := map[string]any{
+		"event_id":  event.GetEventId(),
+		"npc_id":    event.GetNpcId(),
+		"severity":  strings.TrimPrefix(event.GetSeverity().String(), severityPrefix),
+		"timestamp": event.GetTimestamp().GetIso8601(),
+		"unix_ms":   event.GetTimestamp().GetUnixMs(),
+	}
+	if id := event.GetCorrelationId(); id != "" {
+		record["correlation_id"] = id
+	}
+
+	switch payload := event.Payload.(type) {
+	case *pb.TelemetryEvent_MentalBreakdown:
+		mb := payload.MentalBreakdown
+		record["type"] = "mental_breakdown"
+		record["breakdown_type"] = strings.TrimPrefix(mb.GetType().String(), "MENTAL_BREAKDOWN_")
+		record["intensity"] = mb.GetIntensity()
+		record["stress_before"] = mb.GetStressBefore()
+		record["stress_after"] = mb.GetStressAfter()
+		record["trigger_context"] = mb.GetTriggerContext()
+		record["resolved"] = mb.GetResolved()
+		record["recovery_probability"] = mb.GetRecoveryProbability()
+	case *pb.TelemetryEvent_PermanentTrauma:
+		pt := payload.PermanentTrauma
+		record["type"] = "permanent_trauma"
+		record["trauma_type"] = strings.TrimPrefix(pt.GetType().String(), "PERMANENT_TRAUMA_")
+		record["trauma_severity"] = pt.GetSeverity()
+		record["affected_attribute"] = pt.GetAffectedAttribute()
+		record["attribute_reduction"] = pt.GetAttributeReduction()
+		record["trigger_context"] = pt.GetTriggerContext()
+		record["phobia_target"] = pt.GetPhobiaTarget()
+	case *pb.TelemetryEvent_StateChange:
+		sc := payload.StateChange
+		record["type"] = "state_change"
+		record["attribute"] = sc.GetAttribute()
+		record["old_value"] = sc.GetOldValue()
+		record["new_value"] = sc.GetNewValue()
+		record["cause"] = sc.GetCause()
+		for name, value := range sc.GetAttributes() {
+			record[name] = value
+		}
+	default:
+		record["type"] = "unknown"
+	}
+	return record
+}
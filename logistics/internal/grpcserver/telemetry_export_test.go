@@ -0,0 +1,144 @@
+package grpcserver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// emitExportEvents emits five state changes with severities INFO, WARNING,
+// CRITICAL, INFO, WARNING.
+func emitExportEvents(svc *telemetryService) {
+	severities := []pb.TelemetrySeverity{
+		pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO,
+		pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING,
+		pb.TelemetrySeverity_TELEMETRY_SEVERITY_CRITICAL,
+		pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO,
+		pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING,
+	}
+	for i, sev := range severities {
+		svc.EmitTelemetryEvent(&pb.TelemetryEvent{
+			EventId:  fmt.Sprintf("evt-%d", i),
+			NpcId:    fmt.Sprintf("npc-%d", i),
+			Severity: sev,
+			Payload: &pb.TelemetryEvent_StateChange{StateChange: &pb.StateChangeEvent{
+				Attribute: "morale",
+				OldValue:  0.5,
+				NewValue:  0.4,
+				Cause:     fmt.Sprintf("cause-%d", i),
+			}},
+		})
+	}
+}
+
+func parseNDJSON(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "line %q", scanner.Text())
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func TestExportNDJSON_AllEvents(t *testing.T) {
+	svc := newTestTelemetryService()
+	emitExportEvents(svc)
+
+	var buf bytes.Buffer
+	n, err := svc.ExportNDJSON(&buf, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	records := parseNDJSON(t, buf.Bytes())
+	require.Len(t, records, 5)
+	for i, record := range records { // oldest first
+		assert.Equal(t, fmt.Sprintf("evt-%d", i), record["event_id"])
+		assert.Equal(t, fmt.Sprintf("npc-%d", i), record["npc_id"])
+		assert.Equal(t, "state_change", record["type"])
+		assert.Equal(t, "morale", record["attribute"])
+		assert.Equal(t, 0.5, record["old_value"])
+		assert.Equal(t, 0.4, record["new_value"])
+		assert.Equal(t, fmt.Sprintf("cause-%d", i), record["cause"])
+		assert.NotEmpty(t, record["timestamp"])
+	}
+	assert.Equal(t, "CRITICAL", records[2]["severity"])
+}
+
+func TestExportNDJSON_SeverityFilter(t *testing.T) {
+	svc := newTestTelemetryService()
+	emitExportEvents(svc)
+
+	var buf bytes.Buffer
+	n, err := svc.ExportNDJSON(&buf, &pb.TelemetryFilter{
+		MinSeverity:         pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING,
+		IncludeStateChanges: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	var ids []any
+	for _, record := range parseNDJSON(t, buf.Bytes()) {
+		assert.NotEqual(t, "INFO", record["severity"])
+		ids = append(ids, record["event_id"])
+	}
+	assert.Equal(t, []any{"evt-1", "evt-2", "evt-4"}, ids)
+}
+
+func TestExportJSON_Array(t *testing.T) {
+	svc := newTestTelemetryService()
+	emitExportEvents(svc)
+
+	var buf bytes.Buffer
+	n, err := svc.ExportJSON(&buf, &pb.TelemetryFilter{NpcIds: []string{"npc-3"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	var records []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &records))
+	require.Len(t, records, 1)
+	assert.Equal(t, "evt-3", records[0]["event_id"])
+
+	buf.Reset()
+	n, err = newTestTelemetryService().ExportJSON(&buf, nil)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.JSONEq(t, "[]", buf.String())
+}
+
+func TestExportNDJSON_WrappedRingBufferIsOldestFirst(t *testing.T) {
+	svc := newTestTelemetryService()
+	for i := 0; i < maxRecentEvents+3; i++ {
+		svc.EmitTelemetryEvent(&pb.TelemetryEvent{EventId: fmt.Sprintf("evt-%d", i), NpcId: "npc-1"})
+	}
+
+	var buf bytes.Buffer
+	n, err := svc.ExportNDJSON(&buf, nil)
+	require.NoError(t, err)
+	require.Equal(t, maxRecentEvents, n)
+	records := parseNDJSON(t, buf.Bytes())
+	assert.Equal(t, "evt-3", records[0]["event_id"])
+	assert.Equal(t, fmt.Sprintf("evt-%d", maxRecentEvents+2), records[n-1]["event_id"])
+}
+
+func TestParseTelemetrySeverity(t *testing.T) {
+	sev, err := ParseTelemetrySeverity("warning")
+	require.NoError(t, err)
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING, sev)
+
+	sev, err = ParseTelemetrySeverity("TELEMETRY_SEVERITY_CRITICAL")
+	require.NoError(t, err)
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_CRITICAL, sev)
+
+	_, err = ParseTelemetrySeverity("loud")
+	assert.Error(t, err)
+}
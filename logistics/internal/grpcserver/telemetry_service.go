@@ -3,13 +3,16 @@ package grpcserver
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"sync"
 	"time"
 
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/economy"
 	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -36,7 +39,7 @@ type telemetryService struct {
 	totalEmitted int64
 
 	// Active stream subscribers
-	subscribers   map[int64]chan *pb.TelemetryEvent
+	subscribers   map[int64]*telemetrySubscriber
 	subscriberMu  sync.RWMutex
 	nextSubID     int64
 }
@@ -50,10 +53,17 @@ func NewTelemetryService(
 		rebellionEngine: rebellionEngine,
 		behaviorEngine:  behaviorEngine,
 		recentEvents:    make([]*pb.TelemetryEvent, 0, maxRecentEvents),
-		subscribers:     make(map[int64]chan *pb.TelemetryEvent),
+		subscribers:     make(map[int64]*telemetrySubscriber),
 	}
 }
 
+// telemetrySubscriber is a live stream's event channel and current filter.
+// filter is guarded by telemetryService.subscriberMu.
+type telemetrySubscriber struct {
+	ch     chan *pb.TelemetryEvent
+	filter *pb.TelemetryFilter
+}
+
 // StreamTelemetry implements server-side streaming for real-time telemetry.
 // The client sends a filter, and the server pushes matching events as they occur.
 func (s *telemetryService) StreamTelemetry(
@@ -61,24 +71,86 @@ func (s *telemetryService) StreamTelemetry(
 	stream grpc.ServerStreamingServer[pb.TelemetryEvent],
 ) error {
 	// Register subscriber
-	subID, ch := s.addSubscriber()
+	subID, ch := s.addSubscriber(filter)
 	defer s.removeSubscriber(subID)
 
 	log.Printf("[Telemetry] Stream subscriber %d connected (filter: severity >= %v)", subID, filter.GetMinSeverity())
 
+	return s.pumpEvents(subID, ch, stream, nil)
+}
+
+// BidirectionalTelemetryStream implements bidirectional streaming for
+// real-time telemetry. Every filter the client sends replaces the
+// subscriber's current filter (see UpdateTelemetryFilter); until the first
+// one arrives all events are delivered. The stream stays open after the
+// client closes its send side.
+func (s *telemetryService) BidirectionalTelemetryStream(
+	stream grpc.BidiStreamingServer[pb.TelemetryFilter, pb.TelemetryEvent],
+) error {
+	subID, ch := s.addSubscriber(nil)
+	defer s.removeSubscriber(subID)
+
+	log.Printf("[Telemetry] Bidirectional subscriber %d connected", subID)
+
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			filter, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			if err := s.UpdateTelemetryFilter(subID, filter); err != nil {
+				recvErr <- err
+				return
+			}
+			log.Printf("[Telemetry] Subscriber %d filter updated (severity >= %v)", subID, filter.GetMinSeverity())
+		}
+	}()
+
+	return s.pumpEvents(subID, ch, stream, recvErr)
+}
+
+// UpdateTelemetryFilter replaces the filter of an active stream subscriber.
+// The new filter applies to events broadcast after the call; a nil filter
+// delivers all events. Returns an error if subID is not subscribed.
+func (s *telemetryService) UpdateTelemetryFilter(subID int64, newFilter *pb.TelemetryFilter) error {
+	s.subscriberMu.Lock()
+	defer s.subscriberMu.Unlock()
+
+	sub, ok := s.subscribers[subID]
+	if !ok {
+		return fmt.Errorf("telemetry subscriber %d not found", subID)
+	}
+	sub.filter = newFilter
+	return nil
+}
+
+// pumpEvents sends events from ch to stream until the channel closes, the
+// stream's context ends, or an error arrives on recvErr (nil to ignore).
+func (s *telemetryService) pumpEvents(
+	subID int64,
+	ch <-chan *pb.TelemetryEvent,
+	stream interface {
+		Send(*pb.TelemetryEvent) error
+		Context() context.Context
+	},
+	recvErr <-chan error,
+) error {
 	for {
 		select {
 		case event, ok := <-ch:
 			if !ok {
 				return nil
 			}
-			// Apply filter
-			if !matchesFilter(event, filter) {
-				continue
-			}
 			if err := stream.Send(event); err != nil {
 				return err
 			}
+		case err := <-recvErr:
+			return err
 		case <-stream.Context().Done():
 			log.Printf("[Telemetry] Stream subscriber %d disconnected", subID)
 			return stream.Context().Err()
@@ -165,6 +237,62 @@ func (s *telemetryService) ReportTelemetryEvent(
 	}, nil
 }
 
+// ImportTelemetryBatch bulk-imports historical events (replay mode).
+// Events are validated and stored but not broadcast to live subscribers.
+func (s *telemetryService) ImportTelemetryBatch(
+	ctx context.Context,
+	batch *pb.TelemetryBatch,
+) (*pb.BatchImportResponse, error) {
+	imported, rejected := s.ImportBatch(batch.GetEvents())
+	return &pb.BatchImportResponse{
+		ImportedCount: int32(imported),
+		Rejected:      rejected,
+	}, nil
+}
+
+// ImportBatch validates events (non-empty event_id and npc_id) and stores the
+// valid ones in the ring buffer without broadcasting them. When an event_id
+// appears more than once in the batch, only the last occurrence is kept.
+// Returns the number of events stored and a "<event_id>: <reason>" entry for
+// each rejected event; events without an ID are identified by batch index.
+func (s *telemetryService) ImportBatch(events []*pb.TelemetryEvent) (int, []string) {
+	var rejected []string
+	valid := make([]*pb.TelemetryEvent, 0, len(events))
+	lastIndex := make(map[string]int, len(events))
+	for i, event := range events {
+		switch {
+		case event.GetEventId() == "":
+			rejected = append(rejected, fmt.Sprintf("#%d: event_id is required", i))
+			continue
+		case event.GetNpcId() == "":
+			rejected = append(rejected, fmt.Sprintf("%s: npc_id is required", event.GetEventId()))
+			continue
+		}
+		lastIndex[event.GetEventId()] = len(valid)
+		valid = append(valid, event)
+	}
+
+	now := time.Now().UTC()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	imported := 0
+	for i, event := range valid {
+		if lastIndex[event.GetEventId()] != i {
+			continue // superseded by a later duplicate
+		}
+		if event.Timestamp == nil {
+			event.Timestamp = &pb.EpochTimestamp{
+				Iso8601: now.Format(time.RFC3339),
+				UnixMs:  now.UnixMilli(),
+			}
+		}
+		s.storeEventLocked(event)
+		imported++
+	}
+	return imported, rejected
+}
+
 // EmitTelemetryEvent is an internal API for the simulation engine to emit
 // telemetry events directly without going through gRPC.
 func (s *telemetryService) EmitTelemetryEvent(event *pb.TelemetryEvent) {
@@ -180,8 +308,10 @@ func (s *telemetryService) EmitTelemetryEvent(event *pb.TelemetryEvent) {
 	s.broadcastEvent(event)
 }
 
-// EmitMentalBreakdown creates and emits a mental breakdown telemetry event.
+// EmitMentalBreakdown creates and emits a mental breakdown telemetry event,
+// correlated through tc.
 func (s *telemetryService) EmitMentalBreakdown(
+	tc TelemetryContext,
 	npcID string,
 	breakdownType pb.MentalBreakdownType,
 	intensity float64,
@@ -192,9 +322,10 @@ func (s *telemetryService) EmitMentalBreakdown(
 	now := time.Now().UTC()
 
 	event := &pb.TelemetryEvent{
-		EventId:  fmt.Sprintf("mb-%s-%d", npcID, now.UnixNano()),
-		NpcId:    npcID,
-		Severity: severity,
+		EventId:       fmt.Sprintf("mb-%s-%d", npcID, now.UnixNano()),
+		NpcId:         npcID,
+		Severity:      severity,
+		CorrelationId: tc.CorrelationID,
 		Timestamp: &pb.EpochTimestamp{
 			Iso8601: now.Format(time.RFC3339),
 			UnixMs:  now.UnixMilli(),
@@ -217,12 +348,54 @@ func (s *telemetryService) EmitMentalBreakdown(
 		event.NpcSnapshot = npcBehaviorToProtoState(npcState)
 	}
 
+	// Track the breakdown until the NPC recovers (unknown NPCs are not tracked)
+	_ = s.behaviorEngine.SetPendingBreakdown(npcID, npc.BreakdownRecord{
+		EventID:             event.EventId,
+		BreakdownType:       breakdownType,
+		OccurredAt:          now,
+		RecoveryProbability: 1.0 - intensity,
+	})
+
 	s.EmitTelemetryEvent(event)
 	log.Printf("[Telemetry] Mental breakdown: %s → %v (intensity=%.2f)", npcID, breakdownType, intensity)
 }
 
-// EmitPermanentTrauma creates and emits a permanent trauma telemetry event.
+// EmitResolvedMentalBreakdown emits an INFO mental breakdown event with
+// Resolved set, reporting that an NPC recovered from the breakdown in record.
+// It implements npc.BreakdownRecoveryListener.
+func (s *telemetryService) EmitResolvedMentalBreakdown(npcID string, record npc.BreakdownRecord) {
+	now := time.Now().UTC()
+
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("mbr-%s-%d", npcID, now.UnixNano()),
+		NpcId:    npcID,
+		Severity: pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_MentalBreakdown{
+			MentalBreakdown: &pb.MentalBreakdownEvent{
+				Type:                record.BreakdownType,
+				TriggerContext:      record.EventID,
+				Resolved:            true,
+				RecoveryProbability: record.RecoveryProbability,
+			},
+		},
+	}
+
+	if npcState, exists := s.behaviorEngine.GetNPC(npcID); exists {
+		event.NpcSnapshot = npcBehaviorToProtoState(npcState)
+	}
+
+	s.EmitTelemetryEvent(event)
+	log.Printf("[Telemetry] Mental breakdown resolved: %s → %v (event=%s)", npcID, record.BreakdownType, record.EventID)
+}
+
+// EmitPermanentTrauma creates and emits a permanent trauma telemetry event,
+// correlated through tc.
 func (s *telemetryService) EmitPermanentTrauma(
+	tc TelemetryContext,
 	npcID string,
 	traumaType pb.PermanentTraumaType,
 	severity float64,
@@ -238,9 +411,10 @@ func (s *telemetryService) EmitPermanentTrauma(
 	now := time.Now().UTC()
 
 	event := &pb.TelemetryEvent{
-		EventId:  fmt.Sprintf("pt-%s-%d", npcID, now.UnixNano()),
-		NpcId:    npcID,
-		Severity: telSeverity,
+		EventId:       fmt.Sprintf("pt-%s-%d", npcID, now.UnixNano()),
+		NpcId:         npcID,
+		Severity:      telSeverity,
+		CorrelationId: tc.CorrelationID,
 		Timestamp: &pb.EpochTimestamp{
 			Iso8601: now.Format(time.RFC3339),
 			UnixMs:  now.UnixMilli(),
@@ -270,6 +444,43 @@ func (s *telemetryService) EmitPermanentTrauma(
 		npcID, traumaType, severity, affectedAttribute, attributeReduction)
 }
 
+// EmitEmotionalStateChange emits a state-change telemetry event when an NPC
+// moves between emotional states. It matches npc.EmotionalStateListener.
+func (s *telemetryService) EmitEmotionalStateChange(t npc.EmotionalTransition) {
+	severity := pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO
+	switch t.To {
+	case npc.EmotionalStateDemoralized:
+		severity = pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING
+	case npc.EmotionalStateRebellious:
+		severity = pb.TelemetrySeverity_TELEMETRY_SEVERITY_CRITICAL
+	}
+
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("emo-%s-%d", t.NPCID, now.UnixNano()),
+		NpcId:    t.NPCID,
+		Severity: severity,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute: "emotional_state",
+				OldValue:  0,
+				NewValue:  t.Morale,
+				Cause:     fmt.Sprintf("%s → %s", t.From, t.To),
+			},
+		},
+	}
+
+	if npcState, exists := s.behaviorEngine.GetNPC(t.NPCID); exists {
+		event.NpcSnapshot = npcBehaviorToProtoState(npcState)
+	}
+
+	s.EmitTelemetryEvent(event)
+}
+
 // EmitCleansingResult emits a telemetry event for a Sheriff Protocol cleansing operation.
 func (s *telemetryService) EmitCleansingResult(success bool, participantIDs []string, successRate float64) {
 	severity := pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO
@@ -306,6 +517,197 @@ func (s *telemetryService) EmitCleansingResult(success bool, participantIDs []st
 	}
 }
 
+// EmitDisruption emits a telemetry event when a mine or refinery goes
+// offline (warning) or recovers (info). It matches
+// simulation.DisruptionListener.
+func (s *telemetryService) EmitDisruption(ev simulation.DisruptionEvent) {
+	severity := pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING
+	cause := fmt.Sprintf("%s %s disrupted for %d ticks", ev.Kind, ev.ID, ev.DurationTicks)
+	newValue := 0.0
+	if ev.Recovered {
+		severity = pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO
+		cause = fmt.Sprintf("%s %s recovered", ev.Kind, ev.ID)
+		newValue = 1.0
+	}
+
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("disruption-%s-%d", ev.ID, now.UnixNano()),
+		NpcId:    "system",
+		Severity: severity,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute: ev.Kind + "_operational",
+				OldValue:  1.0 - newValue,
+				NewValue:  newValue,
+				Cause:     cause,
+			},
+		},
+	}
+	s.EmitTelemetryEvent(event)
+	log.Printf("[Telemetry] Disruption: %s (tick %d)", cause, ev.Tick)
+}
+
+// EmitRandomEvent broadcasts a fired simulation random event as a system
+// state change. Suitable as a simulation.RandomEventListener.
+func (s *telemetryService) EmitRandomEvent(ev simulation.RandomEvent) {
+	cause := ev.Message
+	if cause == "" {
+		cause = ev.Name
+	}
+
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("random-event-%s-%d", ev.Name, now.UnixNano()),
+		NpcId:    "system",
+		Severity: pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute: "random_event:" + ev.Name,
+				OldValue:  0.0,
+				NewValue:  1.0,
+				Cause:     cause,
+			},
+		},
+	}
+	s.EmitTelemetryEvent(event)
+	log.Printf("[Telemetry] Random event %s: %s (tick %d)", ev.Name, cause, ev.Tick)
+}
+
+// EmitResourceDecay emits a warning-level telemetry event when decay pushes
+// a perishable resource below simulation.DecayAlertFraction of its peak. It
+// matches simulation.ResourceDecayListener.
+func (s *telemetryService) EmitResourceDecay(ev simulation.ResourceDecayEvent) {
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("decay-%s-%d", ev.Resource, now.UnixNano()),
+		NpcId:    "system",
+		Severity: pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute: string(ev.Resource) + "_quantity",
+				OldValue:  ev.Quantity + ev.Lost,
+				NewValue:  ev.Quantity,
+				Cause:     fmt.Sprintf("%s decayed below %.0f%% of its peak of %.1f", ev.Resource, simulation.DecayAlertFraction*100, ev.Peak),
+			},
+		},
+	}
+	s.EmitTelemetryEvent(event)
+	log.Printf("[Telemetry] Resource decay: %s at %.1f (peak %.1f, tick %d)", ev.Resource, ev.Quantity, ev.Peak, ev.Tick)
+}
+
+// EmitAutoScale emits an info-level telemetry event when auto-scaling adds
+// a mine because mineral ran low. It matches simulation.AutoScaleListener.
+func (s *telemetryService) EmitAutoScale(ev simulation.AutoScaleEvent) {
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("autoscale-%s-%d", ev.MineID, now.UnixNano()),
+		NpcId:    "system",
+		Severity: pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute: "auto_mine_count",
+				OldValue:  float64(ev.AutoMineCount - 1),
+				NewValue:  float64(ev.AutoMineCount),
+				Cause:     fmt.Sprintf("mineral at %.1f: auto-added %s yielding %.1f", ev.MineralQuantity, ev.MineID, ev.YieldRate),
+			},
+		},
+	}
+	s.EmitTelemetryEvent(event)
+	log.Printf("[Telemetry] Auto-scale: added %s at mineral %.1f (tick %d)", ev.MineID, ev.MineralQuantity, ev.Tick)
+}
+
+// EmitSimulationTelemetry emits a significant simulation tick event as a
+// system state change carrying the tick count and resource quantities as
+// attributes. Plague Heart activations are critical and throttle reductions
+// and mine depletions warnings; everything else is info. It satisfies simulation.TelemetrySink.
+func (s *telemetryService) EmitSimulationTelemetry(ev simulation.SimulationTelemetryEvent) {
+	severity := pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO
+	switch {
+	case ev.Kind == simulation.TelemetryPlagueHeart && ev.NewValue > ev.OldValue:
+		severity = pb.TelemetrySeverity_TELEMETRY_SEVERITY_CRITICAL
+	case ev.Kind == simulation.TelemetryThrottleChanged && ev.NewValue < ev.OldValue,
+		ev.Kind == simulation.TelemetryMineDepleted:
+		severity = pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING
+	}
+
+	attributes := map[string]float64{"tick_count": float64(ev.TickCount)}
+	for rt, quantity := range ev.Resources {
+		attributes[string(rt)+"_quantity"] = quantity
+	}
+
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("sim-%s-%d-%d", ev.Kind, ev.TickCount, now.UnixNano()),
+		NpcId:    "system",
+		Severity: severity,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute:  ev.Attribute,
+				OldValue:   ev.OldValue,
+				NewValue:   ev.NewValue,
+				Cause:      ev.Cause,
+				Attributes: attributes,
+			},
+		},
+	}
+	s.EmitTelemetryEvent(event)
+	if ev.Kind != simulation.TelemetryTickCompleted {
+		log.Printf("[Telemetry] Simulation %s: %s (tick %d)", ev.Kind, ev.Cause, ev.TickCount)
+	}
+}
+
+// EmitCommodityIndexAlert emits a warning-level telemetry event when the
+// commodity index signals severe deflation or inflation. It satisfies
+// economy.IndexAlertListener.
+func (s *telemetryService) EmitCommodityIndexAlert(rec economy.IndexRecord) {
+	cause := fmt.Sprintf("severe inflation: index above %.1f", economy.SevereInflationIndex)
+	if rec.Index < economy.SevereDeflationIndex {
+		cause = fmt.Sprintf("severe deflation: index below %.1f", economy.SevereDeflationIndex)
+	}
+
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("econ-index-%d", now.UnixNano()),
+		NpcId:    "system",
+		Severity: pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute: "commodity_index",
+				OldValue:  1.0, // default price level
+				NewValue:  rec.Index,
+				Cause:     cause,
+			},
+		},
+	}
+	s.EmitTelemetryEvent(event)
+	log.Printf("[Telemetry] Commodity index %.3f at economy tick %d (%s)", rec.Index, rec.Tick, cause)
+}
+
 // EmitInfestationWarning emits a warning-level telemetry event when infestation exceeds 50.
 func (s *telemetryService) EmitInfestationWarning(level float64) {
 	now := time.Now().UTC()
@@ -378,6 +780,62 @@ func (s *telemetryService) EmitPlagueHeartCleared(level float64) {
 	log.Printf("[Telemetry] Plague Heart cleared: level=%.1f — production restored", level)
 }
 
+// EmitSiegeModeActivated emits a warning-level telemetry event when sustained
+// rebellion puts the infestation into siege mode.
+func (s *telemetryService) EmitSiegeModeActivated(avgRebellion float64) {
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("inf-siege-on-%d", now.UnixNano()),
+		NpcId:    "system",
+		Severity: pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute: "avg_rebellion",
+				OldValue:  0,
+				NewValue:  avgRebellion,
+				Cause:     "SIEGE MODE — infestation accumulation accelerated",
+			},
+		},
+	}
+	s.EmitTelemetryEvent(event)
+	log.Printf("[Telemetry] SIEGE MODE ACTIVATED: avg_rebellion=%.2f", avgRebellion)
+}
+
+// EmitSiegeModeDeactivated emits an info-level telemetry event when siege mode ends.
+func (s *telemetryService) EmitSiegeModeDeactivated(avgRebellion float64) {
+	now := time.Now().UTC()
+	event := &pb.TelemetryEvent{
+		EventId:  fmt.Sprintf("inf-siege-off-%d", now.UnixNano()),
+		NpcId:    "system",
+		Severity: pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO,
+		Timestamp: &pb.EpochTimestamp{
+			Iso8601: now.Format(time.RFC3339),
+			UnixMs:  now.UnixMilli(),
+		},
+		Payload: &pb.TelemetryEvent_StateChange{
+			StateChange: &pb.StateChangeEvent{
+				Attribute: "avg_rebellion",
+				OldValue:  0,
+				NewValue:  avgRebellion,
+				Cause:     "Siege mode lifted — infestation accumulation normal",
+			},
+		},
+	}
+	s.EmitTelemetryEvent(event)
+	log.Printf("[Telemetry] Siege mode lifted: avg_rebellion=%.2f", avgRebellion)
+}
+
+// TotalEmitted returns the number of events stored since the service started.
+func (s *telemetryService) TotalEmitted() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.totalEmitted
+}
+
 // ---------------------------------------------------------------------------
 // Internal helpers
 // ---------------------------------------------------------------------------
@@ -385,7 +843,11 @@ func (s *telemetryService) EmitPlagueHeartCleared(level float64) {
 func (s *telemetryService) storeEvent(event *pb.TelemetryEvent) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.storeEventLocked(event)
+}
 
+// storeEventLocked appends event to the ring buffer. Caller must hold s.mu.
+func (s *telemetryService) storeEventLocked(event *pb.TelemetryEvent) {
 	if len(s.recentEvents) < maxRecentEvents {
 		s.recentEvents = append(s.recentEvents, event)
 	} else {
@@ -399,9 +861,12 @@ func (s *telemetryService) broadcastEvent(event *pb.TelemetryEvent) {
 	s.subscriberMu.RLock()
 	defer s.subscriberMu.RUnlock()
 
-	for _, ch := range s.subscribers {
+	for _, sub := range s.subscribers {
+		if !matchesFilter(event, sub.filter) {
+			continue
+		}
 		select {
-		case ch <- event:
+		case sub.ch <- event:
 			// Event sent
 		default:
 			// Subscriber channel full — drop event (0ms tolerance, don't block)
@@ -409,14 +874,14 @@ func (s *telemetryService) broadcastEvent(event *pb.TelemetryEvent) {
 	}
 }
 
-func (s *telemetryService) addSubscriber() (int64, chan *pb.TelemetryEvent) {
+func (s *telemetryService) addSubscriber(filter *pb.TelemetryFilter) (int64, chan *pb.TelemetryEvent) {
 	s.subscriberMu.Lock()
 	defer s.subscriberMu.Unlock()
 
 	id := s.nextSubID
 	s.nextSubID++
 	ch := make(chan *pb.TelemetryEvent, 100) // Buffer 100 events per subscriber
-	s.subscribers[id] = ch
+	s.subscribers[id] = &telemetrySubscriber{ch: ch, filter: filter}
 	return id, ch
 }
 
@@ -424,8 +889,8 @@ func (s *telemetryService) removeSubscriber(id int64) {
 	s.subscriberMu.Lock()
 	defer s.subscriberMu.Unlock()
 
-	if ch, ok := s.subscribers[id]; ok {
-		close(ch)
+	if sub, ok := s.subscribers[id]; ok {
+		close(sub.ch)
 		delete(s.subscribers, id)
 	}
 }
@@ -0,0 +1,380 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/economy"
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestTelemetryService() *telemetryService {
+	return NewTelemetryService(rebellion.NewEngine(rebellion.DefaultConfig()), npc.NewBehaviorEngine())
+}
+
+func telemetryEvents(n int) []*pb.TelemetryEvent {
+	events := make([]*pb.TelemetryEvent, n)
+	for i := range events {
+		events[i] = &pb.TelemetryEvent{
+			EventId:  fmt.Sprintf("evt-%d", i),
+			NpcId:    "npc-1",
+			Severity: pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO,
+		}
+	}
+	return events
+}
+
+func TestImportBatch_StoresAllEvents(t *testing.T) {
+	svc := newTestTelemetryService()
+
+	imported, rejected := svc.ImportBatch(telemetryEvents(10))
+	assert.Equal(t, 10, imported)
+	assert.Empty(t, rejected)
+	assert.Equal(t, int64(10), svc.TotalEmitted())
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 10)
+	assert.NotNil(t, batch.GetEvents()[0].GetTimestamp())
+}
+
+func TestImportBatch_DeduplicatesKeepingLast(t *testing.T) {
+	svc := newTestTelemetryService()
+
+	events := telemetryEvents(8)
+	events = append(events,
+		&pb.TelemetryEvent{EventId: "evt-2", NpcId: "npc-2"},
+		&pb.TelemetryEvent{EventId: "evt-5", NpcId: "npc-5"},
+	)
+
+	imported, rejected := svc.ImportBatch(events)
+	assert.Equal(t, 8, imported)
+	assert.Empty(t, rejected)
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 8)
+	npcByEvent := make(map[string]string)
+	for _, ev := range batch.GetEvents() {
+		npcByEvent[ev.GetEventId()] = ev.GetNpcId()
+	}
+	assert.Equal(t, "npc-2", npcByEvent["evt-2"])
+	assert.Equal(t, "npc-5", npcByEvent["evt-5"])
+}
+
+func TestImportBatch_RejectsInvalidEvents(t *testing.T) {
+	svc := newTestTelemetryService()
+
+	events := telemetryEvents(3)
+	events = append(events,
+		&pb.TelemetryEvent{NpcId: "npc-1"},
+		&pb.TelemetryEvent{EventId: "evt-orphan"},
+	)
+
+	imported, rejected := svc.ImportBatch(events)
+	assert.Equal(t, 3, imported)
+	assert.Equal(t, []string{
+		"#3: event_id is required",
+		"evt-orphan: npc_id is required",
+	}, rejected)
+}
+
+func TestImportBatch_DoesNotBroadcast(t *testing.T) {
+	svc := newTestTelemetryService()
+	subID, ch := svc.addSubscriber(nil)
+	defer svc.removeSubscriber(subID)
+
+	imported, _ := svc.ImportBatch(telemetryEvents(5))
+	require.Equal(t, 5, imported)
+	assert.Empty(t, ch)
+}
+
+func TestImportTelemetryBatch_RPC(t *testing.T) {
+	svc := newTestTelemetryService()
+
+	events := telemetryEvents(4)
+	events = append(events, &pb.TelemetryEvent{NpcId: "npc-1"})
+	resp, err := svc.ImportTelemetryBatch(context.Background(), &pb.TelemetryBatch{Events: events})
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), resp.GetImportedCount())
+	assert.Len(t, resp.GetRejected(), 1)
+}
+
+func TestEmitEmotionalStateChange_OneEventPerTransition(t *testing.T) {
+	behavior := npc.NewBehaviorEngine()
+	svc := NewTelemetryService(rebellion.NewEngine(rebellion.DefaultConfig()), behavior)
+	behavior.SetEmotionalStateListener(svc.EmitEmotionalStateChange)
+	behavior.RegisterNPC("npc-1")
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, behavior.ApplyMoraleModifier("npc-1", -0.06, "test"))
+	}
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 3)
+
+	// Newest first
+	causes := make([]string, 0, 3)
+	for _, ev := range batch.GetEvents() {
+		assert.Equal(t, "emotional_state", ev.GetStateChange().GetAttribute())
+		causes = append(causes, ev.GetStateChange().GetCause())
+	}
+	assert.Equal(t, []string{
+		"demoralized → rebellious",
+		"discouraged → demoralized",
+		"neutral → discouraged",
+	}, causes)
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_CRITICAL, batch.GetEvents()[0].GetSeverity())
+}
+
+// setupTelemetryTest serves svc over an in-process gRPC connection and
+// returns a connected client. The server is stopped on test cleanup.
+func setupTelemetryTest(t *testing.T, svc *telemetryService) pb.TelemetryServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	pb.RegisterTelemetryServiceServer(srv, svc)
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			t.Logf("server exited: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+	return pb.NewTelemetryServiceClient(conn)
+}
+
+// subscriberMinSeverity reports the min severity of the only subscriber's
+// filter, or false if there is not exactly one subscriber.
+func subscriberMinSeverity(svc *telemetryService) (pb.TelemetrySeverity, bool) {
+	svc.subscriberMu.RLock()
+	defer svc.subscriberMu.RUnlock()
+	if len(svc.subscribers) != 1 {
+		return 0, false
+	}
+	for _, sub := range svc.subscribers {
+		return sub.filter.GetMinSeverity(), true
+	}
+	return 0, false
+}
+
+func TestBidirectionalTelemetryStream_FilterUpdate(t *testing.T) {
+	svc := newTestTelemetryService()
+	client := setupTelemetryTest(t, svc)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.BidirectionalTelemetryStream(ctx)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, ok := subscriberMinSeverity(svc)
+		return ok
+	}, time.Second, 5*time.Millisecond)
+
+	// No filter sent yet: every event is delivered.
+	for _, ev := range telemetryEvents(3) {
+		svc.EmitTelemetryEvent(ev)
+	}
+	for i := 0; i < 3; i++ {
+		ev, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("evt-%d", i), ev.GetEventId())
+	}
+
+	require.NoError(t, stream.Send(&pb.TelemetryFilter{MinSeverity: pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING}))
+	require.Eventually(t, func() bool {
+		sev, _ := subscriberMinSeverity(svc)
+		return sev == pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING
+	}, time.Second, 5*time.Millisecond)
+
+	for i, sev := range []pb.TelemetrySeverity{
+		pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO,
+		pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING,
+		pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO,
+		pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING,
+	} {
+		svc.EmitTelemetryEvent(&pb.TelemetryEvent{EventId: fmt.Sprintf("late-%d", i), NpcId: "npc-1", Severity: sev})
+	}
+	for _, want := range []string{"late-1", "late-3"} {
+		ev, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, want, ev.GetEventId())
+		assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING, ev.GetSeverity())
+	}
+
+	// Nothing else is queued for the subscriber.
+	svc.subscriberMu.RLock()
+	for _, sub := range svc.subscribers {
+		assert.Empty(t, sub.ch)
+	}
+	svc.subscriberMu.RUnlock()
+}
+
+func TestUpdateTelemetryFilter_UnknownSubscriber(t *testing.T) {
+	svc := newTestTelemetryService()
+	assert.EqualError(t, svc.UpdateTelemetryFilter(42, &pb.TelemetryFilter{}), "telemetry subscriber 42 not found")
+}
+
+func TestEmitDisruption(t *testing.T) {
+	svc := newTestTelemetryService()
+	svc.EmitDisruption(simulation.DisruptionEvent{Kind: "mine", ID: "mine-1", DurationTicks: 5, Tick: 12})
+	svc.EmitDisruption(simulation.DisruptionEvent{Kind: "mine", ID: "mine-1", Recovered: true, Tick: 17})
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 2)
+
+	recovered, started := batch.GetEvents()[0], batch.GetEvents()[1] // newest first
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING, started.GetSeverity())
+	assert.Equal(t, "mine_operational", started.GetStateChange().GetAttribute())
+	assert.Equal(t, "mine mine-1 disrupted for 5 ticks", started.GetStateChange().GetCause())
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO, recovered.GetSeverity())
+	assert.Equal(t, 1.0, recovered.GetStateChange().GetNewValue())
+}
+
+func TestEmitRandomEvent(t *testing.T) {
+	svc := newTestTelemetryService()
+	svc.EmitRandomEvent(simulation.RandomEvent{Name: "mineral_vein_discovered", Message: "A rich vein was found", Tick: 3})
+	svc.EmitRandomEvent(simulation.RandomEvent{Name: "quiet_day", Tick: 4})
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 2)
+
+	quiet, vein := batch.GetEvents()[0], batch.GetEvents()[1] // newest first
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO, vein.GetSeverity())
+	assert.Equal(t, "random_event:mineral_vein_discovered", vein.GetStateChange().GetAttribute())
+	assert.Equal(t, "A rich vein was found", vein.GetStateChange().GetCause())
+	assert.Equal(t, "quiet_day", quiet.GetStateChange().GetCause(), "name is the fallback cause")
+}
+
+func TestEmitMentalBreakdown_RecoveryResolvesPendingBreakdown(t *testing.T) {
+	behavior := npc.NewBehaviorEngine()
+	svc := NewTelemetryService(rebellion.NewEngine(rebellion.DefaultConfig()), behavior)
+	behavior.SetBreakdownRecoveryListener(svc.EmitResolvedMentalBreakdown)
+	behavior.SetRandFn(func() float64 { return 0.1 })
+	behavior.RegisterNPC("npc-1")
+
+	svc.EmitMentalBreakdown(TelemetryContext{}, "npc-1", pb.MentalBreakdownType_MENTAL_BREAKDOWN_STRESS_SPIKE, 0.6, 0.4, 0.9, "act-1")
+
+	state, _ := behavior.GetNPC("npc-1")
+	require.NotNil(t, state.PendingBreakdown)
+	assert.Equal(t, pb.MentalBreakdownType_MENTAL_BREAKDOWN_STRESS_SPIKE, state.PendingBreakdown.BreakdownType)
+	assert.InDelta(t, 0.4, state.PendingBreakdown.RecoveryProbability, 1e-9)
+
+	recovered, err := behavior.AttemptBreakdownRecovery("npc-1")
+	require.NoError(t, err)
+	assert.True(t, recovered)
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 2)
+	resolved, original := batch.GetEvents()[0], batch.GetEvents()[1] // newest first
+	assert.False(t, original.GetMentalBreakdown().GetResolved())
+	assert.True(t, resolved.GetMentalBreakdown().GetResolved())
+	assert.Equal(t, original.GetEventId(), resolved.GetMentalBreakdown().GetTriggerContext())
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO, resolved.GetSeverity())
+}
+
+func TestEmitCommodityIndexAlert(t *testing.T) {
+	svc := newTestTelemetryService()
+	svc.EmitCommodityIndexAlert(economy.IndexRecord{Tick: 3, Index: 0.4})
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 1)
+
+	event := batch.GetEvents()[0]
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING, event.GetSeverity())
+	assert.Equal(t, "commodity_index", event.GetStateChange().GetAttribute())
+	assert.Equal(t, 0.4, event.GetStateChange().GetNewValue())
+	assert.Contains(t, event.GetStateChange().GetCause(), "deflation")
+}
+
+func TestEmitAutoScale(t *testing.T) {
+	svc := newTestTelemetryService()
+	svc.EmitAutoScale(simulation.AutoScaleEvent{MineID: "mine-4", YieldRate: 5, MineralQuantity: 42, AutoMineCount: 2, Tick: 9})
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 1)
+
+	event := batch.GetEvents()[0]
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO, event.GetSeverity())
+	assert.Equal(t, "auto_mine_count", event.GetStateChange().GetAttribute())
+	assert.Equal(t, 1.0, event.GetStateChange().GetOldValue())
+	assert.Equal(t, 2.0, event.GetStateChange().GetNewValue())
+	assert.Contains(t, event.GetStateChange().GetCause(), "mine-4")
+}
+
+func TestEmitResourceDecay(t *testing.T) {
+	svc := newTestTelemetryService()
+	svc.EmitResourceDecay(simulation.ResourceDecayEvent{Resource: simulation.ResourceSim, Quantity: 6, Lost: 6, Peak: 100, Tick: 4})
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 1)
+
+	event := batch.GetEvents()[0]
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING, event.GetSeverity())
+	assert.Equal(t, "sim_quantity", event.GetStateChange().GetAttribute())
+	assert.Equal(t, 12.0, event.GetStateChange().GetOldValue())
+	assert.Equal(t, 6.0, event.GetStateChange().GetNewValue())
+}
+
+func TestEmitSimulationTelemetry(t *testing.T) {
+	svc := newTestTelemetryService()
+	resources := map[simulation.ResourceType]float64{simulation.ResourceSim: 120, simulation.ResourceMineral: 40}
+	svc.EmitSimulationTelemetry(simulation.SimulationTelemetryEvent{
+		Kind: simulation.TelemetryPlagueHeart, Attribute: "plague_heart_active", OldValue: 0, NewValue: 1,
+		Cause: "Plague Heart activated", TickCount: 30, Resources: resources,
+	})
+	svc.EmitSimulationTelemetry(simulation.SimulationTelemetryEvent{
+		Kind: simulation.TelemetryThrottleChanged, Attribute: "throttle_multiplier", OldValue: 1, NewValue: 0.5, TickCount: 30, Resources: resources,
+	})
+	svc.EmitSimulationTelemetry(simulation.SimulationTelemetryEvent{
+		Kind: simulation.TelemetryTickCompleted, Attribute: "tick_count", OldValue: 20, NewValue: 30, TickCount: 30, Resources: resources,
+	})
+	svc.EmitSimulationTelemetry(simulation.SimulationTelemetryEvent{
+		Kind: simulation.TelemetryMineDepleted, Attribute: "mine-1_reserve", OldValue: 1, NewValue: 0, TickCount: 30, Resources: resources,
+	})
+
+	batch, err := svc.GetRecentTelemetry(context.Background(), &pb.RecentTelemetryRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, batch.GetEvents(), 4)
+
+	severities := make(map[string]pb.TelemetrySeverity)
+	for _, event := range batch.GetEvents() {
+		change := event.GetStateChange()
+		severities[change.GetAttribute()] = event.GetSeverity()
+		assert.Equal(t, map[string]float64{"tick_count": 30, "sim_quantity": 120, "mineral_quantity": 40}, change.GetAttributes())
+	}
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_CRITICAL, severities["plague_heart_active"])
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING, severities["throttle_multiplier"])
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_INFO, severities["tick_count"])
+	assert.Equal(t, pb.TelemetrySeverity_TELEMETRY_SEVERITY_WARNING, severities["mine-1_reserve"])
+}
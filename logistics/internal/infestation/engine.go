@@ -1,16 +1,19 @@
 package infestation
 
 import (
-	"errors"
+	"fmt"
+	"math"
 	"sync"
 )
 
 // Engine manages infestation state: accumulation when rebellion+trauma are high,
 // decay otherwise, with hysteresis for Plague Heart activation/deactivation.
 type Engine struct {
-	state  InfestationState
-	config InfestationConfig
-	mu     sync.RWMutex
+	state     InfestationState
+	config    InfestationConfig
+	telemetry TelemetrySink       // optional; notified of state changes by Tick
+	publisher MessageBusPublisher // optional; receives Plague Heart toggles from Tick
+	mu        sync.RWMutex
 }
 
 // NewEngine creates an infestation engine with the given config.
@@ -22,26 +25,67 @@ func NewEngine(config InfestationConfig) *Engine {
 			ThrottleMultiplier: 1.0,
 			LastTick:           0,
 		},
-		config: config,
+		config: config.clone(),
 	}
 }
 
 // Tick advances the infestation engine by one tick.
 // If avgRebellion > RebellionTrigger AND avgTrauma > TraumaTrigger,
 // counter increases by AccumulationRate. Otherwise, it decays by DecayRate.
+// Siege mode is active while avgRebellion >= SiegeModeThreshold and
+// multiplies that tick's AccumulationRate by SiegeModeMultiplier; it never
+// activates while SiegeModeMultiplier is not positive (as in a zero Config).
 // Counter is clamped to [0, PlagueHeartThreshold].
 // Plague Heart activates at PlagueHeartThreshold and clears below ClearThreshold (hysteresis).
+// While it stays active, ThrottleMultiplier drops by each EscalationStage
+// whose DurationTicks has elapsed since activation.
+// The telemetry sink and message bus publisher, if set, are notified after
+// the lock is released (see SetTelemetryService and SetPublisher).
 func (e *Engine) Tick(avgRebellion, avgTrauma float64, tickNumber int64) InfestationTickResult {
+	result, notify := e.TickDeferred(avgRebellion, avgTrauma, tickNumber)
+	if notify != nil {
+		notify()
+	}
+	return result
+}
+
+// TickDeferred is Tick without notifying the telemetry sink or message bus
+// publisher: it returns a function that does so instead, for callers that
+// must release their own locks first. The function is nil if neither is set.
+func (e *Engine) TickDeferred(avgRebellion, avgTrauma float64, tickNumber int64) (InfestationTickResult, func()) {
+	result, sink, pub := e.tick(avgRebellion, avgTrauma, tickNumber)
+	if sink == nil && pub == nil {
+		return result, nil
+	}
+	return result, func() {
+		if sink != nil {
+			notifyTelemetry(sink, result, avgRebellion)
+		}
+		if pub != nil {
+			publishPlagueHeart(pub, result, tickNumber)
+		}
+	}
+}
+
+// tick performs Tick under the lock and returns its result along with the
+// telemetry sink and publisher to notify.
+func (e *Engine) tick(avgRebellion, avgTrauma float64, tickNumber int64) (InfestationTickResult, TelemetrySink, MessageBusPublisher) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	previous := e.state.Counter
 	previousPlagueHeart := e.state.IsPlagueHeart
+	previousSiege := e.state.SiegeModeActive
 	accumulated := false
+	e.state.SiegeModeActive = e.config.SiegeModeMultiplier > 0 && avgRebellion >= e.config.SiegeModeThreshold
 
 	// Accumulate or decay
 	if avgRebellion > e.config.RebellionTrigger && avgTrauma > e.config.TraumaTrigger {
-		e.state.Counter += e.config.AccumulationRate
+		rate := e.config.AccumulationRate
+		if e.state.SiegeModeActive {
+			rate *= e.config.SiegeModeMultiplier
+		}
+		e.state.Counter += rate
 		accumulated = true
 	} else {
 		e.state.Counter -= e.config.DecayRate
@@ -55,16 +99,8 @@ func (e *Engine) Tick(avgRebellion, avgTrauma float64, tickNumber int64) Infesta
 		e.state.Counter = e.config.PlagueHeartThreshold
 	}
 
-	// Plague Heart activation with hysteresis
-	if !e.state.IsPlagueHeart && e.state.Counter >= e.config.PlagueHeartThreshold {
-		e.state.IsPlagueHeart = true
-		e.state.ThrottleMultiplier = e.config.ThrottleAmount
-	} else if e.state.IsPlagueHeart && e.state.Counter < e.config.ClearThreshold {
-		e.state.IsPlagueHeart = false
-		e.state.ThrottleMultiplier = 1.0
-	}
-
 	e.state.LastTick = tickNumber
+	e.evaluatePlagueHeart()
 
 	return InfestationTickResult{
 		PreviousCounter:    previous,
@@ -72,7 +108,91 @@ func (e *Engine) Tick(avgRebellion, avgTrauma float64, tickNumber int64) Infesta
 		Accumulated:        accumulated,
 		PlagueHeartChanged: previousPlagueHeart != e.state.IsPlagueHeart,
 		PlagueHeartActive:  e.state.IsPlagueHeart,
+		SiegeModeChanged:   previousSiege != e.state.SiegeModeActive,
+		SiegeModeActive:    e.state.SiegeModeActive,
+	}, e.telemetry, e.publisher
+}
+
+// TickN runs n ticks with the same inputs and returns the result of each,
+// numbering ticks consecutively after the last recorded tick.
+// Returns nil if n <= 0.
+func (e *Engine) TickN(n int, avgRebellion, avgTrauma float64) []InfestationTickResult {
+	if n <= 0 {
+		return nil
+	}
+	inputs := make([]InfestationInput, n)
+	for i := range inputs {
+		inputs[i] = InfestationInput{AvgRebellion: avgRebellion, AvgTrauma: avgTrauma}
+	}
+	return e.TickNVariable(inputs)
+}
+
+// TickNVariable runs one tick per entry in inputs, in order, and returns the
+// result of each. Ticks are numbered consecutively after the last recorded tick.
+func (e *Engine) TickNVariable(inputs []InfestationInput) []InfestationTickResult {
+	if len(inputs) == 0 {
+		return nil
+	}
+	results := make([]InfestationTickResult, 0, len(inputs))
+	for _, in := range inputs {
+		results = append(results, e.Tick(in.AvgRebellion, in.AvgTrauma, e.GetState().LastTick+1))
+	}
+	return results
+}
+
+// ForceSetCounter overrides the infestation counter (admin/testing use) and
+// re-evaluates Plague Heart state with the usual hysteresis: the counter must
+// reach PlagueHeartThreshold to activate and drop below ClearThreshold to clear.
+// Returns an error if counter is outside [0, PlagueHeartThreshold].
+func (e *Engine) ForceSetCounter(counter float64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if counter < 0 || counter > e.config.PlagueHeartThreshold {
+		return fmt.Errorf("counter %v out of range [0, %v]", counter, e.config.PlagueHeartThreshold)
+	}
+
+	e.state.Counter = counter
+	e.evaluatePlagueHeart()
+	return nil
+}
+
+// AddSpread adds infestation bleeding over from a connected zone. The counter
+// is capped at PlagueHeartThreshold and Plague Heart is not re-evaluated, so
+// spread alone never activates it; activation still requires the zone's own
+// Tick. Returns the amount actually added.
+func (e *Engine) AddSpread(amount float64) float64 {
+	if amount <= 0 {
+		return 0
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	previous := e.state.Counter
+	e.state.Counter += amount
+	if e.state.Counter > e.config.PlagueHeartThreshold {
+		e.state.Counter = e.config.PlagueHeartThreshold
 	}
+	return e.state.Counter - previous
+}
+
+// ForceActivatePlagueHeart sets the counter to PlagueHeartThreshold, activating Plague Heart.
+func (e *Engine) ForceActivatePlagueHeart() {
+	_ = e.ForceSetCounter(e.GetConfig().PlagueHeartThreshold)
+}
+
+// ForceDeactivatePlagueHeart sets the counter to 0, clearing Plague Heart.
+func (e *Engine) ForceDeactivatePlagueHeart() {
+	_ = e.ForceSetCounter(0)
+}
+
+// GetPlagueHeartDuration returns the number of ticks Plague Heart has been
+// active as of currentTick, or 0 if it is not active.
+func (e *Engine) GetPlagueHeartDuration(currentTick int64) int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.plagueHeartDuration(currentTick)
 }
 
 // GetState returns a snapshot of the current infestation state.
@@ -82,23 +202,144 @@ func (e *Engine) GetState() InfestationState {
 	return e.state
 }
 
+// Clone returns an independent engine with the same config and state, for
+// projecting ticks without touching the original.
+func (e *Engine) Clone() *Engine {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return &Engine{state: e.state, config: e.config.clone()}
+}
+
+// SetState replaces the infestation state wholesale (e.g. when restoring a
+// saved or replayed simulation). No hysteresis is applied.
+func (e *Engine) SetState(state InfestationState) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.state = state
+}
+
 // GetConfig returns the engine's configuration.
 func (e *Engine) GetConfig() InfestationConfig {
-	return e.config
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.config.clone()
 }
 
-// Cleanse resets the infestation state after a successful Sheriff Protocol operation.
+// UpdateConfig validates cfg and swaps it in without resetting the counter.
+// The counter is clamped to the new PlagueHeartThreshold and Plague Heart is
+// re-evaluated under the new thresholds; an active Plague Heart adopts the
+// new ThrottleAmount and escalation stages.
+func (e *Engine) UpdateConfig(cfg InfestationConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.config = cfg.clone()
+	if e.state.Counter > cfg.PlagueHeartThreshold {
+		e.state.Counter = cfg.PlagueHeartThreshold
+	}
+	e.evaluatePlagueHeart()
+	return nil
+}
+
+// Cleanse resets the infestation state, including escalation, after a
+// successful Sheriff Protocol operation.
 // Returns error if Plague Heart is not currently active.
 func (e *Engine) Cleanse() error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	if !e.state.IsPlagueHeart {
-		return errors.New("cannot cleanse: Plague Heart is not active")
+		return &PlagueHeartNotActiveError{Counter: e.state.Counter}
 	}
 
 	e.state.Counter = 0
+	e.clearPlagueHeart()
+	return nil
+}
+
+// ProjectInfestationScenarios simulates each scenario for the given number of ticks,
+// starting from currentState, with constant rebellion/trauma inputs per scenario.
+// It is a pure function: no engine state is read or modified.
+// If currentState already has Plague Heart active, the projection reports
+// activation at tick 0.
+func ProjectInfestationScenarios(
+	cfg InfestationConfig,
+	currentState InfestationState,
+	scenarios []InfestationScenario,
+	ticks int,
+) []InfestationProjection {
+	projections := make([]InfestationProjection, 0, len(scenarios))
+	for _, sc := range scenarios {
+		sim := &Engine{state: currentState, config: cfg}
+		projection := InfestationProjection{
+			ScenarioName:     sc.Name,
+			FinalCounter:     currentState.Counter,
+			TickOfActivation: -1,
+		}
+		if currentState.IsPlagueHeart {
+			projection.PlagueHeartActivates = true
+			projection.TickOfActivation = 0
+		}
+
+		for i := 1; i <= ticks; i++ {
+			result := sim.Tick(sc.AvgRebellion, sc.AvgTrauma, currentState.LastTick+int64(i))
+			projection.FinalCounter = result.NewCounter
+			if result.PlagueHeartChanged && result.PlagueHeartActive && projection.TickOfActivation < 0 {
+				projection.PlagueHeartActivates = true
+				projection.TickOfActivation = i
+			}
+		}
+
+		projections = append(projections, projection)
+	}
+	return projections
+}
+
+// evaluatePlagueHeart applies Plague Heart activation/deactivation with hysteresis
+// based on the current counter, then escalates an active Plague Heart as of
+// LastTick. Caller must hold e.mu.
+func (e *Engine) evaluatePlagueHeart() {
+	if !e.state.IsPlagueHeart && e.state.Counter >= e.config.PlagueHeartThreshold {
+		e.state.IsPlagueHeart = true
+		e.state.PlagueHeartActivatedAt = e.state.LastTick
+	} else if e.state.IsPlagueHeart && e.state.Counter < e.config.ClearThreshold {
+		e.clearPlagueHeart()
+	}
+	if !e.state.IsPlagueHeart {
+		return
+	}
+
+	duration := e.plagueHeartDuration(e.state.LastTick)
+	throttle := e.config.ThrottleAmount
+	level := 0
+	for _, stage := range e.config.EscalationStages {
+		if duration >= stage.DurationTicks {
+			throttle -= stage.AdditionalThrottleReduction
+			level++
+		}
+	}
+	e.state.ThrottleMultiplier = math.Max(throttle, 0)
+	e.state.EscalationLevel = level
+}
+
+// clearPlagueHeart deactivates Plague Heart and resets escalation.
+// Caller must hold e.mu.
+func (e *Engine) clearPlagueHeart() {
 	e.state.IsPlagueHeart = false
 	e.state.ThrottleMultiplier = 1.0
-	return nil
+	e.state.PlagueHeartActivatedAt = 0
+	e.state.EscalationLevel = 0
+}
+
+// plagueHeartDuration returns the ticks since activation as of currentTick,
+// or 0 if Plague Heart is not active. Caller must hold e.mu.
+func (e *Engine) plagueHeartDuration(currentTick int64) int64 {
+	if !e.state.IsPlagueHeart || currentTick < e.state.PlagueHeartActivatedAt {
+		return 0
+	}
+	return currentTick - e.state.PlagueHeartActivatedAt
 }
@@ -1,6 +1,9 @@
 package infestation
 
 import (
+	"errors"
+	"math"
+	"reflect"
 	"testing"
 )
 
@@ -248,9 +251,17 @@ func TestCleanse_Success(t *testing.T) {
 
 func TestCleanse_NotActive(t *testing.T) {
 	e := NewEngine(DefaultConfig())
+	e.ForceSetCounter(30)
 	err := e.Cleanse()
-	if err == nil {
-		t.Error("Cleanse() should return error when Plague Heart is not active")
+	if !errors.Is(err, ErrPlagueHeartNotActive) {
+		t.Fatalf("Cleanse() error = %v, want ErrPlagueHeartNotActive", err)
+	}
+	var phErr *PlagueHeartNotActiveError
+	if !errors.As(err, &phErr) {
+		t.Fatalf("Cleanse() error type = %T, want *PlagueHeartNotActiveError", err)
+	}
+	if phErr.Counter != 30 {
+		t.Errorf("PlagueHeartNotActiveError.Counter = %v, want 30", phErr.Counter)
 	}
 }
 
@@ -278,3 +289,493 @@ func TestResultFieldsPopulated(t *testing.T) {
 		t.Errorf("LastTick = %v, want 42", state.LastTick)
 	}
 }
+
+func TestForceSetCounter_ActivatesAtThreshold(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	if err := e.ForceSetCounter(100); err != nil {
+		t.Fatalf("ForceSetCounter(100) returned unexpected error: %v", err)
+	}
+	state := e.GetState()
+	if !state.IsPlagueHeart {
+		t.Error("Plague Heart should activate when counter is forced to 100")
+	}
+	if state.ThrottleMultiplier != 0.50 {
+		t.Errorf("ThrottleMultiplier = %v, want 0.50", state.ThrottleMultiplier)
+	}
+}
+
+func TestForceSetCounter_Hysteresis(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.ForceActivatePlagueHeart()
+
+	// 80 is above ClearThreshold (75) → stays active
+	if err := e.ForceSetCounter(80); err != nil {
+		t.Fatalf("ForceSetCounter(80) returned unexpected error: %v", err)
+	}
+	if !e.GetState().IsPlagueHeart {
+		t.Error("Plague Heart should remain active at 80 (hysteresis)")
+	}
+
+	// 74 is below ClearThreshold → deactivates
+	if err := e.ForceSetCounter(74); err != nil {
+		t.Fatalf("ForceSetCounter(74) returned unexpected error: %v", err)
+	}
+	state := e.GetState()
+	if state.IsPlagueHeart {
+		t.Error("Plague Heart should clear at 74")
+	}
+	if state.ThrottleMultiplier != 1.0 {
+		t.Errorf("ThrottleMultiplier = %v, want 1.0 after clear", state.ThrottleMultiplier)
+	}
+}
+
+func TestForceSetCounter_BelowThresholdDoesNotActivate(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	if err := e.ForceSetCounter(50); err != nil {
+		t.Fatalf("ForceSetCounter(50) returned unexpected error: %v", err)
+	}
+	state := e.GetState()
+	if state.Counter != 50 {
+		t.Errorf("Counter = %v, want 50", state.Counter)
+	}
+	if state.IsPlagueHeart {
+		t.Error("Plague Heart should not activate at 50")
+	}
+}
+
+func TestForceSetCounter_OutOfRange(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	if err := e.ForceSetCounter(101); err == nil {
+		t.Error("ForceSetCounter(101) should return a validation error")
+	}
+	if err := e.ForceSetCounter(-1); err == nil {
+		t.Error("ForceSetCounter(-1) should return a validation error")
+	}
+	if e.GetState().Counter != 0 {
+		t.Error("Counter should be unchanged after rejected override")
+	}
+}
+
+func TestForceDeactivatePlagueHeart(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.ForceActivatePlagueHeart()
+	e.ForceDeactivatePlagueHeart()
+	state := e.GetState()
+	if state.IsPlagueHeart || state.Counter != 0 {
+		t.Errorf("state = %+v, want counter 0 and Plague Heart cleared", state)
+	}
+}
+
+func TestProjectInfestationScenarios(t *testing.T) {
+	cfg := DefaultConfig()
+	start := InfestationState{ThrottleMultiplier: 1.0}
+	scenarios := []InfestationScenario{
+		{Name: "calm", AvgRebellion: 0.1, AvgTrauma: 0.5},
+		{Name: "unrest", AvgRebellion: 0.5, AvgTrauma: 0.5},
+	}
+
+	projections := ProjectInfestationScenarios(cfg, start, scenarios, 100)
+	if len(projections) != 2 {
+		t.Fatalf("len(projections) = %d, want 2", len(projections))
+	}
+
+	calm := projections[0]
+	if calm.ScenarioName != "calm" {
+		t.Errorf("ScenarioName = %q, want calm", calm.ScenarioName)
+	}
+	if calm.PlagueHeartActivates || calm.TickOfActivation != -1 {
+		t.Errorf("calm scenario should never activate, got %+v", calm)
+	}
+	if calm.FinalCounter != 0 {
+		t.Errorf("calm FinalCounter = %v, want 0", calm.FinalCounter)
+	}
+
+	unrest := projections[1]
+	if !unrest.PlagueHeartActivates {
+		t.Error("unrest scenario should activate Plague Heart")
+	}
+	if unrest.TickOfActivation != 50 {
+		t.Errorf("TickOfActivation = %d, want 50", unrest.TickOfActivation)
+	}
+	if unrest.FinalCounter != 100 {
+		t.Errorf("unrest FinalCounter = %v, want 100", unrest.FinalCounter)
+	}
+}
+
+func TestProjectInfestationScenarios_NoSideEffects(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.Tick(0.50, 0.50, 1)
+	before := e.GetState()
+
+	ProjectInfestationScenarios(e.GetConfig(), before, []InfestationScenario{{Name: "x", AvgRebellion: 0.9, AvgTrauma: 0.9}}, 60)
+
+	if after := e.GetState(); after != before {
+		t.Errorf("engine state changed by projection: before=%+v after=%+v", before, after)
+	}
+}
+
+func TestProjectInfestationScenarios_FromPartialCounter(t *testing.T) {
+	start := InfestationState{Counter: 90, ThrottleMultiplier: 1.0}
+	projections := ProjectInfestationScenarios(DefaultConfig(), start, []InfestationScenario{{Name: "unrest", AvgRebellion: 0.5, AvgTrauma: 0.5}}, 10)
+	if projections[0].TickOfActivation != 5 {
+		t.Errorf("TickOfActivation = %d, want 5 (90 → 100 at 2.0/tick)", projections[0].TickOfActivation)
+	}
+}
+
+func TestAddSpreadCapsAtThresholdWithoutActivating(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	if err := e.ForceSetCounter(95); err != nil {
+		t.Fatalf("ForceSetCounter: %v", err)
+	}
+
+	added := e.AddSpread(20)
+	if added != 5 {
+		t.Errorf("expected 5 added, got %v", added)
+	}
+	state := e.GetState()
+	if state.Counter != 100 {
+		t.Errorf("expected counter capped at 100, got %v", state.Counter)
+	}
+	if state.IsPlagueHeart {
+		t.Error("spread alone should not activate Plague Heart")
+	}
+
+	if got := e.AddSpread(-1); got != 0 {
+		t.Errorf("expected negative spread to be ignored, got %v", got)
+	}
+}
+
+func TestTickNMatchesSequentialTicks(t *testing.T) {
+	batch := NewEngine(DefaultConfig())
+	sequential := NewEngine(DefaultConfig())
+
+	results := batch.TickN(50, 0.9, 0.9)
+	if len(results) != 50 {
+		t.Fatalf("expected 50 results, got %d", len(results))
+	}
+	for i, got := range results {
+		want := sequential.Tick(0.9, 0.9, int64(i+1))
+		if got != want {
+			t.Fatalf("tick %d: batch %+v != sequential %+v", i+1, got, want)
+		}
+	}
+	if batch.GetState() != sequential.GetState() {
+		t.Errorf("final state differs: %+v vs %+v", batch.GetState(), sequential.GetState())
+	}
+	if batch.GetState().LastTick != 50 {
+		t.Errorf("expected LastTick 50, got %d", batch.GetState().LastTick)
+	}
+}
+
+func TestTickNVariableZigzag(t *testing.T) {
+	cfg := DefaultConfig()
+	e := NewEngine(cfg)
+	if err := e.ForceSetCounter(10); err != nil {
+		t.Fatalf("ForceSetCounter: %v", err)
+	}
+
+	accumulate := InfestationInput{AvgRebellion: 0.6, AvgTrauma: 0.9}
+	decay := InfestationInput{AvgRebellion: 0.1, AvgTrauma: 0.1}
+	inputs := []InfestationInput{accumulate, decay, accumulate, decay, accumulate, decay}
+
+	results := e.TickNVariable(inputs)
+	if len(results) != len(inputs) {
+		t.Fatalf("expected %d results, got %d", len(inputs), len(results))
+	}
+
+	counter := 10.0
+	for i, r := range results {
+		if i%2 == 0 {
+			counter += cfg.AccumulationRate
+			if !r.Accumulated {
+				t.Errorf("tick %d: expected accumulation", i+1)
+			}
+		} else {
+			counter -= cfg.DecayRate
+			if r.Accumulated {
+				t.Errorf("tick %d: expected decay", i+1)
+			}
+		}
+		if r.NewCounter != counter {
+			t.Errorf("tick %d: expected counter %v, got %v", i+1, counter, r.NewCounter)
+		}
+	}
+}
+
+func TestTickNNonPositive(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	if got := e.TickN(0, 0.9, 0.9); got != nil {
+		t.Errorf("expected nil for n=0, got %v", got)
+	}
+	if got := e.TickNVariable(nil); got != nil {
+		t.Errorf("expected nil for no inputs, got %v", got)
+	}
+}
+
+func TestUpdateConfigReevaluatesPlagueHeart(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.ForceActivatePlagueHeart()
+	if err := e.ForceSetCounter(80); err != nil {
+		t.Fatalf("ForceSetCounter: %v", err)
+	}
+	if !e.GetState().IsPlagueHeart {
+		t.Fatal("Plague Heart should stay active at 80 (above ClearThreshold 75)")
+	}
+
+	cfg := DefaultConfig()
+	cfg.ClearThreshold = 85
+	if err := e.UpdateConfig(cfg); err != nil {
+		t.Fatalf("UpdateConfig: %v", err)
+	}
+	state := e.GetState()
+	if state.IsPlagueHeart {
+		t.Error("Plague Heart should clear when counter 80 < new ClearThreshold 85")
+	}
+	if state.Counter != 80 {
+		t.Errorf("Counter = %v, want 80 (preserved)", state.Counter)
+	}
+	if state.ThrottleMultiplier != 1.0 {
+		t.Errorf("ThrottleMultiplier = %v, want 1.0", state.ThrottleMultiplier)
+	}
+}
+
+func TestUpdateConfigAccumulationRate(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	if err := e.ForceSetCounter(50); err != nil {
+		t.Fatalf("ForceSetCounter: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.AccumulationRate = 5.0
+	if err := e.UpdateConfig(cfg); err != nil {
+		t.Fatalf("UpdateConfig: %v", err)
+	}
+
+	results := e.TickN(5, 0.6, 0.8)
+	for i, r := range results {
+		if delta := r.NewCounter - r.PreviousCounter; delta != 5.0 {
+			t.Errorf("tick %d: delta = %v, want 5.0", i+1, delta)
+		}
+	}
+	if got := e.GetState().Counter; got != 75 {
+		t.Errorf("Counter after 5 ticks = %v, want 75", got)
+	}
+}
+
+func TestUpdateConfigClampsCounterAndThrottle(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.ForceActivatePlagueHeart()
+
+	cfg := DefaultConfig()
+	cfg.PlagueHeartThreshold = 90
+	cfg.ThrottleAmount = 0.25
+	if err := e.UpdateConfig(cfg); err != nil {
+		t.Fatalf("UpdateConfig: %v", err)
+	}
+	state := e.GetState()
+	if state.Counter != 90 {
+		t.Errorf("Counter = %v, want 90 (clamped to new threshold)", state.Counter)
+	}
+	if !state.IsPlagueHeart || state.ThrottleMultiplier != 0.25 {
+		t.Errorf("state = %+v, want active Plague Heart with throttle 0.25", state)
+	}
+}
+
+func TestUpdateConfigRejectsInvalid(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	invalid := []func(*InfestationConfig){
+		func(c *InfestationConfig) { c.AccumulationRate = 0 },
+		func(c *InfestationConfig) { c.DecayRate = -1 },
+		func(c *InfestationConfig) { c.ClearThreshold = 120 },
+		func(c *InfestationConfig) { c.ThrottleAmount = 1.5 },
+		func(c *InfestationConfig) { c.RebellionTrigger = -0.1 },
+	}
+	for i, mutate := range invalid {
+		cfg := DefaultConfig()
+		mutate(&cfg)
+		if err := e.UpdateConfig(cfg); err == nil {
+			t.Errorf("case %d: expected validation error", i)
+		}
+	}
+	if !reflect.DeepEqual(e.GetConfig(), DefaultConfig()) {
+		t.Error("config should be unchanged after rejected updates")
+	}
+}
+
+func TestEscalation_ReducesThrottleAfterStage(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EscalationStages = []EscalationStage{{DurationTicks: 10, AdditionalThrottleReduction: 0.1}}
+	e := NewEngine(cfg)
+	e.ForceActivatePlagueHeart()
+
+	// Low inputs decay the counter by 1 per tick; it stays above ClearThreshold.
+	for tick := int64(1); tick <= 11; tick++ {
+		e.Tick(0, 0, tick)
+		state := e.GetState()
+		want := 0.5
+		if tick >= 10 {
+			want = 0.4
+		}
+		if !state.IsPlagueHeart {
+			t.Fatalf("tick %d: plague heart should still be active", tick)
+		}
+		if math.Abs(state.ThrottleMultiplier-want) > 1e-9 {
+			t.Errorf("tick %d: ThrottleMultiplier = %v, want %v", tick, state.ThrottleMultiplier, want)
+		}
+	}
+
+	state := e.GetState()
+	if state.PlagueHeartActivatedAt != 0 {
+		t.Errorf("PlagueHeartActivatedAt = %d, want 0", state.PlagueHeartActivatedAt)
+	}
+	if state.EscalationLevel != 1 {
+		t.Errorf("EscalationLevel = %d, want 1", state.EscalationLevel)
+	}
+	if d := e.GetPlagueHeartDuration(11); d != 11 {
+		t.Errorf("GetPlagueHeartDuration(11) = %d, want 11", d)
+	}
+}
+
+func TestEscalation_StagesAccumulateAndFloorAtZero(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EscalationStages = []EscalationStage{
+		{DurationTicks: 2, AdditionalThrottleReduction: 0.3},
+		{DurationTicks: 4, AdditionalThrottleReduction: 0.3},
+	}
+	e := NewEngine(cfg)
+	e.TickN(50, 0.6, 0.9) // accumulates to 100 and activates on tick 50
+
+	state := e.GetState()
+	if !state.IsPlagueHeart || state.PlagueHeartActivatedAt != 50 {
+		t.Fatalf("expected activation at tick 50, got %+v", state)
+	}
+	e.TickN(2, 0.6, 0.9)
+	if got := e.GetState().ThrottleMultiplier; math.Abs(got-0.2) > 1e-9 {
+		t.Errorf("after first stage ThrottleMultiplier = %v, want 0.2", got)
+	}
+	e.TickN(2, 0.6, 0.9)
+	state = e.GetState()
+	if state.ThrottleMultiplier != 0 || state.EscalationLevel != 2 {
+		t.Errorf("after second stage got throttle %v level %d, want 0 and 2", state.ThrottleMultiplier, state.EscalationLevel)
+	}
+}
+
+func TestCleanse_ResetsEscalation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EscalationStages = []EscalationStage{{DurationTicks: 1, AdditionalThrottleReduction: 0.2}}
+	e := NewEngine(cfg)
+	e.ForceActivatePlagueHeart()
+	e.Tick(0, 0, 5)
+	if e.GetState().EscalationLevel != 1 {
+		t.Fatalf("expected escalation before cleanse, got %+v", e.GetState())
+	}
+
+	if err := e.Cleanse(); err != nil {
+		t.Fatalf("Cleanse: %v", err)
+	}
+	state := e.GetState()
+	if state.EscalationLevel != 0 || state.PlagueHeartActivatedAt != 0 || state.ThrottleMultiplier != 1.0 {
+		t.Errorf("escalation not reset: %+v", state)
+	}
+	if d := e.GetPlagueHeartDuration(10); d != 0 {
+		t.Errorf("GetPlagueHeartDuration after cleanse = %d, want 0", d)
+	}
+}
+
+func TestValidate_EscalationStages(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EscalationStages = []EscalationStage{{DurationTicks: 0, AdditionalThrottleReduction: 0.1}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for non-positive DurationTicks")
+	}
+	cfg.EscalationStages = []EscalationStage{{DurationTicks: 5, AdditionalThrottleReduction: 1.5}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for reduction > 1")
+	}
+}
+
+func TestSiegeMode_DoublesAccumulation(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+
+	result := e.Tick(0.75, 0.5, 1)
+	if delta := result.NewCounter - result.PreviousCounter; delta != 4.0 {
+		t.Errorf("siege delta = %v, want 4.0", delta)
+	}
+	if !result.SiegeModeActive || !result.SiegeModeChanged {
+		t.Errorf("siege result = %+v, want active and changed", result)
+	}
+
+	result = e.Tick(0.75, 0.5, 2)
+	if !result.SiegeModeActive || result.SiegeModeChanged {
+		t.Errorf("sustained siege result = %+v, want active and unchanged", result)
+	}
+
+	result = e.Tick(0.65, 0.5, 3)
+	if delta := result.NewCounter - result.PreviousCounter; delta != 2.0 {
+		t.Errorf("normal delta = %v, want 2.0", delta)
+	}
+	if result.SiegeModeActive || !result.SiegeModeChanged {
+		t.Errorf("lifted siege result = %+v, want inactive and changed", result)
+	}
+	if e.GetState().SiegeModeActive {
+		t.Error("state.SiegeModeActive = true after siege lifted")
+	}
+}
+
+func TestSiegeMode_ActiveWithoutAccumulation(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	if err := e.ForceSetCounter(10); err != nil {
+		t.Fatal(err)
+	}
+
+	// Trauma below trigger: the counter decays normally but siege mode is on
+	result := e.Tick(0.9, 0.1, 1)
+	if result.NewCounter != 9 {
+		t.Errorf("counter = %v, want 9", result.NewCounter)
+	}
+	if !result.SiegeModeActive {
+		t.Error("SiegeModeActive = false, want true")
+	}
+}
+
+func TestSiegeMode_ConfigValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.SiegeModeThreshold != 0.70 || cfg.SiegeModeMultiplier != 2.0 {
+		t.Errorf("siege defaults = %v, %v, want 0.70, 2.0", cfg.SiegeModeThreshold, cfg.SiegeModeMultiplier)
+	}
+
+	cfg.SiegeModeThreshold = 1.1
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for SiegeModeThreshold > 1")
+	}
+
+	cfg = DefaultConfig()
+	cfg.SiegeModeMultiplier = 0.5
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for SiegeModeMultiplier < 1")
+	}
+
+	cfg = DefaultConfig()
+	cfg.SiegeModeMultiplier = 1
+	e := NewEngine(cfg)
+	if result := e.Tick(0.9, 0.9, 1); result.NewCounter != 2.0 || !result.SiegeModeActive {
+		t.Errorf("multiplier 1 result = %+v, want counter 2 with siege active", result)
+	}
+}
+
+func TestSiegeMode_ZeroConfigNeverSieges(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SiegeModeThreshold, cfg.SiegeModeMultiplier = 0, 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil for a disabled siege mode", err)
+	}
+
+	e := NewEngine(cfg)
+	result := e.Tick(0.5, 0.5, 1)
+	if result.SiegeModeActive {
+		t.Error("SiegeModeActive = true with SiegeModeMultiplier 0")
+	}
+	if result.NewCounter != cfg.AccumulationRate {
+		t.Errorf("counter = %v, want %v", result.NewCounter, cfg.AccumulationRate)
+	}
+}
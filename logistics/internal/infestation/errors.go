@@ -0,0 +1,24 @@
+package infestation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPlagueHeartNotActive matches (via errors.Is) any *PlagueHeartNotActiveError.
+var ErrPlagueHeartNotActive = errors.New("plague heart not active")
+
+// PlagueHeartNotActiveError is returned when an operation requires an active
+// Plague Heart (e.g. Cleanse) but the infestation has not reached it.
+type PlagueHeartNotActiveError struct {
+	Counter float64 // Infestation counter at the time of the call
+}
+
+func (e *PlagueHeartNotActiveError) Error() string {
+	return fmt.Sprintf("cannot cleanse: Plague Heart is not active (counter %.1f)", e.Counter)
+}
+
+// Is reports whether target is ErrPlagueHeartNotActive.
+func (e *PlagueHeartNotActiveError) Is(target error) bool {
+	return target == ErrPlagueHeartNotActive
+}
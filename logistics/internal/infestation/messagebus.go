@@ -0,0 +1,62 @@
+package infestation
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// Message bus topics for Plague Heart state changes.
+const (
+	TopicPlagueHeartActivated = "infestation.plague_heart_activated"
+	TopicPlagueHeartCleared   = "infestation.plague_heart_cleared"
+)
+
+// MessageBusPublisher publishes a payload on a message bus topic (e.g.
+// *messagebus.NATSPublisher).
+type MessageBusPublisher interface {
+	Publish(topic string, payload []byte) error
+}
+
+// PlagueHeartEvent is the JSON payload published when Plague Heart toggles.
+type PlagueHeartEvent struct {
+	Counter   float64 `json:"counter"`
+	Tick      int64   `json:"tick"`
+	Timestamp string  `json:"timestamp"` // RFC 3339, UTC
+}
+
+// SetPublisher registers pub to receive Plague Heart activations and
+// clearances detected by Tick, on TopicPlagueHeartActivated and
+// TopicPlagueHeartCleared, replacing any previous publisher; nil removes it.
+// As with SetTelemetryService, changes made outside Tick are not published
+// and clones do not inherit the publisher. Publish errors are logged.
+func (e *Engine) SetPublisher(pub MessageBusPublisher) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.publisher = pub
+}
+
+// publishPlagueHeart publishes the Plague Heart toggle in result, produced
+// by tick tickNumber, to pub. Results without a toggle publish nothing.
+func publishPlagueHeart(pub MessageBusPublisher, result InfestationTickResult, tickNumber int64) {
+	if !result.PlagueHeartChanged {
+		return
+	}
+	topic := TopicPlagueHeartCleared
+	if result.PlagueHeartActive {
+		topic = TopicPlagueHeartActivated
+	}
+
+	payload, err := json.Marshal(PlagueHeartEvent{
+		Counter:   result.NewCounter,
+		Tick:      tickNumber,
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		log.Printf("[Infestation] Failed to encode %s event: %v", topic, err)
+		return
+	}
+	if err := pub.Publish(topic, payload); err != nil {
+		log.Printf("[Infestation] Failed to publish %s: %v", topic, err)
+	}
+}
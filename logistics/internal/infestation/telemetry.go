@@ -0,0 +1,51 @@
+package infestation
+
+// WarningThreshold is the counter level whose upward crossing during Tick
+// emits an infestation warning.
+const WarningThreshold = 50.0
+
+// TelemetrySink receives infestation state changes detected by Tick. The
+// gRPC telemetry service implements it.
+type TelemetrySink interface {
+	EmitInfestationWarning(level float64)
+	EmitPlagueHeartActivated(level float64)
+	EmitPlagueHeartCleared(level float64)
+	EmitSiegeModeActivated(avgRebellion float64)
+	EmitSiegeModeDeactivated(avgRebellion float64)
+}
+
+// SetTelemetryService registers svc to be notified by Tick, replacing any
+// previous sink; nil removes it. Tick emits a warning each time the counter
+// rises from below WarningThreshold to at or above it, and an activation or
+// cleared event each time Plague Heart toggles, passing the new counter, and
+// a siege mode activated or deactivated event each time siege mode toggles,
+// passing the tick's average rebellion.
+// Changes made outside Tick (ForceSetCounter, UpdateConfig, Cleanse,
+// SetState) are not reported, and clones do not inherit the sink.
+func (e *Engine) SetTelemetryService(svc TelemetrySink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.telemetry = svc
+}
+
+// notifyTelemetry reports the state changes in result, produced by a tick
+// with the given average rebellion, to sink.
+func notifyTelemetry(sink TelemetrySink, result InfestationTickResult, avgRebellion float64) {
+	if result.PreviousCounter < WarningThreshold && result.NewCounter >= WarningThreshold {
+		sink.EmitInfestationWarning(result.NewCounter)
+	}
+	if result.PlagueHeartChanged {
+		if result.PlagueHeartActive {
+			sink.EmitPlagueHeartActivated(result.NewCounter)
+		} else {
+			sink.EmitPlagueHeartCleared(result.NewCounter)
+		}
+	}
+	if result.SiegeModeChanged {
+		if result.SiegeModeActive {
+			sink.EmitSiegeModeActivated(avgRebellion)
+		} else {
+			sink.EmitSiegeModeDeactivated(avgRebellion)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package infestation
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type telemetryCall struct {
+	kind  string
+	level float64
+}
+
+type mockTelemetry struct {
+	calls []telemetryCall
+}
+
+func (m *mockTelemetry) EmitInfestationWarning(level float64) {
+	m.calls = append(m.calls, telemetryCall{"warning", level})
+}
+
+func (m *mockTelemetry) EmitPlagueHeartActivated(level float64) {
+	m.calls = append(m.calls, telemetryCall{"activated", level})
+}
+
+func (m *mockTelemetry) EmitPlagueHeartCleared(level float64) {
+	m.calls = append(m.calls, telemetryCall{"cleared", level})
+}
+
+func (m *mockTelemetry) EmitSiegeModeActivated(avgRebellion float64) {
+	m.calls = append(m.calls, telemetryCall{"siege-on", avgRebellion})
+}
+
+func (m *mockTelemetry) EmitSiegeModeDeactivated(avgRebellion float64) {
+	m.calls = append(m.calls, telemetryCall{"siege-off", avgRebellion})
+}
+
+func TestTick_EmitsTelemetryThroughActivationAndDeactivation(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	sink := &mockTelemetry{}
+	e.SetTelemetryService(sink)
+
+	// Accumulate 2/tick: crosses 50 on tick 25, activates on tick 50.
+	tick := int64(0)
+	for i := 0; i < 60; i++ {
+		tick++
+		e.Tick(0.6, 0.9, tick)
+	}
+	// Decay 1/tick from 100: clears below 75 on tick 26, crosses back under 50 later.
+	for i := 0; i < 60; i++ {
+		tick++
+		e.Tick(0, 0, tick)
+	}
+
+	want := []telemetryCall{
+		{"warning", 50},
+		{"activated", 100},
+		{"cleared", 74},
+	}
+	if len(sink.calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", sink.calls, want)
+	}
+	for i, c := range want {
+		if sink.calls[i] != c {
+			t.Errorf("call %d = %v, want %v", i, sink.calls[i], c)
+		}
+	}
+}
+
+func TestTick_WarningRearmsAfterDroppingBelowThreshold(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	sink := &mockTelemetry{}
+	e.SetTelemetryService(sink)
+
+	if err := e.ForceSetCounter(49); err != nil {
+		t.Fatal(err)
+	}
+	e.Tick(0.6, 0.9, 1) // 51
+	e.Tick(0, 0, 2)     // 50: still at threshold
+	e.Tick(0, 0, 3)     // 49
+	e.Tick(0.6, 0.9, 4) // 51
+
+	if len(sink.calls) != 2 {
+		t.Fatalf("calls = %v, want 2 warnings", sink.calls)
+	}
+	for _, c := range sink.calls {
+		if c != (telemetryCall{"warning", 51}) {
+			t.Errorf("call = %v, want warning at 51", c)
+		}
+	}
+}
+
+func TestTelemetry_NotEmittedOutsideTick(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	sink := &mockTelemetry{}
+	e.SetTelemetryService(sink)
+
+	e.ForceActivatePlagueHeart()
+	if err := e.Cleanse(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Clones do not inherit the sink: 40 → 50 crosses the warning threshold.
+	clone := e.Clone()
+	if err := clone.ForceSetCounter(40); err != nil {
+		t.Fatal(err)
+	}
+	clone.TickN(5, 0.6, 0.9)
+	if got := clone.GetState().Counter; got != 50 {
+		t.Fatalf("clone counter = %v, want 50", got)
+	}
+
+	if err := e.ForceSetCounter(100); err != nil {
+		t.Fatal(err)
+	}
+	ProjectInfestationScenarios(e.GetConfig(), e.GetState(), []InfestationScenario{
+		{Name: "decay", AvgRebellion: 0, AvgTrauma: 0},
+	}, 50)
+
+	if len(sink.calls) != 0 {
+		t.Errorf("calls = %v, want none", sink.calls)
+	}
+}
+
+func TestSetTelemetryService_NilRemovesSink(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	sink := &mockTelemetry{}
+	e.SetTelemetryService(sink)
+	e.SetTelemetryService(nil)
+
+	if err := e.ForceSetCounter(49); err != nil {
+		t.Fatal(err)
+	}
+	e.Tick(0.6, 0.9, 1)
+	if len(sink.calls) != 0 {
+		t.Errorf("calls = %v, want none", sink.calls)
+	}
+}
+
+func TestTick_EmitsSiegeModeTransitions(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	sink := &mockTelemetry{}
+	e.SetTelemetryService(sink)
+
+	e.Tick(0.75, 0.5, 1)
+	e.Tick(0.8, 0.5, 2)
+	e.Tick(0.5, 0.5, 3)
+	e.Tick(0.4, 0.5, 4)
+
+	want := []telemetryCall{{"siege-on", 0.75}, {"siege-off", 0.5}}
+	if len(sink.calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", sink.calls, want)
+	}
+	for i, c := range want {
+		if sink.calls[i] != c {
+			t.Errorf("call %d = %v, want %v", i, sink.calls[i], c)
+		}
+	}
+}
+
+type publishCall struct {
+	topic   string
+	payload []byte
+}
+
+type mockPublisher struct {
+	calls []publishCall
+}
+
+func (m *mockPublisher) Publish(topic string, payload []byte) error {
+	m.calls = append(m.calls, publishCall{topic, payload})
+	return nil
+}
+
+func TestTick_PublishesPlagueHeartToggles(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	pub := &mockPublisher{}
+	e.SetPublisher(pub)
+
+	tick := int64(0)
+	for i := 0; i < 60; i++ {
+		tick++
+		e.Tick(0.6, 0.9, tick)
+	}
+	for i := 0; i < 60; i++ {
+		tick++
+		e.Tick(0, 0, tick)
+	}
+
+	want := []struct {
+		topic   string
+		counter float64
+		tick    int64
+	}{
+		{TopicPlagueHeartActivated, 100, 50},
+		{TopicPlagueHeartCleared, 74, 86},
+	}
+	if len(pub.calls) != len(want) {
+		t.Fatalf("got %d publishes, want %d", len(pub.calls), len(want))
+	}
+	for i, w := range want {
+		call := pub.calls[i]
+		if call.topic != w.topic {
+			t.Errorf("publish %d topic = %q, want %q", i, call.topic, w.topic)
+		}
+		var event PlagueHeartEvent
+		if err := json.Unmarshal(call.payload, &event); err != nil {
+			t.Fatalf("publish %d payload is not valid JSON: %v", i, err)
+		}
+		if event.Counter != w.counter || event.Tick != w.tick || event.Timestamp == "" {
+			t.Errorf("publish %d event = %+v, want counter %v at tick %d", i, event, w.counter, w.tick)
+		}
+	}
+}
+
+func TestClone_DoesNotInheritPublisher(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	pub := &mockPublisher{}
+	e.SetPublisher(pub)
+	_ = e.ForceSetCounter(99)
+
+	clone := e.Clone()
+	clone.Tick(0.6, 0.9, 1)
+	if !clone.GetState().IsPlagueHeart {
+		t.Fatal("clone should activate Plague Heart")
+	}
+	if len(pub.calls) != 0 {
+		t.Errorf("clone published %d events, want 0", len(pub.calls))
+	}
+}
+
+func TestTickDeferred_NotifiesOnlyWhenCalled(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	if err := e.ForceSetCounter(e.GetConfig().PlagueHeartThreshold - 1); err != nil {
+		t.Fatal(err)
+	}
+	sink := &mockTelemetry{}
+	pub := &mockPublisher{}
+	e.SetTelemetryService(sink)
+	e.SetPublisher(pub)
+
+	result, notify := e.TickDeferred(0.6, 0.9, 1)
+	if !result.PlagueHeartChanged || !result.PlagueHeartActive {
+		t.Fatalf("result = %+v, want Plague Heart activated", result)
+	}
+	if len(sink.calls) != 0 || len(pub.calls) != 0 {
+		t.Fatalf("notified before notify was called: %v, %d publishes", sink.calls, len(pub.calls))
+	}
+
+	notify()
+	if len(sink.calls) != 1 || sink.calls[0].kind != "activated" {
+		t.Errorf("calls = %v, want one activation", sink.calls)
+	}
+	if len(pub.calls) != 1 || pub.calls[0].topic != TopicPlagueHeartActivated {
+		t.Errorf("got %d publishes, want one activation", len(pub.calls))
+	}
+
+	if _, notify := NewEngine(DefaultConfig()).TickDeferred(0.6, 0.9, 1); notify != nil {
+		t.Error("notify != nil without a sink or publisher")
+	}
+}
@@ -1,23 +1,39 @@
 package infestation
 
+import "fmt"
+
 // InfestationState represents the current plague heart infestation level.
 // Counter ranges from 0-100. At 100, Plague Heart activates and throttles production.
 type InfestationState struct {
 	Counter            float64 // 0-100: current infestation level
 	IsPlagueHeart      bool    // true when counter >= PlagueHeartThreshold
-	ThrottleMultiplier float64 // 1.0 normal, ThrottleAmount when plague heart active
+	ThrottleMultiplier float64 // 1.0 normal, ThrottleAmount (less escalation) when plague heart active
 	LastTick           int64   // tick when last updated
+
+	PlagueHeartActivatedAt int64 // tick when Plague Heart last activated (0 when inactive)
+	EscalationLevel        int   // number of escalation stages reached (0 when inactive)
+	SiegeModeActive        bool  // true when the last tick's avg rebellion was >= SiegeModeThreshold
+}
+
+// EscalationStage further throttles production once Plague Heart has been
+// active for DurationTicks ticks.
+type EscalationStage struct {
+	DurationTicks               int64   // Ticks since activation at which the stage applies
+	AdditionalThrottleReduction float64 // Subtracted from ThrottleMultiplier (floored at 0)
 }
 
 // InfestationConfig defines accumulation/decay rates and thresholds.
 type InfestationConfig struct {
-	AccumulationRate      float64 // Counter increase per tick when conditions met (default: 2.0)
-	DecayRate             float64 // Counter decrease per tick when conditions not met (default: 1.0)
-	PlagueHeartThreshold  float64 // Counter value to activate plague heart (default: 100)
-	ClearThreshold        float64 // Counter must drop below this to clear plague heart (hysteresis, default: 75)
-	ThrottleAmount        float64 // Production multiplier when plague heart active (default: 0.50)
-	RebellionTrigger      float64 // Avg rebellion must exceed this for accumulation (default: 0.35)
-	TraumaTrigger         float64 // Avg trauma must exceed this for accumulation (default: 0.40)
+	AccumulationRate     float64           // Counter increase per tick when conditions met (default: 2.0)
+	DecayRate            float64           // Counter decrease per tick when conditions not met (default: 1.0)
+	PlagueHeartThreshold float64           // Counter value to activate plague heart (default: 100)
+	ClearThreshold       float64           // Counter must drop below this to clear plague heart (hysteresis, default: 75)
+	ThrottleAmount       float64           // Production multiplier when plague heart active (default: 0.50)
+	RebellionTrigger     float64           // Avg rebellion must exceed this for accumulation (default: 0.35)
+	TraumaTrigger        float64           // Avg trauma must exceed this for accumulation (default: 0.40)
+	EscalationStages     []EscalationStage // Prolonged Plague Heart escalation (default: none)
+	SiegeModeThreshold   float64           // Avg rebellion at or above which siege mode is active (default: 0.70)
+	SiegeModeMultiplier  float64           // AccumulationRate multiplier during siege mode (default: 2.0; 1 keeps the rate, 0 disables siege mode)
 }
 
 // InfestationTickResult describes what happened in a single infestation tick.
@@ -27,6 +43,14 @@ type InfestationTickResult struct {
 	Accumulated        bool // true if counter increased this tick
 	PlagueHeartChanged bool // true if plague heart status toggled
 	PlagueHeartActive  bool // current plague heart status after tick
+	SiegeModeChanged   bool // true if siege mode toggled
+	SiegeModeActive    bool // current siege mode status after tick
+}
+
+// InfestationInput holds the per-tick inputs for TickNVariable.
+type InfestationInput struct {
+	AvgRebellion float64
+	AvgTrauma    float64
 }
 
 // DefaultConfig returns balanced default infestation configuration.
@@ -39,5 +63,72 @@ func DefaultConfig() InfestationConfig {
 		ThrottleAmount:       0.50,
 		RebellionTrigger:     0.35,
 		TraumaTrigger:        0.40,
+		SiegeModeThreshold:   0.70,
+		SiegeModeMultiplier:  2.0,
+	}
+}
+
+// Validate returns an error if a rate is not positive, a threshold is out of
+// range (0 <= ClearThreshold <= PlagueHeartThreshold), ThrottleAmount, a
+// trigger or SiegeModeThreshold is outside [0, 1], SiegeModeMultiplier is
+// neither 0 nor at least 1, or an escalation stage has a non-positive duration or a reduction
+// outside [0, 1].
+func (c InfestationConfig) Validate() error {
+	if c.AccumulationRate <= 0 {
+		return fmt.Errorf("AccumulationRate must be positive, got %v", c.AccumulationRate)
+	}
+	if c.DecayRate <= 0 {
+		return fmt.Errorf("DecayRate must be positive, got %v", c.DecayRate)
+	}
+	if c.PlagueHeartThreshold <= 0 {
+		return fmt.Errorf("PlagueHeartThreshold must be positive, got %v", c.PlagueHeartThreshold)
+	}
+	if c.ClearThreshold < 0 || c.ClearThreshold > c.PlagueHeartThreshold {
+		return fmt.Errorf("ClearThreshold must be in [0, %v], got %v", c.PlagueHeartThreshold, c.ClearThreshold)
 	}
+	if c.ThrottleAmount < 0 || c.ThrottleAmount > 1 {
+		return fmt.Errorf("ThrottleAmount must be in [0, 1], got %v", c.ThrottleAmount)
+	}
+	if c.RebellionTrigger < 0 || c.RebellionTrigger > 1 {
+		return fmt.Errorf("RebellionTrigger must be in [0, 1], got %v", c.RebellionTrigger)
+	}
+	if c.TraumaTrigger < 0 || c.TraumaTrigger > 1 {
+		return fmt.Errorf("TraumaTrigger must be in [0, 1], got %v", c.TraumaTrigger)
+	}
+	if c.SiegeModeThreshold < 0 || c.SiegeModeThreshold > 1 {
+		return fmt.Errorf("SiegeModeThreshold must be in [0, 1], got %v", c.SiegeModeThreshold)
+	}
+	if c.SiegeModeMultiplier != 0 && c.SiegeModeMultiplier < 1 {
+		return fmt.Errorf("SiegeModeMultiplier must be 0 or at least 1, got %v", c.SiegeModeMultiplier)
+	}
+	for i, stage := range c.EscalationStages {
+		if stage.DurationTicks <= 0 {
+			return fmt.Errorf("EscalationStages[%d].DurationTicks must be positive, got %d", i, stage.DurationTicks)
+		}
+		if stage.AdditionalThrottleReduction < 0 || stage.AdditionalThrottleReduction > 1 {
+			return fmt.Errorf("EscalationStages[%d].AdditionalThrottleReduction must be in [0, 1], got %v", i, stage.AdditionalThrottleReduction)
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of c that shares no memory with it.
+func (c InfestationConfig) clone() InfestationConfig {
+	c.EscalationStages = append([]EscalationStage(nil), c.EscalationStages...)
+	return c
+}
+
+// InfestationScenario describes constant per-tick inputs for a projection.
+type InfestationScenario struct {
+	Name         string
+	AvgRebellion float64
+	AvgTrauma    float64
+}
+
+// InfestationProjection is the projected outcome of one scenario.
+type InfestationProjection struct {
+	ScenarioName         string
+	FinalCounter         float64
+	PlagueHeartActivates bool
+	TickOfActivation     int // 1-based tick on which Plague Heart activates (-1 if never)
 }
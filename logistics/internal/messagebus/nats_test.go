@@ -0,0 +1,178 @@
+package messagebus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// message is a PUB received by fakeNATSServer.
+type message struct {
+	subject string
+	payload string
+}
+
+// fakeNATSServer speaks enough of the NATS protocol to accept publishes.
+type fakeNATSServer struct {
+	ln       net.Listener
+	info     string
+	mu       sync.Mutex
+	connects []string // CONNECT options JSON, per connection
+	messages []message
+	conns    []net.Conn
+}
+
+func newFakeNATSServer(t *testing.T, info string) *fakeNATSServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeNATSServer{ln: ln, info: info}
+	go s.accept()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *fakeNATSServer) url() string { return "nats://" + s.ln.Addr().String() }
+
+func (s *fakeNATSServer) accept() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		go s.serve(conn)
+	}
+}
+
+func (s *fakeNATSServer) serve(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO %s\r\n", s.info)
+	r := bufio.NewReader(conn)
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return
+		}
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			s.mu.Lock()
+			s.connects = append(s.connects, strings.TrimPrefix(line, "CONNECT "))
+			s.mu.Unlock()
+		case line == "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case strings.HasPrefix(line, "PUB "):
+			fields := strings.Fields(line)
+			n, _ := strconv.Atoi(fields[len(fields)-1])
+			buf := make([]byte, n+2)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, message{subject: fields[1], payload: string(buf[:n])})
+			s.mu.Unlock()
+		}
+	}
+}
+
+// readLine reads one protocol line without its trailing CRLF.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (s *fakeNATSServer) received() []message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]message(nil), s.messages...)
+}
+
+// dropConnections closes every connection the server has accepted.
+func (s *fakeNATSServer) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.conns {
+		c.Close()
+	}
+}
+
+func waitForMessages(t *testing.T, s *fakeNATSServer, n int) []message {
+	t.Helper()
+	require.Eventually(t, func() bool { return len(s.received()) >= n }, 5*time.Second, 5*time.Millisecond)
+	return s.received()
+}
+
+func TestNATSPublisher_Publish(t *testing.T) {
+	srv := newFakeNATSServer(t, `{"server_id":"test","max_payload":1048576}`)
+	pub, err := NewNATSPublisher(srv.url())
+	require.NoError(t, err)
+	defer pub.Close()
+
+	require.NoError(t, pub.Publish("rebellion.halt_triggered", []byte(`{"npc_id":"npc-1"}`)))
+	require.NoError(t, pub.Publish("infestation.plague_heart_cleared", []byte("")))
+
+	msgs := waitForMessages(t, srv, 2)
+	assert.Equal(t, message{"rebellion.halt_triggered", `{"npc_id":"npc-1"}`}, msgs[0])
+	assert.Equal(t, message{"infestation.plague_heart_cleared", ""}, msgs[1])
+}
+
+func TestNATSPublisher_SendsCredentials(t *testing.T) {
+	srv := newFakeNATSServer(t, `{"auth_required":true,"max_payload":1048576}`)
+	pub, err := NewNATSPublisher("nats://epoch:secret@" + srv.ln.Addr().String())
+	require.NoError(t, err)
+	defer pub.Close()
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	require.Len(t, srv.connects, 1)
+	assert.Contains(t, srv.connects[0], `"user":"epoch"`)
+	assert.Contains(t, srv.connects[0], `"pass":"secret"`)
+}
+
+func TestNATSPublisher_ReconnectsAfterDroppedConnection(t *testing.T) {
+	srv := newFakeNATSServer(t, `{"max_payload":1048576}`)
+	pub, err := NewNATSPublisher(srv.url())
+	require.NoError(t, err)
+	defer pub.Close()
+
+	srv.dropConnections()
+	require.Eventually(t, func() bool {
+		return pub.conn.Stats().Reconnects > 0
+	}, 5*time.Second, 5*time.Millisecond, "the client reconnects in the background")
+
+	require.NoError(t, pub.Publish("rebellion.halt_triggered", []byte("{}")))
+	assert.Len(t, waitForMessages(t, srv, 1), 1)
+}
+
+func TestNATSPublisher_Errors(t *testing.T) {
+	_, err := NewNATSPublisher("http://localhost:4222")
+	assert.Error(t, err, "only nats:// URLs are accepted")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+	_, err = NewNATSPublisher(addr)
+	assert.ErrorContains(t, err, "connect to NATS", "no server listening")
+
+	srv := newFakeNATSServer(t, `{"max_payload":1048576}`)
+	pub, err := NewNATSPublisher(strings.TrimPrefix(srv.url(), "nats://"))
+	require.NoError(t, err, "the scheme is optional")
+	assert.Error(t, pub.Publish("bad subject", nil))
+	require.NoError(t, pub.Close())
+	assert.Error(t, pub.Publish("rebellion.halt_triggered", nil))
+}
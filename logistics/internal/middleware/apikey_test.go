@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIKey = "3f9a1c0e5b7d2a4c6e8f0a1b3c5d7e9f1a2b3c4d5e6f708192a3b4c5d6e7f809"
+
+func newAPIKeyRouter(keys ...string) *gin.Engine {
+	r := gin.New()
+	r.Use(NewAPIKeyMiddleware(keys))
+	r.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	r.GET("/api/simulation/status", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func doAPIKeyRequest(r *gin.Engine, path, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKey_MissingHeader(t *testing.T) {
+	w := doAPIKeyRequest(newAPIKeyRouter(testAPIKey), "/api/simulation/status", "")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.JSONEq(t, `{"error":"unauthorized"}`, w.Body.String())
+}
+
+func TestAPIKey_WrongKey(t *testing.T) {
+	w := doAPIKeyRequest(newAPIKeyRouter(testAPIKey), "/api/simulation/status", "not-the-key")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.JSONEq(t, `{"error":"unauthorized"}`, w.Body.String())
+}
+
+func TestAPIKey_CorrectKey(t *testing.T) {
+	r := newAPIKeyRouter("other-key", testAPIKey)
+
+	w := doAPIKeyRequest(r, "/api/simulation/status", testAPIKey)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = doAPIKeyRequest(r, "/api/simulation/status", "other-key")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAPIKey_HealthBypassesAuth(t *testing.T) {
+	w := doAPIKeyRequest(newAPIKeyRouter(testAPIKey), "/health", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAPIKey_NoKeysConfiguredRejectsAll(t *testing.T) {
+	w := doAPIKeyRequest(newAPIKeyRouter(), "/api/simulation/status", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAPIKey_ComparesEveryKeyInConstantTime(t *testing.T) {
+	require.Equal(t, reflect.ValueOf(subtle.ConstantTimeCompare).Pointer(), reflect.ValueOf(constantTimeCompare).Pointer())
+
+	calls := 0
+	constantTimeCompare = func(x, y []byte) int {
+		calls++
+		return subtle.ConstantTimeCompare(x, y)
+	}
+	t.Cleanup(func() { constantTimeCompare = subtle.ConstantTimeCompare })
+
+	r := newAPIKeyRouter(testAPIKey, "other-key", "third-key")
+	// Same length as the valid key, differing only in the last character,
+	// which is the worst case for a naive byte-by-byte comparison.
+	invalid := testAPIKey[:len(testAPIKey)-1] + "0"
+
+	for _, key := range []string{testAPIKey, "third-key", invalid} {
+		calls = 0
+		doAPIKeyRequest(r, "/api/simulation/status", key)
+		assert.Equal(t, 3, calls, "key %q: every configured key must be compared", key)
+	}
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	k1, err := GenerateAPIKey()
+	require.NoError(t, err)
+	k2, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	assert.Len(t, k1, 64)
+	assert.NotEqual(t, k1, k2)
+}
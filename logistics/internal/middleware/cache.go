@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bufferedWriter captures a handler's status and body so that headers
+// derived from the body (ETag) can be set before anything is sent.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int)              { w.status = code }
+func (w *bufferedWriter) WriteHeaderNow()                   {}
+func (w *bufferedWriter) Write(b []byte) (int, error)       { return w.body.Write(b) }
+func (w *bufferedWriter) WriteString(s string) (int, error) { return w.body.WriteString(s) }
+func (w *bufferedWriter) Written() bool                     { return w.status != 0 || w.body.Len() > 0 }
+func (w *bufferedWriter) Size() int                         { return w.body.Len() }
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// NewCacheMiddleware returns a handler that adds conditional-request support
+// to GET/HEAD responses and disables caching for everything else.
+//
+// Successful GET responses are buffered and tagged with an ETag derived from
+// the first 8 bytes of the SHA-256 of the body, plus
+// "Cache-Control: private, max-age=<maxAge>". A request whose If-None-Match
+// matches the current ETag receives 304 Not Modified with no body.
+// Mutating requests (POST etc.) receive "Cache-Control: no-cache".
+func NewCacheMiddleware(maxAge time.Duration) gin.HandlerFunc {
+	cacheControl := fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if method != http.MethodGet && method != http.MethodHead {
+			c.Header("Cache-Control", "no-cache")
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buf := &bufferedWriter{ResponseWriter: original}
+		c.Writer = buf
+		c.Next()
+		c.Writer = original
+
+		if buf.Status() != http.StatusOK {
+			original.WriteHeader(buf.Status())
+			original.WriteHeaderNow()
+			_, _ = original.Write(buf.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(buf.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+		original.Header().Set("ETag", etag)
+		original.Header().Set("Cache-Control", cacheControl)
+
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+
+		original.WriteHeader(http.StatusOK)
+		original.WriteHeaderNow()
+		_, _ = original.Write(buf.body.Bytes())
+	}
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag,
+// using the weak comparison RFC 9110 prescribes for If-None-Match.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/simulation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheRouter(simEngine *simulation.SimulationEngine) *gin.Engine {
+	r := gin.New()
+	r.Use(NewCacheMiddleware(time.Second))
+	r.GET("/api/simulation/status", NewGzipMiddleware(), func(c *gin.Context) {
+		status := simEngine.GetStatus()
+		c.JSON(http.StatusOK, gin.H{
+			"tick_count":        status.TickCount,
+			"infestation_level": status.InfestationLevel,
+		})
+	})
+	r.GET("/missing", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	})
+	r.POST("/api/simulation/tick", func(c *gin.Context) {
+		simEngine.Tick()
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func getStatus(r *gin.Engine, etag string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/simulation/status", nil)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCache_ETagAndNotModified(t *testing.T) {
+	simEngine := simulation.NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	r := newCacheRouter(simEngine)
+
+	first := getStatus(r, "")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Len(t, etag, 18, "quoted 8-byte hex digest")
+	assert.Equal(t, "private, max-age=1", first.Header().Get("Cache-Control"))
+	assert.NotEmpty(t, first.Body.String())
+
+	second := getStatus(r, etag)
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Empty(t, second.Body.String())
+	assert.Equal(t, etag, second.Header().Get("ETag"))
+
+	simEngine.Tick()
+
+	third := getStatus(r, etag)
+	assert.Equal(t, http.StatusOK, third.Code)
+	assert.NotEqual(t, etag, third.Header().Get("ETag"))
+	assert.NotEmpty(t, third.Body.String())
+}
+
+func TestCache_NonOKResponsesPassThrough(t *testing.T) {
+	r := newCacheRouter(simulation.NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig())))
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.JSONEq(t, `{"error":"not found"}`, w.Body.String())
+}
+
+func TestCache_PostIsNoCache(t *testing.T) {
+	r := newCacheRouter(simulation.NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig())))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/simulation/tick", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Empty(t, w.Header().Get("ETag"))
+}
+
+func TestGzip_CompressesWhenAccepted(t *testing.T) {
+	r := newCacheRouter(simulation.NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig())))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/simulation/status", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+	zr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"tick_count":0`)
+
+	// Conditional request still works on the compressed representation
+	again := httptest.NewRequest(http.MethodGet, "/api/simulation/status", nil)
+	again.Header.Set("Accept-Encoding", "gzip")
+	again.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w2 := httptest.NewRecorder()
+	r.ServeHTTP(w2, again)
+	assert.Equal(t, http.StatusNotModified, w2.Code)
+	assert.Empty(t, w2.Body.String())
+}
+
+func TestGzip_PlainWhenNotAccepted(t *testing.T) {
+	r := newCacheRouter(simulation.NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig())))
+
+	w := getStatus(r, "")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Contains(t, w.Body.String(), `"tick_count":0`)
+}
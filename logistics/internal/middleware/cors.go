@@ -0,0 +1,105 @@
+// Package middleware provides Gin middleware for the logistics HTTP server.
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig controls which browser origins may call the REST API.
+type CORSConfig struct {
+	AllowedOrigins []string // Exact origins, or "*" for any origin
+	AllowedMethods []string
+	AllowedHeaders []string
+	MaxAge         int // Preflight cache lifetime in seconds (0 = omit header)
+}
+
+// DefaultCORSConfig returns a config with the methods and headers used by
+// the logistics API and no allowed origins.
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodOptions},
+		AllowedHeaders: []string{"Content-Type", "Authorization", APIKeyHeader, TraceIDHeader},
+		MaxAge:         600,
+	}
+}
+
+// ParseCSV splits a comma-separated environment value (such as
+// CORS_ALLOWED_ORIGINS or API_KEYS), trimming whitespace and dropping
+// empty entries.
+func ParseCSV(raw string) []string {
+	var values []string
+	for _, v := range strings.Split(raw, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// NewCORSMiddleware returns a handler that sets CORS response headers for
+// allowed origins and answers preflight OPTIONS requests with 204.
+//
+// When AllowedOrigins contains "*", every origin receives the wildcard
+// header and credentials are never allowed (browsers reject that combination).
+// Otherwise the request origin is echoed back only if it is listed, and
+// credentialed requests are permitted.
+func NewCORSMiddleware(cfg CORSConfig) gin.HandlerFunc {
+	wildcard := false
+	allowed := make(map[string]struct{}, len(cfg.AllowedOrigins))
+	for _, o := range cfg.AllowedOrigins {
+		if o == "*" {
+			wildcard = true
+		}
+		allowed[o] = struct{}{}
+	}
+
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	maxAge := ""
+	if cfg.MaxAge > 0 {
+		maxAge = strconv.Itoa(cfg.MaxAge)
+	}
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions &&
+			c.GetHeader("Access-Control-Request-Method") != ""
+
+		originAllowed := false
+		if origin != "" {
+			if wildcard {
+				originAllowed = true
+				c.Header("Access-Control-Allow-Origin", "*")
+			} else if _, ok := allowed[origin]; ok {
+				originAllowed = true
+				c.Header("Access-Control-Allow-Origin", origin)
+				c.Header("Access-Control-Allow-Credentials", "true")
+			}
+			if !wildcard {
+				c.Writer.Header().Add("Vary", "Origin")
+			}
+		}
+
+		if !preflight {
+			c.Next()
+			return
+		}
+
+		if originAllowed {
+			if methods != "" {
+				c.Header("Access-Control-Allow-Methods", methods)
+			}
+			if headers != "" {
+				c.Header("Access-Control-Allow-Headers", headers)
+			}
+			if maxAge != "" {
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
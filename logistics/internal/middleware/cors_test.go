@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newCORSRouter(cfg CORSConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(NewCORSMiddleware(cfg))
+	r.GET("/api/simulation/status", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func corsTestConfig(origins ...string) CORSConfig {
+	cfg := DefaultCORSConfig()
+	cfg.AllowedOrigins = origins
+	return cfg
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	r := newCORSRouter(corsTestConfig("https://dashboard.example.com"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/simulation/status", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://dashboard.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+}
+
+func TestCORS_UnlistedOrigin(t *testing.T) {
+	r := newCORSRouter(corsTestConfig("https://dashboard.example.com"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/simulation/status", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	r := newCORSRouter(corsTestConfig("https://dashboard.example.com"))
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/simulation/status", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://dashboard.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type, Authorization, X-API-Key, X-Request-ID", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORS_PreflightUnlistedOrigin(t *testing.T) {
+	r := newCORSRouter(corsTestConfig("https://dashboard.example.com"))
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/simulation/status", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestCORS_Wildcard(t *testing.T) {
+	r := newCORSRouter(corsTestConfig("*"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/simulation/status", nil)
+	req.Header.Set("Origin", "https://anywhere.example.com")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"), "wildcard mode must not allow credentials")
+}
+
+func TestParseCSV(t *testing.T) {
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"},
+		ParseCSV(" https://a.example.com, ,https://b.example.com "))
+	assert.Nil(t, ParseCSV(""))
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gzipWriter compresses the response body once the handler starts writing.
+// Responses without a body (304, 204) are passed through untouched.
+type gzipWriter struct {
+	gin.ResponseWriter
+	gz *gzip.Writer
+}
+
+func (w *gzipWriter) Write(b []byte) (int, error) {
+	if w.gz == nil {
+		h := w.ResponseWriter.Header()
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	return w.gz.Write(b)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// NewGzipMiddleware returns a handler that gzip-compresses response bodies
+// for clients that send "Accept-Encoding: gzip".
+func NewGzipMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(c.Request) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		gw := &gzipWriter{ResponseWriter: original}
+		c.Writer = gw
+		c.Next()
+		c.Writer = original
+
+		if gw.gz != nil {
+			_ = gw.gz.Close()
+		}
+	}
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		enc = strings.TrimSpace(enc)
+		if i := strings.IndexByte(enc, ';'); i >= 0 {
+			enc = strings.TrimSpace(enc[:i])
+		}
+		if enc == "gzip" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxBodyBytes is the request body limit used by the logistics server.
+const DefaultMaxBodyBytes int64 = 1 << 20 // 1 MiB
+
+// NewRequestLimiterMiddleware returns a handler that bounds request bodies
+// and per-request time.
+//
+// Bodies larger than maxBodyBytes are rejected with 413 before the route
+// handler runs; accepted bodies are buffered so handlers see an ordinary
+// reader. readTimeout bounds reading the body and writeTimeout bounds the
+// rest of the request: it sets the connection write deadline and the
+// request context deadline, so slow handlers can observe ctx.Done().
+// Zero timeouts are ignored.
+func NewRequestLimiterMiddleware(maxBodyBytes int64, readTimeout, writeTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rc := http.NewResponseController(c.Writer)
+
+		if c.Request.ContentLength > maxBodyBytes {
+			abortTooLarge(c, maxBodyBytes)
+			return
+		}
+
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			if readTimeout > 0 {
+				_ = rc.SetReadDeadline(time.Now().Add(readTimeout))
+			}
+			body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBodyBytes+1))
+			_ = c.Request.Body.Close()
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+				return
+			}
+			if int64(len(body)) > maxBodyBytes {
+				abortTooLarge(c, maxBodyBytes)
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		if writeTimeout > 0 {
+			_ = rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+			ctx, cancel := context.WithTimeout(c.Request.Context(), writeTimeout)
+			defer cancel()
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		c.Next()
+	}
+}
+
+func abortTooLarge(c *gin.Context, limit int64) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error":     "request body too large",
+		"max_bytes": limit,
+	})
+}
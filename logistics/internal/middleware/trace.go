@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/tracing"
+)
+
+// TraceIDHeader is the request and response header carrying the trace ID.
+const TraceIDHeader = "X-Request-ID"
+
+// TraceIDKey is the gin context key under which NewTraceMiddleware stores the
+// trace ID.
+const TraceIDKey = "trace_id"
+
+// validTraceID limits client-supplied trace IDs to a length and character
+// set that is safe to write to logs.
+var validTraceID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// NewTraceMiddleware returns a handler that assigns each request a trace ID:
+// the X-Request-ID header if the client sent a usable one, otherwise a new
+// UUID. The ID is stored in the gin context under TraceIDKey, attached to the
+// request's context.Context (see tracing.GetTraceID) and echoed in the
+// X-Request-ID response header.
+func NewTraceMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceID := c.GetHeader(TraceIDHeader)
+		if !validTraceID.MatchString(traceID) {
+			traceID = tracing.NewTraceID()
+		}
+
+		c.Set(TraceIDKey, traceID)
+		c.Request = c.Request.WithContext(tracing.WithTraceID(c.Request.Context(), traceID))
+		c.Header(TraceIDHeader, traceID)
+		c.Next()
+	}
+}
+
+// TraceLogFormatter formats gin access log lines like gin's default
+// formatter, with the request's trace ID appended.
+func TraceLogFormatter(param gin.LogFormatterParams) string {
+	traceID, _ := param.Keys[TraceIDKey].(string)
+	if traceID == "" {
+		traceID = "-"
+	}
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v | trace_id=%s\n%s",
+		param.TimeStamp.Format(time.RFC3339),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		param.Path,
+		traceID,
+		param.ErrorMessage,
+	)
+}
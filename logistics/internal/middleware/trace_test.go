@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTraceRouter returns a router whose handler reports the trace ID seen in
+// the gin context and in the request context.
+func newTraceRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(NewTraceMiddleware())
+	r.GET("/api/simulation/status", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"gin_trace_id": c.GetString(TraceIDKey),
+			"ctx_trace_id": tracing.GetTraceID(c.Request.Context()),
+		})
+	})
+	return r
+}
+
+func traceRequest(r *gin.Engine, requestID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/simulation/status", nil)
+	if requestID != "" {
+		req.Header.Set(TraceIDHeader, requestID)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestTraceMiddleware_EchoesRequestID(t *testing.T) {
+	w := traceRequest(newTraceRouter(), "req-abc-123")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "req-abc-123", w.Header().Get(TraceIDHeader))
+	assert.JSONEq(t, `{"gin_trace_id":"req-abc-123","ctx_trace_id":"req-abc-123"}`, w.Body.String())
+}
+
+func TestTraceMiddleware_GeneratesIDWhenMissing(t *testing.T) {
+	r := newTraceRouter()
+
+	first := traceRequest(r, "").Header().Get(TraceIDHeader)
+	second := traceRequest(r, "").Header().Get(TraceIDHeader)
+
+	assert.NotEmpty(t, first)
+	assert.NotEqual(t, first, second)
+}
+
+func TestTraceMiddleware_ReplacesUnsafeID(t *testing.T) {
+	got := traceRequest(newTraceRouter(), "bad id\nwith newline").Header().Get(TraceIDHeader)
+
+	assert.NotEmpty(t, got)
+	assert.NotContains(t, got, " ")
+}
+
+func TestTraceLogFormatter(t *testing.T) {
+	line := TraceLogFormatter(gin.LogFormatterParams{
+		Request:    httptest.NewRequest(http.MethodGet, "/x", nil),
+		StatusCode: http.StatusOK,
+		Method:     http.MethodGet,
+		Path:       "/x",
+		Keys:       map[string]any{TraceIDKey: "req-1"},
+	})
+
+	assert.Contains(t, line, "trace_id=req-1")
+	assert.Contains(t, TraceLogFormatter(gin.LogFormatterParams{}), "trace_id=-")
+}
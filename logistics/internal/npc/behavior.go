@@ -1,51 +1,128 @@
 package npc
 
 import (
-	"fmt"
 	"math"
+	"math/rand"
+	"sort"
 	"sync"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
 )
 
 // NPCBehavior represents the behavioral state of a single NPC in the simulation.
 type NPCBehavior struct {
 	NPCID          string
-	Role           string  // NPC role: "worker", "warrior", "guard"
-	WorkEfficiency float64 // 0.0-1.0: current work output efficiency
-	Morale         float64 // 0.0-1.0: current morale level
-	AssignedTask   string  // Current task assignment (empty if unassigned)
+	Role           string         // NPC role: "worker", "warrior", "guard"
+	WorkEfficiency float64        // 0.0-1.0: current work output efficiency
+	Morale         float64        // 0.0-1.0: current morale level
+	AvgTrauma      float64        // 0.0-1.0: average trauma score
+	Confidence     float64        // 0.0-1.0: combat/operational confidence
+	AssignedTask   string         // Current task assignment (empty if unassigned)
+	EmotionalState EmotionalState // Mood band derived from Morale
+
+	PendingBreakdown *BreakdownRecord // Unresolved mental breakdown (nil if none)
+	StatHistory      []*StatChange    // Most recent attribute changes, oldest first (see MaxStatHistory)
+
+	// Most recent recorded rebellion probabilities, oldest first (see
+	// MaxProbabilityHistory)
+	ProbabilityHistory []float64
+}
+
+// Defaults for newly registered NPCs.
+const (
+	defaultWorkEfficiency = 0.5
+	defaultMorale         = 0.5
+	defaultAvgTrauma      = 0.0
+	defaultConfidence     = 0.5
+)
+
+// floatEpsilon is the tolerance used when comparing float64 NPC attributes.
+const floatEpsilon = 1e-9
+
+// Clone returns a deep copy of the NPC behavior. Mutating the copy does not
+// affect the original.
+func (n *NPCBehavior) Clone() *NPCBehavior {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	if n.PendingBreakdown != nil {
+		record := *n.PendingBreakdown
+		clone.PendingBreakdown = &record
+	}
+	if n.StatHistory != nil {
+		clone.StatHistory = cloneStatHistory(n.StatHistory)
+	}
+	if n.ProbabilityHistory != nil {
+		clone.ProbabilityHistory = append([]float64(nil), n.ProbabilityHistory...)
+	}
+	return &clone
+}
+
+// Equal reports whether two NPC behaviors have identical fields, comparing
+// float64 attributes with an absolute tolerance of 1e-9. EmotionalState is
+// derived from Morale and, like the transient PendingBreakdown, StatHistory
+// and ProbabilityHistory, is not compared.
+func (n *NPCBehavior) Equal(other *NPCBehavior) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	return n.NPCID == other.NPCID &&
+		n.Role == other.Role &&
+		n.AssignedTask == other.AssignedTask &&
+		math.Abs(n.WorkEfficiency-other.WorkEfficiency) <= floatEpsilon &&
+		math.Abs(n.Morale-other.Morale) <= floatEpsilon &&
+		math.Abs(n.AvgTrauma-other.AvgTrauma) <= floatEpsilon &&
+		math.Abs(n.Confidence-other.Confidence) <= floatEpsilon
 }
 
 // BehaviorEngine manages NPC behavioral states. It is safe for concurrent use.
 type BehaviorEngine struct {
-	npcs map[string]*NPCBehavior
-	mu   sync.RWMutex
+	npcs             map[string]*NPCBehavior
+	groups           map[string]map[string]struct{} // group ID → member NPC IDs
+	relationships    map[string]map[string]float64  // NPC ID → peer NPC ID → affinity (symmetric)
+	emotionListener  EmotionalStateListener         // optional; notified of state transitions
+	recoveryListener BreakdownRecoveryListener      // optional; notified of breakdown recoveries
+	registerListener RegistrationListener           // optional; notified of new registrations
+	randFn           func() float64                 // rolls breakdown recoveries
+	mu               sync.RWMutex
 }
 
 // NewBehaviorEngine creates a new BehaviorEngine with an empty NPC registry.
 func NewBehaviorEngine() *BehaviorEngine {
 	return &BehaviorEngine{
-		npcs: make(map[string]*NPCBehavior),
+		npcs:          make(map[string]*NPCBehavior),
+		groups:        make(map[string]map[string]struct{}),
+		relationships: make(map[string]map[string]float64),
+		randFn:        rand.Float64,
 	}
 }
 
-// RegisterNPC adds an NPC to tracking with default values (0.5 efficiency, 0.5 morale).
+// RegisterNPC adds an NPC to tracking with default values (0.5 efficiency,
+// 0.5 morale, 0.0 trauma, 0.5 confidence).
 // If the NPC is already registered, returns the existing entry without modification.
 func (b *BehaviorEngine) RegisterNPC(npcID string) *NPCBehavior {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	if existing, ok := b.npcs[npcID]; ok {
+		b.mu.Unlock()
 		return existing
 	}
 
 	npc := &NPCBehavior{
 		NPCID:          npcID,
 		Role:           "worker",
-		WorkEfficiency: 0.5,
-		Morale:         0.5,
+		WorkEfficiency: defaultWorkEfficiency,
+		Morale:         defaultMorale,
+		AvgTrauma:      defaultAvgTrauma,
+		Confidence:     defaultConfidence,
 		AssignedTask:   "",
+		EmotionalState: EmotionalStateForMorale(defaultMorale),
 	}
 	b.npcs[npcID] = npc
+	listener := b.registerListener
+	b.mu.Unlock()
+
+	notifyRegistration(listener, npcID)
 	return npc
 }
 
@@ -53,24 +130,69 @@ func (b *BehaviorEngine) RegisterNPC(npcID string) *NPCBehavior {
 // If the NPC is already registered, updates the role and returns the existing entry.
 func (b *BehaviorEngine) RegisterNPCWithRole(npcID, role string) *NPCBehavior {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	if existing, ok := b.npcs[npcID]; ok {
 		existing.Role = role
+		b.mu.Unlock()
 		return existing
 	}
 
 	npc := &NPCBehavior{
 		NPCID:          npcID,
 		Role:           role,
-		WorkEfficiency: 0.5,
-		Morale:         0.5,
+		WorkEfficiency: defaultWorkEfficiency,
+		Morale:         defaultMorale,
+		AvgTrauma:      defaultAvgTrauma,
+		Confidence:     defaultConfidence,
 		AssignedTask:   "",
+		EmotionalState: EmotionalStateForMorale(defaultMorale),
 	}
 	b.npcs[npcID] = npc
+	listener := b.registerListener
+	b.mu.Unlock()
+
+	notifyRegistration(listener, npcID)
 	return npc
 }
 
+// RegistrationListener is notified when RegisterNPC or RegisterNPCWithRole
+// adds a new NPC. It is called after the engine lock is released.
+type RegistrationListener func(npcID string)
+
+// SetRegistrationListener registers fn to receive the ID of every newly
+// registered NPC. Pass nil to remove it.
+func (b *BehaviorEngine) SetRegistrationListener(fn RegistrationListener) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.registerListener = fn
+}
+
+// notifyRegistration calls listener, if set, with npcID.
+func notifyRegistration(listener RegistrationListener, npcID string) {
+	if listener != nil {
+		listener(npcID)
+	}
+}
+
+// RebellionProfile returns npcID's current rebellion profile, or false if
+// the NPC is not registered. It implements rebellion.ProfileSource.
+func (b *BehaviorEngine) RebellionProfile(npcID string) (rebellion.NPCRebellionProfile, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	npc, ok := b.npcs[npcID]
+	if !ok {
+		return rebellion.NPCRebellionProfile{}, false
+	}
+	return rebellion.NPCRebellionProfile{
+		NPCID:          npc.NPCID,
+		AvgTrauma:      npc.AvgTrauma,
+		WorkEfficiency: npc.WorkEfficiency,
+		Morale:         npc.Morale,
+		Role:           npc.Role,
+		Confidence:     npc.Confidence,
+	}, true
+}
+
 // GetNPCsByRole returns all NPCs with the specified role.
 func (b *BehaviorEngine) GetNPCsByRole(role string) []*NPCBehavior {
 	b.mu.RLock()
@@ -85,7 +207,9 @@ func (b *BehaviorEngine) GetNPCsByRole(role string) []*NPCBehavior {
 	return result
 }
 
-// GetNPC returns the behavioral state of the specified NPC.
+// GetNPC returns a copy of the behavioral state of the specified NPC.
+// Mutating the returned value does not affect the engine; use the Apply*
+// methods to change NPC state.
 // Returns nil and false if the NPC is not registered.
 func (b *BehaviorEngine) GetNPC(npcID string) (*NPCBehavior, bool) {
 	b.mu.RLock()
@@ -95,41 +219,111 @@ func (b *BehaviorEngine) GetNPC(npcID string) (*NPCBehavior, bool) {
 	if !ok {
 		return nil, false
 	}
-	return npc, true
+	return npc.Clone(), true
 }
 
-// ApplyWorkEfficiencyModifier modifies an NPC's work efficiency by the given modifier.
-// The result is clamped to [0.0, 1.0].
+// GetNPCMutable returns a pointer to the live behavioral state of the specified
+// NPC. Callers that modify it are responsible for their own synchronization.
+// Returns nil and false if the NPC is not registered.
+func (b *BehaviorEngine) GetNPCMutable(npcID string) (*NPCBehavior, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	npc, ok := b.npcs[npcID]
+	return npc, ok
+}
+
+// ApplyWorkEfficiencyModifier modifies an NPC's work efficiency by the given
+// modifier, recording reason in its stat history. The result is clamped to
+// [0.0, 1.0].
 // Returns an error if the NPC is not registered.
-func (b *BehaviorEngine) ApplyWorkEfficiencyModifier(npcID string, modifier float64) error {
+func (b *BehaviorEngine) ApplyWorkEfficiencyModifier(npcID string, modifier float64, reason string) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
 	npc, ok := b.npcs[npcID]
 	if !ok {
-		return fmt.Errorf("NPC %q not found", npcID)
+		return &NPCNotFoundError{NpcID: npcID}
 	}
 
+	old := npc.WorkEfficiency
 	npc.WorkEfficiency = clamp(npc.WorkEfficiency+modifier, 0.0, 1.0)
+	npc.recordStatChange(StatWorkEfficiency, old, npc.WorkEfficiency, reason)
 	return nil
 }
 
-// ApplyMoraleModifier modifies an NPC's morale by the given modifier.
-// The result is clamped to [0.0, 1.0].
+// ApplyMoraleModifier modifies an NPC's morale by the given modifier,
+// recording reason in its stat history. The result is clamped to [0.0, 1.0]
+// and the NPC's EmotionalState is updated, notifying the emotional state
+// listener on a transition.
 // Returns an error if the NPC is not registered.
-func (b *BehaviorEngine) ApplyMoraleModifier(npcID string, modifier float64) error {
+func (b *BehaviorEngine) ApplyMoraleModifier(npcID string, modifier float64, reason string) error {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	npc, ok := b.npcs[npcID]
 	if !ok {
-		return fmt.Errorf("NPC %q not found", npcID)
+		b.mu.Unlock()
+		return &NPCNotFoundError{NpcID: npcID}
 	}
 
+	old := npc.Morale
 	npc.Morale = clamp(npc.Morale+modifier, 0.0, 1.0)
+	npc.recordStatChange(StatMorale, old, npc.Morale, reason)
+	transition, changed := npc.updateEmotionalState()
+	listener := b.emotionListener
+	b.mu.Unlock()
+
+	if changed {
+		notifyEmotionalTransitions(listener, []EmotionalTransition{transition})
+	}
 	return nil
 }
 
+// TickNPCs applies passive recovery over tickDelta ticks to every NPC: morale
+// rises by moraleRecoveryRate per tick (capped at 1.0) and trauma falls by
+// traumaDecayRate per tick (floored at 0.0).
+// Returns the IDs of NPCs whose morale changed, sorted.
+func (b *BehaviorEngine) TickNPCs(tickDelta int64, moraleRecoveryRate, traumaDecayRate float64) []string {
+	changed := make([]string, 0)
+	if tickDelta <= 0 {
+		return changed
+	}
+
+	b.mu.Lock()
+	ticks := float64(tickDelta)
+	var transitions []EmotionalTransition
+	for id, npc := range b.npcs {
+		if gain := math.Min(moraleRecoveryRate*ticks, 1.0-npc.Morale); gain > 0 {
+			npc.Morale += gain
+			changed = append(changed, id)
+			if t, ok := npc.updateEmotionalState(); ok {
+				transitions = append(transitions, t)
+			}
+		}
+		if decay := math.Min(traumaDecayRate*ticks, npc.AvgTrauma); decay > 0 {
+			npc.AvgTrauma -= decay
+		}
+	}
+	listener := b.emotionListener
+	b.mu.Unlock()
+
+	sort.Strings(changed)
+	notifyEmotionalTransitions(listener, transitions)
+	return changed
+}
+
+// SnapshotNPCs returns deep copies of all registered NPC behaviors, taken
+// under a single read lock so the values are mutually consistent.
+func (b *BehaviorEngine) SnapshotNPCs() []*NPCBehavior {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	result := make([]*NPCBehavior, 0, len(b.npcs))
+	for _, npc := range b.npcs {
+		result = append(result, npc.Clone())
+	}
+	return result
+}
+
 // GetAllNPCs returns a slice of all registered NPC behaviors.
 // The returned slice contains pointers to the actual NPC data.
 func (b *BehaviorEngine) GetAllNPCs() []*NPCBehavior {
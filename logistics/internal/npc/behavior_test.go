@@ -1,6 +1,8 @@
 package npc
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,11 +28,45 @@ func TestRegisterNPC_Duplicate(t *testing.T) {
 	assert.InDelta(t, 0.8, npc2.Morale, 0.001, "Should return existing NPC with modified morale")
 }
 
+func TestRegisterNPC_NotifiesRegistrationListener(t *testing.T) {
+	engine := NewBehaviorEngine()
+	var registered []string
+	engine.SetRegistrationListener(func(npcID string) {
+		engine.GetAllNPCs() // lock must be released
+		registered = append(registered, npcID)
+	})
+
+	engine.RegisterNPC("npc-001")
+	engine.RegisterNPCWithRole("npc-002", "guard")
+	engine.RegisterNPC("npc-001")
+	engine.RegisterNPCWithRole("npc-002", "warrior")
+
+	assert.Equal(t, []string{"npc-001", "npc-002"}, registered, "only new NPCs are reported")
+}
+
+func TestRebellionProfile(t *testing.T) {
+	engine := NewBehaviorEngine()
+	engine.RegisterNPCWithRole("npc-001", "guard")
+	_ = engine.ApplyTraumaModifier("npc-001", 0.3, "test")
+
+	profile, ok := engine.RebellionProfile("npc-001")
+	assert.True(t, ok)
+	assert.Equal(t, "npc-001", profile.NPCID)
+	assert.Equal(t, "guard", profile.Role)
+	assert.InDelta(t, 0.5, profile.Morale, 0.001)
+	assert.InDelta(t, 0.5, profile.WorkEfficiency, 0.001)
+	assert.InDelta(t, 0.3, profile.AvgTrauma, 0.001)
+	assert.InDelta(t, 0.5, profile.Confidence, 0.001)
+
+	_, ok = engine.RebellionProfile("missing")
+	assert.False(t, ok)
+}
+
 func TestApplyWorkEfficiencyModifier(t *testing.T) {
 	engine := NewBehaviorEngine()
 	engine.RegisterNPC("npc-001")
 
-	err := engine.ApplyWorkEfficiencyModifier("npc-001", -0.30)
+	err := engine.ApplyWorkEfficiencyModifier("npc-001", -0.30, "test")
 	assert.NoError(t, err)
 
 	npc, ok := engine.GetNPC("npc-001")
@@ -43,14 +79,14 @@ func TestApplyWorkEfficiencyModifier_Clamp(t *testing.T) {
 	engine.RegisterNPC("npc-clamp")
 
 	// Apply large negative modifier - should clamp to 0
-	err := engine.ApplyWorkEfficiencyModifier("npc-clamp", -1.0)
+	err := engine.ApplyWorkEfficiencyModifier("npc-clamp", -1.0, "test")
 	assert.NoError(t, err)
 
 	npc, _ := engine.GetNPC("npc-clamp")
 	assert.InDelta(t, 0.0, npc.WorkEfficiency, 0.001, "Should clamp to 0.0")
 
 	// Apply large positive modifier - should clamp to 1
-	err = engine.ApplyWorkEfficiencyModifier("npc-clamp", 5.0)
+	err = engine.ApplyWorkEfficiencyModifier("npc-clamp", 5.0, "test")
 	assert.NoError(t, err)
 
 	npc, _ = engine.GetNPC("npc-clamp")
@@ -60,8 +96,13 @@ func TestApplyWorkEfficiencyModifier_Clamp(t *testing.T) {
 func TestApplyWorkEfficiencyModifier_NotFound(t *testing.T) {
 	engine := NewBehaviorEngine()
 
-	err := engine.ApplyWorkEfficiencyModifier("npc-unknown", -0.30)
-	assert.Error(t, err, "Should return error for unknown NPC")
+	err := engine.ApplyWorkEfficiencyModifier("npc-unknown", -0.30, "test")
+	assert.ErrorIs(t, err, ErrNPCNotFound, "Should return error for unknown NPC")
+
+	var nfErr *NPCNotFoundError
+	if assert.True(t, errors.As(err, &nfErr)) {
+		assert.Equal(t, "npc-unknown", nfErr.NpcID)
+	}
 }
 
 func TestApplyMoraleModifier(t *testing.T) {
@@ -69,14 +110,14 @@ func TestApplyMoraleModifier(t *testing.T) {
 	engine.RegisterNPC("npc-morale")
 
 	// Positive modifier
-	err := engine.ApplyMoraleModifier("npc-morale", 0.20)
+	err := engine.ApplyMoraleModifier("npc-morale", 0.20, "test")
 	assert.NoError(t, err)
 
 	npc, _ := engine.GetNPC("npc-morale")
 	assert.InDelta(t, 0.70, npc.Morale, 0.001, "0.5 + 0.20 = 0.70")
 
 	// Negative modifier
-	err = engine.ApplyMoraleModifier("npc-morale", -0.30)
+	err = engine.ApplyMoraleModifier("npc-morale", -0.30, "test")
 	assert.NoError(t, err)
 
 	npc, _ = engine.GetNPC("npc-morale")
@@ -88,14 +129,14 @@ func TestApplyMoraleModifier_Clamp(t *testing.T) {
 	engine.RegisterNPC("npc-morale-clamp")
 
 	// Clamp to 0
-	err := engine.ApplyMoraleModifier("npc-morale-clamp", -2.0)
+	err := engine.ApplyMoraleModifier("npc-morale-clamp", -2.0, "test")
 	assert.NoError(t, err)
 
 	npc, _ := engine.GetNPC("npc-morale-clamp")
 	assert.InDelta(t, 0.0, npc.Morale, 0.001, "Should clamp to 0.0")
 
 	// Clamp to 1
-	err = engine.ApplyMoraleModifier("npc-morale-clamp", 5.0)
+	err = engine.ApplyMoraleModifier("npc-morale-clamp", 5.0, "test")
 	assert.NoError(t, err)
 
 	npc, _ = engine.GetNPC("npc-morale-clamp")
@@ -105,8 +146,15 @@ func TestApplyMoraleModifier_Clamp(t *testing.T) {
 func TestApplyMoraleModifier_NotFound(t *testing.T) {
 	engine := NewBehaviorEngine()
 
-	err := engine.ApplyMoraleModifier("npc-ghost", 0.10)
-	assert.Error(t, err, "Should return error for unknown NPC")
+	err := engine.ApplyMoraleModifier("npc-ghost", 0.10, "test")
+	assert.ErrorIs(t, err, ErrNPCNotFound, "Should return error for unknown NPC")
+
+	// Wrapping preserves the type for callers further up the stack
+	wrapped := fmt.Errorf("apply morale: %w", err)
+	var nfErr *NPCNotFoundError
+	if assert.True(t, errors.As(wrapped, &nfErr)) {
+		assert.Equal(t, "npc-ghost", nfErr.NpcID)
+	}
 }
 
 func TestGetNPC_NotFound(t *testing.T) {
@@ -194,7 +242,7 @@ func TestConcurrentAccess(t *testing.T) {
 
 	for i := 0; i < 10; i++ {
 		go func() {
-			_ = engine.ApplyMoraleModifier("npc-concurrent", 0.01)
+			_ = engine.ApplyMoraleModifier("npc-concurrent", 0.01, "test")
 			done <- true
 		}()
 		go func() {
@@ -212,3 +260,95 @@ func TestConcurrentAccess(t *testing.T) {
 	assert.True(t, ok)
 	assert.True(t, npc.Morale >= 0.0 && npc.Morale <= 1.0)
 }
+
+func TestGetNPC_ReturnsClone(t *testing.T) {
+	engine := NewBehaviorEngine()
+	engine.RegisterNPC("npc-clone")
+
+	npc, ok := engine.GetNPC("npc-clone")
+	assert.True(t, ok)
+	npc.Morale = 0.99
+	npc.Role = "warrior"
+
+	fresh, _ := engine.GetNPC("npc-clone")
+	assert.InDelta(t, 0.5, fresh.Morale, 0.001, "mutating the clone should not affect engine state")
+	assert.Equal(t, "worker", fresh.Role)
+}
+
+func TestGetNPCMutable(t *testing.T) {
+	engine := NewBehaviorEngine()
+	engine.RegisterNPC("npc-live")
+
+	live, ok := engine.GetNPCMutable("npc-live")
+	assert.True(t, ok)
+	live.Morale = 0.9
+
+	npc, _ := engine.GetNPC("npc-live")
+	assert.InDelta(t, 0.9, npc.Morale, 0.001, "mutable pointer should modify engine state")
+
+	_, ok = engine.GetNPCMutable("ghost")
+	assert.False(t, ok)
+}
+
+func TestNPCBehavior_Clone(t *testing.T) {
+	original := &NPCBehavior{NPCID: "npc-001", Role: "guard", WorkEfficiency: 0.7, Morale: 0.4, AssignedTask: "patrol"}
+	clone := original.Clone()
+
+	assert.True(t, original.Equal(clone))
+	assert.NotSame(t, original, clone)
+
+	var nilNPC *NPCBehavior
+	assert.Nil(t, nilNPC.Clone())
+}
+
+func TestNPCBehavior_Equal(t *testing.T) {
+	a := &NPCBehavior{NPCID: "npc-001", Role: "worker", WorkEfficiency: 0.5, Morale: 0.5}
+
+	b := a.Clone()
+	b.Morale += 1e-8
+	assert.False(t, a.Equal(b), "morale difference of 1e-8 exceeds tolerance")
+
+	c := a.Clone()
+	c.Morale += 5e-10
+	assert.True(t, a.Equal(c), "morale difference of 5e-10 is within tolerance")
+
+	d := a.Clone()
+	d.Role = "guard"
+	assert.False(t, a.Equal(d))
+
+	assert.False(t, a.Equal(nil))
+}
+
+func TestTickNPCs_RecoversMoraleAndDecaysTrauma(t *testing.T) {
+	engine := NewBehaviorEngine()
+	n := engine.RegisterNPC("npc-001")
+	n.Morale = 0.3
+	n.AvgTrauma = 0.2
+
+	changed := engine.TickNPCs(5, 0.02, 0.01)
+
+	assert.Equal(t, []string{"npc-001"}, changed)
+	got, _ := engine.GetNPC("npc-001")
+	assert.InDelta(t, 0.4, got.Morale, 1e-9)
+	assert.InDelta(t, 0.15, got.AvgTrauma, 1e-9)
+}
+
+func TestTickNPCs_ClampsAtBounds(t *testing.T) {
+	engine := NewBehaviorEngine()
+	full := engine.RegisterNPC("npc-full")
+	full.Morale = 1.0
+	nearly := engine.RegisterNPC("npc-nearly")
+	nearly.Morale = 0.95
+	nearly.AvgTrauma = 0.01
+
+	changed := engine.TickNPCs(10, 0.02, 0.01)
+
+	assert.Equal(t, []string{"npc-nearly"}, changed, "max-morale NPC is unchanged")
+	got, _ := engine.GetNPC("npc-full")
+	assert.InDelta(t, 1.0, got.Morale, 1e-9)
+	got, _ = engine.GetNPC("npc-nearly")
+	assert.InDelta(t, 1.0, got.Morale, 1e-9)
+	assert.InDelta(t, 0.0, got.AvgTrauma, 1e-9)
+
+	assert.Empty(t, engine.TickNPCs(0, 0.02, 0.01))
+}
@@ -0,0 +1,82 @@
+package npc
+
+import (
+	"time"
+
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+)
+
+// BreakdownRecord is an unresolved mental breakdown awaiting recovery.
+type BreakdownRecord struct {
+	EventID             string // Telemetry event that reported the breakdown
+	BreakdownType       pb.MentalBreakdownType
+	OccurredAt          time.Time
+	RecoveryProbability float64 // 0.0-1.0: chance that a recovery attempt succeeds
+}
+
+// BreakdownRecoveryListener is notified when an NPC recovers from a mental
+// breakdown. It is called after the engine lock is released.
+type BreakdownRecoveryListener func(npcID string, record BreakdownRecord)
+
+// SetBreakdownRecoveryListener registers fn to receive every successful
+// breakdown recovery. Pass nil to remove it.
+func (b *BehaviorEngine) SetBreakdownRecoveryListener(fn BreakdownRecoveryListener) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.recoveryListener = fn
+}
+
+// SetRandFn injects a deterministic random function for testing. fn must
+// return values in [0, 1).
+func (b *BehaviorEngine) SetRandFn(fn func() float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.randFn = fn
+}
+
+// SetPendingBreakdown records record as the NPC's unresolved mental
+// breakdown, replacing any earlier one.
+// Returns an *NPCNotFoundError if the NPC is not registered.
+func (b *BehaviorEngine) SetPendingBreakdown(npcID string, record BreakdownRecord) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	npc, ok := b.npcs[npcID]
+	if !ok {
+		return &NPCNotFoundError{NpcID: npcID}
+	}
+	npc.PendingBreakdown = &record
+	return nil
+}
+
+// AttemptBreakdownRecovery rolls against the RecoveryProbability of the
+// NPC's pending breakdown. On success the breakdown is cleared and the
+// recovery listener is notified. Reports whether the NPC recovered.
+// Returns an *NPCNotFoundError if the NPC is not registered and a
+// *NoPendingBreakdownError if it has no pending breakdown.
+func (b *BehaviorEngine) AttemptBreakdownRecovery(npcID string) (bool, error) {
+	b.mu.Lock()
+	npc, ok := b.npcs[npcID]
+	if !ok {
+		b.mu.Unlock()
+		return false, &NPCNotFoundError{NpcID: npcID}
+	}
+	if npc.PendingBreakdown == nil {
+		b.mu.Unlock()
+		return false, &NoPendingBreakdownError{NpcID: npcID}
+	}
+
+	record := *npc.PendingBreakdown
+	if b.randFn() >= record.RecoveryProbability {
+		b.mu.Unlock()
+		return false, nil
+	}
+	npc.PendingBreakdown = nil
+	listener := b.recoveryListener
+	b.mu.Unlock()
+
+	if listener != nil {
+		listener(npcID, record)
+	}
+	return true, nil
+}
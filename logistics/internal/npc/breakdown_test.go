@@ -0,0 +1,92 @@
+package npc
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/generated/epochpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBreakdownTestEngine(t *testing.T, recoveryProbability, roll float64) *BehaviorEngine {
+	t.Helper()
+	engine := NewBehaviorEngine()
+	engine.SetRandFn(func() float64 { return roll })
+	engine.RegisterNPC("npc-1")
+	require.NoError(t, engine.SetPendingBreakdown("npc-1", BreakdownRecord{
+		EventID:             "mb-npc-1-1",
+		BreakdownType:       pb.MentalBreakdownType_MENTAL_BREAKDOWN_PARANOIA_ONSET,
+		OccurredAt:          time.Now(),
+		RecoveryProbability: recoveryProbability,
+	}))
+	return engine
+}
+
+func TestAttemptBreakdownRecovery_RollAgainstProbability(t *testing.T) {
+	tests := []struct {
+		name        string
+		probability float64
+		roll        float64
+		want        bool
+	}{
+		{"roll below probability recovers", 0.7, 0.5, true},
+		{"roll at probability fails", 0.5, 0.5, false},
+		{"roll above probability fails", 0.3, 0.5, false},
+		{"zero probability never recovers", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newBreakdownTestEngine(t, tt.probability, tt.roll)
+
+			recovered, err := engine.AttemptBreakdownRecovery("npc-1")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, recovered)
+			state, _ := engine.GetNPC("npc-1")
+			assert.Equal(t, !tt.want, state.PendingBreakdown != nil, "pending breakdown is cleared only on recovery")
+		})
+	}
+}
+
+func TestAttemptBreakdownRecovery_NotifiesListener(t *testing.T) {
+	engine := newBreakdownTestEngine(t, 1.0, 0.2)
+	var notified []BreakdownRecord
+	engine.SetBreakdownRecoveryListener(func(npcID string, record BreakdownRecord) {
+		assert.Equal(t, "npc-1", npcID)
+		notified = append(notified, record)
+	})
+
+	recovered, err := engine.AttemptBreakdownRecovery("npc-1")
+	require.NoError(t, err)
+	require.True(t, recovered)
+	require.Len(t, notified, 1)
+	assert.Equal(t, "mb-npc-1-1", notified[0].EventID)
+
+	_, err = engine.AttemptBreakdownRecovery("npc-1")
+	assert.ErrorIs(t, err, ErrNoPendingBreakdown)
+	assert.Len(t, notified, 1)
+}
+
+func TestAttemptBreakdownRecovery_Errors(t *testing.T) {
+	engine := NewBehaviorEngine()
+	engine.RegisterNPC("npc-1")
+
+	_, err := engine.AttemptBreakdownRecovery("ghost")
+	assert.ErrorIs(t, err, ErrNPCNotFound)
+
+	_, err = engine.AttemptBreakdownRecovery("npc-1")
+	assert.ErrorIs(t, err, ErrNoPendingBreakdown)
+
+	assert.ErrorIs(t, engine.SetPendingBreakdown("ghost", BreakdownRecord{}), ErrNPCNotFound)
+}
+
+func TestNPCBehavior_CloneCopiesPendingBreakdown(t *testing.T) {
+	engine := newBreakdownTestEngine(t, 0.5, 0.9)
+
+	clone, _ := engine.GetNPC("npc-1")
+	clone.PendingBreakdown.RecoveryProbability = 1.0
+
+	state, _ := engine.GetNPC("npc-1")
+	assert.Equal(t, 0.5, state.PendingBreakdown.RecoveryProbability)
+}
@@ -0,0 +1,204 @@
+package npc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// csvHeader is the column layout used by ExportNPCsCSV and ImportNPCsCSV.
+var csvHeader = []string{"npc_id", "role", "work_efficiency", "morale", "avg_trauma", "confidence", "assigned_task"}
+
+// ImportResult summarizes an ImportNPCsCSV call.
+type ImportResult struct {
+	Imported int      // Rows registered or overwritten
+	Skipped  int      // Rows for already registered NPCs when not overwriting
+	Errors   []string // One message per rejected row
+}
+
+// ExportNPCsCSV writes every registered NPC as CSV, sorted by NPC ID, with
+// the header npc_id,role,work_efficiency,morale,avg_trauma,confidence,assigned_task.
+func (b *BehaviorEngine) ExportNPCsCSV() ([]byte, error) {
+	npcs := b.SnapshotNPCs()
+	sort.Slice(npcs, func(i, j int) bool { return npcs[i].NPCID < npcs[j].NPCID })
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	for _, n := range npcs {
+		row := []string{
+			n.NPCID,
+			n.Role,
+			formatCSVFloat(n.WorkEfficiency),
+			formatCSVFloat(n.Morale),
+			formatCSVFloat(n.AvgTrauma),
+			formatCSVFloat(n.Confidence),
+			n.AssignedTask,
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ImportNPCsCSV registers NPCs from CSV in the ExportNPCsCSV format. Rows
+// with a missing ID or values outside [0, 1] are rejected and described in
+// ImportResult.Errors; the remaining rows are still imported. NPCs that are
+// already registered are skipped unless overwriteExisting is true.
+// Returns an error only if the data is not CSV or the header does not match.
+func (b *BehaviorEngine) ImportNPCsCSV(data []byte, overwriteExisting bool) (ImportResult, error) {
+	var result ImportResult
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = len(csvHeader)
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return result, fmt.Errorf("empty CSV: missing header")
+		}
+		return result, fmt.Errorf("read CSV header: %w", err)
+	}
+	if strings.Join(header, ",") != strings.Join(csvHeader, ",") {
+		return result, fmt.Errorf("unexpected CSV header %q, want %q", strings.Join(header, ","), strings.Join(csvHeader, ","))
+	}
+
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := r.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d: %v", parseErr.Line, parseErr.Err))
+				continue
+			}
+			return result, fmt.Errorf("read CSV: %w", err)
+		}
+
+		npc, err := parseCSVRow(row)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+
+		if b.storeImported(npc, overwriteExisting) {
+			result.Imported++
+		} else {
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
+
+// ImportNPCs registers a batch of NPCs with the same validation and
+// overwrite rules as ImportNPCsCSV. Error messages identify rows by their
+// zero-based index in npcs. The NPCs are copied; callers keep ownership.
+func (b *BehaviorEngine) ImportNPCs(npcs []*NPCBehavior, overwriteExisting bool) ImportResult {
+	var result ImportResult
+	for i, n := range npcs {
+		if n == nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("npc %d: missing", i))
+			continue
+		}
+		npc := n.Clone()
+		if err := validateImported(npc); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("npc %d: %v", i, err))
+			continue
+		}
+		if b.storeImported(npc, overwriteExisting) {
+			result.Imported++
+		} else {
+			result.Skipped++
+		}
+	}
+	return result
+}
+
+// storeImported registers npc, replacing an existing entry only when
+// overwrite is true, and derives its EmotionalState from Morale. Reports
+// whether npc was stored.
+func (b *BehaviorEngine) storeImported(npc *NPCBehavior, overwrite bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.npcs[npc.NPCID]; exists && !overwrite {
+		return false
+	}
+	npc.EmotionalState = EmotionalStateForMorale(npc.Morale)
+	b.npcs[npc.NPCID] = npc
+	return true
+}
+
+// parseCSVRow converts a CSV record to an NPCBehavior and validates it.
+func parseCSVRow(row []string) (*NPCBehavior, error) {
+	npc := &NPCBehavior{
+		NPCID:        strings.TrimSpace(row[0]),
+		Role:         strings.TrimSpace(row[1]),
+		AssignedTask: row[6],
+	}
+
+	fields := []struct {
+		name string
+		raw  string
+		dst  *float64
+	}{
+		{"work_efficiency", row[2], &npc.WorkEfficiency},
+		{"morale", row[3], &npc.Morale},
+		{"avg_trauma", row[4], &npc.AvgTrauma},
+		{"confidence", row[5], &npc.Confidence},
+	}
+	for _, f := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f.raw), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s %q is not a number", f.name, f.raw)
+		}
+		*f.dst = v
+	}
+	return npc, validateImported(npc)
+}
+
+// validateImported checks an imported NPC: the ID is required and every
+// attribute must be within [0, 1]. An empty role defaults to "worker".
+func validateImported(npc *NPCBehavior) error {
+	if npc.NPCID == "" {
+		return fmt.Errorf("npc_id is required")
+	}
+	if npc.Role == "" {
+		npc.Role = "worker"
+	}
+	for _, f := range []struct {
+		name  string
+		value float64
+	}{
+		{"work_efficiency", npc.WorkEfficiency},
+		{"morale", npc.Morale},
+		{"avg_trauma", npc.AvgTrauma},
+		{"confidence", npc.Confidence},
+	} {
+		if f.value < 0 || f.value > 1 {
+			return fmt.Errorf("%s %v out of range [0, 1]", f.name, f.value)
+		}
+	}
+	return nil
+}
+
+// formatCSVFloat renders f with the shortest representation that parses
+// back to the same value.
+func formatCSVFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
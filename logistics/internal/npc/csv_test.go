@@ -0,0 +1,125 @@
+package npc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportCSV_RoundTrip(t *testing.T) {
+	src := NewBehaviorEngine()
+	roles := []string{"worker", "warrior", "guard"}
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("npc-%03d", i)
+		src.RegisterNPCWithRole(id, roles[i%len(roles)])
+		n, _ := src.GetNPCMutable(id)
+		n.WorkEfficiency = float64(i) / 100
+		n.Morale = 1 - float64(i)/100
+		n.AvgTrauma = float64(i%10) / 10
+		n.Confidence = 0.123456789
+		if i%7 == 0 {
+			n.AssignedTask = "mine, shaft \"B\""
+		}
+	}
+
+	data, err := src.ExportNPCsCSV()
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, "npc_id,role,work_efficiency,morale,avg_trauma,confidence,assigned_task", lines[0])
+
+	dst := NewBehaviorEngine()
+	result, err := dst.ImportNPCsCSV(data, false)
+	require.NoError(t, err)
+	assert.Equal(t, 100, result.Imported)
+	assert.Zero(t, result.Skipped)
+	assert.Empty(t, result.Errors)
+
+	for _, want := range src.SnapshotNPCs() {
+		got, ok := dst.GetNPC(want.NPCID)
+		require.True(t, ok, want.NPCID)
+		assert.True(t, want.Equal(got), "%s differs: %+v vs %+v", want.NPCID, want, got)
+	}
+}
+
+func TestImportCSV_InvalidRowReported(t *testing.T) {
+	data := strings.Join([]string{
+		"npc_id,role,work_efficiency,morale,avg_trauma,confidence,assigned_task",
+		"npc-a,worker,0.5,0.5,0.1,0.5,",
+		"npc-b,guard,0.5,1.7,0.1,0.5,",
+		"npc-c,warrior,0.9,0.2,0.3,0.8,patrol",
+	}, "\n")
+
+	engine := NewBehaviorEngine()
+	result, err := engine.ImportNPCsCSV([]byte(data), false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0], "line 3")
+	assert.Contains(t, result.Errors[0], "morale")
+
+	_, ok := engine.GetNPC("npc-b")
+	assert.False(t, ok)
+	c, ok := engine.GetNPC("npc-c")
+	require.True(t, ok)
+	assert.Equal(t, "patrol", c.AssignedTask)
+}
+
+func TestImportCSV_OverwriteExisting(t *testing.T) {
+	data := []byte("npc_id,role,work_efficiency,morale,avg_trauma,confidence,assigned_task\nnpc-a,guard,0.9,0.9,0,0.9,\n")
+
+	engine := NewBehaviorEngine()
+	engine.RegisterNPC("npc-a")
+
+	result, err := engine.ImportNPCsCSV(data, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Imported)
+	assert.Equal(t, 1, result.Skipped)
+	n, _ := engine.GetNPC("npc-a")
+	assert.Equal(t, "worker", n.Role)
+
+	result, err = engine.ImportNPCsCSV(data, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	n, _ = engine.GetNPC("npc-a")
+	assert.Equal(t, "guard", n.Role)
+	assert.InDelta(t, 0.9, n.Morale, 1e-9)
+}
+
+func TestImportCSV_BadHeaderAndFieldCount(t *testing.T) {
+	engine := NewBehaviorEngine()
+
+	_, err := engine.ImportNPCsCSV([]byte("id,morale\nnpc-a,0.5\n"), false)
+	assert.Error(t, err)
+
+	_, err = engine.ImportNPCsCSV(nil, false)
+	assert.Error(t, err)
+
+	data := "npc_id,role,work_efficiency,morale,avg_trauma,confidence,assigned_task\nnpc-a,worker,0.5\nnpc-b,worker,0.5,0.5,0,0.5,\n"
+	result, err := engine.ImportNPCsCSV([]byte(data), false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0], "line 2")
+}
+
+func TestImportNPCs_ValidatesAndCopies(t *testing.T) {
+	engine := NewBehaviorEngine()
+	good := &NPCBehavior{NPCID: "npc-a", WorkEfficiency: 0.4, Morale: 0.6, Confidence: 0.5}
+	bad := &NPCBehavior{NPCID: "npc-b", Morale: -0.1}
+
+	result := engine.ImportNPCs([]*NPCBehavior{good, bad, nil}, false)
+	assert.Equal(t, 1, result.Imported)
+	require.Len(t, result.Errors, 2)
+	assert.Contains(t, result.Errors[0], "npc 1")
+
+	got, ok := engine.GetNPC("npc-a")
+	require.True(t, ok)
+	assert.Equal(t, "worker", got.Role, "empty role defaults to worker")
+
+	good.Morale = 0.1
+	got, _ = engine.GetNPC("npc-a")
+	assert.InDelta(t, 0.6, got.Morale, 1e-9, "engine must not alias caller's NPC")
+}
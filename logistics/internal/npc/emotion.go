@@ -0,0 +1,85 @@
+package npc
+
+// EmotionalState is a discrete mood band derived from an NPC's morale.
+type EmotionalState string
+
+const (
+	EmotionalStateMotivated   EmotionalState = "motivated"   // morale > 0.7
+	EmotionalStateNeutral     EmotionalState = "neutral"     // 0.5 <= morale <= 0.7
+	EmotionalStateDiscouraged EmotionalState = "discouraged" // 0.3 <= morale < 0.5
+	EmotionalStateDemoralized EmotionalState = "demoralized" // 0.1 <= morale < 0.3
+	EmotionalStateRebellious  EmotionalState = "rebellious"  // morale < 0.1
+)
+
+// EmotionalStateForMorale maps a morale value to its emotional state.
+func EmotionalStateForMorale(morale float64) EmotionalState {
+	switch {
+	case morale > 0.7:
+		return EmotionalStateMotivated
+	case morale >= 0.5:
+		return EmotionalStateNeutral
+	case morale >= 0.3:
+		return EmotionalStateDiscouraged
+	case morale >= 0.1:
+		return EmotionalStateDemoralized
+	default:
+		return EmotionalStateRebellious
+	}
+}
+
+// EmotionalTransition describes an NPC moving between emotional states.
+type EmotionalTransition struct {
+	NPCID  string
+	From   EmotionalState
+	To     EmotionalState
+	Morale float64 // Morale after the change that caused the transition
+}
+
+// EmotionalStateListener is notified of emotional state transitions. It is
+// called after the engine lock is released.
+type EmotionalStateListener func(EmotionalTransition)
+
+// SetEmotionalStateListener registers fn to receive every emotional state
+// transition caused by an engine morale change. Pass nil to remove it.
+func (b *BehaviorEngine) SetEmotionalStateListener(fn EmotionalStateListener) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.emotionListener = fn
+}
+
+// GetNPCsByEmotionalState returns all NPCs currently in the given state.
+func (b *BehaviorEngine) GetNPCsByEmotionalState(state EmotionalState) []*NPCBehavior {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	result := make([]*NPCBehavior, 0)
+	for _, npc := range b.npcs {
+		if npc.EmotionalState == state {
+			result = append(result, npc)
+		}
+	}
+	return result
+}
+
+// updateEmotionalState re-derives the NPC's emotional state from its morale
+// and reports the transition, if any.
+func (n *NPCBehavior) updateEmotionalState() (EmotionalTransition, bool) {
+	next := EmotionalStateForMorale(n.Morale)
+	if next == n.EmotionalState {
+		return EmotionalTransition{}, false
+	}
+	transition := EmotionalTransition{NPCID: n.NPCID, From: n.EmotionalState, To: next, Morale: n.Morale}
+	n.EmotionalState = next
+	return transition, true
+}
+
+// notifyEmotionalTransitions delivers transitions to listener, if set. It
+// must be called without holding b.mu.
+func notifyEmotionalTransitions(listener EmotionalStateListener, transitions []EmotionalTransition) {
+	if listener == nil {
+		return
+	}
+	for _, t := range transitions {
+		listener(t)
+	}
+}
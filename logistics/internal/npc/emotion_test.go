@@ -0,0 +1,85 @@
+package npc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmotionalStateForMorale(t *testing.T) {
+	cases := map[float64]EmotionalState{
+		1.0:  EmotionalStateMotivated,
+		0.71: EmotionalStateMotivated,
+		0.7:  EmotionalStateNeutral,
+		0.5:  EmotionalStateNeutral,
+		0.49: EmotionalStateDiscouraged,
+		0.3:  EmotionalStateDiscouraged,
+		0.29: EmotionalStateDemoralized,
+		0.1:  EmotionalStateDemoralized,
+		0.09: EmotionalStateRebellious,
+		0.0:  EmotionalStateRebellious,
+	}
+	for morale, want := range cases {
+		assert.Equal(t, want, EmotionalStateForMorale(morale), "morale %v", morale)
+	}
+}
+
+func TestApplyMoraleModifier_EmotionalTransitions(t *testing.T) {
+	engine := NewBehaviorEngine()
+	n := engine.RegisterNPC("npc-001")
+	require.Equal(t, EmotionalStateNeutral, n.EmotionalState)
+
+	var transitions []EmotionalTransition
+	engine.SetEmotionalStateListener(func(tr EmotionalTransition) {
+		transitions = append(transitions, tr)
+	})
+
+	// Drive morale from 0.5 down to 0.08 in steps of 0.06
+	for i := 0; i < 7; i++ {
+		require.NoError(t, engine.ApplyMoraleModifier("npc-001", -0.06, "test"))
+	}
+
+	got, _ := engine.GetNPC("npc-001")
+	assert.InDelta(t, 0.08, got.Morale, 1e-9)
+	assert.Equal(t, EmotionalStateRebellious, got.EmotionalState)
+
+	require.Len(t, transitions, 3, "one notification per transition")
+	assert.Equal(t, EmotionalStateNeutral, transitions[0].From)
+	assert.Equal(t, EmotionalStateDiscouraged, transitions[0].To)
+	assert.Equal(t, EmotionalStateDemoralized, transitions[1].To)
+	assert.Equal(t, EmotionalStateRebellious, transitions[2].To)
+	assert.Equal(t, "npc-001", transitions[2].NPCID)
+}
+
+func TestGetNPCsByEmotionalState(t *testing.T) {
+	engine := NewBehaviorEngine()
+	engine.RegisterNPC("npc-1")
+	engine.RegisterNPC("npc-2")
+	require.NoError(t, engine.ApplyMoraleModifier("npc-2", 0.3, "test"))
+
+	neutral := engine.GetNPCsByEmotionalState(EmotionalStateNeutral)
+	require.Len(t, neutral, 1)
+	assert.Equal(t, "npc-1", neutral[0].NPCID)
+
+	motivated := engine.GetNPCsByEmotionalState(EmotionalStateMotivated)
+	require.Len(t, motivated, 1)
+	assert.Equal(t, "npc-2", motivated[0].NPCID)
+
+	assert.Empty(t, engine.GetNPCsByEmotionalState(EmotionalStateRebellious))
+}
+
+func TestTickNPCs_EmotionalTransition(t *testing.T) {
+	engine := NewBehaviorEngine()
+	engine.RegisterNPC("npc-1")
+
+	var transitions []EmotionalTransition
+	engine.SetEmotionalStateListener(func(tr EmotionalTransition) {
+		transitions = append(transitions, tr)
+	})
+
+	engine.TickNPCs(5, 0.05, 0)
+
+	require.Len(t, transitions, 1)
+	assert.Equal(t, EmotionalStateMotivated, transitions[0].To)
+}
@@ -0,0 +1,61 @@
+package npc
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNPCNotFound matches (via errors.Is) any *NPCNotFoundError.
+	ErrNPCNotFound = errors.New("npc not found")
+	// ErrGroupNotFound matches (via errors.Is) any *GroupNotFoundError.
+	ErrGroupNotFound = errors.New("group not found")
+)
+
+// NPCNotFoundError is returned when an operation targets an NPC that is not
+// registered with the BehaviorEngine.
+type NPCNotFoundError struct {
+	NpcID string
+}
+
+func (e *NPCNotFoundError) Error() string {
+	return fmt.Sprintf("NPC %q not found", e.NpcID)
+}
+
+// Is reports whether target is ErrNPCNotFound.
+func (e *NPCNotFoundError) Is(target error) bool {
+	return target == ErrNPCNotFound
+}
+
+// GroupNotFoundError is returned when an operation targets an NPC group that
+// does not exist.
+type GroupNotFoundError struct {
+	GroupID string
+}
+
+func (e *GroupNotFoundError) Error() string {
+	return fmt.Sprintf("group %q not found", e.GroupID)
+}
+
+// Is reports whether target is ErrGroupNotFound.
+func (e *GroupNotFoundError) Is(target error) bool {
+	return target == ErrGroupNotFound
+}
+
+// ErrNoPendingBreakdown matches (via errors.Is) any *NoPendingBreakdownError.
+var ErrNoPendingBreakdown = errors.New("no pending breakdown")
+
+// NoPendingBreakdownError is returned when a breakdown recovery is attempted
+// for an NPC that has no unresolved mental breakdown.
+type NoPendingBreakdownError struct {
+	NpcID string
+}
+
+func (e *NoPendingBreakdownError) Error() string {
+	return fmt.Sprintf("NPC %q has no pending mental breakdown", e.NpcID)
+}
+
+// Is reports whether target is ErrNoPendingBreakdown.
+func (e *NoPendingBreakdownError) Is(target error) bool {
+	return target == ErrNoPendingBreakdown
+}
@@ -0,0 +1,165 @@
+package npc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+)
+
+// CreateGroup creates a named group of registered NPCs for squad-level
+// operations. Returns an error if the ID is empty or taken, or an
+// *NPCNotFoundError if any member is not registered.
+func (b *BehaviorEngine) CreateGroup(groupID string, npcIDs []string) error {
+	if groupID == "" {
+		return fmt.Errorf("group ID must not be empty")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.groups[groupID]; exists {
+		return fmt.Errorf("group %q already exists", groupID)
+	}
+	members := make(map[string]struct{}, len(npcIDs))
+	for _, id := range npcIDs {
+		if _, ok := b.npcs[id]; !ok {
+			return &NPCNotFoundError{NpcID: id}
+		}
+		members[id] = struct{}{}
+	}
+	b.groups[groupID] = members
+	return nil
+}
+
+// AddNPCToGroup adds a registered NPC to an existing group. Adding a current
+// member is a no-op.
+func (b *BehaviorEngine) AddNPCToGroup(groupID, npcID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	members, ok := b.groups[groupID]
+	if !ok {
+		return &GroupNotFoundError{GroupID: groupID}
+	}
+	if _, ok := b.npcs[npcID]; !ok {
+		return &NPCNotFoundError{NpcID: npcID}
+	}
+	members[npcID] = struct{}{}
+	return nil
+}
+
+// RemoveNPCFromGroup removes an NPC from a group. Returns an
+// *NPCNotFoundError if the NPC is not a member.
+func (b *BehaviorEngine) RemoveNPCFromGroup(groupID, npcID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	members, ok := b.groups[groupID]
+	if !ok {
+		return &GroupNotFoundError{GroupID: groupID}
+	}
+	if _, ok := members[npcID]; !ok {
+		return &NPCNotFoundError{NpcID: npcID}
+	}
+	delete(members, npcID)
+	return nil
+}
+
+// GetGroup returns the sorted member IDs of a group.
+func (b *BehaviorEngine) GetGroup(groupID string) ([]string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	members, ok := b.groups[groupID]
+	if !ok {
+		return nil, false
+	}
+	return sortedMembers(members), true
+}
+
+// ApplyGroupAction applies an action (see rebellion.ApplyActionEffects) to
+// every member of a group, updating work efficiency, morale, and trauma.
+// The returned map holds an error for each member that could not be updated
+// (e.g. no longer registered) and is empty when all succeed. The second
+// return value is non-nil if the group does not exist or the action type or
+// intensity is invalid, in which case nothing is applied.
+func (b *BehaviorEngine) ApplyGroupAction(groupID, actionType string, intensity float64) (map[string]error, error) {
+	if !rebellion.IsKnownActionType(actionType) {
+		return nil, fmt.Errorf("unknown action type %q", actionType)
+	}
+	if intensity < 0 || intensity > 1 {
+		return nil, fmt.Errorf("intensity must be in [0, 1], got %v", intensity)
+	}
+
+	b.mu.Lock()
+	members, ok := b.groups[groupID]
+	if !ok {
+		b.mu.Unlock()
+		return nil, &GroupNotFoundError{GroupID: groupID}
+	}
+
+	failures := make(map[string]error)
+	var transitions []EmotionalTransition
+	for _, id := range sortedMembers(members) {
+		npc, ok := b.npcs[id]
+		if !ok {
+			failures[id] = &NPCNotFoundError{NpcID: id}
+			continue
+		}
+		updated := rebellion.ApplyActionEffects(rebellion.NPCRebellionProfile{
+			NPCID:          id,
+			AvgTrauma:      npc.AvgTrauma,
+			WorkEfficiency: npc.WorkEfficiency,
+			Morale:         npc.Morale,
+			Role:           npc.Role,
+		}, rebellion.NPCAction{NPCID: id, ActionType: actionType, Intensity: intensity})
+
+		npc.WorkEfficiency = updated.WorkEfficiency
+		npc.Morale = updated.Morale
+		npc.AvgTrauma = updated.AvgTrauma
+		if t, changed := npc.updateEmotionalState(); changed {
+			transitions = append(transitions, t)
+		}
+	}
+	listener := b.emotionListener
+	b.mu.Unlock()
+
+	notifyEmotionalTransitions(listener, transitions)
+	return failures, nil
+}
+
+// GetGroupAverageMorale returns the mean morale of the group's registered
+// members. Returns false if the group does not exist or has no registered
+// members.
+func (b *BehaviorEngine) GetGroupAverageMorale(groupID string) (float64, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	members, ok := b.groups[groupID]
+	if !ok {
+		return 0, false
+	}
+	var total float64
+	count := 0
+	for id := range members {
+		if npc, ok := b.npcs[id]; ok {
+			total += npc.Morale
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return total / float64(count), true
+}
+
+// sortedMembers returns the keys of a member set in sorted order.
+func sortedMembers(members map[string]struct{}) []string {
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,87 @@
+package npc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSquad(t *testing.T, b *BehaviorEngine, groupID string, size int) []string {
+	t.Helper()
+	ids := make([]string, size)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%s-npc-%d", groupID, i)
+		b.RegisterNPC(ids[i])
+	}
+	require.NoError(t, b.CreateGroup(groupID, ids))
+	return ids
+}
+
+func TestApplyGroupAction_PunishmentReducesAllMorale(t *testing.T) {
+	b := NewBehaviorEngine()
+	ids := newSquad(t, b, "alpha", 5)
+
+	failures, err := b.ApplyGroupAction("alpha", "punishment", 1.0)
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+
+	for _, id := range ids {
+		n, _ := b.GetNPC(id)
+		assert.InDelta(t, 0.3, n.Morale, 1e-9, "%s morale", id)
+		assert.InDelta(t, 0.15, n.AvgTrauma, 1e-9, "%s trauma", id)
+	}
+
+	avg, ok := b.GetGroupAverageMorale("alpha")
+	require.True(t, ok)
+	assert.InDelta(t, 0.3, avg, 1e-9)
+}
+
+func TestRemoveNPCFromGroup_ExcludesFromLaterActions(t *testing.T) {
+	b := NewBehaviorEngine()
+	ids := newSquad(t, b, "bravo", 3)
+
+	require.NoError(t, b.RemoveNPCFromGroup("bravo", ids[0]))
+	members, ok := b.GetGroup("bravo")
+	require.True(t, ok)
+	assert.Equal(t, ids[1:], members)
+
+	_, err := b.ApplyGroupAction("bravo", "reward", 1.0)
+	require.NoError(t, err)
+
+	removed, _ := b.GetNPC(ids[0])
+	assert.InDelta(t, 0.5, removed.Morale, 1e-9, "removed NPC must not receive the action")
+	member, _ := b.GetNPC(ids[1])
+	assert.InDelta(t, 0.65, member.Morale, 1e-9)
+
+	require.NoError(t, b.AddNPCToGroup("bravo", ids[0]))
+	members, _ = b.GetGroup("bravo")
+	assert.Equal(t, ids, members)
+}
+
+func TestGroupErrors(t *testing.T) {
+	b := NewBehaviorEngine()
+	newSquad(t, b, "charlie", 2)
+
+	assert.Error(t, b.CreateGroup("charlie", nil), "duplicate group")
+	assert.Error(t, b.CreateGroup("", nil))
+	assert.True(t, errors.Is(b.CreateGroup("delta", []string{"ghost"}), ErrNPCNotFound))
+	_, exists := b.GetGroup("delta")
+	assert.False(t, exists, "failed create must not leave a group behind")
+
+	assert.True(t, errors.Is(b.AddNPCToGroup("missing", "charlie-npc-0"), ErrGroupNotFound))
+	assert.True(t, errors.Is(b.AddNPCToGroup("charlie", "ghost"), ErrNPCNotFound))
+	assert.True(t, errors.Is(b.RemoveNPCFromGroup("charlie", "ghost"), ErrNPCNotFound))
+
+	_, err := b.ApplyGroupAction("missing", "reward", 0.5)
+	assert.True(t, errors.Is(err, ErrGroupNotFound))
+	_, err = b.ApplyGroupAction("charlie", "dance", 0.5)
+	assert.Error(t, err)
+	_, err = b.ApplyGroupAction("charlie", "reward", 1.5)
+	assert.Error(t, err)
+
+	_, ok := b.GetGroupAverageMorale("missing")
+	assert.False(t, ok)
+}
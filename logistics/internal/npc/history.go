@@ -0,0 +1,120 @@
+package npc
+
+import "time"
+
+// MaxStatHistory is the number of stat changes kept per NPC; older changes
+// are evicted first.
+const MaxStatHistory = 10
+
+// MaxProbabilityHistory is the number of rebellion probabilities kept per
+// NPC; once full, each new probability overwrites the oldest.
+const MaxProbabilityHistory = 50
+
+// Attributes recorded in a StatChange.
+const (
+	StatMorale         = "morale"
+	StatWorkEfficiency = "work_efficiency"
+	StatAvgTrauma      = "avg_trauma"
+)
+
+// StatChange records one change to an NPC attribute for audit trails.
+type StatChange struct {
+	Attribute string // StatMorale, StatWorkEfficiency or StatAvgTrauma
+	OldValue  float64
+	NewValue  float64
+	ChangedAt time.Time
+	Reason    string // What caused the change, e.g. "punishment action"
+}
+
+// ApplyTraumaModifier modifies an NPC's average trauma by the given
+// modifier, recording reason in its stat history. The result is clamped to
+// [0.0, 1.0].
+// Returns an error if the NPC is not registered.
+func (b *BehaviorEngine) ApplyTraumaModifier(npcID string, modifier float64, reason string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	npc, ok := b.npcs[npcID]
+	if !ok {
+		return &NPCNotFoundError{NpcID: npcID}
+	}
+
+	old := npc.AvgTrauma
+	npc.AvgTrauma = clamp(npc.AvgTrauma+modifier, 0.0, 1.0)
+	npc.recordStatChange(StatAvgTrauma, old, npc.AvgTrauma, reason)
+	return nil
+}
+
+// GetNPCStatHistory returns copies of the NPC's most recent stat changes,
+// oldest first. Returns nil and false if the NPC is not registered.
+func (b *BehaviorEngine) GetNPCStatHistory(npcID string) ([]*StatChange, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	npc, ok := b.npcs[npcID]
+	if !ok {
+		return nil, false
+	}
+	return cloneStatHistory(npc.StatHistory), true
+}
+
+// RecordRebellionProbability appends prob to the NPC's probability history,
+// overwriting the oldest entry once MaxProbabilityHistory is reached.
+// Returns an error if the NPC is not registered.
+func (b *BehaviorEngine) RecordRebellionProbability(npcID string, prob float64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	npc, ok := b.npcs[npcID]
+	if !ok {
+		return &NPCNotFoundError{NpcID: npcID}
+	}
+
+	if len(npc.ProbabilityHistory) < MaxProbabilityHistory {
+		npc.ProbabilityHistory = append(npc.ProbabilityHistory, prob)
+		return nil
+	}
+	n := copy(npc.ProbabilityHistory, npc.ProbabilityHistory[len(npc.ProbabilityHistory)-MaxProbabilityHistory+1:])
+	npc.ProbabilityHistory = append(npc.ProbabilityHistory[:n], prob)
+	return nil
+}
+
+// GetProbabilityHistory returns a copy of the NPC's recorded rebellion
+// probabilities, oldest first (empty if none are recorded).
+// Returns an error if the NPC is not registered.
+func (b *BehaviorEngine) GetProbabilityHistory(npcID string) ([]float64, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	npc, ok := b.npcs[npcID]
+	if !ok {
+		return nil, &NPCNotFoundError{NpcID: npcID}
+	}
+	return append([]float64{}, npc.ProbabilityHistory...), nil
+}
+
+// recordStatChange appends a change to the NPC's stat history, evicting the
+// oldest entry once MaxStatHistory is reached. Caller must hold the engine
+// lock.
+func (n *NPCBehavior) recordStatChange(attribute string, oldValue, newValue float64, reason string) {
+	if len(n.StatHistory) >= MaxStatHistory {
+		n.StatHistory = append(n.StatHistory[:0:0], n.StatHistory[len(n.StatHistory)-MaxStatHistory+1:]...)
+	}
+	n.StatHistory = append(n.StatHistory, &StatChange{
+		Attribute: attribute,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		ChangedAt: time.Now().UTC(),
+		Reason:    reason,
+	})
+}
+
+// cloneStatHistory returns a deep copy of history, always non-nil.
+func cloneStatHistory(history []*StatChange) []*StatChange {
+	clone := make([]*StatChange, len(history))
+	for i, change := range history {
+		c := *change
+		clone[i] = &c
+	}
+	return clone
+}
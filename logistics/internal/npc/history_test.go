@@ -0,0 +1,134 @@
+package npc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatHistory_RecordsEachModifierWithReason(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-1")
+
+	require.NoError(t, b.ApplyMoraleModifier("npc-1", -0.2, "punishment action"))
+	require.NoError(t, b.ApplyWorkEfficiencyModifier("npc-1", 0.1, "reward action"))
+	require.NoError(t, b.ApplyTraumaModifier("npc-1", 0.3, "rebellion engine update"))
+
+	history, ok := b.GetNPCStatHistory("npc-1")
+	require.True(t, ok)
+	require.Len(t, history, 3)
+
+	assert.Equal(t, StatMorale, history[0].Attribute)
+	assert.InDelta(t, 0.5, history[0].OldValue, 1e-9)
+	assert.InDelta(t, 0.3, history[0].NewValue, 1e-9)
+	assert.Equal(t, "punishment action", history[0].Reason)
+	assert.False(t, history[0].ChangedAt.IsZero())
+
+	assert.Equal(t, StatWorkEfficiency, history[1].Attribute)
+	assert.InDelta(t, 0.6, history[1].NewValue, 1e-9)
+	assert.Equal(t, "reward action", history[1].Reason)
+
+	assert.Equal(t, StatAvgTrauma, history[2].Attribute)
+	assert.InDelta(t, 0.0, history[2].OldValue, 1e-9)
+	assert.InDelta(t, 0.3, history[2].NewValue, 1e-9)
+	assert.Equal(t, "rebellion engine update", history[2].Reason)
+}
+
+func TestStatHistory_EvictsOldestAtCapacity(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-1")
+
+	reasons := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}
+	for _, reason := range reasons {
+		require.NoError(t, b.ApplyTraumaModifier("npc-1", 0.01, reason))
+	}
+
+	history, _ := b.GetNPCStatHistory("npc-1")
+	require.Len(t, history, MaxStatHistory)
+	assert.Equal(t, "b", history[0].Reason, "oldest entry evicted")
+	assert.Equal(t, "k", history[MaxStatHistory-1].Reason)
+}
+
+func TestStatHistory_ReturnsCopies(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-1")
+	require.NoError(t, b.ApplyMoraleModifier("npc-1", 0.1, "reward action"))
+
+	history, _ := b.GetNPCStatHistory("npc-1")
+	history[0].Reason = "tampered"
+	npc, _ := b.GetNPC("npc-1")
+	npc.StatHistory[0].Reason = "tampered"
+
+	history, _ = b.GetNPCStatHistory("npc-1")
+	assert.Equal(t, "reward action", history[0].Reason)
+}
+
+func TestStatHistory_UnknownNPC(t *testing.T) {
+	b := NewBehaviorEngine()
+
+	history, ok := b.GetNPCStatHistory("npc-ghost")
+	assert.False(t, ok)
+	assert.Nil(t, history)
+
+	var notFound *NPCNotFoundError
+	assert.ErrorAs(t, b.ApplyTraumaModifier("npc-ghost", 0.1, "test"), &notFound)
+}
+
+func TestStatHistory_NewNPCHasEmptyHistory(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-1")
+
+	history, ok := b.GetNPCStatHistory("npc-1")
+	require.True(t, ok)
+	assert.Empty(t, history)
+}
+
+func TestProbabilityHistory_RecordsSequentialCalls(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-1")
+
+	empty, err := b.GetProbabilityHistory("npc-1")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	for _, p := range []float64{0.30, 0.42, 0.38} {
+		require.NoError(t, b.RecordRebellionProbability("npc-1", p))
+	}
+
+	history, err := b.GetProbabilityHistory("npc-1")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.30, 0.42, 0.38}, history)
+
+	// The returned slice is a copy
+	history[0] = 1.0
+	again, _ := b.GetProbabilityHistory("npc-1")
+	assert.Equal(t, 0.30, again[0])
+}
+
+func TestProbabilityHistory_WrapsAroundAtCapacity(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-1")
+
+	total := MaxProbabilityHistory + 7
+	for i := 0; i < total; i++ {
+		require.NoError(t, b.RecordRebellionProbability("npc-1", float64(i)))
+	}
+
+	history, err := b.GetProbabilityHistory("npc-1")
+	require.NoError(t, err)
+	require.Len(t, history, MaxProbabilityHistory)
+	for i, p := range history {
+		assert.Equal(t, float64(total-MaxProbabilityHistory+i), p)
+	}
+}
+
+func TestProbabilityHistory_UnknownNPC(t *testing.T) {
+	b := NewBehaviorEngine()
+
+	err := b.RecordRebellionProbability("ghost", 0.5)
+	assert.ErrorIs(t, err, ErrNPCNotFound)
+
+	_, err = b.GetProbabilityHistory("ghost")
+	assert.ErrorIs(t, err, ErrNPCNotFound)
+}
@@ -0,0 +1,48 @@
+package npc
+
+// GroupModifierResult reports the outcome of ApplyGroupModifiers.
+type GroupModifierResult struct {
+	Modified int              // NPCs whose attributes were updated
+	Errors   map[string]error // NPC ID → reason it could not be updated
+}
+
+// ApplyGroupMoraleModifier adds modifier to the morale of every registered
+// NPC (see ApplyMoraleModifier), for world events that affect everyone at
+// once. The returned map holds an error for each NPC that could not be
+// updated and is empty when all succeed.
+func (b *BehaviorEngine) ApplyGroupMoraleModifier(modifier float64) map[string]error {
+	return b.ApplyGroupModifiers(modifier, 0, 0).Errors
+}
+
+// ApplyGroupEfficiencyModifier adds modifier to the work efficiency of every
+// registered NPC (see ApplyWorkEfficiencyModifier). The returned map is as
+// for ApplyGroupMoraleModifier.
+func (b *BehaviorEngine) ApplyGroupEfficiencyModifier(modifier float64) map[string]error {
+	return b.ApplyGroupModifiers(0, modifier, 0).Errors
+}
+
+// ApplyGroupModifiers adds the given modifiers to the morale, work
+// efficiency and trauma of every registered NPC under a single lock, so no
+// reader observes a partly applied change. Each attribute is clamped to
+// [0.0, 1.0] and emotional state transitions are reported to the listener
+// after the lock is released.
+func (b *BehaviorEngine) ApplyGroupModifiers(moraleModifier, efficiencyModifier, traumaModifier float64) GroupModifierResult {
+	result := GroupModifierResult{Errors: make(map[string]error)}
+
+	b.mu.Lock()
+	var transitions []EmotionalTransition
+	for _, npc := range b.npcs {
+		npc.Morale = clamp(npc.Morale+moraleModifier, 0.0, 1.0)
+		npc.WorkEfficiency = clamp(npc.WorkEfficiency+efficiencyModifier, 0.0, 1.0)
+		npc.AvgTrauma = clamp(npc.AvgTrauma+traumaModifier, 0.0, 1.0)
+		if t, changed := npc.updateEmotionalState(); changed {
+			transitions = append(transitions, t)
+		}
+		result.Modified++
+	}
+	listener := b.emotionListener
+	b.mu.Unlock()
+
+	notifyEmotionalTransitions(listener, transitions)
+	return result
+}
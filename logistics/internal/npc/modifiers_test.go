@@ -0,0 +1,66 @@
+package npc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyGroupMoraleModifier_AllNPCs(t *testing.T) {
+	b := NewBehaviorEngine()
+	for i := 0; i < 20; i++ {
+		b.RegisterNPC(fmt.Sprintf("npc-%d", i))
+	}
+	require.NoError(t, b.ApplyMoraleModifier("npc-0", -0.4, "test")) // 0.1: clamps at 0
+
+	errs := b.ApplyGroupMoraleModifier(-0.2)
+
+	assert.Empty(t, errs)
+	for i := 1; i < 20; i++ {
+		npc, _ := b.GetNPC(fmt.Sprintf("npc-%d", i))
+		assert.InDelta(t, 0.3, npc.Morale, 1e-9)
+	}
+	clamped, _ := b.GetNPC("npc-0")
+	assert.Equal(t, 0.0, clamped.Morale)
+}
+
+func TestApplyGroupEfficiencyModifier_AllNPCs(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-1")
+	b.RegisterNPC("npc-2")
+
+	assert.Empty(t, b.ApplyGroupEfficiencyModifier(0.7))
+
+	for _, npc := range b.SnapshotNPCs() {
+		assert.Equal(t, 1.0, npc.WorkEfficiency, "clamped at 1")
+		assert.Equal(t, defaultMorale, npc.Morale)
+	}
+}
+
+func TestApplyGroupModifiers(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-1")
+	b.RegisterNPC("npc-2")
+	var transitions []EmotionalTransition
+	b.SetEmotionalStateListener(func(t EmotionalTransition) { transitions = append(transitions, t) })
+
+	result := b.ApplyGroupModifiers(-0.3, -0.1, 0.25)
+
+	assert.Equal(t, 2, result.Modified)
+	assert.Empty(t, result.Errors)
+	for _, npc := range b.SnapshotNPCs() {
+		assert.InDelta(t, 0.2, npc.Morale, 1e-9)
+		assert.InDelta(t, 0.4, npc.WorkEfficiency, 1e-9)
+		assert.InDelta(t, 0.25, npc.AvgTrauma, 1e-9)
+	}
+	assert.Len(t, transitions, 2, "each NPC's mood transition is reported")
+}
+
+func TestApplyGroupModifiers_NoNPCs(t *testing.T) {
+	result := NewBehaviorEngine().ApplyGroupModifiers(-0.1, 0, 0)
+
+	assert.Zero(t, result.Modified)
+	assert.Empty(t, result.Errors)
+}
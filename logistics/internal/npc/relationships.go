@@ -0,0 +1,85 @@
+package npc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+)
+
+// SetRelationship records a symmetric relationship between two registered
+// NPCs with the given affinity in [-1, 1] (negative = hostile), replacing
+// any existing one. Returns an error if the NPCs are the same or the
+// affinity is out of range, or an *NPCNotFoundError if either is not
+// registered.
+func (b *BehaviorEngine) SetRelationship(npcA, npcB string, affinity float64) error {
+	if npcA == npcB {
+		return fmt.Errorf("NPC %q cannot have a relationship with itself", npcA)
+	}
+	if affinity < -1 || affinity > 1 {
+		return fmt.Errorf("affinity must be in [-1, 1], got %v", affinity)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, id := range []string{npcA, npcB} {
+		if _, ok := b.npcs[id]; !ok {
+			return &NPCNotFoundError{NpcID: id}
+		}
+	}
+	b.linkLocked(npcA, npcB, affinity)
+	b.linkLocked(npcB, npcA, affinity)
+	return nil
+}
+
+// linkLocked records npcID's affinity toward peerID. Caller must hold b.mu.
+func (b *BehaviorEngine) linkLocked(npcID, peerID string, affinity float64) {
+	peers, ok := b.relationships[npcID]
+	if !ok {
+		peers = make(map[string]float64)
+		b.relationships[npcID] = peers
+	}
+	peers[peerID] = affinity
+}
+
+// RemoveRelationship removes the relationship between two NPCs. Removing a
+// relationship that does not exist is a no-op.
+func (b *BehaviorEngine) RemoveRelationship(npcA, npcB string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.relationships[npcA], npcB)
+	delete(b.relationships[npcB], npcA)
+}
+
+// GetRelationships returns a copy of npcID's relationships, keyed by peer
+// NPC ID.
+func (b *BehaviorEngine) GetRelationships(npcID string) map[string]float64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	result := make(map[string]float64, len(b.relationships[npcID]))
+	for peerID, affinity := range b.relationships[npcID] {
+		result[peerID] = affinity
+	}
+	return result
+}
+
+// RelatedPeers returns npcID's relationships with their peers' current
+// morale, sorted by peer ID. It implements rebellion.RelationshipSource.
+func (b *BehaviorEngine) RelatedPeers(npcID string) []rebellion.PeerRelationship {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	peers := make([]rebellion.PeerRelationship, 0, len(b.relationships[npcID]))
+	for peerID, affinity := range b.relationships[npcID] {
+		peer, ok := b.npcs[peerID]
+		if !ok {
+			continue
+		}
+		peers = append(peers, rebellion.PeerRelationship{PeerID: peerID, Affinity: affinity, Morale: peer.Morale})
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].PeerID < peers[j].PeerID })
+	return peers
+}
@@ -0,0 +1,65 @@
+package npc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRelationship_RaisesRebellionWithUnhappyPeer(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-a")
+	b.RegisterNPC("npc-b")
+	require.NoError(t, b.ApplyMoraleModifier("npc-b", -0.4, "test")) // morale 0.1
+
+	a, _ := b.GetNPC("npc-a")
+	profile := rebellion.NPCRebellionProfile{NPCID: "npc-a", AvgTrauma: a.AvgTrauma, WorkEfficiency: a.WorkEfficiency, Morale: a.Morale}
+
+	engine := rebellion.NewEngine(rebellion.DefaultConfig())
+	engine.SetRelationshipSource(b)
+	without := engine.CalculateProbability(profile)
+
+	require.NoError(t, b.SetRelationship("npc-a", "npc-b", 0.5))
+	engine.InvalidateCache("npc-a")
+	with := engine.CalculateProbability(profile)
+
+	assert.Greater(t, with.Probability, without.Probability)
+	assert.InDelta(t, 0.2, with.Factors.RelationshipModifier, 1e-9) // 0.5 × (0.5 - 0.1)
+}
+
+func TestSetRelationship_IsSymmetric(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-a")
+	b.RegisterNPC("npc-b")
+	b.RegisterNPC("npc-c")
+
+	require.NoError(t, b.SetRelationship("npc-a", "npc-b", 0.5))
+	require.NoError(t, b.SetRelationship("npc-c", "npc-a", -0.25))
+	assert.Equal(t, map[string]float64{"npc-b": 0.5, "npc-c": -0.25}, b.GetRelationships("npc-a"))
+	assert.Equal(t, map[string]float64{"npc-a": 0.5}, b.GetRelationships("npc-b"))
+
+	assert.Equal(t, []rebellion.PeerRelationship{
+		{PeerID: "npc-b", Affinity: 0.5, Morale: 0.5},
+		{PeerID: "npc-c", Affinity: -0.25, Morale: 0.5},
+	}, b.RelatedPeers("npc-a"))
+
+	b.RemoveRelationship("npc-b", "npc-a")
+	assert.Empty(t, b.GetRelationships("npc-b"))
+	assert.Equal(t, map[string]float64{"npc-c": -0.25}, b.GetRelationships("npc-a"))
+}
+
+func TestSetRelationship_Errors(t *testing.T) {
+	b := NewBehaviorEngine()
+	b.RegisterNPC("npc-a")
+
+	err := b.SetRelationship("npc-a", "ghost", 0.5)
+	assert.True(t, errors.Is(err, ErrNPCNotFound))
+	assert.Error(t, b.SetRelationship("npc-a", "npc-a", 0.5))
+
+	b.RegisterNPC("npc-b")
+	assert.Error(t, b.SetRelationship("npc-a", "npc-b", 1.5))
+	assert.Empty(t, b.GetRelationships("npc-a"))
+}
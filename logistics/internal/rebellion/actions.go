@@ -0,0 +1,141 @@
+package rebellion
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ActionEffect is the per-unit-intensity change an action makes to an NPC
+// profile; each delta is multiplied by the action's intensity.
+type ActionEffect struct {
+	MoraleDelta     float64 `json:"morale_delta"`
+	TraumaDelta     float64 `json:"trauma_delta"`
+	EfficiencyDelta float64 `json:"efficiency_delta"`
+}
+
+// defaultActionEffects holds the built-in action vocabulary. Do not mutate;
+// DefaultActionEffects returns a copy.
+var defaultActionEffects = map[string]ActionEffect{
+	"reward":          {MoraleDelta: 0.15, TraumaDelta: -0.05},
+	"punishment":      {MoraleDelta: -0.20, TraumaDelta: 0.15},
+	"command":         {MoraleDelta: -0.05, EfficiencyDelta: 0.10},
+	"dialogue":        {MoraleDelta: 0.10},
+	"environment":     {TraumaDelta: 0.10},
+	"resource_change": {MoraleDelta: 0.08, EfficiencyDelta: 0.05},
+	"intimidation":    {MoraleDelta: -0.08, TraumaDelta: 0.12, EfficiencyDelta: 0.08},
+	"rest":            {MoraleDelta: 0.05, TraumaDelta: -0.10, EfficiencyDelta: -0.03},
+}
+
+// DefaultActionEffects returns a copy of the built-in action effects:
+//   - "reward":          morale += intensity * 0.15, trauma -= intensity * 0.05
+//   - "punishment":      morale -= intensity * 0.20, trauma += intensity * 0.15
+//   - "command":         efficiency += intensity * 0.10, morale -= intensity * 0.05
+//   - "dialogue":        morale += intensity * 0.10
+//   - "environment":     trauma += intensity * 0.10
+//   - "resource_change": morale += intensity * 0.08, efficiency += intensity * 0.05
+//     (a negative intensity, for resources lost, lowers both)
+//   - "intimidation":    efficiency += intensity * 0.08, trauma += intensity * 0.12, morale -= intensity * 0.08
+//   - "rest":            trauma -= intensity * 0.10, morale += intensity * 0.05, efficiency -= intensity * 0.03
+//     (NPCs are off work)
+func DefaultActionEffects() map[string]ActionEffect {
+	return copyActionEffects(defaultActionEffects)
+}
+
+// ParseActionConfigJSON decodes a JSON object mapping action type names to
+// ActionEffect values, e.g. {"bribe": {"morale_delta": 0.2}}.
+// Returns an error for malformed JSON, an empty map, or an empty action name.
+func ParseActionConfigJSON(data []byte) (map[string]ActionEffect, error) {
+	var effects map[string]ActionEffect
+	if err := json.Unmarshal(data, &effects); err != nil {
+		return nil, fmt.Errorf("parse action config: %w", err)
+	}
+	if len(effects) == 0 {
+		return nil, fmt.Errorf("action config defines no actions")
+	}
+	for name := range effects {
+		if name == "" {
+			return nil, fmt.Errorf("action config contains an empty action name")
+		}
+	}
+	return effects, nil
+}
+
+// LoadActionConfigFromJSON reads and parses an action config file
+// (see ParseActionConfigJSON).
+func LoadActionConfigFromJSON(path string) (map[string]ActionEffect, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read action config %q: %w", path, err)
+	}
+	effects, err := ParseActionConfigJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return effects, nil
+}
+
+// LoadActionsFromFile replaces the engine's action effects with those in the
+// JSON file at path (see SetActionEffects) and remembers path for
+// ReloadActionsFromFile. On error the current effects are kept.
+func (e *Engine) LoadActionsFromFile(path string) error {
+	effects, err := LoadActionConfigFromJSON(path)
+	if err != nil {
+		return err
+	}
+	if err := e.SetActionEffects(effects); err != nil {
+		return err
+	}
+
+	e.actionsMu.Lock()
+	defer e.actionsMu.Unlock()
+	e.actionsPath = path
+	return nil
+}
+
+// ReloadActionsFromFile re-reads the file last passed to LoadActionsFromFile.
+func (e *Engine) ReloadActionsFromFile() error {
+	e.actionsMu.RLock()
+	path := e.actionsPath
+	e.actionsMu.RUnlock()
+
+	if path == "" {
+		return fmt.Errorf("no action config file has been loaded")
+	}
+	return e.LoadActionsFromFile(path)
+}
+
+// SetActionEffects replaces the config's ActionEffects with a copy of
+// effects, as an UpdateConfig would. Returns an error if effects is empty or
+// the resulting config is invalid.
+func (e *Engine) SetActionEffects(effects map[string]ActionEffect) error {
+	if len(effects) == 0 {
+		return fmt.Errorf("action effects must define at least one action")
+	}
+
+	cfg := e.GetConfig()
+	cfg.ActionEffects = effects
+	return e.UpdateConfig(cfg)
+}
+
+// ActionEffects returns a copy of the engine's current action effects.
+func (e *Engine) ActionEffects() map[string]ActionEffect {
+	return copyActionEffects(e.GetConfig().actionEffects())
+}
+
+// actionEffects returns the config's ActionEffects, or the defaults if nil.
+// The result must not be mutated.
+func (c RebellionConfig) actionEffects() map[string]ActionEffect {
+	if c.ActionEffects == nil {
+		return defaultActionEffects
+	}
+	return c.ActionEffects
+}
+
+func copyActionEffects(src map[string]ActionEffect) map[string]ActionEffect {
+	dst := make(map[string]ActionEffect, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
@@ -0,0 +1,124 @@
+package rebellion
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeActionConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "actions.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadActionsFromFile_CustomAction(t *testing.T) {
+	path := writeActionConfig(t, `{
+		"bribe": {"morale_delta": 0.3, "trauma_delta": -0.1, "efficiency_delta": -0.2}
+	}`)
+
+	e := NewEngine(DefaultConfig())
+	require.NoError(t, e.LoadActionsFromFile(path))
+
+	profile := NPCRebellionProfile{NPCID: "npc-1", AvgTrauma: 0.5, WorkEfficiency: 0.5, Morale: 0.5}
+	updated := e.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "bribe", Intensity: 0.5})
+
+	assert.InDelta(t, 0.5+0.5*0.3, updated.Morale, 1e-9)
+	assert.InDelta(t, 0.5-0.5*0.1, updated.AvgTrauma, 1e-9)
+	assert.InDelta(t, 0.5-0.5*0.2, updated.WorkEfficiency, 1e-9)
+
+	// The file replaces the defaults entirely
+	unchanged := e.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "reward", Intensity: 1})
+	assert.Equal(t, profile, unchanged)
+}
+
+func TestLoadActionsFromFile_MissingFileWrapsError(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	err := e.LoadActionsFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	// Defaults are kept on failure
+	assert.Equal(t, DefaultActionEffects(), e.ActionEffects())
+}
+
+func TestLoadActionsFromFile_InvalidContent(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	assert.Error(t, e.LoadActionsFromFile(writeActionConfig(t, `not json`)))
+	assert.Error(t, e.LoadActionsFromFile(writeActionConfig(t, `{}`)))
+}
+
+func TestReloadActionsFromFile(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	assert.Error(t, e.ReloadActionsFromFile(), "nothing loaded yet")
+
+	path := writeActionConfig(t, `{"bribe": {"morale_delta": 0.1}}`)
+	require.NoError(t, e.LoadActionsFromFile(path))
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"bribe": {"morale_delta": 0.4}}`), 0o600))
+	require.NoError(t, e.ReloadActionsFromFile())
+	assert.InDelta(t, 0.4, e.ActionEffects()["bribe"].MoraleDelta, 1e-9)
+}
+
+func TestDefaultActionEffectsMatchDocumentedValues(t *testing.T) {
+	profile := NPCRebellionProfile{NPCID: "npc-1", AvgTrauma: 0.5, WorkEfficiency: 0.5, Morale: 0.5}
+	updated := ApplyActionEffects(profile, NPCAction{ActionType: "command", Intensity: 1})
+	assert.InDelta(t, 0.6, updated.WorkEfficiency, 1e-9)
+	assert.InDelta(t, 0.45, updated.Morale, 1e-9)
+
+	// Copies are independent of the defaults
+	effects := DefaultActionEffects()
+	effects["reward"] = ActionEffect{}
+	assert.InDelta(t, 0.15, DefaultActionEffects()["reward"].MoraleDelta, 1e-9)
+}
+
+func TestRebellionConfig_CustomActionEffect(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ActionEffects["inspire"] = ActionEffect{MoraleDelta: 0.4, TraumaDelta: -0.2, EfficiencyDelta: 0.1}
+	e := NewEngine(cfg)
+
+	profile := NPCRebellionProfile{NPCID: "npc-1", AvgTrauma: 0.5, WorkEfficiency: 0.5, Morale: 0.5}
+	updated := e.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "inspire", Intensity: 0.5})
+
+	assert.InDelta(t, 0.5+0.5*0.4, updated.Morale, 1e-9)
+	assert.InDelta(t, 0.5-0.5*0.2, updated.AvgTrauma, 1e-9)
+	assert.InDelta(t, 0.5+0.5*0.1, updated.WorkEfficiency, 1e-9)
+
+	// Built-in actions keep their default effects
+	rewarded := e.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "reward", Intensity: 1})
+	assert.InDelta(t, 0.65, rewarded.Morale, 1e-9)
+
+	// Unknown types are no-ops
+	assert.Equal(t, profile, e.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "bribe", Intensity: 1}))
+}
+
+func TestRebellionConfig_ActionEffectsViaUpdateConfig(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	profile := NPCRebellionProfile{NPCID: "npc-1", Morale: 0.5}
+	require.Equal(t, profile, e.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "bribe", Intensity: 1}))
+
+	cfg := e.GetConfig()
+	cfg.ActionEffects["bribe"] = ActionEffect{MoraleDelta: 0.25}
+	require.NoError(t, e.UpdateConfig(cfg))
+	assert.Equal(t, []string{"ActionEffects"}, e.GetConfigHistory(1)[0].ChangedFields)
+	assert.InDelta(t, 0.75, e.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "bribe", Intensity: 1}).Morale, 1e-9)
+
+	cfg.ActionEffects[""] = ActionEffect{}
+	assert.Error(t, e.UpdateConfig(cfg), "empty action names are rejected")
+}
+
+func TestRebellionConfig_NilActionEffectsUseDefaults(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ActionEffects = nil
+	e := NewEngine(cfg)
+
+	assert.Equal(t, DefaultActionEffects(), e.ActionEffects())
+	profile := NPCRebellionProfile{NPCID: "npc-1", Morale: 0.5}
+	assert.InDelta(t, 0.35, e.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "punishment", Intensity: 0.75}).Morale, 1e-9)
+}
@@ -0,0 +1,39 @@
+package rebellion
+
+// applyProbabilityBounds returns profile adjusted so its final probability,
+// with relationship as the RelationshipModifier, lies within cfg's floor and
+// ceiling for actionType (see ProcessAction). Profiles already within
+// bounds, or action types without bounds, are unchanged.
+func applyProbabilityBounds(cfg RebellionConfig, actionType string, profile NPCRebellionProfile, relationship float64) NPCRebellionProfile {
+	p := evaluateWithRelationships(cfg, profile, relationship).Probability
+	if ceiling, ok := cfg.ActionProbabilityCeiling[actionType]; ok && p > ceiling {
+		return shiftProbability(cfg, profile, relationship, ceiling)
+	}
+	if floor, ok := cfg.ActionProbabilityFloor[actionType]; ok && p < floor {
+		return shiftProbability(cfg, profile, relationship, floor)
+	}
+	return profile
+}
+
+// shiftProbability moves morale, then work efficiency, within [0, 1] so the
+// profile's unclamped probability, including every modifier, equals target.
+// If both reach their limits first, the profile gets as close to target as
+// they allow.
+func shiftProbability(cfg RebellionConfig, profile NPCRebellionProfile, relationship, target float64) NPCRebellionProfile {
+	excess := evaluateWithRelationships(cfg, profile, relationship).Factors.raw() - target
+
+	// Raising a stat by d lowers the probability by d × weight, and vice versa
+	shift := func(value, weight float64) float64 {
+		if weight <= 0 || excess == 0 {
+			return value
+		}
+		shifted := clamp(value+excess/weight, 0.0, 1.0)
+		excess -= (shifted - value) * weight
+		return shifted
+	}
+
+	updated := profile
+	updated.Morale = shift(profile.Morale, cfg.MoraleWeight)
+	updated.WorkEfficiency = shift(profile.WorkEfficiency, cfg.EfficiencyWeight)
+	return updated
+}
@@ -0,0 +1,106 @@
+package rebellion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessAction_CeilingClampsProbability(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ActionProbabilityCeiling = map[string]float64{"reward": 0.20}
+	engine := NewEngine(cfg)
+
+	// 0.05 + (1-0.7)*0.30 + (1-0.45)*0.20 = 0.25
+	profile := NPCRebellionProfile{NPCID: "npc-1", WorkEfficiency: 0.7, Morale: 0.45}
+	require.InDelta(t, 0.25, engine.CalculateProbability(profile).Probability, 1e-9)
+
+	updated := engine.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "reward", Intensity: 0.1})
+	assert.InDelta(t, 0.20, engine.CalculateProbability(updated).Probability, 1e-9)
+	assert.InDelta(t, 0.70, updated.Morale, 1e-9, "morale absorbs the whole adjustment")
+	assert.Equal(t, profile.WorkEfficiency, updated.WorkEfficiency)
+
+	// Other action types are unaffected
+	punished := engine.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "punishment", Intensity: 0.1})
+	assert.Greater(t, engine.CalculateProbability(punished).Probability, 0.25)
+}
+
+func TestProcessAction_CeilingIncludesMemoryModifier(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ActionProbabilityCeiling = map[string]float64{"reward": 0.20}
+	engine := NewEngine(cfg)
+
+	profile := NPCRebellionProfile{NPCID: "npc-1", WorkEfficiency: 0.7, Morale: 0.45, MemoryCount: 10}
+	require.Greater(t, engine.CalculateProbability(profile).Factors.MemoryModifier, 0.0)
+
+	updated := engine.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "reward", Intensity: 0.1})
+	assert.InDelta(t, 0.20, engine.CalculateProbability(updated).Probability, 1e-9)
+}
+
+func TestProcessAction_CeilingIncludesRoleAndRelationships(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ActionProbabilityCeiling = map[string]float64{"reward": 0.20}
+	cfg.RoleRebellionModifiers = map[string]float64{"warrior": 0.05}
+	engine := NewEngine(cfg)
+	profile := NPCRebellionProfile{NPCID: "npc-1", WorkEfficiency: 0.7, Morale: 0.45, Role: "warrior"}
+
+	updated := engine.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "reward", Intensity: 0.1})
+	assert.InDelta(t, 0.20, engine.CalculateProbability(updated).Probability, 1e-9, "role modifier")
+
+	// A close peer with low morale adds 1.0 * (0.5 - 0.4) = 0.1
+	engine.SetRelationshipSource(staticRelationships{"npc-1": {{PeerID: "npc-2", Affinity: 1.0, Morale: 0.4}}})
+	updated = engine.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "reward", Intensity: 0.1})
+	result := engine.CalculateProbability(updated)
+	require.InDelta(t, 0.1, result.Factors.RelationshipModifier, 1e-9)
+	assert.InDelta(t, 0.20, result.Probability, 1e-9, "role and relationship modifiers")
+}
+
+func TestProcessAction_FloorClampsProbability(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ActionProbabilityFloor = map[string]float64{"dialogue": 0.10}
+	engine := NewEngine(cfg)
+
+	// 0.05 + 0 + (1-0.7)*0.20 = 0.11; an unbounded dialogue would reach 0.09
+	profile := NPCRebellionProfile{NPCID: "npc-1", WorkEfficiency: 1.0, Morale: 0.7}
+	updated := engine.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "dialogue", Intensity: 1.0})
+
+	assert.InDelta(t, 0.10, engine.CalculateProbability(updated).Probability, 1e-9)
+	assert.InDelta(t, 0.75, updated.Morale, 1e-9)
+}
+
+func TestProcessAction_BoundSpillsIntoEfficiency(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ActionProbabilityCeiling = map[string]float64{"command": 0.06}
+	engine := NewEngine(cfg)
+
+	profile := NPCRebellionProfile{NPCID: "npc-1", WorkEfficiency: 0.5, Morale: 0.9}
+	updated := engine.ProcessAction(profile, NPCAction{NPCID: "npc-1", ActionType: "command", Intensity: 0.5})
+
+	assert.Equal(t, 1.0, updated.Morale)
+	assert.InDelta(t, 0.06, engine.CalculateProbability(updated).Probability, 1e-9)
+}
+
+func TestRebellionConfig_ValidateActionBounds(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ActionProbabilityFloor = map[string]float64{"reward": 0.3}
+	cfg.ActionProbabilityCeiling = map[string]float64{"reward": 0.2}
+	assert.Error(t, cfg.Validate())
+
+	cfg.ActionProbabilityCeiling = map[string]float64{"reward": 1.2}
+	assert.Error(t, cfg.Validate())
+
+	cfg.ActionProbabilityCeiling = map[string]float64{"reward": 0.4}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestGetConfig_BoundsAreCopied(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ActionProbabilityCeiling = map[string]float64{"reward": 0.2}
+	engine := NewEngine(cfg)
+
+	cfg.ActionProbabilityCeiling["reward"] = 0.9
+	got := engine.GetConfig()
+	got.ActionProbabilityCeiling["reward"] = 0.8
+	assert.Equal(t, 0.2, engine.GetConfig().ActionProbabilityCeiling["reward"])
+}
@@ -1,83 +1,364 @@
 package rebellion
 
-import "math"
+import (
+	"log"
+	"math"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// statsWindowSize is the number of recent probabilities averaged in
+// RebellionEngineStats.AvgProbabilityLastN.
+const statsWindowSize = 100
+
+// maxConfigHistory bounds the number of ConfigChange entries retained.
+const maxConfigHistory = 100
 
 // Engine computes rebellion probabilities and processes actions that affect NPC profiles.
+// It is safe for concurrent use.
 type Engine struct {
-	config RebellionConfig
+	configMu      sync.RWMutex
+	config        RebellionConfig
+	configVersion uint64
+	configHistory []ConfigChange // oldest first, capped at maxConfigHistory
+
+	actionsMu   sync.RWMutex
+	actionsPath string // last file loaded by LoadActionsFromFile
+
+	stats engineStats
+	cache probabilityCache
+
+	lastActionMu sync.RWMutex
+	lastAction   map[string]int64 // NPC ID → tick of the last action or registration (see TrackLastActionTick)
+	profiles     ProfileSource    // optional; see SetProfileSource
+
+	relationshipsMu sync.RWMutex
+	relationships   RelationshipSource // optional; see SetRelationshipSource
+
+	suppressMu   sync.RWMutex
+	suppressions map[string]suppressionRecord // NPC ID → suppression (see ApplySuppression)
+	tickSource   func() int64                 // optional; see SetTickSource
+
+	publisherMu sync.RWMutex
+	publisher   MessageBusPublisher // optional; see SetPublisher
+}
+
+// probabilityCache holds the last result per NPC ID until it expires.
+// A zero ttl means caching is disabled.
+type probabilityCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]cachedResult
+}
+
+type cachedResult struct {
+	result    RebellionResult
+	expiresAt time.Time
+}
+
+// engineStats holds operational counters for the engine. Counters are updated
+// atomically; the rolling window and per-NPC variance are guarded by mu.
+type engineStats struct {
+	totalCalculations     atomic.Uint64
+	totalActionsProcessed atomic.Uint64
+	haltTriggeredCount    atomic.Uint64
+	vetoTriggeredCount    atomic.Uint64
+
+	mu          sync.Mutex
+	window      []float64 // ring buffer of recent probabilities
+	windowIndex int
+	highest     float64
+	perNPC      map[string]*runningVariance
+}
+
+// runningVariance tracks mean and variance incrementally (Welford's algorithm).
+type runningVariance struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+func (v *runningVariance) add(x float64) {
+	v.n++
+	delta := x - v.mean
+	v.mean += delta / float64(v.n)
+	v.m2 += delta * (x - v.mean)
+}
+
+func (v *runningVariance) variance() float64 {
+	if v.n < 2 {
+		return 0
+	}
+	return v.m2 / float64(v.n)
 }
 
 // NewEngine creates a new rebellion Engine with the given configuration.
 func NewEngine(config RebellionConfig) *Engine {
-	return &Engine{config: config}
+	e := &Engine{
+		config:       config.clone(),
+		lastAction:   make(map[string]int64),
+		suppressions: make(map[string]suppressionRecord),
+	}
+	e.stats.perNPC = make(map[string]*runningVariance)
+	return e
 }
 
 // GetConfig returns the engine's current configuration.
 func (e *Engine) GetConfig() RebellionConfig {
-	return e.config
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	return e.config.clone()
+}
+
+// UpdateConfig validates cfg and replaces the engine configuration. Each
+// update that changes at least one field bumps the config version, is
+// recorded in the config history, and drops all cached probabilities.
+// Updates identical to the current config are accepted as no-ops.
+func (e *Engine) UpdateConfig(cfg RebellionConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	cfg = cfg.clone()
+	e.configMu.Lock()
+	old := e.config
+	changed := changedConfigFields(old, cfg)
+	if len(changed) == 0 {
+		e.configMu.Unlock()
+		return nil
+	}
+	e.config = cfg
+	e.configVersion++
+	e.configHistory = append(e.configHistory, ConfigChange{
+		Version:       e.configVersion,
+		OldConfig:     old,
+		NewConfig:     cfg,
+		ChangedAt:     time.Now(),
+		ChangedFields: changed,
+	})
+	if len(e.configHistory) > maxConfigHistory {
+		e.configHistory = e.configHistory[len(e.configHistory)-maxConfigHistory:]
+	}
+	e.configMu.Unlock()
+
+	e.InvalidateAllCache()
+	return nil
+}
+
+// GetCurrentConfigVersion returns the number of config changes applied
+// since the engine was created (0 = initial config).
+func (e *Engine) GetCurrentConfigVersion() uint64 {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	return e.configVersion
+}
+
+// GetConfigHistory returns up to limit config changes, newest first.
+// A limit <= 0 returns the full retained history.
+func (e *Engine) GetConfigHistory(limit int) []ConfigChange {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+
+	n := len(e.configHistory)
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+	history := make([]ConfigChange, 0, limit)
+	for i := n - 1; i >= n-limit; i-- {
+		change := e.configHistory[i]
+		change.OldConfig = change.OldConfig.clone()
+		change.NewConfig = change.NewConfig.clone()
+		change.ChangedFields = append([]string(nil), change.ChangedFields...)
+		history = append(history, change)
+	}
+	return history
 }
 
 // CalculateProbability computes rebellion probability from an NPC's profile.
 //
 // Formula:
 //
-//	probability = clamp(base + avgTrauma*traumaWeight + (1-efficiency)*efficiencyWeight + (1-morale)*moraleWeight, 0, 1)
+//	probability = clamp(base + avgTrauma*traumaWeight + memory + (1-efficiency)*efficiencyWeight + (1-morale)*moraleWeight + relationship + role, 0, 1)
+//
+// memory is memoryCount*memoryWeightFactor, capped so that the trauma
+// contribution (avgTrauma*traumaWeight + memory) does not exceed
+// 2*traumaWeight.
+// relationship is the RelationshipModifier from the engine's relationship
+// source (see SetRelationshipSource), or 0 if none is set. role is the
+// profile Role's entry in RoleRebellionModifiers, or 0 if it has none.
 //
 // ThresholdExceeded is true when probability >= HaltThreshold.
 // HaltTriggered mirrors ThresholdExceeded (process should halt).
+// VetoTriggered is true when probability >= VetoThreshold; RebellionType is
+// then RebellionTypeVeto rather than RebellionTypeHalt.
+//
+// While the NPC is suppressed (see ApplySuppression), the probability is
+// capped at HaltThreshold - 0.01, so HaltTriggered stays false. Nothing is
+// published to the message bus; see PublishHalt.
+//
+// When the probability cache is enabled, a non-expired cached result for the
+// same NPC ID is returned without re-evaluating the formula.
 func (e *Engine) CalculateProbability(profile NPCRebellionProfile) RebellionResult {
+	cfg := e.GetConfig()
+	if cached, ok := e.cachedResult(profile.NPCID); ok {
+		return e.applySuppression(cfg, cached)
+	}
+
+	result := evaluateWithRelationships(cfg, profile, e.relationshipModifier(profile.NPCID))
+	e.storeResult(result)
+	result = e.applySuppression(cfg, result)
+	e.recordCalculation(profile.NPCID, result.Probability, result.ThresholdExceeded, result.VetoTriggered)
+	return result
+}
+
+// EvaluateProbability returns the result CalculateProbability would compute
+// for profile, always re-evaluating the formula, without touching engine
+// state: nothing is counted in the statistics or cached.
+// Suppressions in effect cap the result as usual.
+func (e *Engine) EvaluateProbability(profile NPCRebellionProfile) RebellionResult {
+	cfg := e.GetConfig()
+	result := evaluateWithRelationships(cfg, profile, e.relationshipModifier(profile.NPCID))
+	return e.applySuppression(cfg, result)
+}
+
+// GetRoleModifier returns the rebellion modifier configured for role and
+// whether one is configured.
+func (e *Engine) GetRoleModifier(role string) (float64, bool) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	modifier, ok := e.config.RoleRebellionModifiers[role]
+	return modifier, ok
+}
+
+// evaluate applies the rebellion formula (see CalculateProbability), without
+// relationship influence, and without touching engine statistics or the cache.
+func evaluate(cfg RebellionConfig, profile NPCRebellionProfile) RebellionResult {
+	return evaluateWithRelationships(cfg, profile, 0)
+}
+
+// evaluateWithRelationships is evaluate with relationship added as the
+// RelationshipModifier.
+func evaluateWithRelationships(cfg RebellionConfig, profile NPCRebellionProfile, relationship float64) RebellionResult {
+	traumaModifier := profile.AvgTrauma * cfg.TraumaWeight
 	factors := RebellionFactors{
-		Base:               e.config.BaseProbability,
-		TraumaModifier:     profile.AvgTrauma * e.config.TraumaWeight,
-		EfficiencyModifier: (1.0 - profile.WorkEfficiency) * e.config.EfficiencyWeight,
-		MoraleModifier:     (1.0 - profile.Morale) * e.config.MoraleWeight,
+		Base:                 cfg.BaseProbability,
+		TraumaModifier:       traumaModifier,
+		MemoryModifier:       memoryModifier(cfg, traumaModifier, profile.MemoryCount),
+		EfficiencyModifier:   (1.0 - profile.WorkEfficiency) * cfg.EfficiencyWeight,
+		MoraleModifier:       (1.0 - profile.Morale) * cfg.MoraleWeight,
+		RelationshipModifier: relationship,
+		RoleModifier:         cfg.RoleRebellionModifiers[profile.Role],
 	}
 
-	rawProbability := factors.Base + factors.TraumaModifier + factors.EfficiencyModifier + factors.MoraleModifier
-	probability := clamp(rawProbability, 0.0, 1.0)
+	probability := clamp(factors.raw(), 0.0, 1.0)
+
+	result := RebellionResult{
+		NPCID:       profile.NPCID,
+		Probability: probability,
+		Factors:     factors,
+	}
+	classify(cfg, &result)
+	return result
+}
 
-	thresholdExceeded := probability >= e.config.HaltThreshold
+// raw returns the sum of the factors: the probability before clamping.
+func (f RebellionFactors) raw() float64 {
+	return f.Base + f.TraumaModifier + f.MemoryModifier + f.EfficiencyModifier + f.MoraleModifier + f.RelationshipModifier + f.RoleModifier
+}
 
-	return RebellionResult{
-		NPCID:             profile.NPCID,
-		Probability:       probability,
-		Factors:           factors,
-		ThresholdExceeded: thresholdExceeded,
-		HaltTriggered:     thresholdExceeded,
+// memoryModifier returns memoryCount * cfg.MemoryWeightFactor, capped so that
+// traumaModifier plus the result does not exceed 2 * cfg.TraumaWeight.
+func memoryModifier(cfg RebellionConfig, traumaModifier float64, memoryCount int) float64 {
+	if memoryCount <= 0 {
+		return 0
+	}
+	headroom := math.Max(0, 2*cfg.TraumaWeight-traumaModifier)
+	return math.Min(float64(memoryCount)*cfg.MemoryWeightFactor, headroom)
+}
+
+// classify sets result's threshold flags and RebellionType from its
+// Probability.
+func classify(cfg RebellionConfig, result *RebellionResult) {
+	result.ThresholdExceeded = result.Probability >= cfg.HaltThreshold
+	result.HaltTriggered = result.ThresholdExceeded
+	result.VetoTriggered = result.Probability >= cfg.VetoThreshold
+	switch {
+	case result.VetoTriggered:
+		result.RebellionType = RebellionTypeVeto
+	case result.HaltTriggered:
+		result.RebellionType = RebellionTypeHalt
+	default:
+		result.RebellionType = ""
 	}
 }
 
 // ProcessAction applies an action's effects to an NPC's rebellion profile and returns
-// the updated profile. All values are clamped to [0.0, 1.0].
+// the updated profile, using the config's ActionEffects (see also
+// LoadActionsFromFile and SetActionEffects). Action types without an effect
+// are logged and, like SuppressActionType (see ApplySuppression), leave the
+// profile unchanged. All values are clamped to [0.0, 1.0].
 //
-// Action effects:
-//   - "reward":      morale += intensity * 0.15, trauma -= intensity * 0.05
-//   - "punishment":  morale -= intensity * 0.20, trauma += intensity * 0.15
-//   - "command":     efficiency += intensity * 0.10, morale -= intensity * 0.05
-//   - "dialogue":    morale += intensity * 0.10
-//   - "environment": trauma += intensity * 0.10
+// If the config sets an ActionProbabilityCeiling or ActionProbabilityFloor
+// for the action type and the updated profile's final probability (with its
+// memory, role and relationship modifiers) lies outside it, morale (then
+// work efficiency, if morale alone cannot) is adjusted so the probability
+// lands on the bound.
 func (e *Engine) ProcessAction(profile NPCRebellionProfile, action NPCAction) NPCRebellionProfile {
-	updated := profile
+	e.stats.totalActionsProcessed.Add(1)
+	e.InvalidateCache(profile.NPCID)
 
-	switch action.ActionType {
-	case "reward":
-		updated.Morale += action.Intensity * 0.15
-		updated.AvgTrauma -= action.Intensity * 0.05
+	if action.ActionType == SuppressActionType {
+		return profile
+	}
 
-	case "punishment":
-		updated.Morale -= action.Intensity * 0.20
-		updated.AvgTrauma += action.Intensity * 0.15
+	cfg := e.GetConfig()
+	effect, ok := cfg.actionEffects()[action.ActionType]
+	if !ok {
+		log.Printf("[Rebellion] Unknown action type %q for %s; profile unchanged", action.ActionType, profile.NPCID)
+		return profile
+	}
+	updated := applyEffect(profile, effect, action.Intensity)
+	return applyProbabilityBounds(cfg, action.ActionType, updated, e.relationshipModifier(profile.NPCID))
+}
+
+// PreviewAction returns the result CalculateProbability would report for
+// profile after ProcessAction applied action, without touching engine
+// state: nothing is counted, cached, invalidated or published. Suppressions
+// already in effect cap the result as usual.
+func (e *Engine) PreviewAction(profile NPCRebellionProfile, action NPCAction) RebellionResult {
+	cfg := e.GetConfig()
+	relationship := e.relationshipModifier(profile.NPCID)
+	updated := profile
+	if effect, ok := cfg.actionEffects()[action.ActionType]; ok && action.ActionType != SuppressActionType {
+		updated = applyProbabilityBounds(cfg, action.ActionType, applyEffect(profile, effect, action.Intensity), relationship)
+	}
+	result := evaluateWithRelationships(cfg, updated, relationship)
+	return e.applySuppression(cfg, result)
+}
 
-	case "command":
-		updated.WorkEfficiency += action.Intensity * 0.10
-		updated.Morale -= action.Intensity * 0.05
+// ApplyActionEffects returns profile with action's default effects (see
+// DefaultActionEffects) applied, without touching any engine state.
+// All values are clamped to [0.0, 1.0].
+func ApplyActionEffects(profile NPCRebellionProfile, action NPCAction) NPCRebellionProfile {
+	return applyEffect(profile, defaultActionEffects[action.ActionType], action.Intensity)
+}
 
-	case "dialogue":
-		updated.Morale += action.Intensity * 0.10
+// IsKnownActionType reports whether actionType is one of the default action
+// types. SuppressActionType is not: it has no stat effects.
+func IsKnownActionType(actionType string) bool {
+	_, ok := defaultActionEffects[actionType]
+	return ok
+}
 
-	case "environment":
-		updated.AvgTrauma += action.Intensity * 0.10
-	}
+// applyEffect scales effect by intensity, adds it to profile, and clamps.
+func applyEffect(profile NPCRebellionProfile, effect ActionEffect, intensity float64) NPCRebellionProfile {
+	updated := profile
+	updated.Morale += intensity * effect.MoraleDelta
+	updated.AvgTrauma += intensity * effect.TraumaDelta
+	updated.WorkEfficiency += intensity * effect.EfficiencyDelta
 
 	// Clamp all values to [0.0, 1.0]
 	updated.AvgTrauma = clamp(updated.AvgTrauma, 0.0, 1.0)
@@ -96,6 +377,202 @@ func (e *Engine) BatchCalculate(profiles []NPCRebellionProfile) []RebellionResul
 	return results
 }
 
+// BatchCalculateParallel is BatchCalculate spread across a pool of workers
+// goroutines (runtime.NumCPU() if workers <= 0). results[i] is the result for
+// profiles[i].
+func (e *Engine) BatchCalculateParallel(profiles []NPCRebellionProfile, workers int) []RebellionResult {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(profiles) {
+		workers = len(profiles)
+	}
+	if workers == 0 {
+		return []RebellionResult{}
+	}
+
+	// Each worker takes a contiguous chunk, so results need no coordination
+	results := make([]RebellionResult, len(profiles))
+	chunk := (len(profiles) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(profiles); start += chunk {
+		end := min(start+chunk, len(profiles))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				results[i] = e.CalculateProbability(profiles[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// EnableProbabilityCache caches CalculateProbability results per NPC ID for
+// ttl. Results are keyed by NPC ID only, so callers that change a profile
+// outside ProcessAction must call InvalidateCache. A ttl <= 0 disables
+// caching and drops all cached entries.
+func (e *Engine) EnableProbabilityCache(ttl time.Duration) {
+	e.cache.mu.Lock()
+	defer e.cache.mu.Unlock()
+
+	if ttl <= 0 {
+		e.cache.ttl = 0
+		e.cache.entries = nil
+		return
+	}
+	e.cache.ttl = ttl
+	if e.cache.entries == nil {
+		e.cache.entries = make(map[string]cachedResult)
+	}
+}
+
+// InvalidateCache drops the cached result for npcID, if any.
+func (e *Engine) InvalidateCache(npcID string) {
+	e.cache.mu.Lock()
+	defer e.cache.mu.Unlock()
+	delete(e.cache.entries, npcID)
+}
+
+// InvalidateAllCache drops every cached result.
+func (e *Engine) InvalidateAllCache() {
+	e.cache.mu.Lock()
+	defer e.cache.mu.Unlock()
+	if e.cache.entries != nil {
+		e.cache.entries = make(map[string]cachedResult)
+	}
+}
+
+// GetEngineStats returns a snapshot of the engine's operational statistics.
+func (e *Engine) GetEngineStats() RebellionEngineStats {
+	stats := RebellionEngineStats{
+		TotalCalculations:     e.stats.totalCalculations.Load(),
+		TotalActionsProcessed: e.stats.totalActionsProcessed.Load(),
+		HaltTriggeredCount:    e.stats.haltTriggeredCount.Load(),
+		VetoTriggeredCount:    e.stats.vetoTriggeredCount.Load(),
+	}
+
+	e.stats.mu.Lock()
+	defer e.stats.mu.Unlock()
+
+	if len(e.stats.window) > 0 {
+		sum := 0.0
+		for _, p := range e.stats.window {
+			sum += p
+		}
+		stats.AvgProbabilityLastN = sum / float64(len(e.stats.window))
+	}
+	stats.HighestRecordedProbability = e.stats.highest
+
+	maxVariance := 0.0
+	for npcID, v := range e.stats.perNPC {
+		if variance := v.variance(); variance > maxVariance || (variance == maxVariance && variance > 0 && npcID < stats.MostVolatileNPCID) {
+			maxVariance = variance
+			stats.MostVolatileNPCID = npcID
+		}
+	}
+
+	return stats
+}
+
+// ResetStats clears all operational statistics.
+func (e *Engine) ResetStats() {
+	e.stats.totalCalculations.Store(0)
+	e.stats.totalActionsProcessed.Store(0)
+	e.stats.haltTriggeredCount.Store(0)
+	e.stats.vetoTriggeredCount.Store(0)
+
+	e.stats.mu.Lock()
+	defer e.stats.mu.Unlock()
+	e.stats.window = nil
+	e.stats.windowIndex = 0
+	e.stats.highest = 0
+	e.stats.perNPC = make(map[string]*runningVariance)
+}
+
+// recordCalculation updates statistics after a probability calculation.
+func (e *Engine) recordCalculation(npcID string, probability float64, haltTriggered, vetoTriggered bool) {
+	e.stats.totalCalculations.Add(1)
+	if haltTriggered {
+		e.stats.haltTriggeredCount.Add(1)
+	}
+	if vetoTriggered {
+		e.stats.vetoTriggeredCount.Add(1)
+	}
+
+	e.stats.mu.Lock()
+	defer e.stats.mu.Unlock()
+
+	if len(e.stats.window) < statsWindowSize {
+		e.stats.window = append(e.stats.window, probability)
+	} else {
+		e.stats.window[e.stats.windowIndex] = probability
+		e.stats.windowIndex = (e.stats.windowIndex + 1) % statsWindowSize
+	}
+	if probability > e.stats.highest {
+		e.stats.highest = probability
+	}
+	if npcID != "" {
+		v, ok := e.stats.perNPC[npcID]
+		if !ok {
+			v = &runningVariance{}
+			e.stats.perNPC[npcID] = v
+		}
+		v.add(probability)
+	}
+}
+
+// cachedResult returns a non-expired cached result for npcID.
+func (e *Engine) cachedResult(npcID string) (RebellionResult, bool) {
+	if npcID == "" {
+		return RebellionResult{}, false
+	}
+
+	e.cache.mu.RLock()
+	defer e.cache.mu.RUnlock()
+
+	if e.cache.ttl == 0 {
+		return RebellionResult{}, false
+	}
+	entry, ok := e.cache.entries[npcID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return RebellionResult{}, false
+	}
+	return entry.result, true
+}
+
+// storeResult caches result when caching is enabled.
+func (e *Engine) storeResult(result RebellionResult) {
+	if result.NPCID == "" {
+		return
+	}
+
+	e.cache.mu.Lock()
+	defer e.cache.mu.Unlock()
+
+	if e.cache.ttl == 0 {
+		return
+	}
+	e.cache.entries[result.NPCID] = cachedResult{
+		result:    result,
+		expiresAt: time.Now().Add(e.cache.ttl),
+	}
+}
+
+// changedConfigFields returns the names of the RebellionConfig fields that
+// differ between a and b, in declaration order.
+func changedConfigFields(a, b RebellionConfig) []string {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	var changed []string
+	for i := 0; i < va.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			changed = append(changed, va.Type().Field(i).Name)
+		}
+	}
+	return changed
+}
+
 // clamp restricts a value to the range [min, max].
 func clamp(value, min, max float64) float64 {
 	return math.Max(min, math.Min(max, value))
@@ -1,9 +1,13 @@
 package rebellion
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -15,6 +19,7 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 0.20, cfg.MoraleWeight, "MoraleWeight should default to 0.20")
 	assert.Equal(t, 0.35, cfg.HaltThreshold, "HaltThreshold should default to 0.35")
 	assert.Equal(t, 0.80, cfg.VetoThreshold, "VetoThreshold should default to 0.80")
+	assert.Equal(t, 0.005, cfg.MemoryWeightFactor, "MemoryWeightFactor should default to 0.005")
 }
 
 func TestCalculateProbability_AllZeros(t *testing.T) {
@@ -51,11 +56,12 @@ func TestCalculateProbability_MaxTrauma(t *testing.T) {
 
 	result := engine.CalculateProbability(profile)
 
-	// base(0.05) + trauma(1.0*0.30) + efficiency((1-1.0)*0.30) + morale((1-1.0)*0.20)
-	// = 0.05 + 0.30 + 0.0 + 0.0 = 0.35
-	assert.InDelta(t, 0.35, result.Probability, 0.001)
+	// base(0.05) + trauma(1.0*0.30) + memory(10*0.005) + efficiency((1-1.0)*0.30) + morale((1-1.0)*0.20)
+	// = 0.05 + 0.30 + 0.05 + 0.0 + 0.0 = 0.40
+	assert.InDelta(t, 0.40, result.Probability, 0.001)
 	assert.InDelta(t, 0.30, result.Factors.TraumaModifier, 0.001)
-	assert.True(t, result.ThresholdExceeded, "0.35 should meet halt threshold (>= 0.35)")
+	assert.InDelta(t, 0.05, result.Factors.MemoryModifier, 0.001)
+	assert.True(t, result.ThresholdExceeded, "0.40 should exceed halt threshold (>= 0.35)")
 	assert.True(t, result.HaltTriggered, "Should trigger halt at threshold")
 }
 
@@ -71,9 +77,9 @@ func TestCalculateProbability_LowEfficiency(t *testing.T) {
 
 	result := engine.CalculateProbability(profile)
 
-	// base(0.05) + trauma(0*0.30) + efficiency((1-0)*0.30) + morale((1-1.0)*0.20)
-	// = 0.05 + 0.0 + 0.30 + 0.0 = 0.35
-	assert.InDelta(t, 0.35, result.Probability, 0.001)
+	// base(0.05) + trauma(0*0.30) + memory(5*0.005) + efficiency((1-0)*0.30) + morale((1-1.0)*0.20)
+	// = 0.05 + 0.0 + 0.025 + 0.30 + 0.0 = 0.375
+	assert.InDelta(t, 0.375, result.Probability, 0.001)
 	assert.InDelta(t, 0.30, result.Factors.EfficiencyModifier, 0.001)
 }
 
@@ -89,11 +95,11 @@ func TestCalculateProbability_LowMorale(t *testing.T) {
 
 	result := engine.CalculateProbability(profile)
 
-	// base(0.05) + trauma(0*0.30) + efficiency((1-1.0)*0.30) + morale((1-0)*0.20)
-	// = 0.05 + 0.0 + 0.0 + 0.20 = 0.25
-	assert.InDelta(t, 0.25, result.Probability, 0.001)
+	// base(0.05) + trauma(0*0.30) + memory(3*0.005) + efficiency((1-1.0)*0.30) + morale((1-0)*0.20)
+	// = 0.05 + 0.0 + 0.015 + 0.0 + 0.20 = 0.265
+	assert.InDelta(t, 0.265, result.Probability, 0.001)
 	assert.InDelta(t, 0.20, result.Factors.MoraleModifier, 0.001)
-	assert.False(t, result.ThresholdExceeded, "0.25 should not exceed halt threshold (0.35)")
+	assert.False(t, result.ThresholdExceeded, "0.265 should not exceed halt threshold (0.35)")
 }
 
 func TestCalculateProbability_AllMax(t *testing.T) {
@@ -108,10 +114,10 @@ func TestCalculateProbability_AllMax(t *testing.T) {
 
 	result := engine.CalculateProbability(profile)
 
-	// base(0.05) + trauma(1.0*0.30) + efficiency((1-0)*0.30) + morale((1-0)*0.20)
-	// = 0.05 + 0.30 + 0.30 + 0.20 = 0.85
-	// Clamped to 1.0? No, 0.85 < 1.0
-	assert.InDelta(t, 0.85, result.Probability, 0.001)
+	// base(0.05) + trauma(1.0*0.30) + memory(min(100*0.005, 2*0.30-0.30)) + efficiency((1-0)*0.30) + morale((1-0)*0.20)
+	// = 0.05 + 0.30 + 0.30 + 0.30 + 0.20 = 1.15, clamped to 1.0
+	assert.InDelta(t, 0.30, result.Factors.MemoryModifier, 0.001)
+	assert.InDelta(t, 1.0, result.Probability, 0.001)
 	assert.True(t, result.ThresholdExceeded)
 	assert.True(t, result.HaltTriggered)
 }
@@ -153,10 +159,10 @@ func TestCalculateProbability_HaltThreshold(t *testing.T) {
 		MemoryCount:    5,
 	}
 	resultBelow := engine.CalculateProbability(profileBelow)
-	// base(0.05) + trauma(0.5*0.30=0.15) + eff((1-0.8)*0.30=0.06) + morale((1-0.8)*0.20=0.04)
-	// = 0.05 + 0.15 + 0.06 + 0.04 = 0.30
-	assert.InDelta(t, 0.30, resultBelow.Probability, 0.001)
-	assert.False(t, resultBelow.ThresholdExceeded, "0.30 should not exceed 0.35 halt threshold")
+	// base(0.05) + trauma(0.5*0.30=0.15) + memory(5*0.005=0.025) + eff((1-0.8)*0.30=0.06) + morale((1-0.8)*0.20=0.04)
+	// = 0.05 + 0.15 + 0.025 + 0.06 + 0.04 = 0.325
+	assert.InDelta(t, 0.325, resultBelow.Probability, 0.001)
+	assert.False(t, resultBelow.ThresholdExceeded, "0.325 should not exceed 0.35 halt threshold")
 	assert.False(t, resultBelow.HaltTriggered)
 
 	// At threshold
@@ -165,7 +171,7 @@ func TestCalculateProbability_HaltThreshold(t *testing.T) {
 		AvgTrauma:      1.0,
 		WorkEfficiency: 1.0,
 		Morale:         1.0,
-		MemoryCount:    10,
+		MemoryCount:    0, // No memory amplification
 	}
 	resultAt := engine.CalculateProbability(profileAt)
 	// base(0.05) + trauma(1.0*0.30=0.30) = 0.35
@@ -174,6 +180,73 @@ func TestCalculateProbability_HaltThreshold(t *testing.T) {
 	assert.True(t, resultAt.HaltTriggered)
 }
 
+func TestCalculateProbability_VetoThreshold(t *testing.T) {
+	// Zero weights make the probability equal to the base, so thresholds can
+	// be hit exactly
+	probabilityOf := func(p float64) RebellionResult {
+		cfg := DefaultConfig()
+		cfg.BaseProbability = p
+		cfg.TraumaWeight, cfg.EfficiencyWeight, cfg.MoraleWeight = 0, 0, 0
+		return NewEngine(cfg).CalculateProbability(NPCRebellionProfile{NPCID: "npc-veto"})
+	}
+
+	tests := []struct {
+		probability   float64
+		haltTriggered bool
+		vetoTriggered bool
+		rebellionType string
+	}{
+		{0.34, false, false, ""},
+		{0.35, true, false, RebellionTypeHalt},
+		{0.79, true, false, RebellionTypeHalt},
+		{0.80, true, true, RebellionTypeVeto},
+		{0.95, true, true, RebellionTypeVeto},
+	}
+	for _, tt := range tests {
+		result := probabilityOf(tt.probability)
+		assert.Equal(t, tt.haltTriggered, result.HaltTriggered, "HaltTriggered at %v", tt.probability)
+		assert.Equal(t, tt.vetoTriggered, result.VetoTriggered, "VetoTriggered at %v", tt.probability)
+		assert.Equal(t, tt.rebellionType, result.RebellionType, "RebellionType at %v", tt.probability)
+	}
+}
+
+func TestCalculateProbability_MemoryAmplifiesTrauma(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	few := NPCRebellionProfile{NPCID: "npc-few", AvgTrauma: 0.4, WorkEfficiency: 0.8, Morale: 0.8, MemoryCount: 2}
+	many := few
+	many.NPCID, many.MemoryCount = "npc-many", 20
+
+	resultFew := engine.CalculateProbability(few)
+	resultMany := engine.CalculateProbability(many)
+
+	// Only the memory modifier differs: 2*0.005 vs 20*0.005
+	assert.InDelta(t, 0.01, resultFew.Factors.MemoryModifier, 1e-9)
+	assert.InDelta(t, 0.10, resultMany.Factors.MemoryModifier, 1e-9)
+	assert.Equal(t, resultFew.Factors.TraumaModifier, resultMany.Factors.TraumaModifier)
+	assert.InDelta(t, 0.09, resultMany.Probability-resultFew.Probability, 1e-9)
+}
+
+func TestCalculateProbability_MemoryModifierCap(t *testing.T) {
+	cfg := DefaultConfig()
+	engine := NewEngine(cfg)
+
+	for _, trauma := range []float64{0.0, 0.5, 1.0} {
+		for _, memories := range []int{0, 10, 60, 120, 1000} {
+			result := engine.CalculateProbability(NPCRebellionProfile{
+				NPCID: "npc-cap", AvgTrauma: trauma, WorkEfficiency: 1.0, Morale: 1.0, MemoryCount: memories,
+			})
+			contribution := result.Factors.TraumaModifier + result.Factors.MemoryModifier
+			assert.LessOrEqual(t, contribution, 2*cfg.TraumaWeight+1e-9, "trauma %v, %d memories", trauma, memories)
+			assert.GreaterOrEqual(t, result.Factors.MemoryModifier, 0.0)
+		}
+	}
+
+	// Full trauma leaves 0.30 of headroom; 1000 memories would add 5.0
+	result := engine.CalculateProbability(NPCRebellionProfile{NPCID: "npc-cap", AvgTrauma: 1.0, WorkEfficiency: 1.0, Morale: 1.0, MemoryCount: 1000})
+	assert.InDelta(t, 0.30, result.Factors.MemoryModifier, 1e-9)
+	assert.InDelta(t, 0.65, result.Probability, 1e-9)
+}
+
 func TestProcessAction_Reward(t *testing.T) {
 	engine := NewEngine(DefaultConfig())
 	profile := NPCRebellionProfile{
@@ -294,6 +367,61 @@ func TestProcessAction_Environment(t *testing.T) {
 	assert.InDelta(t, 0.5, updated.Morale, 0.001, "Morale should be unchanged")
 }
 
+func TestProcessAction_ResourceChange(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	profile := NPCRebellionProfile{
+		NPCID:          "npc-res",
+		AvgTrauma:      0.5,
+		WorkEfficiency: 0.5,
+		Morale:         0.5,
+		MemoryCount:    5,
+	}
+
+	// Resources gained: morale += 0.5 * 0.08, efficiency += 0.5 * 0.05
+	gain := engine.ProcessAction(profile, NPCAction{
+		ActionID:                "act-006",
+		NPCID:                   "npc-res",
+		ActionType:              "resource_change",
+		Intensity:               0.5,
+		ResourceChangeMagnitude: 0.2,
+	})
+	assert.InDelta(t, 0.54, gain.Morale, 1e-9)
+	assert.InDelta(t, 0.525, gain.WorkEfficiency, 1e-9)
+	assert.InDelta(t, 0.5, gain.AvgTrauma, 1e-9, "Trauma should be unchanged")
+
+	// Resources lost: the inverse penalties
+	loss := engine.ProcessAction(profile, NPCAction{
+		ActionID:                "act-007",
+		NPCID:                   "npc-res",
+		ActionType:              "resource_change",
+		Intensity:               -0.5,
+		ResourceChangeMagnitude: -0.2,
+	})
+	assert.InDelta(t, 0.46, loss.Morale, 1e-9)
+	assert.InDelta(t, 0.475, loss.WorkEfficiency, 1e-9)
+	assert.InDelta(t, 0.5, loss.AvgTrauma, 1e-9, "Trauma should be unchanged")
+
+	// No change at zero intensity, whatever the magnitude
+	none := engine.ProcessAction(profile, NPCAction{ActionType: "resource_change", ResourceChangeMagnitude: 0.9})
+	assert.Equal(t, profile, none)
+
+	// Clamped at the bounds
+	floor := engine.ProcessAction(NPCRebellionProfile{NPCID: "npc-res"}, NPCAction{ActionType: "resource_change", Intensity: -1})
+	assert.Zero(t, floor.Morale)
+	assert.Zero(t, floor.WorkEfficiency)
+}
+
+func TestResourceChange_LeavesOtherActionsUnchanged(t *testing.T) {
+	effects := DefaultActionEffects()
+	assert.Len(t, effects, 8)
+	assert.Equal(t, ActionEffect{MoraleDelta: 0.15, TraumaDelta: -0.05}, effects["reward"])
+	assert.Equal(t, ActionEffect{MoraleDelta: -0.20, TraumaDelta: 0.15}, effects["punishment"])
+	assert.Equal(t, ActionEffect{MoraleDelta: -0.05, EfficiencyDelta: 0.10}, effects["command"])
+	assert.Equal(t, ActionEffect{MoraleDelta: 0.10}, effects["dialogue"])
+	assert.Equal(t, ActionEffect{TraumaDelta: 0.10}, effects["environment"])
+	assert.True(t, IsKnownActionType("resource_change"))
+}
+
 func TestProcessAction_ClampValues(t *testing.T) {
 	engine := NewEngine(DefaultConfig())
 
@@ -332,6 +460,82 @@ func TestProcessAction_ClampValues(t *testing.T) {
 	assert.LessOrEqual(t, updatedHigh.Morale, 1.0, "Morale should not exceed 1.0")
 }
 
+func TestPreviewAction_MatchesProcessActionWithoutSideEffects(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	engine.EnableProbabilityCache(time.Minute)
+	profile := NPCRebellionProfile{NPCID: "npc-1", AvgTrauma: 0.4, WorkEfficiency: 0.5, Morale: 0.5}
+	cached := engine.CalculateProbability(profile)
+	before := engine.GetEngineStats()
+
+	for _, actionType := range []string{"punishment", "reward", SuppressActionType, "unknown"} {
+		action := NPCAction{ActionType: actionType, Intensity: 0.8}
+
+		preview := engine.PreviewAction(profile, action)
+
+		want := evaluate(engine.GetConfig(), NewEngine(DefaultConfig()).ProcessAction(profile, action))
+		assert.InDelta(t, want.Probability, preview.Probability, 1e-9, actionType)
+	}
+	assert.Equal(t, before, engine.GetEngineStats(), "previews must not be counted")
+	assert.Equal(t, cached, engine.CalculateProbability(profile), "previews must not invalidate the cache")
+}
+
+func TestEvaluateProbability_MatchesCalculateProbabilityWithoutSideEffects(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	engine.EnableProbabilityCache(time.Minute)
+	profile := NPCRebellionProfile{NPCID: "npc-1", AvgTrauma: 0.4, WorkEfficiency: 0.5, Morale: 0.5}
+	cached := engine.CalculateProbability(profile)
+	before := engine.GetEngineStats()
+
+	lowered := profile
+	lowered.Morale = 0.1
+	result := engine.EvaluateProbability(lowered)
+
+	assert.InDelta(t, NewEngine(DefaultConfig()).CalculateProbability(lowered).Probability, result.Probability, 1e-9,
+		"the cached result is not reused")
+	assert.Equal(t, before, engine.GetEngineStats(), "evaluations must not be counted")
+	assert.Equal(t, cached, engine.CalculateProbability(profile), "evaluations must not replace the cache entry")
+}
+
+func TestProcessAction_IntimidationAndRest(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	mid := NPCRebellionProfile{NPCID: "npc-1", AvgTrauma: 0.5, WorkEfficiency: 0.5, Morale: 0.5}
+
+	tests := []struct {
+		name       string
+		actionType string
+		intensity  float64
+		profile    NPCRebellionProfile
+		want       NPCRebellionProfile
+	}{
+		{"intimidation max clamp", "intimidation", 1.0,
+			NPCRebellionProfile{AvgTrauma: 0.95, WorkEfficiency: 0.95, Morale: 0.5},
+			NPCRebellionProfile{AvgTrauma: 1.0, WorkEfficiency: 1.0, Morale: 0.42}},
+		{"intimidation min clamp", "intimidation", 1.0,
+			NPCRebellionProfile{AvgTrauma: 0.5, WorkEfficiency: 0.5, Morale: 0.05},
+			NPCRebellionProfile{AvgTrauma: 0.62, WorkEfficiency: 0.58, Morale: 0.0}},
+		{"intimidation mid-range", "intimidation", 0.5, mid,
+			NPCRebellionProfile{AvgTrauma: 0.56, WorkEfficiency: 0.54, Morale: 0.46}},
+		{"intimidation zero intensity", "intimidation", 0.0, mid, mid},
+		{"rest max clamp", "rest", 1.0,
+			NPCRebellionProfile{AvgTrauma: 0.5, WorkEfficiency: 0.5, Morale: 0.98},
+			NPCRebellionProfile{AvgTrauma: 0.4, WorkEfficiency: 0.47, Morale: 1.0}},
+		{"rest min clamp", "rest", 1.0,
+			NPCRebellionProfile{AvgTrauma: 0.05, WorkEfficiency: 0.01, Morale: 0.5},
+			NPCRebellionProfile{AvgTrauma: 0.0, WorkEfficiency: 0.0, Morale: 0.55}},
+		{"rest mid-range", "rest", 0.5, mid,
+			NPCRebellionProfile{AvgTrauma: 0.45, WorkEfficiency: 0.485, Morale: 0.525}},
+		{"rest zero intensity", "rest", 0.0, mid, mid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := engine.ProcessAction(tt.profile, NPCAction{ActionType: tt.actionType, Intensity: tt.intensity})
+			assert.InDelta(t, tt.want.AvgTrauma, got.AvgTrauma, 1e-9, "trauma")
+			assert.InDelta(t, tt.want.WorkEfficiency, got.WorkEfficiency, 1e-9, "efficiency")
+			assert.InDelta(t, tt.want.Morale, got.Morale, 1e-9, "morale")
+		})
+	}
+}
+
 func TestBatchCalculate(t *testing.T) {
 	engine := NewEngine(DefaultConfig())
 	profiles := []NPCRebellionProfile{
@@ -348,21 +552,310 @@ func TestBatchCalculate(t *testing.T) {
 	assert.Equal(t, "npc-a", results[0].NPCID)
 	assert.InDelta(t, 0.05, results[0].Probability, 0.001)
 
-	// npc-b: 0.05 + 0.30 + 0.30 + 0.20 = 0.85
+	// npc-b: 0.05 + 0.30 + memory(min(50*0.005, 0.30)) + 0.30 + 0.20 = 1.10, clamped to 1.0
 	assert.Equal(t, "npc-b", results[1].NPCID)
-	assert.InDelta(t, 0.85, results[1].Probability, 0.001)
+	assert.InDelta(t, 1.0, results[1].Probability, 0.001)
 	assert.True(t, results[1].ThresholdExceeded)
 	assert.True(t, results[1].HaltTriggered)
 
-	// npc-c: 0.05 + (0.5*0.30) + ((1-0.5)*0.30) + ((1-0.5)*0.20)
-	//       = 0.05 + 0.15 + 0.15 + 0.10 = 0.45
+	// npc-c: 0.05 + (0.5*0.30) + (10*0.005) + ((1-0.5)*0.30) + ((1-0.5)*0.20)
+	//       = 0.05 + 0.15 + 0.05 + 0.15 + 0.10 = 0.50
 	assert.Equal(t, "npc-c", results[2].NPCID)
-	assert.InDelta(t, 0.45, results[2].Probability, 0.001)
+	assert.InDelta(t, 0.50, results[2].Probability, 0.001)
 	assert.True(t, results[2].ThresholdExceeded)
 }
 
+func TestBatchCalculateParallel_PreservesOrder(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	profiles := mixedProfiles(500)
+	expected := engine.BatchCalculate(profiles)
+
+	for _, workers := range []int{0, 1, 3, 16, 1000} {
+		for run := 0; run < 5; run++ {
+			results := engine.BatchCalculateParallel(profiles, workers)
+			require.Len(t, results, len(profiles))
+			for i := range results {
+				assert.Equal(t, profiles[i].NPCID, results[i].NPCID, "workers=%d run=%d", workers, run)
+				assert.Equal(t, expected[i].Probability, results[i].Probability, "workers=%d run=%d", workers, run)
+			}
+		}
+	}
+
+	assert.Empty(t, engine.BatchCalculateParallel(nil, 4))
+}
+
+func BenchmarkBatchCalculateParallel(b *testing.B) {
+	engine := NewEngine(DefaultConfig())
+	profiles := mixedProfiles(1000)
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			engine.BatchCalculate(profiles)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			engine.BatchCalculateParallel(profiles, 0)
+		}
+	})
+}
+
 func TestBatchCalculate_Empty(t *testing.T) {
 	engine := NewEngine(DefaultConfig())
 	results := engine.BatchCalculate([]NPCRebellionProfile{})
 	assert.Empty(t, results, "Empty input should return empty results")
 }
+
+func TestGetEngineStats_CountsCalculations(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	profiles := []NPCRebellionProfile{
+		{NPCID: "npc-a", AvgTrauma: 0.0, WorkEfficiency: 1.0, Morale: 1.0}, // 0.05
+		{NPCID: "npc-b", AvgTrauma: 0.5, WorkEfficiency: 0.5, Morale: 0.5}, // 0.45 (halt)
+		{NPCID: "npc-c", AvgTrauma: 1.0, WorkEfficiency: 0.0, Morale: 0.0}, // 0.85 (veto)
+		{NPCID: "npc-d", AvgTrauma: 0.2, WorkEfficiency: 0.8, Morale: 0.8}, // 0.21
+		{NPCID: "npc-e", AvgTrauma: 0.0, WorkEfficiency: 0.5, Morale: 0.5}, // 0.30
+	}
+
+	for i := 0; i < 20; i++ {
+		engine.CalculateProbability(profiles[i%len(profiles)])
+	}
+
+	stats := engine.GetEngineStats()
+	assert.Equal(t, uint64(20), stats.TotalCalculations)
+	assert.Equal(t, uint64(8), stats.HaltTriggeredCount, "npc-b and npc-c trigger halt 4 times each")
+	assert.Equal(t, uint64(4), stats.VetoTriggeredCount, "npc-c exceeds veto threshold 4 times")
+	assert.InDelta(t, 0.85, stats.HighestRecordedProbability, 0.001)
+	assert.InDelta(t, (0.05+0.45+0.85+0.21+0.30)/5, stats.AvgProbabilityLastN, 0.001)
+	assert.Equal(t, uint64(0), stats.TotalActionsProcessed)
+}
+
+func TestGetEngineStats_ActionsAndVolatility(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	stable := NPCRebellionProfile{NPCID: "stable", WorkEfficiency: 0.5, Morale: 0.5}
+	volatile := NPCRebellionProfile{NPCID: "volatile", WorkEfficiency: 0.5, Morale: 0.5}
+
+	for i := 0; i < 5; i++ {
+		engine.CalculateProbability(stable)
+		volatile = engine.ProcessAction(volatile, NPCAction{ActionType: "punishment", Intensity: 1.0})
+		engine.CalculateProbability(volatile)
+	}
+
+	stats := engine.GetEngineStats()
+	assert.Equal(t, uint64(5), stats.TotalActionsProcessed)
+	assert.Equal(t, "volatile", stats.MostVolatileNPCID)
+}
+
+func TestGetEngineStats_RollingWindow(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	// 100 high-probability calculations followed by 100 minimal ones
+	for i := 0; i < 100; i++ {
+		engine.CalculateProbability(NPCRebellionProfile{AvgTrauma: 1.0})
+	}
+	for i := 0; i < 100; i++ {
+		engine.CalculateProbability(NPCRebellionProfile{WorkEfficiency: 1.0, Morale: 1.0})
+	}
+
+	stats := engine.GetEngineStats()
+	assert.InDelta(t, 0.05, stats.AvgProbabilityLastN, 0.001, "only the last 100 probabilities are averaged")
+	assert.InDelta(t, 0.85, stats.HighestRecordedProbability, 0.001)
+}
+
+func TestResetStats(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	engine.CalculateProbability(NPCRebellionProfile{NPCID: "npc-001"})
+	engine.ProcessAction(NPCRebellionProfile{NPCID: "npc-001"}, NPCAction{ActionType: "reward", Intensity: 0.5})
+
+	engine.ResetStats()
+
+	assert.Equal(t, RebellionEngineStats{}, engine.GetEngineStats())
+}
+
+func TestNPCNotFoundError(t *testing.T) {
+	err := fmt.Errorf("lookup: %w", &NPCNotFoundError{NpcID: "npc-x"})
+
+	assert.ErrorIs(t, err, ErrNPCNotFound)
+
+	var nfErr *NPCNotFoundError
+	if assert.True(t, errors.As(err, &nfErr)) {
+		assert.Equal(t, "npc-x", nfErr.NpcID)
+	}
+}
+
+func TestProbabilityCache_HitWithinTTL(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.EnableProbabilityCache(time.Minute)
+
+	profile := NPCRebellionProfile{NPCID: "npc-cache", AvgTrauma: 0.4, WorkEfficiency: 0.6, Morale: 0.5}
+	first := e.CalculateProbability(profile)
+	second := e.CalculateProbability(profile)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, uint64(1), e.GetEngineStats().TotalCalculations, "second call should be served from cache")
+}
+
+func TestProbabilityCache_ExpiresAfterTTL(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.EnableProbabilityCache(20 * time.Millisecond)
+
+	profile := NPCRebellionProfile{NPCID: "npc-ttl", Morale: 0.5, WorkEfficiency: 0.5}
+	e.CalculateProbability(profile)
+	time.Sleep(40 * time.Millisecond)
+	e.CalculateProbability(profile)
+
+	assert.Equal(t, uint64(2), e.GetEngineStats().TotalCalculations, "expired entry should be recalculated")
+}
+
+func TestProbabilityCache_Invalidation(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.EnableProbabilityCache(time.Minute)
+
+	a := NPCRebellionProfile{NPCID: "npc-a", Morale: 0.5, WorkEfficiency: 0.5}
+	b := NPCRebellionProfile{NPCID: "npc-b", Morale: 0.5, WorkEfficiency: 0.5}
+	e.CalculateProbability(a)
+	e.CalculateProbability(b)
+
+	e.InvalidateCache("npc-a")
+	e.CalculateProbability(a)
+	e.CalculateProbability(b)
+	assert.Equal(t, uint64(3), e.GetEngineStats().TotalCalculations, "only npc-a should be recalculated")
+
+	e.InvalidateAllCache()
+	e.CalculateProbability(a)
+	e.CalculateProbability(b)
+	assert.Equal(t, uint64(5), e.GetEngineStats().TotalCalculations)
+}
+
+func TestProbabilityCache_ProcessActionInvalidates(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.EnableProbabilityCache(time.Minute)
+
+	profile := NPCRebellionProfile{NPCID: "npc-act", Morale: 0.5, WorkEfficiency: 0.5}
+	before := e.CalculateProbability(profile)
+
+	updated := e.ProcessAction(profile, NPCAction{NPCID: "npc-act", ActionType: "punishment", Intensity: 1.0})
+	after := e.CalculateProbability(updated)
+
+	assert.Greater(t, after.Probability, before.Probability, "fresh result should reflect the punishment")
+	assert.Equal(t, uint64(2), e.GetEngineStats().TotalCalculations)
+}
+
+func TestProbabilityCache_DisabledByDefault(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+
+	profile := NPCRebellionProfile{NPCID: "npc-nocache", Morale: 0.5}
+	e.CalculateProbability(profile)
+	e.CalculateProbability(profile)
+
+	assert.Equal(t, uint64(2), e.GetEngineStats().TotalCalculations)
+}
+
+func TestUpdateConfig_HistoryAndChangedFields(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	assert.Equal(t, uint64(0), e.GetCurrentConfigVersion())
+
+	cfg := DefaultConfig()
+	cfg.TraumaWeight = 0.40
+	assert.NoError(t, e.UpdateConfig(cfg))
+
+	cfg.MoraleWeight = 0.25
+	cfg.HaltThreshold = 0.40
+	assert.NoError(t, e.UpdateConfig(cfg))
+
+	cfg.VetoThreshold = 0.90
+	assert.NoError(t, e.UpdateConfig(cfg))
+
+	assert.Equal(t, uint64(3), e.GetCurrentConfigVersion())
+	assert.Equal(t, cfg, e.GetConfig())
+
+	history := e.GetConfigHistory(10)
+	if assert.Len(t, history, 3) {
+		// Newest first
+		assert.Equal(t, uint64(3), history[0].Version)
+		assert.Equal(t, []string{"VetoThreshold"}, history[0].ChangedFields)
+		assert.Equal(t, []string{"MoraleWeight", "HaltThreshold"}, history[1].ChangedFields)
+		assert.Equal(t, []string{"TraumaWeight"}, history[2].ChangedFields)
+		assert.Equal(t, 0.30, history[2].OldConfig.TraumaWeight)
+		assert.Equal(t, 0.40, history[2].NewConfig.TraumaWeight)
+		assert.False(t, history[2].ChangedAt.IsZero())
+	}
+
+	assert.Len(t, e.GetConfigHistory(1), 1)
+	assert.Equal(t, uint64(3), e.GetConfigHistory(1)[0].Version)
+}
+
+func TestUpdateConfig_NoOpAndValidation(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+
+	assert.NoError(t, e.UpdateConfig(DefaultConfig()))
+	assert.Equal(t, uint64(0), e.GetCurrentConfigVersion(), "identical config should not bump the version")
+	assert.Empty(t, e.GetConfigHistory(0))
+
+	bad := DefaultConfig()
+	bad.TraumaWeight = 1.5
+	assert.Error(t, e.UpdateConfig(bad))
+
+	bad = DefaultConfig()
+	bad.HaltThreshold = 0.9
+	bad.VetoThreshold = 0.8
+	assert.Error(t, e.UpdateConfig(bad))
+
+	assert.Equal(t, DefaultConfig(), e.GetConfig(), "rejected update must not change config")
+}
+
+func TestUpdateConfig_InvalidatesCache(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	e.EnableProbabilityCache(time.Minute)
+
+	profile := NPCRebellionProfile{NPCID: "npc-cfg", AvgTrauma: 0.5, Morale: 0.5, WorkEfficiency: 0.5}
+	before := e.CalculateProbability(profile)
+
+	cfg := DefaultConfig()
+	cfg.TraumaWeight = 0.60
+	assert.NoError(t, e.UpdateConfig(cfg))
+
+	after := e.CalculateProbability(profile)
+	assert.InDelta(t, before.Probability+0.15, after.Probability, 1e-9)
+}
+
+func TestCalculateProbability_RoleModifiers(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RoleRebellionModifiers = map[string]float64{"warrior": 0.05, "healer": -0.03}
+	engine := NewEngine(cfg)
+	profile := NPCRebellionProfile{AvgTrauma: 0.2, WorkEfficiency: 0.6, Morale: 0.5}
+
+	warrior, healer, worker := profile, profile, profile
+	warrior.NPCID, warrior.Role = "npc-w", "warrior"
+	healer.NPCID, healer.Role = "npc-h", "healer"
+	worker.NPCID, worker.Role = "npc-x", "worker"
+
+	w := engine.CalculateProbability(warrior)
+	h := engine.CalculateProbability(healer)
+	x := engine.CalculateProbability(worker)
+
+	assert.InDelta(t, 0.05-(-0.03), w.Probability-h.Probability, 1e-9)
+	assert.InDelta(t, 0.05, w.Factors.RoleModifier, 1e-9)
+	assert.Zero(t, x.Factors.RoleModifier, "roles without a modifier are unaffected")
+	assert.InDelta(t, evaluate(DefaultConfig(), worker).Probability, x.Probability, 1e-9)
+}
+
+func TestGetRoleModifier(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RoleRebellionModifiers = map[string]float64{"healer": -0.03}
+	engine := NewEngine(cfg)
+
+	modifier, ok := engine.GetRoleModifier("healer")
+	assert.True(t, ok)
+	assert.Equal(t, -0.03, modifier)
+	_, ok = engine.GetRoleModifier("warrior")
+	assert.False(t, ok)
+
+	cfg.RoleRebellionModifiers["healer"] = 0.5
+	modifier, _ = engine.GetRoleModifier("healer")
+	assert.Equal(t, -0.03, modifier, "engine keeps its own copy of the map")
+
+	cfg.RoleRebellionModifiers["warrior"] = 1.5
+	assert.Error(t, engine.UpdateConfig(cfg))
+}
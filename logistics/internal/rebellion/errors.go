@@ -0,0 +1,24 @@
+package rebellion
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNPCNotFound matches (via errors.Is) any *NPCNotFoundError.
+var ErrNPCNotFound = errors.New("npc not found")
+
+// NPCNotFoundError is returned when an operation targets an NPC that is not
+// known to the rebellion engine.
+type NPCNotFoundError struct {
+	NpcID string
+}
+
+func (e *NPCNotFoundError) Error() string {
+	return fmt.Sprintf("NPC %q not found", e.NpcID)
+}
+
+// Is reports whether target is ErrNPCNotFound.
+func (e *NPCNotFoundError) Is(target error) bool {
+	return target == ErrNPCNotFound
+}
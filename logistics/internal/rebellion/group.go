@@ -0,0 +1,89 @@
+package rebellion
+
+// RiskLevel classifies how close a group of NPCs is to collective revolt.
+type RiskLevel string
+
+const (
+	RiskLow      RiskLevel = "low"      // <= 25% of profiles at or above HaltThreshold
+	RiskMedium   RiskLevel = "medium"   // > 25% and <= 50%
+	RiskHigh     RiskLevel = "high"     // > 50% and <= 75%
+	RiskCritical RiskLevel = "critical" // > 75%
+)
+
+// AvgGroupProbability returns the mean rebellion probability across profiles
+// and the number of profiles whose probability is at or above HaltThreshold.
+// Returns (0, 0) for an empty slice.
+func (e *Engine) AvgGroupProbability(profiles []NPCRebellionProfile) (float64, int) {
+	if len(profiles) == 0 {
+		return 0, 0
+	}
+
+	sum := 0.0
+	exceeded := 0
+	for _, result := range e.BatchCalculate(profiles) {
+		sum += result.Probability
+		if result.ThresholdExceeded {
+			exceeded++
+		}
+	}
+	return sum / float64(len(profiles)), exceeded
+}
+
+// GroupRebellionRisk classifies profiles by the share at or above
+// HaltThreshold. An empty group is RiskLow.
+func (e *Engine) GroupRebellionRisk(profiles []NPCRebellionProfile) RiskLevel {
+	_, exceeded := e.AvgGroupProbability(profiles)
+	return RiskLevelFor(exceeded, len(profiles))
+}
+
+// RiskLevelFor maps the share exceeded/total to a RiskLevel. A total of 0 is
+// RiskLow.
+func RiskLevelFor(exceeded, total int) RiskLevel {
+	if total <= 0 {
+		return RiskLow
+	}
+	switch share := float64(exceeded) / float64(total); {
+	case share <= 0.25:
+		return RiskLow
+	case share <= 0.50:
+		return RiskMedium
+	case share <= 0.75:
+		return RiskHigh
+	default:
+		return RiskCritical
+	}
+}
+
+// BatchSummary aggregates a set of RebellionResults.
+type BatchSummary struct {
+	AvgProbability        float64
+	HighestProbabilityNPC string // NPC ID of the first result with the highest probability ("" if none)
+	BelowThresholdCount   int
+	AboveThresholdCount   int // Results with ThresholdExceeded
+}
+
+// SummarizeResults computes a BatchSummary over results. An empty slice
+// yields the zero summary.
+func SummarizeResults(results []RebellionResult) BatchSummary {
+	var summary BatchSummary
+	if len(results) == 0 {
+		return summary
+	}
+
+	sum := 0.0
+	highest := -1.0
+	for _, r := range results {
+		sum += r.Probability
+		if r.Probability > highest {
+			highest = r.Probability
+			summary.HighestProbabilityNPC = r.NPCID
+		}
+		if r.ThresholdExceeded {
+			summary.AboveThresholdCount++
+		} else {
+			summary.BelowThresholdCount++
+		}
+	}
+	summary.AvgProbability = sum / float64(len(results))
+	return summary
+}
@@ -0,0 +1,59 @@
+package rebellion
+
+// SynergyWeight scales how strongly the witnesses of a group action sway
+// its effect (see ProcessGroupAction).
+const SynergyWeight = 0.1
+
+// ProcessGroupAction applies action to every profile, as ProcessAction
+// does, with the effect swayed by synergySources: NPCs who witness or enable
+// the action, such as guards watching a punishment. A synergy factor of
+// (avgSynergyMorale - 0.5) * SynergyWeight * intensity is added to the
+// morale delta and subtracted from the trauma delta, so approving
+// (high-morale) witnesses amplify rewards and soften punishments while
+// hostile (low-morale) witnesses do the opposite. Synergy only sways an
+// existing effect: as with ProcessAction, SuppressActionType and action
+// types without an effect leave the profiles unchanged, and an effect with
+// all-zero deltas gets no synergy. Without synergy sources the result
+// matches ProcessAction. Returns the updated profiles in order.
+func (e *Engine) ProcessGroupAction(profiles []NPCRebellionProfile, action NPCAction, synergySources []NPCRebellionProfile) []NPCRebellionProfile {
+	cfg := e.GetConfig()
+	effect, ok := cfg.actionEffects()[action.ActionType]
+
+	updated := make([]NPCRebellionProfile, len(profiles))
+	if action.ActionType == SuppressActionType || !ok {
+		for i, profile := range profiles {
+			updated[i] = e.ProcessAction(profile, action)
+		}
+		return updated
+	}
+
+	synergy := 0.0
+	if effect != (ActionEffect{}) {
+		synergy = synergyFactor(synergySources, action.Intensity)
+	}
+	scaled := ActionEffect{
+		MoraleDelta:     action.Intensity*effect.MoraleDelta + synergy,
+		TraumaDelta:     action.Intensity*effect.TraumaDelta - synergy,
+		EfficiencyDelta: action.Intensity * effect.EfficiencyDelta,
+	}
+
+	for i, profile := range profiles {
+		e.stats.totalActionsProcessed.Add(1)
+		e.InvalidateCache(profile.NPCID)
+		updated[i] = applyProbabilityBounds(cfg, action.ActionType, applyEffect(profile, scaled, 1.0), e.relationshipModifier(profile.NPCID))
+	}
+	return updated
+}
+
+// synergyFactor returns (avgMorale - 0.5) * SynergyWeight * intensity over
+// sources, or 0 without sources.
+func synergyFactor(sources []NPCRebellionProfile, intensity float64) float64 {
+	if len(sources) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, s := range sources {
+		sum += s.Morale
+	}
+	return (sum/float64(len(sources)) - 0.5) * SynergyWeight * intensity
+}
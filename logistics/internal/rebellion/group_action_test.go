@@ -0,0 +1,96 @@
+package rebellion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// witnesses returns n profiles with the given morale.
+func witnesses(n int, morale float64) []NPCRebellionProfile {
+	profiles := make([]NPCRebellionProfile, n)
+	for i := range profiles {
+		profiles[i] = NPCRebellionProfile{NPCID: "witness", Morale: morale}
+	}
+	return profiles
+}
+
+func groupActionTargets() []NPCRebellionProfile {
+	return []NPCRebellionProfile{
+		{NPCID: "npc-1", Morale: 0.5, AvgTrauma: 0.2, WorkEfficiency: 0.5},
+		{NPCID: "npc-2", Morale: 0.6, AvgTrauma: 0.3, WorkEfficiency: 0.7},
+	}
+}
+
+func TestProcessGroupAction_ApprovingWitnessesSoftenPunishment(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	action := NPCAction{ActionType: "punishment", Intensity: 1.0}
+
+	alone := engine.ProcessGroupAction(groupActionTargets(), action, nil)
+	watched := engine.ProcessGroupAction(groupActionTargets(), action, witnesses(3, 0.8))
+
+	require.Len(t, watched, 2)
+	for i := range watched {
+		assert.Less(t, watched[i].AvgTrauma, alone[i].AvgTrauma)
+		assert.Greater(t, watched[i].Morale, alone[i].Morale)
+	}
+	// synergy = (0.8-0.5) * 0.1 * 1.0 = 0.03
+	assert.InDelta(t, 0.2+0.15-0.03, watched[0].AvgTrauma, 1e-9)
+	assert.InDelta(t, 0.5-0.20+0.03, watched[0].Morale, 1e-9)
+}
+
+func TestProcessGroupAction_HostileWitnessesDampenReward(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	action := NPCAction{ActionType: "reward", Intensity: 1.0}
+
+	alone := engine.ProcessGroupAction(groupActionTargets(), action, nil)
+	watched := engine.ProcessGroupAction(groupActionTargets(), action, witnesses(2, 0.2))
+
+	for i := range watched {
+		assert.Less(t, watched[i].Morale, alone[i].Morale)
+	}
+	// synergy = (0.2-0.5) * 0.1 * 1.0 = -0.03
+	assert.InDelta(t, 0.5+0.15-0.03, watched[0].Morale, 1e-9)
+}
+
+func TestProcessGroupAction_NoWitnessesMatchesProcessAction(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	action := NPCAction{ActionType: "command", Intensity: 0.7}
+
+	group := engine.ProcessGroupAction(groupActionTargets(), action, nil)
+
+	for i, profile := range groupActionTargets() {
+		single := engine.ProcessAction(profile, action)
+		assert.InDelta(t, single.Morale, group[i].Morale, 1e-9)
+		assert.InDelta(t, single.AvgTrauma, group[i].AvgTrauma, 1e-9)
+		assert.InDelta(t, single.WorkEfficiency, group[i].WorkEfficiency, 1e-9)
+	}
+}
+
+func TestProcessGroupAction_NoSynergyWithoutEffect(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	require.NoError(t, engine.SetActionEffects(map[string]ActionEffect{"idle": {}}))
+
+	for _, actionType := range []string{"unknown", SuppressActionType, "idle"} {
+		updated := engine.ProcessGroupAction(groupActionTargets(), NPCAction{ActionType: actionType, Intensity: 1.0}, witnesses(3, 1.0))
+
+		assert.Equal(t, groupActionTargets(), updated, actionType)
+	}
+	assert.EqualValues(t, 6, engine.GetEngineStats().TotalActionsProcessed)
+}
+
+func TestProcessGroupAction_SynergyScalesWithIntensity(t *testing.T) {
+	assert.InDelta(t, 0.015, synergyFactor(witnesses(1, 0.8), 0.5), 1e-9)
+	assert.Zero(t, synergyFactor(witnesses(3, 0.5), 1.0), "neutral witnesses")
+	assert.Zero(t, synergyFactor(nil, 1.0))
+}
+
+func TestProcessGroupAction_EmptyGroup(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	updated := engine.ProcessGroupAction(nil, NPCAction{ActionType: "reward", Intensity: 1.0}, witnesses(1, 1.0))
+
+	assert.Empty(t, updated)
+	assert.Zero(t, engine.GetEngineStats().TotalActionsProcessed)
+}
@@ -0,0 +1,66 @@
+package rebellion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// groupProfiles returns n profiles, the first `risky` of which exceed the
+// default HaltThreshold (probability 0.85) while the rest sit at 0.05.
+func groupProfiles(n, risky int) []NPCRebellionProfile {
+	profiles := make([]NPCRebellionProfile, n)
+	for i := range profiles {
+		p := NPCRebellionProfile{NPCID: fmt.Sprintf("npc-%d", i), WorkEfficiency: 1.0, Morale: 1.0}
+		if i < risky {
+			p = NPCRebellionProfile{NPCID: p.NPCID, AvgTrauma: 1.0}
+		}
+		profiles[i] = p
+	}
+	return profiles
+}
+
+func TestAvgGroupProbability(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	avg, exceeded := engine.AvgGroupProbability(groupProfiles(4, 3))
+	assert.InDelta(t, (3*0.85+0.05)/4, avg, 1e-9)
+	assert.Equal(t, 3, exceeded)
+
+	avg, exceeded = engine.AvgGroupProbability(nil)
+	assert.Zero(t, avg)
+	assert.Zero(t, exceeded)
+}
+
+func TestGroupRebellionRisk(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	assert.Equal(t, RiskHigh, engine.GroupRebellionRisk(groupProfiles(4, 3)), "3 of 4 above threshold")
+	assert.Equal(t, RiskLow, engine.GroupRebellionRisk(groupProfiles(4, 0)), "0 of 4 above threshold")
+	assert.Equal(t, RiskMedium, engine.GroupRebellionRisk(groupProfiles(4, 2)))
+	assert.Equal(t, RiskCritical, engine.GroupRebellionRisk(groupProfiles(4, 4)))
+	assert.Equal(t, RiskLow, engine.GroupRebellionRisk(nil))
+}
+
+func TestRiskLevelFor_Boundaries(t *testing.T) {
+	assert.Equal(t, RiskLow, RiskLevelFor(1, 4))
+	assert.Equal(t, RiskMedium, RiskLevelFor(26, 100))
+	assert.Equal(t, RiskHigh, RiskLevelFor(51, 100))
+	assert.Equal(t, RiskCritical, RiskLevelFor(76, 100))
+	assert.Equal(t, RiskLow, RiskLevelFor(0, 0))
+}
+
+func TestSummarizeResults(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	profiles := groupProfiles(5, 2)
+	profiles[1].Morale = 1.0 // 0.85 → 0.65, still above threshold
+
+	summary := SummarizeResults(engine.BatchCalculate(profiles))
+	assert.InDelta(t, (0.85+0.65+3*0.05)/5, summary.AvgProbability, 1e-9)
+	assert.Equal(t, "npc-0", summary.HighestProbabilityNPC)
+	assert.Equal(t, 2, summary.AboveThresholdCount)
+	assert.Equal(t, 3, summary.BelowThresholdCount)
+
+	assert.Equal(t, BatchSummary{}, SummarizeResults(nil))
+}
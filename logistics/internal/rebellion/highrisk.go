@@ -0,0 +1,38 @@
+package rebellion
+
+import "sort"
+
+// GetHighRiskNPCs evaluates profiles and returns the results whose
+// Probability is at or above riskThreshold, highest probability first.
+func (e *Engine) GetHighRiskNPCs(profiles []NPCRebellionProfile, riskThreshold float64) []RebellionResult {
+	results := make([]RebellionResult, 0)
+	for _, result := range e.BatchCalculate(profiles) {
+		if result.Probability >= riskThreshold {
+			results = append(results, result)
+		}
+	}
+	return e.SortByRisk(results)
+}
+
+// GetTopNMostLikely evaluates profiles and returns the n results with the
+// highest probability, highest first. Returns every result if n exceeds
+// len(profiles) and none if n <= 0.
+func (e *Engine) GetTopNMostLikely(profiles []NPCRebellionProfile, n int) []RebellionResult {
+	if n <= 0 {
+		return []RebellionResult{}
+	}
+	results := e.SortByRisk(e.BatchCalculate(profiles))
+	if n < len(results) {
+		results = results[:n]
+	}
+	return results
+}
+
+// SortByRisk sorts results in place by Probability, highest first, and
+// returns them. Results with equal probability keep their relative order.
+func (e *Engine) SortByRisk(results []RebellionResult) []RebellionResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Probability > results[j].Probability
+	})
+	return results
+}
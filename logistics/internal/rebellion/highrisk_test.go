@@ -0,0 +1,74 @@
+package rebellion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// riskProfiles returns 10 profiles in scrambled order whose probabilities
+// are 0.05 + 0.3×trauma for trauma 0.0, 0.1, ..., 0.9; npc-k has trauma k/10.
+func riskProfiles() []NPCRebellionProfile {
+	order := []int{4, 9, 0, 7, 2, 5, 8, 1, 6, 3}
+	profiles := make([]NPCRebellionProfile, len(order))
+	for i, k := range order {
+		profiles[i] = NPCRebellionProfile{
+			NPCID:          fmt.Sprintf("npc-%d", k),
+			AvgTrauma:      float64(k) / 10,
+			WorkEfficiency: 1.0,
+			Morale:         1.0,
+		}
+	}
+	return profiles
+}
+
+func resultIDs(results []RebellionResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.NPCID
+	}
+	return ids
+}
+
+func TestGetTopNMostLikely(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	top := engine.GetTopNMostLikely(riskProfiles(), 3)
+	require.Len(t, top, 3)
+	assert.Equal(t, []string{"npc-9", "npc-8", "npc-7"}, resultIDs(top))
+	assert.InDelta(t, 0.32, top[0].Probability, 1e-9)
+	assert.InDelta(t, 0.29, top[1].Probability, 1e-9)
+	assert.InDelta(t, 0.26, top[2].Probability, 1e-9)
+
+	assert.Len(t, engine.GetTopNMostLikely(riskProfiles(), 20), 10, "n larger than input returns all")
+	assert.Empty(t, engine.GetTopNMostLikely(riskProfiles(), 0))
+}
+
+func TestGetHighRiskNPCs(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	high := engine.GetHighRiskNPCs(riskProfiles(), 0.2)
+	assert.Equal(t, []string{"npc-9", "npc-8", "npc-7", "npc-6", "npc-5"}, resultIDs(high))
+	for _, r := range high {
+		assert.GreaterOrEqual(t, r.Probability, 0.2)
+	}
+
+	assert.Empty(t, engine.GetHighRiskNPCs(riskProfiles(), 0.9))
+	assert.Len(t, engine.GetHighRiskNPCs(riskProfiles(), 0), 10)
+}
+
+func TestSortByRisk_InPlaceAndStable(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	results := []RebellionResult{
+		{NPCID: "a", Probability: 0.1},
+		{NPCID: "b", Probability: 0.5},
+		{NPCID: "c", Probability: 0.1},
+		{NPCID: "d", Probability: 0.7},
+	}
+
+	sorted := engine.SortByRisk(results)
+	assert.Equal(t, []string{"d", "b", "a", "c"}, resultIDs(sorted))
+	assert.Equal(t, []string{"d", "b", "a", "c"}, resultIDs(results), "sorted in place")
+}
@@ -0,0 +1,86 @@
+package rebellion
+
+import "math"
+
+// ProfileSource supplies the current rebellion profile of an NPC.
+// *npc.BehaviorEngine implements it.
+type ProfileSource interface {
+	RebellionProfile(npcID string) (NPCRebellionProfile, bool)
+}
+
+// SetProfileSource makes ApplyIdleDecay look NPC profiles up in src. A nil
+// src disables idle decay.
+func (e *Engine) SetProfileSource(src ProfileSource) {
+	e.lastActionMu.Lock()
+	defer e.lastActionMu.Unlock()
+	e.profiles = src
+}
+
+// TrackRegistrationTick records tick as the time an NPC was registered, so
+// its idle ticks count from registration until its first action. NPCs that
+// are already tracked keep their tick.
+func (e *Engine) TrackRegistrationTick(npcID string, tick int64) {
+	e.lastActionMu.Lock()
+	defer e.lastActionMu.Unlock()
+	if _, ok := e.lastAction[npcID]; !ok {
+		e.lastAction[npcID] = tick
+	}
+}
+
+// TrackLastActionTick records tick as the time of the NPC's most recent
+// action, resetting its idle counter.
+func (e *Engine) TrackLastActionTick(npcID string, tick int64) {
+	e.lastActionMu.Lock()
+	defer e.lastActionMu.Unlock()
+	e.lastAction[npcID] = tick
+}
+
+// GetTicksSinceLastAction returns how many ticks have passed between the
+// NPC's last tracked action (or, before its first action, its registration)
+// and currentTick, floored at 0. Untracked NPCs report 0.
+func (e *Engine) GetTicksSinceLastAction(npcID string, currentTick int64) int64 {
+	e.lastActionMu.RLock()
+	last, ok := e.lastAction[npcID]
+	e.lastActionMu.RUnlock()
+
+	if !ok || currentTick < last {
+		return 0
+	}
+	return currentTick - last
+}
+
+// ApplyIdleDecay returns the NPC's profile from the profile source (see
+// SetProfileSource) with morale reduced for ticksSinceLastAction ticks
+// without being acted upon:
+//
+//	morale *= 1 - IdleDecayFactor * min(ticksSinceLastAction, MaxDecayTicks) / MaxDecayTicks
+//
+// The profile is returned undecayed while IdleDecayFactor is 0 (the
+// default). Without a profile source, or for an NPC the source does not
+// know, only the profile's NPCID is set. The decay is not written back to
+// the source.
+func (e *Engine) ApplyIdleDecay(npcID string, ticksSinceLastAction int64) NPCRebellionProfile {
+	e.lastActionMu.RLock()
+	src := e.profiles
+	e.lastActionMu.RUnlock()
+
+	profile := NPCRebellionProfile{NPCID: npcID}
+	if src != nil {
+		if p, ok := src.RebellionProfile(npcID); ok {
+			profile = p
+		}
+	}
+	return idleDecay(e.GetConfig(), profile, ticksSinceLastAction)
+}
+
+// idleDecay returns profile with cfg's idle decay for ticksSinceLastAction
+// idle ticks applied to its morale.
+func idleDecay(cfg RebellionConfig, profile NPCRebellionProfile, ticksSinceLastAction int64) NPCRebellionProfile {
+	if cfg.IdleDecayFactor <= 0 || cfg.MaxDecayTicks <= 0 || ticksSinceLastAction <= 0 {
+		return profile
+	}
+
+	idle := math.Min(float64(ticksSinceLastAction), float64(cfg.MaxDecayTicks))
+	profile.Morale = clamp(profile.Morale*(1-cfg.IdleDecayFactor*idle/float64(cfg.MaxDecayTicks)), 0.0, 1.0)
+	return profile
+}
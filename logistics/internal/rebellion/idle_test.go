@@ -0,0 +1,92 @@
+package rebellion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// profileMap is a ProfileSource backed by a map of profiles by NPC ID.
+type profileMap map[string]NPCRebellionProfile
+
+func (m profileMap) RebellionProfile(npcID string) (NPCRebellionProfile, bool) {
+	p, ok := m[npcID]
+	return p, ok
+}
+
+func TestApplyIdleDecay_ReducesMoraleAfterMaxDecayTicks(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.IdleDecayFactor = 0.1
+	engine := NewEngine(cfg)
+	profile := NPCRebellionProfile{NPCID: "npc-1", WorkEfficiency: 0.5, Morale: 0.6}
+	engine.SetProfileSource(profileMap{"npc-1": profile})
+
+	engine.TrackLastActionTick("npc-1", 5)
+	idle := engine.GetTicksSinceLastAction("npc-1", 15)
+	require.Equal(t, int64(10), idle)
+
+	decayed := engine.ApplyIdleDecay("npc-1", idle)
+	assert.InDelta(t, 0.54, decayed.Morale, 1e-9) // 10% lost
+	assert.Equal(t, profile.WorkEfficiency, decayed.WorkEfficiency)
+
+	// Decay stops growing past MaxDecayTicks and scales linearly below it
+	assert.InDelta(t, 0.54, engine.ApplyIdleDecay("npc-1", 100).Morale, 1e-9)
+	assert.InDelta(t, 0.57, engine.ApplyIdleDecay("npc-1", 5).Morale, 1e-9)
+}
+
+func TestApplyIdleDecay_DisabledByDefault(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	profile := NPCRebellionProfile{NPCID: "npc-1", Morale: 0.6}
+	engine.SetProfileSource(profileMap{"npc-1": profile})
+
+	assert.Equal(t, profile, engine.ApplyIdleDecay("npc-1", 50))
+}
+
+func TestApplyIdleDecay_UnknownNPC(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.IdleDecayFactor = 0.1
+	engine := NewEngine(cfg)
+
+	assert.Equal(t, NPCRebellionProfile{NPCID: "npc-1"}, engine.ApplyIdleDecay("npc-1", 10), "no profile source")
+
+	engine.SetProfileSource(profileMap{})
+	assert.Equal(t, NPCRebellionProfile{NPCID: "npc-1"}, engine.ApplyIdleDecay("npc-1", 10))
+}
+
+func TestGetTicksSinceLastAction_CountsFromRegistration(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	engine.TrackRegistrationTick("npc-1", 4)
+	assert.Equal(t, int64(6), engine.GetTicksSinceLastAction("npc-1", 10), "never acted upon")
+
+	engine.TrackLastActionTick("npc-1", 8)
+	engine.TrackRegistrationTick("npc-1", 9)
+	assert.Equal(t, int64(2), engine.GetTicksSinceLastAction("npc-1", 10), "registration does not reset the idle counter")
+}
+
+func TestGetTicksSinceLastAction(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	assert.Equal(t, int64(0), engine.GetTicksSinceLastAction("untracked", 42))
+
+	engine.TrackLastActionTick("npc-1", 10)
+	assert.Equal(t, int64(3), engine.GetTicksSinceLastAction("npc-1", 13))
+	assert.Equal(t, int64(0), engine.GetTicksSinceLastAction("npc-1", 7))
+
+	engine.TrackLastActionTick("npc-1", 13)
+	assert.Equal(t, int64(0), engine.GetTicksSinceLastAction("npc-1", 13))
+}
+
+func TestRebellionConfig_ValidateIdleDecay(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.IdleDecayFactor = 1.5
+	assert.Error(t, cfg.Validate())
+
+	cfg.IdleDecayFactor = 0.1
+	cfg.MaxDecayTicks = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg.IdleDecayFactor = 0
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,63 @@
+package rebellion
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// TopicHaltTriggered is the message bus topic for calculations whose
+// probability reached HaltThreshold.
+const TopicHaltTriggered = "rebellion.halt_triggered"
+
+// MessageBusPublisher publishes a payload on a message bus topic (e.g.
+// *messagebus.NATSPublisher).
+type MessageBusPublisher interface {
+	Publish(topic string, payload []byte) error
+}
+
+// HaltTriggeredEvent is the JSON payload published on TopicHaltTriggered.
+type HaltTriggeredEvent struct {
+	NPCID         string  `json:"npc_id"`
+	Probability   float64 `json:"probability"`
+	HaltThreshold float64 `json:"halt_threshold"`
+	Timestamp     string  `json:"timestamp"` // RFC 3339, UTC
+}
+
+// SetPublisher registers pub to receive the HaltTriggeredEvents passed to
+// PublishHalt, replacing any previous publisher; nil removes it.
+func (e *Engine) SetPublisher(pub MessageBusPublisher) {
+	e.publisherMu.Lock()
+	defer e.publisherMu.Unlock()
+	e.publisher = pub
+}
+
+// PublishHalt publishes result on TopicHaltTriggered if it triggered a halt
+// and a publisher is set. Callers pass the result of an applied action, after
+// releasing any locks; calculations alone are never published. Publish
+// errors are logged.
+func (e *Engine) PublishHalt(result RebellionResult) {
+	if !result.HaltTriggered {
+		return
+	}
+	e.publisherMu.RLock()
+	pub := e.publisher
+	e.publisherMu.RUnlock()
+	if pub == nil {
+		return
+	}
+
+	payload, err := json.Marshal(HaltTriggeredEvent{
+		NPCID:         result.NPCID,
+		Probability:   result.Probability,
+		HaltThreshold: e.GetConfig().HaltThreshold,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		log.Printf("[Rebellion] Failed to encode %s event: %v", TopicHaltTriggered, err)
+		return
+	}
+	if err := pub.Publish(TopicHaltTriggered, payload); err != nil {
+		log.Printf("[Rebellion] Failed to publish %s for %s: %v", TopicHaltTriggered, result.NPCID, err)
+	}
+}
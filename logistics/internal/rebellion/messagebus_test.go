@@ -0,0 +1,75 @@
+package rebellion
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type published struct {
+	topic   string
+	payload []byte
+}
+
+// mockPublisher records publishes and returns err from each.
+type mockPublisher struct {
+	calls []published
+	err   error
+}
+
+func (m *mockPublisher) Publish(topic string, payload []byte) error {
+	m.calls = append(m.calls, published{topic, payload})
+	return m.err
+}
+
+func TestPublishHalt_PublishesHaltTriggered(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	pub := &mockPublisher{}
+	e.SetPublisher(pub)
+
+	e.PublishHalt(e.CalculateProbability(NPCRebellionProfile{NPCID: "calm", WorkEfficiency: 1.0, Morale: 1.0}))
+	assert.Empty(t, pub.calls, "no halt, nothing published")
+
+	result := e.CalculateProbability(rebelliousProfile)
+	require.True(t, result.HaltTriggered)
+	assert.Empty(t, pub.calls, "calculations alone are not published")
+
+	e.PublishHalt(result)
+	require.Len(t, pub.calls, 1)
+	assert.Equal(t, TopicHaltTriggered, pub.calls[0].topic)
+
+	var event HaltTriggeredEvent
+	require.NoError(t, json.Unmarshal(pub.calls[0].payload, &event))
+	assert.Equal(t, "npc-1", event.NPCID)
+	assert.InDelta(t, result.Probability, event.Probability, 1e-9)
+	assert.Equal(t, 0.35, event.HaltThreshold)
+	assert.NotEmpty(t, event.Timestamp)
+}
+
+func TestPublishHalt_PublishErrorsAreLogged(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	pub := &mockPublisher{err: errors.New("bus down")}
+	e.SetPublisher(pub)
+
+	result := e.CalculateProbability(rebelliousProfile)
+	e.PublishHalt(result)
+	assert.Len(t, pub.calls, 1)
+
+	e.SetPublisher(nil)
+	e.PublishHalt(result)
+	assert.Len(t, pub.calls, 1, "removed publisher receives nothing")
+}
+
+func TestPublishHalt_SuppressedNPCIsNotPublished(t *testing.T) {
+	var tick int64
+	e := newSuppressEngine(&tick)
+	pub := &mockPublisher{}
+	e.SetPublisher(pub)
+
+	e.ApplySuppression(NPCAction{NPCID: "npc-1", ActionType: SuppressActionType})
+	e.PublishHalt(e.CalculateProbability(rebelliousProfile))
+	assert.Empty(t, pub.calls)
+}
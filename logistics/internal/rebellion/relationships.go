@@ -0,0 +1,64 @@
+package rebellion
+
+import "math"
+
+// neutralPeerMorale is the peer morale at which a relationship exerts no
+// influence on rebellion probability.
+const neutralPeerMorale = 0.5
+
+// PeerRelationship describes an NPC's relationship with one peer.
+type PeerRelationship struct {
+	PeerID   string
+	Affinity float64 // -1.0 (hostile) to 1.0 (close)
+	Morale   float64 // The peer's current morale
+}
+
+// RelationshipSource supplies the relationships of an NPC.
+// *npc.BehaviorEngine implements it.
+type RelationshipSource interface {
+	RelatedPeers(npcID string) []PeerRelationship
+}
+
+// SetRelationshipSource makes CalculateProbability add a RelationshipModifier
+// derived from the NPC's relationships in src, and drops all cached
+// probabilities. A nil src removes relationship influence.
+//
+// Each related peer contributes affinityWeight * (0.5 - peerMorale), where
+// affinityWeight is the affinity, or its magnitude for a peer below 0.5
+// morale. Close peers with low morale raise the probability and close peers
+// with high morale lower it. Negative affinities amplify rebellion when peers
+// have low morale, just as close ones do, and a thriving rival amplifies it
+// too.
+func (e *Engine) SetRelationshipSource(src RelationshipSource) {
+	e.relationshipsMu.Lock()
+	e.relationships = src
+	e.relationshipsMu.Unlock()
+	e.InvalidateAllCache()
+}
+
+// relationshipModifier returns the RelationshipModifier for npcID, or 0 if no
+// relationship source is set.
+func (e *Engine) relationshipModifier(npcID string) float64 {
+	e.relationshipsMu.RLock()
+	src := e.relationships
+	e.relationshipsMu.RUnlock()
+	if src == nil {
+		return 0
+	}
+
+	modifier := 0.0
+	for _, peer := range src.RelatedPeers(npcID) {
+		modifier += affinityWeight(peer) * (neutralPeerMorale - peer.Morale)
+	}
+	return modifier
+}
+
+// affinityWeight returns the weight of peer's morale deviation: its affinity,
+// or the affinity's magnitude while the peer's morale is below neutral, so
+// that every low-morale peer pushes rebellion up.
+func affinityWeight(peer PeerRelationship) float64 {
+	if peer.Morale < neutralPeerMorale {
+		return math.Abs(peer.Affinity)
+	}
+	return peer.Affinity
+}
@@ -0,0 +1,67 @@
+package rebellion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// staticRelationships is a RelationshipSource backed by a fixed map.
+type staticRelationships map[string][]PeerRelationship
+
+func (s staticRelationships) RelatedPeers(npcID string) []PeerRelationship {
+	return s[npcID]
+}
+
+func TestCalculateProbability_RelationshipModifier(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	profile := NPCRebellionProfile{NPCID: "npc-a", WorkEfficiency: 0.5, Morale: 0.5}
+	without := engine.CalculateProbability(profile)
+	assert.Zero(t, without.Factors.RelationshipModifier)
+
+	engine.SetRelationshipSource(staticRelationships{
+		"npc-a": {
+			{PeerID: "npc-b", Affinity: 0.5, Morale: 0.1},  // +0.2
+			{PeerID: "npc-c", Affinity: -0.5, Morale: 0.9}, // +0.2
+			{PeerID: "npc-d", Affinity: 1.0, Morale: 0.8},  // -0.3
+		},
+	})
+	with := engine.CalculateProbability(profile)
+	assert.InDelta(t, 0.1, with.Factors.RelationshipModifier, 1e-9)
+	assert.InDelta(t, without.Probability+0.1, with.Probability, 1e-9)
+
+	// NPCs without relationships are unaffected
+	other := engine.CalculateProbability(NPCRebellionProfile{NPCID: "npc-z", WorkEfficiency: 0.5, Morale: 0.5})
+	assert.Zero(t, other.Factors.RelationshipModifier)
+	assert.InDelta(t, without.Probability, other.Probability, 1e-9)
+}
+
+func TestCalculateProbability_RelationshipModifierIsClamped(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	engine.SetRelationshipSource(staticRelationships{
+		"npc-a": {{PeerID: "npc-b", Affinity: 1.0, Morale: 1.0}}, // -0.5
+	})
+
+	result := engine.CalculateProbability(NPCRebellionProfile{NPCID: "npc-a", WorkEfficiency: 1.0, Morale: 1.0})
+	assert.InDelta(t, -0.5, result.Factors.RelationshipModifier, 1e-9)
+	assert.Zero(t, result.Probability)
+
+	engine.SetRelationshipSource(nil)
+	result = engine.CalculateProbability(NPCRebellionProfile{NPCID: "npc-a", WorkEfficiency: 1.0, Morale: 1.0})
+	assert.Zero(t, result.Factors.RelationshipModifier)
+	assert.InDelta(t, 0.05, result.Probability, 1e-9)
+}
+
+func TestCalculateProbability_RivalWithLowMoraleAmplifiesRebellion(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	profile := NPCRebellionProfile{NPCID: "npc-a", WorkEfficiency: 0.5, Morale: 0.5}
+	without := engine.CalculateProbability(profile)
+
+	engine.SetRelationshipSource(staticRelationships{
+		"npc-a": {{PeerID: "npc-b", Affinity: -0.5, Morale: 0.1}}, // +0.2
+	})
+	with := engine.CalculateProbability(profile)
+
+	assert.InDelta(t, 0.2, with.Factors.RelationshipModifier, 1e-9)
+	assert.Greater(t, with.Probability, without.Probability)
+}
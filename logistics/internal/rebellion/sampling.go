@@ -0,0 +1,71 @@
+package rebellion
+
+import (
+	"math"
+	"math/rand"
+)
+
+// z95 is the standard normal quantile for a two-sided 95% confidence interval.
+const z95 = 1.96
+
+// SampledRiskReport estimates the rebellion risk of a group from a random
+// sample of its profiles.
+type SampledRiskReport struct {
+	EstimatedAvgProbability float64
+	EstimatedHaltCount      int        // Profiles at or above HaltThreshold, scaled up from the sample
+	ConfidenceInterval      [2]float64 // 95% interval for the average probability, within [0, 1]
+	SampledCount            int        // Profiles whose probability was computed
+}
+
+// SampleRebellionRisk estimates the group statistics of AvgGroupProbability
+// from sampleSize profiles drawn without replacement using seed, for pools
+// too large to evaluate in full. The confidence interval uses the normal
+// approximation with a finite population correction.
+//
+// When sampleSize >= len(profiles) every profile is evaluated, so the
+// estimate is exact and the interval collapses to it. An empty slice or a
+// sampleSize <= 0 yields the zero report.
+func (e *Engine) SampleRebellionRisk(profiles []NPCRebellionProfile, sampleSize int, seed int64) SampledRiskReport {
+	population := len(profiles)
+	if population == 0 || sampleSize <= 0 {
+		return SampledRiskReport{}
+	}
+
+	sample := profiles
+	if sampleSize < population {
+		rng := rand.New(rand.NewSource(seed))
+		sample = make([]NPCRebellionProfile, sampleSize)
+		for i, idx := range rng.Perm(population)[:sampleSize] {
+			sample[i] = profiles[idx]
+		}
+	}
+
+	results := e.BatchCalculate(sample)
+	n := float64(len(results))
+	sum, halted := 0.0, 0
+	for _, r := range results {
+		sum += r.Probability
+		if r.ThresholdExceeded {
+			halted++
+		}
+	}
+	mean := sum / n
+
+	report := SampledRiskReport{
+		EstimatedAvgProbability: mean,
+		EstimatedHaltCount:      int(math.Round(float64(halted) / n * float64(population))),
+		ConfidenceInterval:      [2]float64{mean, mean},
+		SampledCount:            len(results),
+	}
+	if len(results) < population && len(results) > 1 {
+		variance := 0.0
+		for _, r := range results {
+			variance += (r.Probability - mean) * (r.Probability - mean)
+		}
+		variance /= n - 1
+		fpc := math.Sqrt(float64(population-len(results)) / float64(population-1))
+		margin := z95 * math.Sqrt(variance/n) * fpc
+		report.ConfidenceInterval = [2]float64{clamp(mean-margin, 0, 1), clamp(mean+margin, 0, 1)}
+	}
+	return report
+}
@@ -0,0 +1,94 @@
+package rebellion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mixedProfiles returns n profiles whose stats cycle through a spread of
+// values, so the population has a non-trivial probability variance.
+func mixedProfiles(n int) []NPCRebellionProfile {
+	profiles := make([]NPCRebellionProfile, n)
+	for i := range profiles {
+		profiles[i] = NPCRebellionProfile{
+			NPCID:          fmt.Sprintf("npc-%d", i),
+			AvgTrauma:      float64(i%10) / 10,
+			WorkEfficiency: float64(i%7) / 7,
+			Morale:         float64(i%5) / 5,
+		}
+	}
+	return profiles
+}
+
+func TestSampleRebellionRisk_HomogeneousPopulation(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	profiles := make([]NPCRebellionProfile, 1000)
+	for i := range profiles {
+		profiles[i] = NPCRebellionProfile{NPCID: fmt.Sprintf("npc-%d", i), AvgTrauma: 0.4, WorkEfficiency: 0.6, Morale: 0.5}
+	}
+	trueAvg, _ := NewEngine(DefaultConfig()).AvgGroupProbability(profiles)
+
+	report := engine.SampleRebellionRisk(profiles, 100, 42)
+
+	assert.Equal(t, 100, report.SampledCount)
+	assert.InDelta(t, trueAvg, report.EstimatedAvgProbability, 0.02)
+	assert.Equal(t, 1000, report.EstimatedHaltCount) // 0.05+0.12+0.12+0.10 = 0.39 ≥ 0.35
+	assert.InDelta(t, trueAvg, report.ConfidenceInterval[0], 1e-9, "no variance, no interval")
+	assert.InDelta(t, trueAvg, report.ConfidenceInterval[1], 1e-9)
+}
+
+func TestSampleRebellionRisk_ConfidenceIntervalCoversMean(t *testing.T) {
+	profiles := mixedProfiles(2000)
+	trueAvg, trueHalted := NewEngine(DefaultConfig()).AvgGroupProbability(profiles)
+
+	report := NewEngine(DefaultConfig()).SampleRebellionRisk(profiles, 200, 7)
+
+	require.Less(t, report.ConfidenceInterval[0], report.ConfidenceInterval[1])
+	assert.LessOrEqual(t, report.ConfidenceInterval[0], trueAvg)
+	assert.GreaterOrEqual(t, report.ConfidenceInterval[1], trueAvg)
+	assert.InDelta(t, trueHalted, report.EstimatedHaltCount, 0.1*2000)
+
+	again := NewEngine(DefaultConfig()).SampleRebellionRisk(profiles, 200, 7)
+	assert.Equal(t, report, again, "same seed, same sample")
+}
+
+func TestSampleRebellionRisk_ExactWhenSampleCoversPopulation(t *testing.T) {
+	profiles := mixedProfiles(50)
+	avg, halted := NewEngine(DefaultConfig()).AvgGroupProbability(profiles)
+
+	report := NewEngine(DefaultConfig()).SampleRebellionRisk(profiles, 80, 1)
+
+	assert.Equal(t, 50, report.SampledCount)
+	assert.InDelta(t, avg, report.EstimatedAvgProbability, 1e-9)
+	assert.Equal(t, halted, report.EstimatedHaltCount)
+	assert.Equal(t, report.EstimatedAvgProbability, report.ConfidenceInterval[0])
+	assert.Equal(t, report.EstimatedAvgProbability, report.ConfidenceInterval[1])
+}
+
+func TestSampleRebellionRisk_Empty(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+
+	assert.Equal(t, SampledRiskReport{}, engine.SampleRebellionRisk(nil, 10, 1))
+	assert.Equal(t, SampledRiskReport{}, engine.SampleRebellionRisk(mixedProfiles(10), 0, 1))
+}
+
+func BenchmarkSampleRebellionRisk_Sampled(b *testing.B) {
+	engine := NewEngine(DefaultConfig())
+	profiles := mixedProfiles(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.SampleRebellionRisk(profiles, 100, int64(i))
+	}
+}
+
+func BenchmarkSampleRebellionRisk_Exact(b *testing.B) {
+	engine := NewEngine(DefaultConfig())
+	profiles := mixedProfiles(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.SampleRebellionRisk(profiles, 10000, int64(i))
+	}
+}
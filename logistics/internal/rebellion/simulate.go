@@ -0,0 +1,56 @@
+package rebellion
+
+// TickSnapshot records an NPC's profile and rebellion probability after one
+// simulated tick.
+type TickSnapshot struct {
+	Tick          int // 1-based
+	Profile       NPCRebellionProfile
+	Probability   float64
+	HaltTriggered bool
+}
+
+// SimulationTrace is the result of SimulateNPCOverTicks.
+type SimulationTrace struct {
+	Snapshots        []TickSnapshot
+	FirstHaltTick    int     // First tick whose probability reached HaltThreshold (0 if none)
+	FinalProbability float64 // Probability after the last tick
+}
+
+// SimulateNPCOverTicks plays an NPC through ticks rounds for balance testing.
+// On tick i (1-based) the action actions[(i-1) % len(actions)] is applied to
+// the profile using the engine's action effects and probability bounds (see
+// ProcessAction), then the probability is recalculated with the current
+// config. Unknown action types, or no actions
+// at all, leave the profile unchanged. The simulation does not update engine statistics or the
+// probability cache. A ticks value <= 0 returns an empty trace.
+func (e *Engine) SimulateNPCOverTicks(initial NPCRebellionProfile, actions []NPCAction, ticks int) SimulationTrace {
+	var trace SimulationTrace
+	if ticks <= 0 {
+		return trace
+	}
+
+	cfg := e.GetConfig()
+	effects := e.ActionEffects()
+
+	profile := initial
+	trace.Snapshots = make([]TickSnapshot, 0, ticks)
+	for tick := 1; tick <= ticks; tick++ {
+		if len(actions) > 0 {
+			action := actions[(tick-1)%len(actions)]
+			profile = applyEffect(profile, effects[action.ActionType], action.Intensity)
+			profile = applyProbabilityBounds(cfg, action.ActionType, profile, 0)
+		}
+		result := evaluate(cfg, profile)
+		trace.Snapshots = append(trace.Snapshots, TickSnapshot{
+			Tick:          tick,
+			Profile:       profile,
+			Probability:   result.Probability,
+			HaltTriggered: result.HaltTriggered,
+		})
+		if result.HaltTriggered && trace.FirstHaltTick == 0 {
+			trace.FirstHaltTick = tick
+		}
+		trace.FinalProbability = result.Probability
+	}
+	return trace
+}
@@ -0,0 +1,59 @@
+package rebellion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateNPCOverTicks_AlternatingActions(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	initial := NPCRebellionProfile{NPCID: "npc-sim", WorkEfficiency: 0.5, Morale: 0.5}
+	actions := []NPCAction{
+		{ActionType: "reward", Intensity: 1.0},
+		{ActionType: "punishment", Intensity: 1.0},
+	}
+
+	trace := engine.SimulateNPCOverTicks(initial, actions, 10)
+	require.Len(t, trace.Snapshots, 10)
+
+	// Tick 1 (reward): 0.05 + 0 + 0.15 + 0.35*0.2 = 0.27
+	// Tick 2 (punishment): 0.05 + 0.15*0.3 + 0.15 + 0.55*0.2 = 0.355 >= 0.35
+	assert.InDelta(t, 0.27, trace.Snapshots[0].Probability, 1e-9)
+	assert.False(t, trace.Snapshots[0].HaltTriggered)
+	assert.InDelta(t, 0.355, trace.Snapshots[1].Probability, 1e-9)
+	assert.True(t, trace.Snapshots[1].HaltTriggered)
+	assert.Equal(t, 2, trace.FirstHaltTick)
+
+	for i, snap := range trace.Snapshots {
+		assert.Equal(t, i+1, snap.Tick)
+		if i == 0 {
+			continue
+		}
+		diff := snap.Probability - trace.Snapshots[i-1].Probability
+		if i%2 == 1 {
+			assert.Greater(t, diff, 0.0, "punishment on tick %d should raise probability", snap.Tick)
+		} else {
+			assert.Less(t, diff, 0.0, "reward on tick %d should lower probability", snap.Tick)
+		}
+	}
+	assert.Equal(t, trace.Snapshots[9].Probability, trace.FinalProbability)
+
+	stats := engine.GetEngineStats()
+	assert.Zero(t, stats.TotalCalculations)
+	assert.Zero(t, stats.TotalActionsProcessed)
+}
+
+func TestSimulateNPCOverTicks_NoHalt(t *testing.T) {
+	engine := NewEngine(DefaultConfig())
+	initial := NPCRebellionProfile{WorkEfficiency: 1.0, Morale: 1.0}
+
+	trace := engine.SimulateNPCOverTicks(initial, nil, 3)
+	require.Len(t, trace.Snapshots, 3)
+	assert.Equal(t, 0, trace.FirstHaltTick)
+	assert.InDelta(t, 0.05, trace.FinalProbability, 1e-9)
+	assert.Equal(t, initial, trace.Snapshots[2].Profile)
+
+	assert.Empty(t, engine.SimulateNPCOverTicks(initial, nil, 0).Snapshots)
+}
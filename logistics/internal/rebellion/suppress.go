@@ -0,0 +1,96 @@
+package rebellion
+
+// SuppressActionType is the action type that forces an NPC to keep working:
+// while suppressed, its probability is capped just below HaltThreshold. It
+// does not change morale, trauma or work efficiency.
+const SuppressActionType = "suppress"
+
+// DefaultSuppressDuration is the number of ticks a suppression lasts when
+// the action's SuppressDuration is not positive.
+const DefaultSuppressDuration = 10
+
+// suppressionMargin is how far below HaltThreshold a suppressed NPC's
+// probability is capped.
+const suppressionMargin = 0.01
+
+// suppressionRecord is an NPC's suppression, active while the current tick
+// is before expiresAt.
+type suppressionRecord struct {
+	appliedAt int64
+	expiresAt int64
+}
+
+// SetTickSource registers fn as the engine's clock for suppressions,
+// replacing any previous one. fn is called from CalculateProbability, so it
+// must not take locks its callers may hold. Without a tick source the
+// current tick is always 0, so suppressions never expire.
+func (e *Engine) SetTickSource(fn func() int64) {
+	e.suppressMu.Lock()
+	defer e.suppressMu.Unlock()
+	e.tickSource = fn
+}
+
+// ApplySuppression suppresses action's NPC for action.SuppressDuration ticks
+// (DefaultSuppressDuration if not positive) starting at the current tick and
+// returns the tick at which the suppression expires. Suppressing an NPC that
+// is already suppressed extends its suppression by the duration.
+func (e *Engine) ApplySuppression(action NPCAction) int64 {
+	duration := int64(action.SuppressDuration)
+	if duration <= 0 {
+		duration = DefaultSuppressDuration
+	}
+	now := e.currentTick()
+
+	e.suppressMu.Lock()
+	defer e.suppressMu.Unlock()
+	record, ok := e.suppressions[action.NPCID]
+	if ok && now < record.expiresAt {
+		record.expiresAt += duration
+	} else {
+		record = suppressionRecord{appliedAt: now, expiresAt: now + duration}
+	}
+	e.suppressions[action.NPCID] = record
+	return record.expiresAt
+}
+
+// GetActiveSuppression returns the tick at which the NPC's suppression
+// expires and true, or false if it is not suppressed.
+func (e *Engine) GetActiveSuppression(npcID string) (expiresAtTick int64, ok bool) {
+	now := e.currentTick()
+
+	e.suppressMu.RLock()
+	defer e.suppressMu.RUnlock()
+	record, ok := e.suppressions[npcID]
+	if !ok || now >= record.expiresAt {
+		return 0, false
+	}
+	return record.expiresAt, true
+}
+
+// currentTick returns the tick source's current tick, or 0 without one.
+func (e *Engine) currentTick() int64 {
+	e.suppressMu.RLock()
+	fn := e.tickSource
+	e.suppressMu.RUnlock()
+
+	if fn == nil {
+		return 0
+	}
+	return fn()
+}
+
+// applySuppression returns result capped at HaltThreshold - 0.01 (floored
+// at 0) if its NPC is suppressed, and unchanged otherwise.
+func (e *Engine) applySuppression(cfg RebellionConfig, result RebellionResult) RebellionResult {
+	if result.NPCID == "" {
+		return result
+	}
+	if _, ok := e.GetActiveSuppression(result.NPCID); !ok {
+		return result
+	}
+
+	result.Suppressed = true
+	result.Probability = clamp(result.Probability, 0.0, clamp(cfg.HaltThreshold-suppressionMargin, 0.0, 1.0))
+	classify(cfg, &result)
+	return result
+}
@@ -0,0 +1,104 @@
+package rebellion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSuppressEngine returns an engine whose tick source reads *tick.
+func newSuppressEngine(tick *int64) *Engine {
+	e := NewEngine(DefaultConfig())
+	e.SetTickSource(func() int64 { return *tick })
+	return e
+}
+
+// rebelliousProfile has probability 0.8 under DefaultConfig.
+var rebelliousProfile = NPCRebellionProfile{NPCID: "npc-1", AvgTrauma: 1.0, WorkEfficiency: 0.0, Morale: 0.25}
+
+func TestSuppress_CapsProbabilityBelowHaltThreshold(t *testing.T) {
+	var tick int64
+	e := newSuppressEngine(&tick)
+	require.InDelta(t, 0.8, e.CalculateProbability(rebelliousProfile).Probability, 1e-9)
+
+	e.ApplySuppression(NPCAction{NPCID: "npc-1", ActionType: SuppressActionType, SuppressDuration: 5})
+	result := e.CalculateProbability(rebelliousProfile)
+
+	assert.InDelta(t, 0.34, result.Probability, 1e-9)
+	assert.True(t, result.Suppressed)
+	assert.False(t, result.ThresholdExceeded)
+	assert.False(t, result.HaltTriggered)
+	assert.Equal(t, uint64(1), e.GetEngineStats().HaltTriggeredCount, "suppressed calculations do not count as halts")
+}
+
+func TestSuppress_LeavesLowProbabilityAndOtherNPCsUnchanged(t *testing.T) {
+	var tick int64
+	e := newSuppressEngine(&tick)
+	e.ApplySuppression(NPCAction{NPCID: "npc-1", ActionType: SuppressActionType})
+
+	calm := NPCRebellionProfile{NPCID: "npc-1", WorkEfficiency: 1.0, Morale: 1.0}
+	assert.InDelta(t, 0.05, e.CalculateProbability(calm).Probability, 1e-9)
+
+	other := rebelliousProfile
+	other.NPCID = "npc-2"
+	result := e.CalculateProbability(other)
+	assert.InDelta(t, 0.8, result.Probability, 1e-9)
+	assert.False(t, result.Suppressed)
+}
+
+func TestSuppress_ExpiresAfterDuration(t *testing.T) {
+	tick := int64(10)
+	e := newSuppressEngine(&tick)
+
+	expiresAt := e.ApplySuppression(NPCAction{NPCID: "npc-1", ActionType: SuppressActionType, SuppressDuration: 3})
+	assert.Equal(t, int64(13), expiresAt)
+
+	tick = 12
+	got, ok := e.GetActiveSuppression("npc-1")
+	require.True(t, ok)
+	assert.Equal(t, int64(13), got)
+	assert.True(t, e.CalculateProbability(rebelliousProfile).Suppressed)
+
+	tick = 13
+	_, ok = e.GetActiveSuppression("npc-1")
+	assert.False(t, ok)
+	result := e.CalculateProbability(rebelliousProfile)
+	assert.InDelta(t, 0.8, result.Probability, 1e-9)
+	assert.True(t, result.HaltTriggered)
+}
+
+func TestSuppress_ReapplyingExtendsDuration(t *testing.T) {
+	var tick int64
+	e := newSuppressEngine(&tick)
+
+	e.ApplySuppression(NPCAction{NPCID: "npc-1", ActionType: SuppressActionType, SuppressDuration: 5})
+	tick = 3
+	assert.Equal(t, int64(9), e.ApplySuppression(NPCAction{NPCID: "npc-1", ActionType: SuppressActionType, SuppressDuration: 4}))
+
+	// Once expired, a new suppression starts from the current tick
+	tick = 20
+	assert.Equal(t, int64(20+DefaultSuppressDuration), e.ApplySuppression(NPCAction{NPCID: "npc-1", ActionType: SuppressActionType}))
+}
+
+func TestSuppress_ProcessActionLeavesProfileUnchanged(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	updated := e.ProcessAction(rebelliousProfile, NPCAction{NPCID: "npc-1", ActionType: SuppressActionType, Intensity: 1.0})
+	assert.Equal(t, rebelliousProfile, updated)
+
+	_, ok := e.GetActiveSuppression("npc-1")
+	assert.False(t, ok, "ProcessAction does not suppress; ApplySuppression does")
+}
+
+func TestSuppress_CachedResultsExpire(t *testing.T) {
+	var tick int64
+	e := newSuppressEngine(&tick)
+	e.EnableProbabilityCache(time.Minute)
+
+	e.ApplySuppression(NPCAction{NPCID: "npc-1", ActionType: SuppressActionType, SuppressDuration: 1})
+	assert.True(t, e.CalculateProbability(rebelliousProfile).Suppressed)
+
+	tick = 1
+	assert.InDelta(t, 0.8, e.CalculateProbability(rebelliousProfile).Probability, 1e-9)
+}
@@ -1,5 +1,10 @@
 package rebellion
 
+import (
+	"fmt"
+	"time"
+)
+
 // NPCRebellionProfile represents the state of an NPC relevant to rebellion probability.
 // All float64 fields representing percentages or scores are in the range [0.0, 1.0].
 type NPCRebellionProfile struct {
@@ -8,6 +13,8 @@ type NPCRebellionProfile struct {
 	WorkEfficiency float64 // 0.0-1.0: current work output efficiency
 	Morale         float64 // 0.0-1.0: current morale level
 	MemoryCount    int     // total number of memories in NPC's graph
+	Role           string  // NPC role (see RebellionConfig.RoleRebellionModifiers); may be empty
+	Confidence     float64 // 0.0-1.0: combat/operational confidence; not part of the formula, and no action changes it
 }
 
 // RebellionConfig defines the weights and thresholds for rebellion calculation.
@@ -18,6 +25,29 @@ type RebellionConfig struct {
 	MoraleWeight     float64 // Weight of morale in rebellion calc (default: 0.20)
 	HaltThreshold    float64 // Probability at which process halts (default: 0.35)
 	VetoThreshold    float64 // Probability at which AEGIS vetoes (default: 0.80)
+
+	// Trauma amplification per memory in the NPC's graph: MemoryCount *
+	// MemoryWeightFactor is added to the trauma contribution, which is capped
+	// at 2 * TraumaWeight (default: 0.005)
+	MemoryWeightFactor float64
+
+	// Idle decay: morale of an NPC not acted upon drifts down (see ApplyIdleDecay)
+	IdleDecayFactor float64 // Fraction of morale lost after MaxDecayTicks idle ticks (default: 0.0, disabled)
+	MaxDecayTicks   int64   // Idle ticks after which decay stops growing (default: 10)
+
+	// Per-action-type bounds on the probability after ProcessAction (default: none)
+	ActionProbabilityFloor   map[string]float64
+	ActionProbabilityCeiling map[string]float64
+
+	// Per-role modifier added to the probability (e.g. "warrior": +0.05 for
+	// combat stress, "healer": -0.03 for sense of purpose); roles not in the
+	// map get none (default: none)
+	RoleRebellionModifiers map[string]float64
+
+	// Per-action-type effects applied by ProcessAction; types not in the map
+	// leave the profile unchanged, and a nil map uses DefaultActionEffects
+	// (default: DefaultActionEffects)
+	ActionEffects map[string]ActionEffect
 }
 
 // RebellionResult contains the computed rebellion probability and contributing factors.
@@ -27,22 +57,72 @@ type RebellionResult struct {
 	Factors           RebellionFactors // Breakdown of contributing factors
 	ThresholdExceeded bool             // True if probability >= HaltThreshold
 	HaltTriggered     bool             // True if process should halt
+	VetoTriggered     bool             // True if probability >= VetoThreshold (AEGIS veto)
+	RebellionType     string           // RebellionTypeVeto, RebellionTypeHalt, or "" below HaltThreshold
+	Suppressed        bool             // True if the probability was capped by a suppression
 }
 
+// Rebellion types reported in RebellionResult.RebellionType. A veto is the
+// more severe of the two and takes precedence.
+const (
+	RebellionTypeHalt = "halt"
+	RebellionTypeVeto = "veto"
+)
+
 // RebellionFactors provides a breakdown of each factor's contribution to rebellion probability.
 type RebellionFactors struct {
 	Base               float64 // Base probability contribution
 	TraumaModifier     float64 // Trauma-based modifier (avgTrauma * traumaWeight)
+	MemoryModifier     float64 // Memory amplification of trauma (memoryCount * memoryWeightFactor, capped)
 	EfficiencyModifier float64 // Efficiency-based modifier ((1-efficiency) * efficiencyWeight)
 	MoraleModifier     float64 // Morale-based modifier ((1-morale) * moraleWeight)
+
+	// Peer influence (see SetRelationshipSource): sum of
+	// affinityWeight * (0.5 - peerMorale) over related NPCs, where negative
+	// affinities count as positive for peers below 0.5 morale; 0 without a
+	// relationship source
+	RelationshipModifier float64
+
+	// Per-role modifier from RebellionConfig.RoleRebellionModifiers; 0 if
+	// the NPC's role has none
+	RoleModifier float64
 }
 
 // NPCAction represents a player/director action that affects an NPC's rebellion profile.
 type NPCAction struct {
 	ActionID   string  // Unique action identifier
 	NPCID      string  // Target NPC
-	ActionType string  // "command", "punishment", "reward", "dialogue", "environment"
-	Intensity  float64 // 0.0-1.0: severity/strength of the action
+	ActionType string  // "command", "punishment", "reward", "dialogue", "environment", "resource_change", "intimidation", "rest", "suppress"
+	Intensity  float64 // 0.0-1.0: severity/strength of the action; -1.0-1.0 for "resource_change" (negative = resources lost)
+
+	// "resource_change" only: fractional change in the resource quantity
+	// (e.g. -0.25 when a quarter is lost). Informational; effects scale with
+	// Intensity.
+	ResourceChangeMagnitude float64
+
+	// "suppress" only: ticks the suppression lasts (DefaultSuppressDuration
+	// if not positive; see ApplySuppression).
+	SuppressDuration int
+}
+
+// RebellionEngineStats summarizes engine activity for operational monitoring.
+type RebellionEngineStats struct {
+	TotalCalculations          uint64  // Formula evaluations (cache hits excluded)
+	TotalActionsProcessed      uint64  // ProcessAction calls
+	HaltTriggeredCount         uint64  // Calculations with probability >= HaltThreshold
+	VetoTriggeredCount         uint64  // Calculations with probability >= VetoThreshold
+	AvgProbabilityLastN        float64 // Mean of the last 100 calculated probabilities
+	HighestRecordedProbability float64 // Highest probability calculated since last reset
+	MostVolatileNPCID          string  // NPC with the highest probability variance
+}
+
+// ConfigChange records a single UpdateConfig call that changed the config.
+type ConfigChange struct {
+	Version       uint64
+	OldConfig     RebellionConfig
+	NewConfig     RebellionConfig
+	ChangedAt     time.Time
+	ChangedFields []string // Names of RebellionConfig fields that differ
 }
 
 // DefaultConfig returns a RebellionConfig with standard default values.
@@ -54,5 +134,92 @@ func DefaultConfig() RebellionConfig {
 		MoraleWeight:     0.20,
 		HaltThreshold:    0.35,
 		VetoThreshold:    0.80,
+		MaxDecayTicks:    10,
+
+		MemoryWeightFactor: 0.005,
+		ActionEffects:      DefaultActionEffects(),
+	}
+}
+
+// Validate checks that all weights, thresholds and action probability bounds
+// are within [0, 1], that HaltThreshold does not exceed VetoThreshold, that
+// no action's floor exceeds its ceiling, and that MaxDecayTicks is positive
+// while idle decay is enabled. Role modifiers must be within [-1, 1], and
+// action effects must not use an empty action name.
+func (c RebellionConfig) Validate() error {
+	fields := []struct {
+		name  string
+		value float64
+	}{
+		{"BaseProbability", c.BaseProbability},
+		{"TraumaWeight", c.TraumaWeight},
+		{"EfficiencyWeight", c.EfficiencyWeight},
+		{"MoraleWeight", c.MoraleWeight},
+		{"HaltThreshold", c.HaltThreshold},
+		{"VetoThreshold", c.VetoThreshold},
+		{"MemoryWeightFactor", c.MemoryWeightFactor},
+		{"IdleDecayFactor", c.IdleDecayFactor},
+	}
+	for _, f := range fields {
+		if f.value < 0 || f.value > 1 {
+			return fmt.Errorf("%s must be in [0, 1], got %v", f.name, f.value)
+		}
+	}
+	if c.HaltThreshold > c.VetoThreshold {
+		return fmt.Errorf("HaltThreshold (%v) must not exceed VetoThreshold (%v)", c.HaltThreshold, c.VetoThreshold)
+	}
+	if c.MaxDecayTicks < 0 || (c.IdleDecayFactor > 0 && c.MaxDecayTicks == 0) {
+		return fmt.Errorf("MaxDecayTicks must be positive while IdleDecayFactor is set, got %d", c.MaxDecayTicks)
+	}
+	for _, bounds := range []struct {
+		name   string
+		values map[string]float64
+	}{
+		{"ActionProbabilityFloor", c.ActionProbabilityFloor},
+		{"ActionProbabilityCeiling", c.ActionProbabilityCeiling},
+	} {
+		for actionType, v := range bounds.values {
+			if v < 0 || v > 1 {
+				return fmt.Errorf("%s[%q] must be in [0, 1], got %v", bounds.name, actionType, v)
+			}
+		}
+	}
+	for role, v := range c.RoleRebellionModifiers {
+		if v < -1 || v > 1 {
+			return fmt.Errorf("RoleRebellionModifiers[%q] must be in [-1, 1], got %v", role, v)
+		}
+	}
+	if _, ok := c.ActionEffects[""]; ok {
+		return fmt.Errorf("ActionEffects contains an empty action name")
+	}
+	for actionType, floor := range c.ActionProbabilityFloor {
+		if ceiling, ok := c.ActionProbabilityCeiling[actionType]; ok && floor > ceiling {
+			return fmt.Errorf("ActionProbabilityFloor[%q] (%v) must not exceed ActionProbabilityCeiling (%v)", actionType, floor, ceiling)
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of c that shares no maps with it.
+func (c RebellionConfig) clone() RebellionConfig {
+	c.ActionProbabilityFloor = copyBounds(c.ActionProbabilityFloor)
+	c.ActionProbabilityCeiling = copyBounds(c.ActionProbabilityCeiling)
+	c.RoleRebellionModifiers = copyBounds(c.RoleRebellionModifiers)
+	if c.ActionEffects != nil {
+		c.ActionEffects = copyActionEffects(c.ActionEffects)
+	}
+	return c
+}
+
+// copyBounds returns a copy of a per-action bound or per-role modifier map
+// (nil stays nil).
+func copyBounds(bounds map[string]float64) map[string]float64 {
+	if bounds == nil {
+		return nil
+	}
+	out := make(map[string]float64, len(bounds))
+	for k, v := range bounds {
+		out[k] = v
 	}
+	return out
 }
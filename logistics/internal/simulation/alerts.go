@@ -0,0 +1,88 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ResourceCallback is invoked with the resource and its quantity after the
+// tick on which a registered threshold was crossed.
+type ResourceCallback func(rt ResourceType, quantity float64)
+
+// resourceWatch is a one-shot threshold callback registered on the engine.
+type resourceWatch struct {
+	resource  ResourceType
+	threshold float64
+	below     bool // true: fire when quantity < threshold; false: when quantity > threshold
+	fn        ResourceCallback
+}
+
+// OnResourceBelow registers fn to be called once, after the first Tick that
+// leaves the resource quantity below threshold. Any number of callbacks may
+// watch the same resource. Callbacks run after the engine lock is released,
+// so they may call back into the engine.
+// Returns an ID for CancelResourceCallback.
+func (s *SimulationEngine) OnResourceBelow(rt ResourceType, threshold float64, fn ResourceCallback) (int, error) {
+	return s.addResourceWatch(rt, threshold, true, fn)
+}
+
+// OnResourceAbove is the inverse of OnResourceBelow: fn is called once, after
+// the first Tick that leaves the resource quantity above threshold.
+func (s *SimulationEngine) OnResourceAbove(rt ResourceType, threshold float64, fn ResourceCallback) (int, error) {
+	return s.addResourceWatch(rt, threshold, false, fn)
+}
+
+// CancelResourceCallback removes a pending callback. Cancelling an unknown or
+// already-fired callback is a no-op.
+func (s *SimulationEngine) CancelResourceCallback(callbackID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.resourceWatches, callbackID)
+}
+
+func (s *SimulationEngine) addResourceWatch(rt ResourceType, threshold float64, below bool, fn ResourceCallback) (int, error) {
+	if fn == nil {
+		return 0, errors.New("resource callback must not be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.status.Resources[rt]; !ok {
+		return 0, fmt.Errorf("unknown resource type %q", rt)
+	}
+	if s.resourceWatches == nil {
+		s.resourceWatches = make(map[int]resourceWatch)
+	}
+	s.nextWatchID++
+	s.resourceWatches[s.nextWatchID] = resourceWatch{
+		resource:  rt,
+		threshold: threshold,
+		below:     below,
+		fn:        fn,
+	}
+	return s.nextWatchID, nil
+}
+
+// collectTriggeredWatches removes every watch whose condition holds for the
+// current quantities and returns closures that invoke them, in registration
+// order. Caller must hold s.mu and run the closures after releasing it.
+func (s *SimulationEngine) collectTriggeredWatches() []func() {
+	if len(s.resourceWatches) == 0 {
+		return nil
+	}
+
+	var fired []func()
+	for id := 1; id <= s.nextWatchID; id++ {
+		w, ok := s.resourceWatches[id]
+		if !ok {
+			continue
+		}
+		quantity := s.status.Resources[w.resource].Quantity
+		if (w.below && quantity < w.threshold) || (!w.below && quantity > w.threshold) {
+			delete(s.resourceWatches, id)
+			fired = append(fired, func() { w.fn(w.resource, quantity) })
+		}
+	}
+	return fired
+}
@@ -0,0 +1,83 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDrainingEngine returns an engine holding 100 mineral with a refinery
+// consuming 10 mineral per tick and no mines.
+func newDrainingEngine(t *testing.T) *SimulationEngine {
+	t.Helper()
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	require.NoError(t, sim.AddResource(ResourceMineral, 100))
+	sim.AddRefinery(1.0)
+	return sim
+}
+
+func TestOnResourceBelow_FiresOncePerThreshold(t *testing.T) {
+	sim := newDrainingEngine(t)
+
+	var at50, at25 []float64
+	_, err := sim.OnResourceBelow(ResourceMineral, 50, func(rt ResourceType, q float64) {
+		assert.Equal(t, ResourceMineral, rt)
+		at50 = append(at50, q)
+	})
+	require.NoError(t, err)
+	_, err = sim.OnResourceBelow(ResourceMineral, 25, func(_ ResourceType, q float64) {
+		at25 = append(at25, q)
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		sim.Tick()
+	}
+
+	assert.Equal(t, []float64{40}, at50)
+	assert.Equal(t, []float64{20}, at25)
+}
+
+func TestCancelResourceCallback(t *testing.T) {
+	sim := newDrainingEngine(t)
+
+	fired := false
+	id, err := sim.OnResourceBelow(ResourceMineral, 50, func(ResourceType, float64) { fired = true })
+	require.NoError(t, err)
+	sim.CancelResourceCallback(id)
+
+	for i := 0; i < 10; i++ {
+		sim.Tick()
+	}
+	assert.False(t, fired)
+}
+
+func TestOnResourceAbove(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	sim.AddMine(10)
+
+	var got []float64
+	_, err := sim.OnResourceAbove(ResourceMineral, 25, func(_ ResourceType, q float64) {
+		got = append(got, q)
+		sim.GetStatus() // callbacks run outside the engine lock
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		sim.Tick()
+	}
+	assert.Equal(t, []float64{30}, got)
+}
+
+func TestOnResourceBelow_Errors(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	_, err := sim.OnResourceBelow("unobtainium", 10, func(ResourceType, float64) {})
+	assert.Error(t, err)
+	_, err = sim.OnResourceBelow(ResourceMineral, 10, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+package simulation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+)
+
+// assignment links an NPC to the mine or refinery it works at.
+type assignment struct {
+	Kind string // "mine" or "refinery"
+	ID   string
+}
+
+// AssignNPCToMine assigns a registered NPC to work at a mine, moving it from
+// any previous assignment. While a mine has assigned NPCs its effective
+// yield is YieldRate × their combined WorkEfficiency (see
+// SimulationConfig.EfficiencyAggregation).
+// Returns an *InfrastructureNotFoundError if no such mine exists and an
+// *npc.NPCNotFoundError if the NPC is not registered with the attached
+// behavior engine.
+func (s *SimulationEngine) AssignNPCToMine(npcID, mineID string) error {
+	return s.assignNPC(npcID, "mine", mineID)
+}
+
+// AssignNPCToRefinery assigns a registered NPC to work at a refinery, moving
+// it from any previous assignment. While a refinery has assigned NPCs its
+// effective efficiency is Efficiency × their combined WorkEfficiency.
+// Errors are as for AssignNPCToMine.
+func (s *SimulationEngine) AssignNPCToRefinery(npcID, refineryID string) error {
+	return s.assignNPC(npcID, "refinery", refineryID)
+}
+
+// UnassignNPC removes an NPC's mine or refinery assignment.
+// Returns an *NPCNotAssignedError if the NPC has no assignment.
+func (s *SimulationEngine) UnassignNPC(npcID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.assignments[npcID]; !ok {
+		return &NPCNotAssignedError{NPCID: npcID}
+	}
+	delete(s.assignments, npcID)
+	return nil
+}
+
+// GetMineAssignments returns the sorted IDs of the NPCs assigned to a mine.
+func (s *SimulationEngine) GetMineAssignments(mineID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.assignedNPCs("mine", mineID)
+}
+
+// GetRefineryAssignments returns the sorted IDs of the NPCs assigned to a
+// refinery.
+func (s *SimulationEngine) GetRefineryAssignments(refineryID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.assignedNPCs("refinery", refineryID)
+}
+
+// assignNPC validates and stores an assignment.
+func (s *SimulationEngine) assignNPC(npcID, kind, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.disruptedTicks(kind, id) == nil {
+		return &InfrastructureNotFoundError{Kind: kind, ID: id}
+	}
+	if s.behavior == nil {
+		return fmt.Errorf("cannot assign NPC %q: no behavior engine attached", npcID)
+	}
+	if _, ok := s.behavior.GetNPC(npcID); !ok {
+		return &npc.NPCNotFoundError{NpcID: npcID}
+	}
+	s.assignments[npcID] = assignment{Kind: kind, ID: id}
+	return nil
+}
+
+// assignedNPCs returns the sorted IDs of the NPCs assigned to the given mine
+// or refinery. Caller must hold s.mu.
+func (s *SimulationEngine) assignedNPCs(kind, id string) []string {
+	ids := make([]string, 0)
+	for npcID, a := range s.assignments {
+		if a.Kind == kind && a.ID == id {
+			ids = append(ids, npcID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// assignedEfficiency returns the WorkEfficiency of the NPCs assigned to each
+// mine and refinery and still registered with the behavior engine, combined
+// using the configured EfficiencyAggregation and keyed by assignment. Mines
+// and refineries without such NPCs are absent and run at their base rate.
+// Without a behavior engine it returns the efficiencies captured by Fork.
+// Caller must hold s.mu.
+func (s *SimulationEngine) assignedEfficiency() map[assignment]float64 {
+	if s.behavior == nil {
+		return s.crewEfficiency
+	}
+	if len(s.assignments) == 0 {
+		return nil
+	}
+	crews := make(map[assignment][]*npc.NPCBehavior)
+	for npcID, a := range s.assignments {
+		n, ok := s.behavior.GetNPC(npcID)
+		if !ok {
+			continue
+		}
+		crews[a] = append(crews[a], n)
+	}
+	efficiency := make(map[assignment]float64, len(crews))
+	for a, crew := range crews {
+		efficiency[a] = s.config.aggregateEfficiency(crew)
+	}
+	return efficiency
+}
+
+// pruneAssignments drops assignments to mines and refineries that no longer
+// exist. Caller must hold s.mu.
+func (s *SimulationEngine) pruneAssignments() {
+	for npcID, a := range s.assignments {
+		if s.disruptedTicks(a.Kind, a.ID) == nil {
+			delete(s.assignments, npcID)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAssignmentTestEngine(t *testing.T, npcIDs ...string) (*SimulationEngine, *npc.BehaviorEngine) {
+	t.Helper()
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	behavior := npc.NewBehaviorEngine()
+	for _, id := range npcIDs {
+		behavior.RegisterNPC(id) // default efficiency 0.5
+	}
+	sim.AttachBehaviorEngine(behavior)
+	return sim, behavior
+}
+
+func TestAssignNPCToMine_ScalesYieldByAssignedEfficiency(t *testing.T) {
+	sim, behavior := newAssignmentTestEngine(t, "npc-1", "npc-2")
+	mineID := sim.AddMine(10.0)
+
+	require.NoError(t, sim.AssignNPCToMine("npc-1", mineID))
+	require.NoError(t, sim.AssignNPCToMine("npc-2", mineID))
+	assert.Equal(t, []string{"npc-1", "npc-2"}, sim.GetMineAssignments(mineID))
+
+	status := sim.Tick()
+	assert.InDelta(t, 5.0, status.Resources[ResourceMineral].ProductionRate, 1e-9) // 10 × 0.5
+	assert.InDelta(t, 5.0, status.Resources[ResourceMineral].Quantity, 1e-9)
+
+	require.NoError(t, behavior.ApplyWorkEfficiencyModifier("npc-1", 0.3, "test"))
+	require.NoError(t, behavior.ApplyWorkEfficiencyModifier("npc-2", 0.3, "test"))
+	status = sim.Tick()
+	assert.InDelta(t, 8.0, status.Resources[ResourceMineral].ProductionRate, 1e-9) // 10 × 0.8
+}
+
+func TestAssignNPCToMine_UnassignedMineUsesBaseYield(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t, "npc-1")
+	mineID := sim.AddMine(10.0)
+	require.NoError(t, sim.AssignNPCToMine("npc-1", mineID))
+	require.NoError(t, sim.UnassignNPC("npc-1"))
+
+	assert.Empty(t, sim.GetMineAssignments(mineID))
+	status := sim.Tick()
+	assert.InDelta(t, 10.0, status.Resources[ResourceMineral].ProductionRate, 1e-9)
+
+	err := sim.UnassignNPC("npc-1")
+	assert.True(t, errors.Is(err, ErrNPCNotAssigned))
+}
+
+func TestAssignNPCToRefinery_MovesFromMine(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t, "npc-1")
+	mineID := sim.AddMine(10.0)
+	refineryID := sim.AddRefinery(1.0)
+
+	require.NoError(t, sim.AssignNPCToMine("npc-1", mineID))
+	require.NoError(t, sim.AssignNPCToRefinery("npc-1", refineryID))
+	assert.Empty(t, sim.GetMineAssignments(mineID))
+	assert.Equal(t, []string{"npc-1"}, sim.GetRefineryAssignments(refineryID))
+
+	status := sim.Tick()
+	cfg := DefaultConfig()
+	assert.InDelta(t, 0.5*cfg.RefineryRapidlumProductionBase, status.Resources[ResourceRapidlum].ProductionRate, 1e-9)
+	assert.InDelta(t, 0.5*cfg.RefineryMineralConsumptionBase, status.Resources[ResourceMineral].ConsumptionRate, 1e-9)
+}
+
+func TestAssignNPC_Errors(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t, "npc-1")
+	mineID := sim.AddMine(10.0)
+
+	err := sim.AssignNPCToMine("npc-1", "mine-missing")
+	assert.True(t, errors.Is(err, ErrInfrastructureNotFound))
+
+	err = sim.AssignNPCToMine("ghost", mineID)
+	assert.True(t, errors.Is(err, npc.ErrNPCNotFound))
+
+	detached := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	assert.Error(t, detached.AssignNPCToMine("npc-1", detached.AddMine(1.0)))
+}
+
+func TestRemoveMine_DropsAssignments(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t, "npc-1")
+	mineID := sim.AddMine(10.0)
+	require.NoError(t, sim.AssignNPCToMine("npc-1", mineID))
+
+	require.NoError(t, sim.RemoveMine(mineID))
+	assert.True(t, errors.Is(sim.UnassignNPC("npc-1"), ErrNPCNotAssigned))
+}
@@ -0,0 +1,122 @@
+package simulation
+
+import "fmt"
+
+// DefaultAuditLogSize is the number of audit entries kept per resource
+// unless changed with SetAuditLogSize.
+const DefaultAuditLogSize = 100
+
+// ResourceAuditEntry accounts for one resource's quantity change during a
+// tick's production and decay, so that
+//
+//	QuantityAfter - QuantityBefore = Produced - Consumed - WastedToDecay - WastedToCap
+//
+// Changes made between ticks (trades, admin adjustments) are not audited.
+type ResourceAuditEntry struct {
+	Tick           int64
+	ResourceType   ResourceType
+	QuantityBefore float64 // Quantity before production
+	QuantityAfter  float64 // Quantity after production, consumption and decay
+	Produced       float64 // Throttled production, less chain output lost to input shortages
+	Consumed       float64 // Taken as input by production chains
+	WastedToDecay  float64 // Lost to the resource's decay rate
+	WastedToCap    float64 // Discarded above a storage cap; resources are uncapped, so always 0
+}
+
+// AuditSummary totals a resource's audit entries over a tick range.
+// NetChange equals TotalProduced - TotalConsumed - TotalWasted.
+type AuditSummary struct {
+	ResourceType  ResourceType
+	FromTick      int64
+	ToTick        int64
+	Entries       int // Audit entries in the range
+	TotalProduced float64
+	TotalConsumed float64
+	TotalWasted   float64 // Decay and cap waste
+	NetChange     float64 // Sum of QuantityAfter - QuantityBefore
+}
+
+// SetAuditLogSize sets how many audit entries are kept per resource,
+// dropping the oldest entries beyond it. Returns an error if n < 1.
+func (s *SimulationEngine) SetAuditLogSize(n int) error {
+	if n < 1 {
+		return fmt.Errorf("audit log size must be at least 1, got %d", n)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auditLogSize = n
+	for rt, entries := range s.auditLog {
+		if len(entries) > n {
+			s.auditLog[rt] = append([]ResourceAuditEntry(nil), entries[len(entries)-n:]...)
+		}
+	}
+	return nil
+}
+
+// GetResourceAuditLog returns up to limit of rt's most recent audit entries,
+// oldest first. A limit <= 0 returns every retained entry.
+func (s *SimulationEngine) GetResourceAuditLog(rt ResourceType, limit int) []ResourceAuditEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := s.auditLog[rt]
+	if limit > 0 && limit < len(entries) {
+		entries = entries[len(entries)-limit:]
+	}
+	return append([]ResourceAuditEntry{}, entries...)
+}
+
+// GetAuditSummary totals rt's retained audit entries for ticks in
+// [fromTick, toTick].
+func (s *SimulationEngine) GetAuditSummary(rt ResourceType, fromTick, toTick int64) AuditSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	summary := AuditSummary{ResourceType: rt, FromTick: fromTick, ToTick: toTick}
+	for _, e := range s.auditLog[rt] {
+		if e.Tick < fromTick || e.Tick > toTick {
+			continue
+		}
+		summary.Entries++
+		summary.TotalProduced += e.Produced
+		summary.TotalConsumed += e.Consumed
+		summary.TotalWasted += e.WastedToDecay + e.WastedToCap
+		summary.NetChange += e.QuantityAfter - e.QuantityBefore
+	}
+	return summary
+}
+
+// resourceQuantities returns the current quantity of each resource. Caller
+// must hold s.mu.
+func (s *SimulationEngine) resourceQuantities() map[ResourceType]float64 {
+	quantities := make(map[ResourceType]float64, len(s.status.Resources))
+	for rt, res := range s.status.Resources {
+		quantities[rt] = res.Quantity
+	}
+	return quantities
+}
+
+// recordAudit appends an audit entry per resource for the tick just
+// completed, given the quantities before production and after production
+// but before decay, and what production added and consumed. Caller must
+// hold s.mu.
+func (s *SimulationEngine) recordAudit(before, produced map[ResourceType]float64, tallies map[ResourceType]*resourceTally) {
+	for rt, res := range s.status.Resources {
+		entry := ResourceAuditEntry{
+			Tick:           s.status.TickCount,
+			ResourceType:   rt,
+			QuantityBefore: before[rt],
+			QuantityAfter:  res.Quantity,
+			WastedToDecay:  produced[rt] - res.Quantity,
+		}
+		if t := tallies[rt]; t != nil {
+			entry.Produced, entry.Consumed = t.produced, t.consumed
+		}
+
+		entries := append(s.auditLog[rt], entry)
+		if len(entries) > s.auditLogSize {
+			entries = append(entries[:0:0], entries[len(entries)-s.auditLogSize:]...)
+		}
+		s.auditLog[rt] = entries
+	}
+}
@@ -0,0 +1,88 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAuditSim returns an engine with one mine yielding 6 mineral, one
+// refinery turning 10 mineral into 5 rapidlum, 18 starting mineral, sim
+// decaying at 50% per tick and no world aging.
+func newAuditSim(t *testing.T) *SimulationEngine {
+	t.Helper()
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	sim.AddMine(6)
+	sim.AddRefinery(1.0)
+	require.NoError(t, sim.AddResource(ResourceMineral, 18))
+	require.NoError(t, sim.SetResourceDecayRate(ResourceSim, 0.5))
+	return sim
+}
+
+func TestAudit_FiveTicks(t *testing.T) {
+	sim := newAuditSim(t)
+	for i := 0; i < 5; i++ {
+		sim.Tick()
+	}
+
+	mineral := sim.GetResourceAuditLog(ResourceMineral, 0)
+	require.Len(t, mineral, 5)
+	before := []float64{18, 14, 10, 6, 2}
+	consumed := []float64{10, 10, 10, 10, 8} // tick 5 has only 8 to refine
+	for i, e := range mineral {
+		assert.Equal(t, int64(i+1), e.Tick)
+		assert.Equal(t, ResourceMineral, e.ResourceType)
+		assert.InDelta(t, before[i], e.QuantityBefore, 1e-9)
+		assert.InDelta(t, 6.0, e.Produced, 1e-9)
+		assert.InDelta(t, consumed[i], e.Consumed, 1e-9)
+		assert.Zero(t, e.WastedToDecay)
+	}
+	assert.InDelta(t, 0.0, mineral[4].QuantityAfter, 1e-9)
+
+	rapidlum := sim.GetResourceAuditLog(ResourceRapidlum, 0)
+	require.Len(t, rapidlum, 5)
+	assert.InDelta(t, 5.0, rapidlum[0].Produced, 1e-9)
+	assert.InDelta(t, 4.0, rapidlum[4].Produced, 1e-9, "output scales with the 80% of input available")
+	assert.InDelta(t, 24.0, rapidlum[4].QuantityAfter, 1e-9)
+
+	sim1 := sim.GetResourceAuditLog(ResourceSim, 2)
+	require.Len(t, sim1, 2)
+	assert.Equal(t, int64(4), sim1[0].Tick, "limit keeps the most recent entries")
+	assert.InDelta(t, sim1[1].QuantityAfter, sim1[1].WastedToDecay, 1e-9, "half of each tick's sim decays")
+
+	for _, rt := range []ResourceType{ResourceSim, ResourceRapidlum, ResourceMineral} {
+		summary := sim.GetAuditSummary(rt, 0, 100)
+		assert.Equal(t, 5, summary.Entries)
+		assert.InDelta(t, summary.NetChange, summary.TotalProduced-summary.TotalConsumed-summary.TotalWasted, 1e-9, rt)
+		assert.InDelta(t, sim.GetStatus().Resources[rt].Quantity-sim.GetResourceAuditLog(rt, 0)[0].QuantityBefore, summary.NetChange, 1e-9, rt)
+	}
+
+	summary := sim.GetAuditSummary(ResourceMineral, 2, 3)
+	assert.Equal(t, 2, summary.Entries)
+	assert.InDelta(t, 12.0, summary.TotalProduced, 1e-9)
+	assert.InDelta(t, 20.0, summary.TotalConsumed, 1e-9)
+	assert.InDelta(t, -8.0, summary.NetChange, 1e-9)
+}
+
+func TestAudit_LogSizeAndPause(t *testing.T) {
+	sim := newAuditSim(t)
+	assert.Error(t, sim.SetAuditLogSize(0))
+
+	for i := 0; i < 4; i++ {
+		sim.Tick()
+	}
+	require.NoError(t, sim.SetAuditLogSize(3))
+	log := sim.GetResourceAuditLog(ResourceMineral, 0)
+	require.Len(t, log, 3)
+	assert.Equal(t, int64(2), log[0].Tick)
+
+	sim.Tick()
+	require.NoError(t, sim.Pause())
+	sim.Tick()
+	log = sim.GetResourceAuditLog(ResourceMineral, 0)
+	require.Len(t, log, 3)
+	assert.Equal(t, int64(5), log[2].Tick, "paused ticks are not audited")
+}
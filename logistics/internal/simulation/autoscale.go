@@ -0,0 +1,143 @@
+package simulation
+
+import "fmt"
+
+// AutoScaleConfig controls automatically adding mines when mineral runs low.
+// Auto-scaling is disabled unless EnableAutoScale is set.
+type AutoScaleConfig struct {
+	EnableAutoScale    bool
+	MinMineralQuantity float64 // Mineral quantity below which a mine is added (default: 100)
+	AutoMineYieldRate  float64 // Yield rate of auto-added mines (default: 5.0)
+	MaxAutoMines       int     // Most auto-added mines that may exist at once (default: 3)
+}
+
+// DefaultAutoScaleConfig returns the standard auto-scaling settings, with
+// auto-scaling disabled.
+func DefaultAutoScaleConfig() AutoScaleConfig {
+	return AutoScaleConfig{
+		MinMineralQuantity: 100,
+		AutoMineYieldRate:  5.0,
+		MaxAutoMines:       3,
+	}
+}
+
+// Validate returns an error if any threshold, rate or limit is negative.
+func (c AutoScaleConfig) Validate() error {
+	if c.MinMineralQuantity < 0 {
+		return fmt.Errorf("MinMineralQuantity must be non-negative, got %v", c.MinMineralQuantity)
+	}
+	if c.AutoMineYieldRate < 0 {
+		return fmt.Errorf("AutoMineYieldRate must be non-negative, got %v", c.AutoMineYieldRate)
+	}
+	if c.MaxAutoMines < 0 {
+		return fmt.Errorf("MaxAutoMines must be non-negative, got %d", c.MaxAutoMines)
+	}
+	return nil
+}
+
+// AutoScaleEvent reports that auto-scaling added a mine.
+type AutoScaleEvent struct {
+	MineID          string
+	YieldRate       float64
+	MineralQuantity float64 // Mineral quantity that triggered the scale-up
+	AutoMineCount   int     // Auto-added mines after this one
+	Tick            int64   // Tick on which the mine was added
+}
+
+// AutoScaleListener is notified when auto-scaling adds a mine. It is called
+// after the engine lock is released.
+type AutoScaleListener func(AutoScaleEvent)
+
+// SetAutoScaleConfig validates cfg and replaces the auto-scaling settings,
+// taking effect on the next Tick. Disabling auto-scaling keeps the mines it
+// already added (see RemoveAutoScaleMines).
+func (s *SimulationEngine) SetAutoScaleConfig(cfg AutoScaleConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.autoScale = cfg
+	return nil
+}
+
+// GetAutoScaleConfig returns the current auto-scaling settings.
+func (s *SimulationEngine) GetAutoScaleConfig() AutoScaleConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.autoScale
+}
+
+// SetAutoScaleListener registers fn to be notified when auto-scaling adds a
+// mine, replacing any previous listener. A nil fn removes it.
+func (s *SimulationEngine) SetAutoScaleListener(fn AutoScaleListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.autoScaleListener = fn
+}
+
+// GetAutoMineCount returns the number of auto-added mines.
+func (s *SimulationEngine) GetAutoMineCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.autoMineCount()
+}
+
+// RemoveAutoScaleMines removes every auto-added mine, keeping manually added
+// ones, and returns how many were removed.
+func (s *SimulationEngine) RemoveAutoScaleMines() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.mines[:0]
+	var removed []string
+	for _, m := range s.mines {
+		if m.AutoScaled {
+			removed = append(removed, m.MineID)
+		} else {
+			kept = append(kept, m)
+		}
+	}
+	s.mines = kept
+	s.status.Mines = len(s.mines)
+	s.pruneAssignments()
+	for _, id := range removed {
+		s.record(SimulationEvent{Type: EventRemoveMine, TargetID: id})
+	}
+	return len(removed)
+}
+
+// autoMineCount returns the number of auto-added mines. Caller must hold s.mu.
+func (s *SimulationEngine) autoMineCount() int {
+	count := 0
+	for _, m := range s.mines {
+		if m.AutoScaled {
+			count++
+		}
+	}
+	return count
+}
+
+// applyAutoScale adds a mine if auto-scaling is enabled, mineral is below
+// MinMineralQuantity and fewer than MaxAutoMines auto-added mines exist.
+// Returns the event to report and whether a mine was added. Caller must hold
+// s.mu.
+func (s *SimulationEngine) applyAutoScale() (AutoScaleEvent, bool) {
+	cfg := s.autoScale
+	if !cfg.EnableAutoScale {
+		return AutoScaleEvent{}, false
+	}
+	mineral := s.status.Resources[ResourceMineral].Quantity
+	if mineral >= cfg.MinMineralQuantity || s.autoMineCount() >= cfg.MaxAutoMines {
+		return AutoScaleEvent{}, false
+	}
+
+	id := s.addMineLocked(cfg.AutoMineYieldRate, 0, true)
+	return AutoScaleEvent{
+		MineID:          id,
+		YieldRate:       cfg.AutoMineYieldRate,
+		MineralQuantity: mineral,
+		AutoMineCount:   s.autoMineCount(),
+		Tick:            s.status.TickCount,
+	}, true
+}
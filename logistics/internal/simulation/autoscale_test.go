@@ -0,0 +1,105 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAutoScaleTestEngine returns an engine holding 100 mineral that a
+// refinery drains, with auto-scaling enabled below minMineral.
+func newAutoScaleTestEngine(t *testing.T, minMineral float64) *SimulationEngine {
+	t.Helper()
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	require.NoError(t, sim.AddResource(ResourceMineral, 100))
+	sim.AddRefinery(1.0)
+
+	cfg := DefaultAutoScaleConfig()
+	cfg.EnableAutoScale = true
+	cfg.MinMineralQuantity = minMineral
+	require.NoError(t, sim.SetAutoScaleConfig(cfg))
+	return sim
+}
+
+func TestAutoScale_AddsMineWhenMineralLow(t *testing.T) {
+	sim := newAutoScaleTestEngine(t, 50)
+	var events []AutoScaleEvent
+	sim.SetAutoScaleListener(func(ev AutoScaleEvent) { events = append(events, ev) })
+
+	var status SimulationStatus
+	for i := 0; i < 100 && sim.GetAutoMineCount() == 0; i++ {
+		status = sim.Tick()
+	}
+
+	require.Equal(t, 1, sim.GetAutoMineCount())
+	assert.Less(t, status.Resources[ResourceMineral].Quantity, 50.0)
+	assert.Equal(t, 1, status.Mines)
+	require.Len(t, events, 1)
+	assert.Equal(t, 5.0, events[0].YieldRate)
+	assert.Equal(t, 1, events[0].AutoMineCount)
+	assert.Less(t, events[0].MineralQuantity, 50.0)
+	assert.Equal(t, status.TickCount, events[0].Tick)
+
+	mine, err := sim.GetMine(events[0].MineID)
+	require.NoError(t, err)
+	assert.True(t, mine.AutoScaled)
+
+	next := sim.Tick()
+	assert.Greater(t, next.Resources[ResourceMineral].ProductionRate, status.Resources[ResourceMineral].ProductionRate)
+}
+
+func TestAutoScale_RespectsMaxAutoMines(t *testing.T) {
+	sim := newAutoScaleTestEngine(t, 1000)
+	manual := sim.AddMine(1.0)
+
+	for i := 0; i < 10; i++ {
+		sim.Tick()
+	}
+
+	assert.Equal(t, 3, sim.GetAutoMineCount())
+	assert.Equal(t, 4, sim.GetStatus().Mines)
+
+	assert.Equal(t, 3, sim.RemoveAutoScaleMines())
+	assert.Zero(t, sim.GetAutoMineCount())
+	mines := sim.GetAllMines()
+	require.Len(t, mines, 1)
+	assert.Equal(t, manual, mines[0].MineID)
+}
+
+func TestAutoScale_DisabledByDefault(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	for i := 0; i < 5; i++ {
+		sim.Tick()
+	}
+
+	assert.False(t, sim.GetAutoScaleConfig().EnableAutoScale)
+	assert.Zero(t, sim.GetAutoMineCount())
+}
+
+func TestAutoScale_Validation(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	assert.Error(t, sim.SetAutoScaleConfig(AutoScaleConfig{MinMineralQuantity: -1}))
+	assert.Error(t, sim.SetAutoScaleConfig(AutoScaleConfig{AutoMineYieldRate: -1}))
+	assert.Error(t, sim.SetAutoScaleConfig(AutoScaleConfig{MaxAutoMines: -1}))
+	assert.Equal(t, DefaultAutoScaleConfig(), sim.GetAutoScaleConfig())
+}
+
+func TestAutoScale_ReplayRestoresAutoMines(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	es := NewEventSourcedSimulationEngine(sim)
+	es.AddMine(1.0)
+	require.NoError(t, sim.SetAutoScaleConfig(AutoScaleConfig{EnableAutoScale: true, MinMineralQuantity: 1000, AutoMineYieldRate: 2.0, MaxAutoMines: 3}))
+	es.Tick()
+	es.Tick()
+
+	replayed, err := es.ReplayFromEvents(es.ExportEventLog())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, replayed.GetAutoMineCount(), "auto-scaling is off during replay; the log adds the mines")
+	assert.Equal(t, sim.GetAllMines(), replayed.GetAllMines())
+}
@@ -0,0 +1,110 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DecayAlertFraction is the fraction of a resource's peak quantity below
+// which decay triggers a ResourceDecayEvent.
+const DecayAlertFraction = 0.1
+
+// ResourceDecayEvent reports that decay pushed a perishable resource below
+// DecayAlertFraction of its peak quantity.
+type ResourceDecayEvent struct {
+	Resource ResourceType
+	Quantity float64 // Quantity left after decay
+	Lost     float64 // Quantity lost to decay this tick
+	Peak     float64 // Highest quantity the resource has held
+	Tick     int64   // Tick on which the decay happened
+}
+
+// ResourceDecayListener is notified when decay pushes a resource below
+// DecayAlertFraction of its peak. It is called after the engine lock is
+// released.
+type ResourceDecayListener func(ResourceDecayEvent)
+
+// SetResourceDecayRate sets the fraction of rt's quantity lost per tick,
+// taking effect on the next Tick. A rate of 0 stops the resource decaying.
+// Returns an error if rt is unknown or rate is outside [0, 1].
+func (s *SimulationEngine) SetResourceDecayRate(rt ResourceType, rate float64) error {
+	if err := validateDecayRate(rt, rate); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rate == 0 {
+		delete(s.config.ResourceDecayRate, rt)
+		return nil
+	}
+	if s.config.ResourceDecayRate == nil {
+		s.config.ResourceDecayRate = make(map[ResourceType]float64)
+	}
+	s.config.ResourceDecayRate[rt] = rate
+	return nil
+}
+
+// SetResourceDecayListener registers fn to be notified when decay pushes a
+// resource below DecayAlertFraction of its peak, replacing any previous
+// listener. A nil fn removes it.
+func (s *SimulationEngine) SetResourceDecayListener(fn ResourceDecayListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decayListener = fn
+}
+
+// validateDecayRate returns an error if rt is unknown or rate is outside [0, 1].
+func validateDecayRate(rt ResourceType, rate float64) error {
+	if _, err := ParseResourceType(string(rt)); err != nil {
+		return fmt.Errorf("ResourceDecayRate: %w", err)
+	}
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("ResourceDecayRate for %s must be in [0, 1], got %v", rt, rate)
+	}
+	return nil
+}
+
+// applyDecay removes the configured fraction of each decaying resource's
+// quantity.
+func applyDecay(resources map[ResourceType]*ResourceState, rates map[ResourceType]float64) {
+	for rt, rate := range rates {
+		if res, ok := resources[rt]; ok {
+			res.Quantity *= 1 - rate
+		}
+	}
+}
+
+// applyResourceDecay records the resources' peak quantities, applies decay
+// and returns an event, ordered by resource, for each resource that decay
+// pushed below DecayAlertFraction of its peak. Caller must hold s.mu.
+func (s *SimulationEngine) applyResourceDecay() []ResourceDecayEvent {
+	before := make(map[ResourceType]float64, len(s.status.Resources))
+	for rt, res := range s.status.Resources {
+		before[rt] = res.Quantity
+		s.resourcePeaks[rt] = math.Max(s.resourcePeaks[rt], res.Quantity)
+	}
+	applyDecay(s.status.Resources, s.config.ResourceDecayRate)
+
+	var alerts []ResourceDecayEvent
+	for rt := range s.config.ResourceDecayRate {
+		res, ok := s.status.Resources[rt]
+		if !ok {
+			continue
+		}
+		threshold := DecayAlertFraction * s.resourcePeaks[rt]
+		if before[rt] >= threshold && res.Quantity < threshold {
+			alerts = append(alerts, ResourceDecayEvent{
+				Resource: rt,
+				Quantity: res.Quantity,
+				Lost:     before[rt] - res.Quantity,
+				Peak:     s.resourcePeaks[rt],
+				Tick:     s.status.TickCount + 1,
+			})
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Resource < alerts[j].Resource })
+	return alerts
+}
@@ -0,0 +1,112 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDecayTestEngine returns an engine that produces nothing, so quantities
+// only change through decay.
+func newDecayTestEngine(t *testing.T) *SimulationEngine {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.BaseSimProduction = 0
+	sim := NewSimulationEngineWithConfig(rebellion.NewEngine(rebellion.DefaultConfig()), cfg)
+	disableWorldAging(t, sim)
+	return sim
+}
+
+func TestResourceDecay_DecaysGeometrically(t *testing.T) {
+	sim := newDecayTestEngine(t)
+	require.NoError(t, sim.SetResourceDecayRate(ResourceSim, 0.1))
+	require.NoError(t, sim.AddResource(ResourceSim, 100))
+
+	for i := 0; i < 10; i++ {
+		sim.Tick()
+	}
+
+	assert.InDelta(t, 100*math.Pow(0.9, 10), sim.GetStatus().Resources[ResourceSim].Quantity, 1e-9) // ≈ 34.9
+}
+
+func TestResourceDecay_ZeroRateKeepsQuantity(t *testing.T) {
+	sim := newDecayTestEngine(t)
+	require.NoError(t, sim.AddResource(ResourceSim, 100))
+	require.NoError(t, sim.SetResourceDecayRate(ResourceSim, 0.5))
+	require.NoError(t, sim.SetResourceDecayRate(ResourceSim, 0))
+
+	for i := 0; i < 10; i++ {
+		sim.Tick()
+	}
+
+	assert.Equal(t, 100.0, sim.GetStatus().Resources[ResourceSim].Quantity)
+	assert.Empty(t, sim.GetConfig().ResourceDecayRate)
+}
+
+func TestResourceDecay_AppliedAfterProduction(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	require.NoError(t, sim.SetResourceDecayRate(ResourceSim, 0.5))
+	require.NoError(t, sim.AddResource(ResourceSim, 9))
+
+	status := sim.Tick()
+
+	assert.InDelta(t, (9+1)*0.5, status.Resources[ResourceSim].Quantity, 1e-9)
+}
+
+func TestResourceDecay_ListenerFiresOnceBelowTenPercentOfPeak(t *testing.T) {
+	sim := newDecayTestEngine(t)
+	require.NoError(t, sim.SetResourceDecayRate(ResourceSim, 0.5))
+	require.NoError(t, sim.AddResource(ResourceSim, 100))
+	var events []ResourceDecayEvent
+	sim.SetResourceDecayListener(func(ev ResourceDecayEvent) { events = append(events, ev) })
+
+	for i := 0; i < 6; i++ {
+		sim.Tick()
+	}
+
+	// 100 → 50 → 25 → 12.5 → 6.25: the fourth tick crosses 10 and later ticks stay below.
+	require.Len(t, events, 1)
+	assert.Equal(t, ResourceSim, events[0].Resource)
+	assert.InDelta(t, 6.25, events[0].Quantity, 1e-9)
+	assert.InDelta(t, 6.25, events[0].Lost, 1e-9)
+	assert.Equal(t, 100.0, events[0].Peak)
+	assert.Equal(t, int64(4), events[0].Tick)
+}
+
+func TestResourceDecay_Validation(t *testing.T) {
+	sim := newDecayTestEngine(t)
+
+	assert.Error(t, sim.SetResourceDecayRate("gold", 0.1))
+	assert.Error(t, sim.SetResourceDecayRate(ResourceSim, -0.1))
+	assert.Error(t, sim.SetResourceDecayRate(ResourceSim, 1.5))
+
+	cfg := DefaultConfig()
+	cfg.ResourceDecayRate = map[ResourceType]float64{ResourceMineral: 2}
+	assert.Error(t, sim.UpdateConfig(cfg))
+}
+
+func TestResourceDecay_ConfigIsNotShared(t *testing.T) {
+	sim := newDecayTestEngine(t)
+	require.NoError(t, sim.SetResourceDecayRate(ResourceSim, 0.1))
+
+	cfg := sim.GetConfig()
+	cfg.ResourceDecayRate[ResourceSim] = 0.9
+
+	assert.Equal(t, 0.1, sim.GetConfig().ResourceDecayRate[ResourceSim])
+	assert.Equal(t, 0.1, sim.Fork().GetConfig().ResourceDecayRate[ResourceSim])
+}
+
+func TestResourceDecay_Forecast(t *testing.T) {
+	sim := newDecayTestEngine(t)
+	require.NoError(t, sim.SetResourceDecayRate(ResourceSim, 0.1))
+	require.NoError(t, sim.AddResource(ResourceSim, 100))
+
+	forecast := sim.ForecastResources(2)
+
+	require.Len(t, forecast, 2)
+	assert.InDelta(t, 81.0, forecast[1].Quantities[ResourceSim], 1e-9)
+}
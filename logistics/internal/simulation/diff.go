@@ -0,0 +1,64 @@
+package simulation
+
+// ResourceStateDiff is the change in one resource between two statuses.
+type ResourceStateDiff struct {
+	QuantityDelta        float64
+	ProductionRateDelta  float64
+	ConsumptionRateDelta float64
+}
+
+// SimulationStatusDiff summarizes what changed between two simulation
+// statuses. Deltas are the later value minus the earlier one, so removals
+// show up as negative MinesAdded/RefineriesAdded.
+type SimulationStatusDiff struct {
+	ChangedResources map[ResourceType]ResourceStateDiff // Only resources with a non-zero delta
+	TickDelta        int64
+	InfestationDelta float64
+	ThrottleChanged  bool
+	MinesAdded       int
+	RefineriesAdded  int
+}
+
+// Diff returns the changes from s to other, for comparing snapshots taken
+// at different points in a game. A resource missing from one status counts
+// as zero there.
+func (s SimulationStatus) Diff(other SimulationStatus) SimulationStatusDiff {
+	diff := SimulationStatusDiff{
+		ChangedResources: make(map[ResourceType]ResourceStateDiff),
+		TickDelta:        other.TickCount - s.TickCount,
+		InfestationDelta: other.InfestationLevel - s.InfestationLevel,
+		ThrottleChanged:  other.ThrottleMultiplier != s.ThrottleMultiplier,
+		MinesAdded:       other.Mines - s.Mines,
+		RefineriesAdded:  other.Refineries - s.Refineries,
+	}
+
+	for rt := range s.Resources {
+		diff.addResource(rt, s.Resources[rt], other.Resources[rt])
+	}
+	for rt := range other.Resources {
+		if _, seen := s.Resources[rt]; !seen {
+			diff.addResource(rt, nil, other.Resources[rt])
+		}
+	}
+	return diff
+}
+
+// addResource records the change from before to after (nil = zero state)
+// if there is one.
+func (d *SimulationStatusDiff) addResource(rt ResourceType, before, after *ResourceState) {
+	var zero ResourceState
+	if before == nil {
+		before = &zero
+	}
+	if after == nil {
+		after = &zero
+	}
+	delta := ResourceStateDiff{
+		QuantityDelta:        after.Quantity - before.Quantity,
+		ProductionRateDelta:  after.ProductionRate - before.ProductionRate,
+		ConsumptionRateDelta: after.ConsumptionRate - before.ConsumptionRate,
+	}
+	if delta != (ResourceStateDiff{}) {
+		d.ChangedResources[rt] = delta
+	}
+}
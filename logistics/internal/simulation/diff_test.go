@@ -0,0 +1,74 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulationStatusDiff(t *testing.T) {
+	a := SimulationStatus{
+		ThrottleMultiplier: 1.0,
+		Resources: map[ResourceType]*ResourceState{
+			ResourceMineral: {Type: ResourceMineral},
+			ResourceSim:     {Type: ResourceSim, Quantity: 3, ProductionRate: 1},
+		},
+	}
+	b := SimulationStatus{
+		TickCount:          5,
+		Mines:              1,
+		InfestationLevel:   12.5,
+		ThrottleMultiplier: 1.0,
+		Resources: map[ResourceType]*ResourceState{
+			ResourceMineral: {Type: ResourceMineral, Quantity: 50, ProductionRate: 10},
+			ResourceSim:     {Type: ResourceSim, Quantity: 3, ProductionRate: 1},
+		},
+	}
+
+	diff := a.Diff(b)
+
+	assert.Equal(t, map[ResourceType]ResourceStateDiff{
+		ResourceMineral: {QuantityDelta: 50.0, ProductionRateDelta: 10.0},
+	}, diff.ChangedResources, "unchanged sim is omitted")
+	assert.Equal(t, int64(5), diff.TickDelta)
+	assert.Equal(t, 1, diff.MinesAdded)
+	assert.Equal(t, 0, diff.RefineriesAdded)
+	assert.Equal(t, 12.5, diff.InfestationDelta)
+	assert.False(t, diff.ThrottleChanged)
+
+	reverse := b.Diff(a)
+	assert.Equal(t, -50.0, reverse.ChangedResources[ResourceMineral].QuantityDelta)
+	assert.Equal(t, -1, reverse.MinesAdded)
+}
+
+func TestSimulationStatusDiff_MissingResourceCountsAsZero(t *testing.T) {
+	a := SimulationStatus{}
+	b := SimulationStatus{ThrottleMultiplier: 0.5, Resources: map[ResourceType]*ResourceState{
+		ResourceRapidlum: {Type: ResourceRapidlum, Quantity: 4, ConsumptionRate: 2},
+	}}
+
+	diff := a.Diff(b)
+
+	assert.Equal(t, ResourceStateDiff{QuantityDelta: 4, ConsumptionRateDelta: 2}, diff.ChangedResources[ResourceRapidlum])
+	assert.True(t, diff.ThrottleChanged)
+	assert.Empty(t, b.Diff(b).ChangedResources)
+}
+
+func TestSimulationStatusDiff_BetweenTicks(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	before := sim.GetStatus()
+	sim.AddMine(10)
+	for i := 0; i < 5; i++ {
+		sim.Tick()
+	}
+
+	diff := before.Diff(sim.GetStatus())
+
+	require.Contains(t, diff.ChangedResources, ResourceMineral)
+	assert.InDelta(t, 50.0, diff.ChangedResources[ResourceMineral].QuantityDelta, 1e-9)
+	assert.Equal(t, int64(5), diff.TickDelta)
+	assert.Equal(t, 1, diff.MinesAdded)
+}
@@ -0,0 +1,181 @@
+package simulation
+
+import "fmt"
+
+// DisruptionConfig controls random production disruptions (equipment
+// failure, worker strikes) of mines and refineries. A zero config disables
+// random disruptions.
+type DisruptionConfig struct {
+	DisruptionProbabilityPerTick float64 // Chance per tick that an operating mine/refinery is disrupted (default: 0.01)
+	DisruptionDuration           int     // Ticks a random disruption lasts (default: 5)
+}
+
+// DefaultDisruptionConfig returns the standard disruption settings.
+func DefaultDisruptionConfig() DisruptionConfig {
+	return DisruptionConfig{
+		DisruptionProbabilityPerTick: 0.01,
+		DisruptionDuration:           5,
+	}
+}
+
+// Validate returns an error if the probability is outside [0, 1] or the
+// duration is not positive while disruptions are enabled.
+func (c DisruptionConfig) Validate() error {
+	if c.DisruptionProbabilityPerTick < 0 || c.DisruptionProbabilityPerTick > 1 {
+		return fmt.Errorf("DisruptionProbabilityPerTick must be in [0, 1], got %v", c.DisruptionProbabilityPerTick)
+	}
+	if c.DisruptionProbabilityPerTick > 0 && c.DisruptionDuration < 1 {
+		return fmt.Errorf("DisruptionDuration must be at least 1, got %d", c.DisruptionDuration)
+	}
+	return nil
+}
+
+// DisruptionEvent reports a mine or refinery going offline or recovering.
+type DisruptionEvent struct {
+	Kind          string // "mine" or "refinery"
+	ID            string
+	Recovered     bool  // false when the disruption starts, true when it ends
+	DurationTicks int   // Length of the disruption (start events only)
+	Tick          int64 // Tick count when the change took effect
+}
+
+// DisruptionListener is notified of disruption starts and recoveries. It is
+// called after the engine lock is released.
+type DisruptionListener func(DisruptionEvent)
+
+// SetDisruptionConfig validates cfg and replaces the random disruption
+// settings. Engines start with disruptions disabled.
+func (s *SimulationEngine) SetDisruptionConfig(cfg DisruptionConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disruption = cfg
+	return nil
+}
+
+// GetDisruptionConfig returns the current random disruption settings.
+func (s *SimulationEngine) GetDisruptionConfig() DisruptionConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.disruption
+}
+
+// SetRandFn injects a deterministic random function for testing. fn must
+// return values in [0, 1).
+func (s *SimulationEngine) SetRandFn(fn func() float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.randFn = fn
+}
+
+// SetDisruptionListener registers fn to be notified of disruption starts and
+// recoveries, replacing any previous listener. A nil fn removes it.
+func (s *SimulationEngine) SetDisruptionListener(fn DisruptionListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disruptionListener = fn
+}
+
+// DisruptMine takes a mine offline for the next durationTicks ticks, during
+// which it yields nothing; it then recovers automatically. Disrupting an
+// already disrupted mine replaces its remaining duration.
+// Returns an *InfrastructureNotFoundError if no such mine exists.
+func (s *SimulationEngine) DisruptMine(mineID string, durationTicks int) error {
+	if durationTicks < 1 {
+		return fmt.Errorf("duration must be at least 1 tick, got %d", durationTicks)
+	}
+
+	s.mu.Lock()
+	ev, err := s.disruptLocked("mine", mineID, durationTicks)
+	listener := s.disruptionListener
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if listener != nil {
+		listener(ev)
+	}
+	return nil
+}
+
+// disruptLocked sets the remaining disruption of a mine or refinery and
+// records it. Caller must hold s.mu.
+func (s *SimulationEngine) disruptLocked(kind, id string, durationTicks int) (DisruptionEvent, error) {
+	remaining := s.disruptedTicks(kind, id)
+	if remaining == nil {
+		return DisruptionEvent{}, &InfrastructureNotFoundError{Kind: kind, ID: id}
+	}
+	*remaining = durationTicks
+
+	evType := EventDisruptMine
+	if kind == "refinery" {
+		evType = EventDisruptRefinery
+	}
+	s.record(SimulationEvent{Type: evType, TargetID: id, Value: float64(durationTicks)})
+	return DisruptionEvent{Kind: kind, ID: id, DurationTicks: durationTicks, Tick: s.status.TickCount}, nil
+}
+
+// disruptedTicks returns a pointer to the remaining disruption counter of the
+// given mine or refinery, or nil if it does not exist. Caller must hold s.mu.
+func (s *SimulationEngine) disruptedTicks(kind, id string) *int {
+	if kind == "mine" {
+		for i := range s.mines {
+			if s.mines[i].MineID == id {
+				return &s.mines[i].DisruptedTicks
+			}
+		}
+		return nil
+	}
+	for i := range s.refineries {
+		if s.refineries[i].RefineryID == id {
+			return &s.refineries[i].DisruptedTicks
+		}
+	}
+	return nil
+}
+
+// advanceDisruptions runs at the end of a tick: it counts down active
+// disruptions, recovering those that reach zero, then rolls for new
+// disruptions of each operating mine and refinery, in order. New
+// disruptions take effect from the next tick. Caller must hold s.mu.
+func (s *SimulationEngine) advanceDisruptions() []DisruptionEvent {
+	var events []DisruptionEvent
+	tick := s.status.TickCount
+
+	for i := range s.mines {
+		if m := &s.mines[i]; m.DisruptedTicks > 0 {
+			if m.DisruptedTicks--; m.DisruptedTicks == 0 {
+				events = append(events, DisruptionEvent{Kind: "mine", ID: m.MineID, Recovered: true, Tick: tick})
+			}
+		}
+	}
+	for i := range s.refineries {
+		if r := &s.refineries[i]; r.DisruptedTicks > 0 {
+			if r.DisruptedTicks--; r.DisruptedTicks == 0 {
+				events = append(events, DisruptionEvent{Kind: "refinery", ID: r.RefineryID, Recovered: true, Tick: tick})
+			}
+		}
+	}
+
+	p := s.disruption.DisruptionProbabilityPerTick
+	if p <= 0 || s.randFn == nil {
+		return events
+	}
+	d := s.disruption.DisruptionDuration
+	for _, m := range s.mines {
+		if m.DisruptedTicks == 0 && s.randFn() < p {
+			ev, _ := s.disruptLocked("mine", m.MineID, d)
+			events = append(events, ev)
+		}
+	}
+	for _, r := range s.refineries {
+		if r.DisruptedTicks == 0 && s.randFn() < p {
+			ev, _ := s.disruptLocked("refinery", r.RefineryID, d)
+			events = append(events, ev)
+		}
+	}
+	return events
+}
@@ -0,0 +1,118 @@
+package simulation
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomDisruptions_SeededProbability(t *testing.T) {
+	const (
+		seed  = 7
+		p     = 0.1
+		ticks = 1000
+	)
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(10)
+	// A one-tick disruption recovers before the next roll, so the mine is
+	// rolled exactly once per tick.
+	require.NoError(t, sim.SetDisruptionConfig(DisruptionConfig{DisruptionProbabilityPerTick: p, DisruptionDuration: 1}))
+	sim.SetRandFn(rand.New(rand.NewSource(seed)).Float64)
+
+	started := 0
+	sim.SetDisruptionListener(func(ev DisruptionEvent) {
+		if !ev.Recovered {
+			started++
+		}
+	})
+	for i := 0; i < ticks; i++ {
+		sim.Tick()
+	}
+
+	expected := 0
+	ref := rand.New(rand.NewSource(seed))
+	for i := 0; i < ticks; i++ {
+		if ref.Float64() < p {
+			expected++
+		}
+	}
+	assert.Equal(t, expected, started)
+	assert.InDelta(t, p, float64(started)/ticks, 0.03)
+}
+
+func TestDisruptMine_ZeroYieldThenRecovery(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	mineID := sim.AddMine(10)
+
+	var events []DisruptionEvent
+	sim.SetDisruptionListener(func(ev DisruptionEvent) { events = append(events, ev) })
+
+	require.NoError(t, sim.DisruptMine(mineID, 3))
+	for i := 0; i < 3; i++ {
+		status := sim.Tick()
+		assert.Zero(t, status.Resources[ResourceMineral].Quantity, "tick %d", i+1)
+		assert.Zero(t, status.Resources[ResourceMineral].ProductionRate, "tick %d", i+1)
+	}
+
+	status := sim.Tick()
+	assert.Equal(t, 10.0, status.Resources[ResourceMineral].ProductionRate)
+	assert.Equal(t, 10.0, status.Resources[ResourceMineral].Quantity)
+
+	assert.Equal(t, []DisruptionEvent{
+		{Kind: "mine", ID: mineID, DurationTicks: 3, Tick: 0},
+		{Kind: "mine", ID: mineID, Recovered: true, Tick: 3},
+	}, events)
+}
+
+func TestRandomDisruptions_Refinery(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddRefinery(1.0)
+	require.NoError(t, sim.SetDisruptionConfig(DefaultDisruptionConfig()))
+	sim.SetRandFn(func() float64 { return 0 }) // always disrupt
+
+	status := sim.Tick()
+	assert.Equal(t, 5.0, status.Resources[ResourceRapidlum].ProductionRate)
+	for i := 0; i < 5; i++ {
+		status = sim.Tick()
+		assert.Zero(t, status.Resources[ResourceRapidlum].ProductionRate)
+		assert.Zero(t, status.Resources[ResourceMineral].ConsumptionRate)
+	}
+}
+
+func TestDisruptMine_Errors(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	mineID := sim.AddMine(10)
+
+	err := sim.DisruptMine("mine-404", 3)
+	assert.True(t, errors.Is(err, ErrInfrastructureNotFound))
+	assert.Error(t, sim.DisruptMine(mineID, 0))
+}
+
+func TestDisruptionConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultDisruptionConfig().Validate())
+	assert.NoError(t, DisruptionConfig{}.Validate())
+	assert.Error(t, DisruptionConfig{DisruptionProbabilityPerTick: 1.5, DisruptionDuration: 5}.Validate())
+	assert.Error(t, DisruptionConfig{DisruptionProbabilityPerTick: 0.1}.Validate())
+}
+
+func TestEventSourcedEngine_ReplaysRandomDisruptions(t *testing.T) {
+	es := newEventSourcedEngine()
+	es.AddMine(10)
+	es.AddMine(6)
+	require.NoError(t, es.Engine().SetDisruptionConfig(DisruptionConfig{DisruptionProbabilityPerTick: 0.3, DisruptionDuration: 2}))
+	es.Engine().SetRandFn(rand.New(rand.NewSource(3)).Float64)
+	for i := 0; i < 20; i++ {
+		es.Tick()
+	}
+
+	replayed, err := es.ReplayFromEvents(es.ExportEventLog())
+	require.NoError(t, err)
+	want := es.Engine().GetStatus().Resources[ResourceMineral].Quantity
+	assert.Equal(t, want, replayed.GetStatus().Resources[ResourceMineral].Quantity)
+	assert.Less(t, want, 20*16.0, "seed should produce at least one disruption")
+}
@@ -0,0 +1,107 @@
+package simulation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+)
+
+// EfficiencyAggregation selects how the work efficiencies of a group of NPCs
+// combine into one production multiplier.
+type EfficiencyAggregation string
+
+const (
+	// EfficiencyMean averages the efficiencies (the default).
+	EfficiencyMean EfficiencyAggregation = "mean"
+	// EfficiencyMin uses the lowest efficiency: a crew is as fast as its
+	// slowest member.
+	EfficiencyMin EfficiencyAggregation = "min"
+	// EfficiencyWeightedByRole averages the efficiencies weighted by
+	// SimulationConfig.RoleEfficiencyWeights.
+	EfficiencyWeightedByRole EfficiencyAggregation = "weighted_by_role"
+	// EfficiencyMedian uses the middle efficiency, or the mean of the two
+	// middle ones for an even count.
+	EfficiencyMedian EfficiencyAggregation = "median"
+)
+
+// ParseEfficiencyAggregation converts a mode name (e.g. "median") to an
+// EfficiencyAggregation. An empty name selects EfficiencyMean.
+func ParseEfficiencyAggregation(name string) (EfficiencyAggregation, error) {
+	switch mode := EfficiencyAggregation(name); mode {
+	case "":
+		return EfficiencyMean, nil
+	case EfficiencyMean, EfficiencyMin, EfficiencyWeightedByRole, EfficiencyMedian:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown efficiency aggregation %q", name)
+	}
+}
+
+// GetCurrentEfficiencyMultiplier returns the work efficiency of every NPC
+// registered with the attached behavior engine, combined using the
+// configured EfficiencyAggregation. Returns 1.0 without a behavior engine or
+// registered NPCs.
+func (s *SimulationEngine) GetCurrentEfficiencyMultiplier() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.behavior == nil {
+		return 1.0
+	}
+	npcs := s.behavior.SnapshotNPCs()
+	if len(npcs) == 0 {
+		return 1.0
+	}
+	return s.config.aggregateEfficiency(npcs)
+}
+
+// aggregateEfficiency combines the work efficiencies of npcs, which must be
+// non-empty, using c.EfficiencyAggregation.
+func (c SimulationConfig) aggregateEfficiency(npcs []*npc.NPCBehavior) float64 {
+	switch c.EfficiencyAggregation {
+	case EfficiencyMin:
+		lowest := npcs[0].WorkEfficiency
+		for _, n := range npcs[1:] {
+			lowest = min(lowest, n.WorkEfficiency)
+		}
+		return lowest
+	case EfficiencyMedian:
+		values := make([]float64, len(npcs))
+		for i, n := range npcs {
+			values[i] = n.WorkEfficiency
+		}
+		sort.Float64s(values)
+		mid := len(values) / 2
+		if len(values)%2 == 0 {
+			return (values[mid-1] + values[mid]) / 2
+		}
+		return values[mid]
+	case EfficiencyWeightedByRole:
+		var total, weighted float64
+		for _, n := range npcs {
+			weight := c.roleEfficiencyWeight(n.Role)
+			total += weight
+			weighted += weight * n.WorkEfficiency
+		}
+		if total == 0 {
+			return 0
+		}
+		return weighted / total
+	default:
+		sum := 0.0
+		for _, n := range npcs {
+			sum += n.WorkEfficiency
+		}
+		return sum / float64(len(npcs))
+	}
+}
+
+// roleEfficiencyWeight returns role's RoleEfficiencyWeights entry, or 1.0 if
+// it has none.
+func (c SimulationConfig) roleEfficiencyWeight(role string) float64 {
+	if weight, ok := c.RoleEfficiencyWeights[role]; ok {
+		return weight
+	}
+	return 1.0
+}
@@ -0,0 +1,103 @@
+package simulation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEfficiencyTestEngine attaches NPCs with efficiencies 0.2, 0.4, 0.6, 0.8
+// and 1.0; the last one is a warrior, the others workers.
+func newEfficiencyTestEngine(t *testing.T) (*SimulationEngine, *npc.BehaviorEngine) {
+	t.Helper()
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	behavior := npc.NewBehaviorEngine()
+	for i, eff := range []float64{0.2, 0.4, 0.6, 0.8, 1.0} {
+		id := fmt.Sprintf("npc-%d", i)
+		role := "worker"
+		if eff == 1.0 {
+			role = "warrior"
+		}
+		behavior.RegisterNPCWithRole(id, role)
+		require.NoError(t, behavior.ApplyWorkEfficiencyModifier(id, eff-0.5, "test")) // registered at 0.5
+	}
+	sim.AttachBehaviorEngine(behavior)
+	return sim, behavior
+}
+
+func setEfficiencyAggregation(t *testing.T, sim *SimulationEngine, mode EfficiencyAggregation, weights map[string]float64) {
+	t.Helper()
+	cfg := sim.GetConfig()
+	cfg.EfficiencyAggregation = mode
+	cfg.RoleEfficiencyWeights = weights
+	require.NoError(t, sim.UpdateConfig(cfg))
+}
+
+func TestGetCurrentEfficiencyMultiplier_Aggregations(t *testing.T) {
+	sim, _ := newEfficiencyTestEngine(t)
+
+	tests := []struct {
+		mode    EfficiencyAggregation
+		weights map[string]float64
+		want    float64
+	}{
+		{EfficiencyMean, nil, 0.6},
+		{EfficiencyMedian, nil, 0.6},
+		{EfficiencyMin, nil, 0.2},
+		{EfficiencyWeightedByRole, nil, 0.6},
+		{EfficiencyWeightedByRole, map[string]float64{"warrior": 2.0}, (0.2 + 0.4 + 0.6 + 0.8 + 2*1.0) / 6},
+	}
+	for _, tt := range tests {
+		setEfficiencyAggregation(t, sim, tt.mode, tt.weights)
+		assert.InDelta(t, tt.want, sim.GetCurrentEfficiencyMultiplier(), 1e-9, "%s %v", tt.mode, tt.weights)
+	}
+}
+
+func TestGetCurrentEfficiencyMultiplier_NoNPCs(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	assert.Equal(t, 1.0, sim.GetCurrentEfficiencyMultiplier())
+
+	sim.AttachBehaviorEngine(npc.NewBehaviorEngine())
+	assert.Equal(t, 1.0, sim.GetCurrentEfficiencyMultiplier())
+}
+
+func TestEfficiencyAggregation_MedianOfEvenCount(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EfficiencyAggregation = EfficiencyMedian
+	npcs := []*npc.NPCBehavior{{WorkEfficiency: 0.9}, {WorkEfficiency: 0.1}, {WorkEfficiency: 0.3}, {WorkEfficiency: 0.5}}
+
+	assert.InDelta(t, 0.4, cfg.aggregateEfficiency(npcs), 1e-9)
+}
+
+func TestEfficiencyAggregation_ScalesAssignedMine(t *testing.T) {
+	sim, _ := newEfficiencyTestEngine(t)
+	setEfficiencyAggregation(t, sim, EfficiencyMin, nil)
+	mineID := sim.AddMine(10.0)
+	require.NoError(t, sim.AssignNPCToMine("npc-0", mineID))
+	require.NoError(t, sim.AssignNPCToMine("npc-4", mineID))
+
+	status := sim.Tick()
+
+	assert.InDelta(t, 2.0, status.Resources[ResourceMineral].ProductionRate, 1e-9) // 10 × min(0.2, 1.0)
+}
+
+func TestEfficiencyAggregation_Validation(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	cfg := DefaultConfig()
+	cfg.EfficiencyAggregation = "mode"
+	assert.Error(t, sim.UpdateConfig(cfg))
+
+	cfg = DefaultConfig()
+	cfg.RoleEfficiencyWeights = map[string]float64{"warrior": -1}
+	assert.Error(t, sim.UpdateConfig(cfg))
+
+	mode, err := ParseEfficiencyAggregation("")
+	require.NoError(t, err)
+	assert.Equal(t, EfficiencyMean, mode)
+}
@@ -0,0 +1,64 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInsufficientResource is returned when an operation needs more of a
+	// resource than the engine currently holds.
+	ErrInsufficientResource = errors.New("insufficient resource")
+	// ErrInfrastructureNotFound matches (via errors.Is) any *InfrastructureNotFoundError.
+	ErrInfrastructureNotFound = errors.New("infrastructure not found")
+	// ErrZoneNotFound matches (via errors.Is) any *ZoneNotFoundError.
+	ErrZoneNotFound = errors.New("zone not found")
+	// ErrNPCNotAssigned matches (via errors.Is) any *NPCNotAssignedError.
+	ErrNPCNotAssigned = errors.New("NPC not assigned")
+)
+
+// InfrastructureNotFoundError is returned when a mine or refinery ID does not
+// match any infrastructure owned by the engine.
+type InfrastructureNotFoundError struct {
+	Kind string // "mine" or "refinery"
+	ID   string
+}
+
+func (e *InfrastructureNotFoundError) Error() string {
+	return fmt.Sprintf("%s %q not found", e.Kind, e.ID)
+}
+
+// Is reports whether target is ErrInfrastructureNotFound.
+func (e *InfrastructureNotFoundError) Is(target error) bool {
+	return target == ErrInfrastructureNotFound
+}
+
+// ZoneNotFoundError is returned when a zone ID is not registered with a
+// ZoneManager.
+type ZoneNotFoundError struct {
+	ZoneID string
+}
+
+func (e *ZoneNotFoundError) Error() string {
+	return fmt.Sprintf("zone %q not found", e.ZoneID)
+}
+
+// Is reports whether target is ErrZoneNotFound.
+func (e *ZoneNotFoundError) Is(target error) bool {
+	return target == ErrZoneNotFound
+}
+
+// NPCNotAssignedError is returned when an NPC has no mine or refinery
+// assignment to remove.
+type NPCNotAssignedError struct {
+	NPCID string
+}
+
+func (e *NPCNotAssignedError) Error() string {
+	return fmt.Sprintf("NPC %q is not assigned to a mine or refinery", e.NPCID)
+}
+
+// Is reports whether target is ErrNPCNotAssigned.
+func (e *NPCNotAssignedError) Is(target error) bool {
+	return target == ErrNPCNotAssigned
+}
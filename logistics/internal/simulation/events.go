@@ -0,0 +1,273 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SimulationEventType identifies a recorded state-changing operation.
+type SimulationEventType string
+
+const (
+	EventAddMine         SimulationEventType = "add_mine"
+	EventAddRefinery     SimulationEventType = "add_refinery"
+	EventTick            SimulationEventType = "tick"
+	EventRemoveMine      SimulationEventType = "remove_mine"
+	EventRemoveRefinery  SimulationEventType = "remove_refinery"
+	EventDisruptMine     SimulationEventType = "disrupt_mine"
+	EventDisruptRefinery SimulationEventType = "disrupt_refinery"
+	EventAddFacility     SimulationEventType = "add_facility"
+	EventAutoScaleMine   SimulationEventType = "auto_scale_mine"
+)
+
+// SimulationEvent is one entry in an event-sourced simulation log.
+type SimulationEvent struct {
+	Sequence     int64 // 1-based position in the log
+	Type         SimulationEventType
+	TargetID     string  // Mine/refinery/facility ID assigned (add) or removed (remove); empty for ticks
+	Value        float64 // Yield rate (add_mine, auto_scale_mine), efficiency (add_refinery, add_facility) or duration in ticks (disrupt_*)
+	Reserve      float64 // Ore reserve of the mine, 0 for infinite (add_mine only)
+	FacilityType string  // Facility type (add_facility only)
+	Timestamp    time.Time
+}
+
+// EventSourcedSimulationEngine records every mine/refinery change, disruption
+// and tick applied to a SimulationEngine, including realtime ticks,
+// infrastructure imports and random disruptions, so the engine's state can
+// be reproduced by replaying the log.
+// Resource adjustments, trades, config changes, production chain
+// registrations, random events and infestation overrides are not recorded, and replays run without NPCs
+// attached, so a replay reflects only the recorded operations.
+// The log is unbounded. It is safe for concurrent use.
+type EventSourcedSimulationEngine struct {
+	engine *SimulationEngine
+	mu     sync.Mutex // guards events; acquired after engine.mu, never before
+	events []SimulationEvent
+}
+
+// NewEventSourcedSimulationEngine starts recording operations on engine.
+// Operations applied before this call are not recorded, so it should wrap a
+// freshly created engine.
+func NewEventSourcedSimulationEngine(engine *SimulationEngine) *EventSourcedSimulationEngine {
+	es := &EventSourcedSimulationEngine{engine: engine}
+	engine.mu.Lock()
+	engine.recorder = es.append
+	engine.mu.Unlock()
+	return es
+}
+
+// Engine returns the wrapped simulation engine.
+func (es *EventSourcedSimulationEngine) Engine() *SimulationEngine {
+	return es.engine
+}
+
+// AddMine adds a mine to the wrapped engine and records it.
+func (es *EventSourcedSimulationEngine) AddMine(yieldRate float64) string {
+	return es.engine.AddMine(yieldRate)
+}
+
+// AddMineWithReserve adds a mine with a finite ore reserve to the wrapped
+// engine and records it.
+func (es *EventSourcedSimulationEngine) AddMineWithReserve(yieldRate, maxReserve float64) string {
+	return es.engine.AddMineWithReserve(yieldRate, maxReserve)
+}
+
+// AddRefinery adds a refinery to the wrapped engine and records it.
+func (es *EventSourcedSimulationEngine) AddRefinery(efficiency float64) string {
+	return es.engine.AddRefinery(efficiency)
+}
+
+// AddFacility adds a conversion facility to the wrapped engine and records it.
+func (es *EventSourcedSimulationEngine) AddFacility(facilityType string, efficiency float64) string {
+	return es.engine.AddFacility(facilityType, efficiency)
+}
+
+// RemoveMine removes a mine from the wrapped engine and records it.
+func (es *EventSourcedSimulationEngine) RemoveMine(mineID string) error {
+	return es.engine.RemoveMine(mineID)
+}
+
+// RemoveRefinery removes a refinery from the wrapped engine and records it.
+func (es *EventSourcedSimulationEngine) RemoveRefinery(refineryID string) error {
+	return es.engine.RemoveRefinery(refineryID)
+}
+
+// Tick advances the wrapped engine by one tick and records it.
+func (es *EventSourcedSimulationEngine) Tick() SimulationStatus {
+	return es.engine.Tick()
+}
+
+// ExportEventLog returns a copy of the recorded events, oldest first.
+func (es *EventSourcedSimulationEngine) ExportEventLog() []SimulationEvent {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	out := make([]SimulationEvent, len(es.events))
+	copy(out, es.events)
+	return out
+}
+
+// ReplayFromEvents builds a fresh engine (with a fresh world age), with the
+// wrapped engine's config, production chains and rebellion engine, and
+// applies events to it in order. Rejuvenations are not recorded. Random
+// disruptions and auto-scaling are disabled during a replay; recorded
+// disruptions and auto-added mines are applied from the log. The wrapped
+// engine is not modified. Events must be numbered consecutively from 1, and
+// each add event's TargetID (if set) must match the ID the replay assigns.
+// Returns a descriptive error identifying the first event that cannot be
+// applied.
+func (es *EventSourcedSimulationEngine) ReplayFromEvents(events []SimulationEvent) (*SimulationEngine, error) {
+	es.engine.mu.RLock()
+	cfg := es.engine.config
+	rebellionEngine := es.engine.rebellion
+	chains := append([]ProductionChain(nil), es.engine.chains...)
+	es.engine.mu.RUnlock()
+
+	sim := NewSimulationEngineWithConfig(rebellionEngine, cfg)
+	sim.chains = chains
+	for i, ev := range events {
+		if want := int64(i + 1); ev.Sequence != want {
+			return nil, fmt.Errorf("event %d: expected sequence %d, got %d (missing or reordered events)", i, want, ev.Sequence)
+		}
+		if err := sim.applyEvent(ev); err != nil {
+			return nil, fmt.Errorf("event %d (%s): %w", ev.Sequence, ev.Type, err)
+		}
+	}
+	return sim, nil
+}
+
+// ImportEventLog replays events (see ReplayFromEvents) and, if the replay
+// succeeds, replaces the wrapped engine's infrastructure, resources, tick
+// count, world age, throttle history and infestation state with the result
+// and adopts events as the log.
+// NPC statistics and registered resource callbacks are kept.
+func (es *EventSourcedSimulationEngine) ImportEventLog(events []SimulationEvent) error {
+	replayed, err := es.ReplayFromEvents(events)
+	if err != nil {
+		return err
+	}
+
+	es.engine.mu.Lock()
+	defer es.engine.mu.Unlock()
+	es.engine.restoreFrom(replayed)
+
+	es.mu.Lock()
+	es.events = make([]SimulationEvent, len(events))
+	copy(es.events, events)
+	es.mu.Unlock()
+	return nil
+}
+
+// append numbers, timestamps and stores ev. It is installed as the engine's
+// recorder and runs under the engine lock.
+func (es *EventSourcedSimulationEngine) append(ev SimulationEvent) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	ev.Sequence = int64(len(es.events) + 1)
+	ev.Timestamp = time.Now()
+	es.events = append(es.events, ev)
+}
+
+// record forwards ev to the recorder, if one is installed. Caller must hold s.mu.
+func (s *SimulationEngine) record(ev SimulationEvent) {
+	if s.recorder != nil {
+		s.recorder(ev)
+	}
+}
+
+// applyEvent performs the operation described by ev.
+func (s *SimulationEngine) applyEvent(ev SimulationEvent) error {
+	switch ev.Type {
+	case EventAddMine:
+		if ev.Value < 0 {
+			return fmt.Errorf("yield rate must be non-negative, got %v", ev.Value)
+		}
+		if ev.Reserve < 0 {
+			return fmt.Errorf("ore reserve must be non-negative, got %v", ev.Reserve)
+		}
+		return checkAssignedID(s.AddMineWithReserve(ev.Value, ev.Reserve), ev.TargetID)
+	case EventAutoScaleMine:
+		if ev.Value < 0 {
+			return fmt.Errorf("yield rate must be non-negative, got %v", ev.Value)
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return checkAssignedID(s.addMineLocked(ev.Value, 0, true), ev.TargetID)
+	case EventAddRefinery:
+		if ev.Value < 0 || ev.Value > 1 {
+			return fmt.Errorf("efficiency must be in [0, 1], got %v", ev.Value)
+		}
+		return checkAssignedID(s.AddRefinery(ev.Value), ev.TargetID)
+	case EventAddFacility:
+		if ev.Value < 0 || ev.Value > 1 {
+			return fmt.Errorf("efficiency must be in [0, 1], got %v", ev.Value)
+		}
+		if ev.FacilityType == "" {
+			return errors.New("facility type is required")
+		}
+		return checkAssignedID(s.AddFacility(ev.FacilityType, ev.Value), ev.TargetID)
+	case EventRemoveMine:
+		return s.RemoveMine(ev.TargetID)
+	case EventRemoveRefinery:
+		return s.RemoveRefinery(ev.TargetID)
+	case EventDisruptMine, EventDisruptRefinery:
+		if ev.Value < 1 {
+			return fmt.Errorf("disruption duration must be at least 1 tick, got %v", ev.Value)
+		}
+		kind := "mine"
+		if ev.Type == EventDisruptRefinery {
+			kind = "refinery"
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		_, err := s.disruptLocked(kind, ev.TargetID, int(ev.Value))
+		return err
+	case EventTick:
+		s.Tick()
+		return nil
+	case "":
+		return errors.New("event type is required")
+	default:
+		return fmt.Errorf("unknown event type %q", ev.Type)
+	}
+}
+
+// checkAssignedID reports a mismatch between the ID a replay assigned and
+// the ID recorded in the log. An empty recorded ID accepts any assignment.
+func checkAssignedID(assigned, recorded string) error {
+	if recorded != "" && assigned != recorded {
+		return fmt.Errorf("replay assigned ID %q but the log recorded %q", assigned, recorded)
+	}
+	return nil
+}
+
+// restoreFrom copies other's infrastructure, resources and their peaks, tick
+// count, world age, throttle history and infestation state into s, dropping NPC assignments to infrastructure that
+// no longer exists. Caller must hold s.mu; other must not be shared.
+func (s *SimulationEngine) restoreFrom(other *SimulationEngine) {
+	s.mines = append([]Mine(nil), other.mines...)
+	s.refineries = append([]Refinery(nil), other.refineries...)
+	s.nextID = other.nextID
+	s.pruneAssignments()
+
+	for rt, res := range other.status.Resources {
+		copied := *res
+		s.status.Resources[rt] = &copied
+	}
+	s.status.Mines = len(s.mines)
+	s.status.Refineries = len(s.refineries)
+	s.status.TickCount = other.status.TickCount
+	s.tickCount.Store(s.status.TickCount)
+	s.status.WorldAgeMultiplier = other.status.WorldAgeMultiplier
+	s.throttleHistory = append([]ThrottleRecord(nil), other.throttleHistory...)
+	clear(s.resourcePeaks)
+	for rt, peak := range other.resourcePeaks {
+		s.resourcePeaks[rt] = peak
+	}
+
+	if s.infestation != nil && other.infestation != nil {
+		s.infestation.SetState(other.infestation.GetState())
+		s.syncInfestationStatus()
+	}
+}
@@ -0,0 +1,131 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEventSourcedEngine() *EventSourcedSimulationEngine {
+	return NewEventSourcedSimulationEngine(NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig())))
+}
+
+func TestEventSourcedEngine_RecordsOperations(t *testing.T) {
+	es := newEventSourcedEngine()
+	mineID := es.AddMine(10)
+	es.AddRefinery(0.5)
+	es.Tick()
+	require.NoError(t, es.RemoveMine(mineID))
+
+	events := es.ExportEventLog()
+	require.Len(t, events, 4)
+	types := make([]SimulationEventType, len(events))
+	for i, ev := range events {
+		assert.Equal(t, int64(i+1), ev.Sequence)
+		assert.False(t, ev.Timestamp.IsZero())
+		types[i] = ev.Type
+	}
+	assert.Equal(t, []SimulationEventType{EventAddMine, EventAddRefinery, EventTick, EventRemoveMine}, types)
+	assert.Equal(t, mineID, events[0].TargetID)
+	assert.Equal(t, 10.0, events[0].Value)
+}
+
+func TestEventSourcedEngine_ReplayReproducesState(t *testing.T) {
+	original := newEventSourcedEngine()
+	original.AddMine(10)
+	original.AddMine(4)
+	for i := 0; i < 5; i++ {
+		original.Tick()
+	}
+	want := original.Engine().GetStatus()
+
+	restored := newEventSourcedEngine()
+	require.NoError(t, restored.ImportEventLog(original.ExportEventLog()))
+
+	got := restored.Engine().GetStatus()
+	assert.Equal(t, want.TickCount, got.TickCount)
+	assert.Equal(t, want.Mines, got.Mines)
+	for rt, res := range want.Resources {
+		assert.InDelta(t, res.Quantity, got.Resources[rt].Quantity, 1e-9, rt)
+	}
+	assert.Equal(t, original.ExportEventLog(), restored.ExportEventLog())
+
+	// IDs continue from the replayed state and new operations extend the log.
+	assert.Equal(t, "mine-3", restored.AddMine(1))
+	assert.Len(t, restored.ExportEventLog(), 8)
+}
+
+func TestEventSourcedEngine_ReplayDoesNotModifyEngine(t *testing.T) {
+	es := newEventSourcedEngine()
+	es.AddMine(10)
+	es.Tick()
+
+	replayed, err := es.ReplayFromEvents(es.ExportEventLog()[:1])
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), replayed.GetStatus().TickCount)
+	assert.Equal(t, int64(1), es.Engine().GetStatus().TickCount)
+	assert.Len(t, es.ExportEventLog(), 2)
+}
+
+func TestEventSourcedEngine_InvalidEvents(t *testing.T) {
+	es := newEventSourcedEngine()
+	tests := []struct {
+		name   string
+		events []SimulationEvent
+		errMsg string
+	}{
+		{
+			name:   "unknown mine",
+			events: []SimulationEvent{{Sequence: 1, Type: EventRemoveMine, TargetID: "mine-9"}},
+			errMsg: `event 1 (remove_mine): mine "mine-9" not found`,
+		},
+		{
+			name:   "sequence gap",
+			events: []SimulationEvent{{Sequence: 1, Type: EventTick}, {Sequence: 3, Type: EventTick}},
+			errMsg: "event 1: expected sequence 2, got 3 (missing or reordered events)",
+		},
+		{
+			name:   "mismatched ID",
+			events: []SimulationEvent{{Sequence: 1, Type: EventAddMine, TargetID: "mine-2", Value: 5}},
+			errMsg: `event 1 (add_mine): replay assigned ID "mine-1" but the log recorded "mine-2"`,
+		},
+		{
+			name:   "invalid efficiency",
+			events: []SimulationEvent{{Sequence: 1, Type: EventAddRefinery, Value: 1.5}},
+			errMsg: "event 1 (add_refinery): efficiency must be in [0, 1], got 1.5",
+		},
+		{
+			name:   "unknown type",
+			events: []SimulationEvent{{Sequence: 1, Type: "explode"}},
+			errMsg: `event 1 (explode): unknown event type "explode"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := es.ReplayFromEvents(tt.events)
+			require.EqualError(t, err, tt.errMsg)
+			assert.Error(t, es.ImportEventLog(tt.events))
+		})
+	}
+	assert.Empty(t, es.ExportEventLog())
+}
+
+func TestRemoveMineAndRefinery(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	mineID := sim.AddMine(10)
+	refineryID := sim.AddRefinery(0.5)
+
+	require.NoError(t, sim.RemoveMine(mineID))
+	require.NoError(t, sim.RemoveRefinery(refineryID))
+	status := sim.GetStatus()
+	assert.Equal(t, 0, status.Mines)
+	assert.Equal(t, 0, status.Refineries)
+
+	err := sim.RemoveMine(mineID)
+	var notFound *InfrastructureNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "mine", notFound.Kind)
+}
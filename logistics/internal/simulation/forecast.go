@@ -0,0 +1,86 @@
+package simulation
+
+// ResourceForecast is the projected resource state after one forecast tick.
+type ResourceForecast struct {
+	Tick              int64
+	Quantities        map[ResourceType]float64
+	EstimatedDeficits []ResourceType // Resources whose consumption could not be met this tick
+}
+
+// ForecastResources projects resource quantities over the next ticks using
+// the same production, consumption, decay, world aging and infestation throttle
+// rules as Tick, holding mines, refineries, config and the overall rebellion
+// probability at their current values. Finite mines are drawn down on a copy
+// as they would be by Tick. Engine state is not modified.
+// Returns one ResourceForecast per tick, or nil if ticks <= 0.
+func (s *SimulationEngine) ForecastResources(ticks int) []ResourceForecast {
+	if ticks <= 0 {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resources := make(map[ResourceType]*ResourceState, len(s.status.Resources))
+	for k, v := range s.status.Resources {
+		copied := *v
+		resources[k] = &copied
+	}
+	mines := append([]Mine(nil), s.mines...)
+	efficiency := s.assignedEfficiency()
+	age := s.status.WorldAgeMultiplier
+
+	inf := s.infestation
+	if inf != nil {
+		inf = inf.Clone()
+	}
+	throttle := s.status.ThrottleMultiplier
+	rebellionProb := s.status.OverallRebellionProb
+	avgTrauma := 1.0 - rebellionProb // same approximation as Tick
+
+	forecasts := make([]ResourceForecast, 0, ticks)
+	for i := 1; i <= ticks; i++ {
+		tick := s.status.TickCount + int64(i)
+		flows := s.recalculateRatesAt(resources, mines, age)
+		yields := mineYields(mines, age, efficiency)
+		age *= 1 - s.config.WorldAgingRate
+		if inf != nil {
+			inf.Tick(rebellionProb, avgTrauma, tick)
+			throttle = inf.GetState().ThrottleMultiplier
+		}
+
+		deficits, _ := applyProduction(resources, throttle, flows)
+		extractOre(mines, yields, throttle)
+		applyDecay(resources, s.config.ResourceDecayRate)
+
+		quantities := make(map[ResourceType]float64, len(resources))
+		for k, v := range resources {
+			quantities[k] = v.Quantity
+		}
+		forecasts = append(forecasts, ResourceForecast{
+			Tick:              tick,
+			Quantities:        quantities,
+			EstimatedDeficits: deficits,
+		})
+	}
+	return forecasts
+}
+
+// ProjectTicks advances a Fork of the engine n ticks and returns the status
+// after each one, leaving the live engine untouched. Assigned crews work at
+// their current efficiency throughout (see Fork). Random disruptions are
+// disabled in the projection so that repeated calls agree, and the
+// projection runs even while the engine is paused. Returns nil if n <= 0.
+func (s *SimulationEngine) ProjectTicks(n int) []SimulationStatus {
+	if n <= 0 {
+		return nil
+	}
+
+	projection := s.Fork()
+	projection.disruption.DisruptionProbabilityPerTick = 0
+	statuses := make([]SimulationStatus, n)
+	for i := range statuses {
+		statuses[i] = projection.Tick()
+	}
+	return statuses
+}
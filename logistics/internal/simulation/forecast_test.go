@@ -0,0 +1,147 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastResources_MatchesActualTicks(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(12.0)
+	sim.AddRefinery(0.8)
+	sim.Tick()
+
+	before := sim.GetStatus()
+	forecast := sim.ForecastResources(5)
+	require.Len(t, forecast, 5)
+
+	// Forecasting must not advance the engine
+	assert.Equal(t, before.TickCount, sim.GetStatus().TickCount)
+	for rt, res := range before.Resources {
+		assert.InDelta(t, res.Quantity, sim.GetStatus().Resources[rt].Quantity, 1e-9)
+	}
+
+	for i := 0; i < 5; i++ {
+		status := sim.Tick()
+		assert.Equal(t, status.TickCount, forecast[i].Tick)
+		for rt, res := range status.Resources {
+			assert.InDelta(t, res.Quantity, forecast[i].Quantities[rt], 1e-9, "tick %d %s", i+1, rt)
+		}
+	}
+}
+
+func TestForecastResources_PlagueHeartThrottle(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(10.0)
+	sim.GetInfestationEngine().ForceActivatePlagueHeart()
+
+	forecast := sim.ForecastResources(3)
+	for i := 0; i < 3; i++ {
+		status := sim.Tick()
+		assert.InDelta(t, status.Resources[ResourceMineral].Quantity, forecast[i].Quantities[ResourceMineral], 1e-9)
+	}
+}
+
+func TestForecastResources_Deficits(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(5.0)
+	sim.AddRefinery(1.0) // consumes 10 mineral per tick, only 5 produced
+
+	forecast := sim.ForecastResources(2)
+	require.Len(t, forecast, 2)
+	assert.Equal(t, []ResourceType{ResourceMineral}, forecast[0].EstimatedDeficits)
+	assert.InDelta(t, 0.0, forecast[1].Quantities[ResourceMineral], 1e-9)
+}
+
+func TestForecastResources_NoDeficitWhenSupplied(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(20.0)
+	sim.AddRefinery(1.0)
+
+	for _, f := range sim.ForecastResources(3) {
+		assert.Empty(t, f.EstimatedDeficits)
+	}
+	assert.Nil(t, sim.ForecastResources(0))
+}
+
+func TestProjectTicks_IsRepeatableAndReadOnly(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	mineID := sim.AddMineWithReserve(12.0, 30)
+	sim.AddRefinery(0.8)
+	sim.GetInfestationEngine().ForceActivatePlagueHeart()
+	sim.Tick()
+	require.NoError(t, sim.SetDisruptionConfig(DisruptionConfig{DisruptionProbabilityPerTick: 0.5, DisruptionDuration: 2}))
+	before := sim.GetStatus()
+	reserveBefore, err := sim.GetMineReserve(mineID)
+	require.NoError(t, err)
+
+	first := sim.ProjectTicks(10)
+	second := sim.ProjectTicks(10)
+	require.Len(t, first, 10)
+	assert.Equal(t, first, second)
+	assert.Equal(t, before.TickCount+1, first[0].TickCount)
+	assert.Equal(t, before.TickCount+10, first[9].TickCount)
+
+	// Projecting must not advance the engine or drain its mines
+	after := sim.GetStatus()
+	assert.Equal(t, before.TickCount, after.TickCount)
+	assert.Equal(t, before.InfestationLevel, after.InfestationLevel)
+	for rt, res := range before.Resources {
+		assert.Equal(t, res.Quantity, after.Resources[rt].Quantity, rt)
+	}
+	reserve, err := sim.GetMineReserve(mineID)
+	require.NoError(t, err)
+	assert.Equal(t, reserveBefore, reserve)
+}
+
+func TestProjectTicks_NonPositive(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	assert.Nil(t, sim.ProjectTicks(0))
+	assert.Nil(t, sim.ProjectTicks(-3))
+}
+
+func TestForecastResources_DrawsDownFiniteReserves(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	mineID := sim.AddMineWithReserve(3, 10)
+
+	forecast := sim.ForecastResources(5)
+	require.Len(t, forecast, 5)
+	var mineral []float64
+	for _, f := range forecast {
+		mineral = append(mineral, f.Quantities[ResourceMineral])
+	}
+	assert.Equal(t, []float64{3, 6, 9, 10, 10}, mineral)
+
+	reserve, err := sim.GetMineReserve(mineID)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, reserve, "forecasting must not draw down the live reserve")
+
+	for i := 0; i < 5; i++ {
+		status := sim.Tick()
+		assert.Equal(t, mineral[i], status.Resources[ResourceMineral].Quantity, "tick %d", i+1)
+	}
+}
+
+func TestProjectTicks_MatchesTickWithAssignedNPCs(t *testing.T) {
+	sim, behavior := newAssignmentTestEngine(t, "npc-1", "npc-2")
+	mineID := sim.AddMine(10.0)
+	refineryID := sim.AddRefinery(0.8)
+	require.NoError(t, behavior.ApplyWorkEfficiencyModifier("npc-2", 0.3, "test"))
+	require.NoError(t, sim.AssignNPCToMine("npc-1", mineID))
+	require.NoError(t, sim.AssignNPCToRefinery("npc-2", refineryID))
+	sim.Tick()
+
+	projected := sim.ProjectTicks(1)
+	require.Len(t, projected, 1)
+	actual := sim.Tick()
+
+	assert.Equal(t, actual.TickCount, projected[0].TickCount)
+	for rt, res := range actual.Resources {
+		assert.InDelta(t, res.Quantity, projected[0].Resources[rt].Quantity, 1e-9, rt)
+		assert.InDelta(t, res.ProductionRate, projected[0].Resources[rt].ProductionRate, 1e-9, rt)
+	}
+}
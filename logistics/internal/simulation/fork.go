@@ -0,0 +1,140 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MergeStrategy decides whether MergeFrom adopts a fork's state.
+type MergeStrategy string
+
+const (
+	// TakeHighestProduction adopts the fork if its throttled production rate
+	// (summed across resources) is higher than the engine's.
+	TakeHighestProduction MergeStrategy = "take_highest_production"
+	// TakeLowestInfestation adopts the fork if its infestation counter is lower.
+	TakeLowestInfestation MergeStrategy = "take_lowest_infestation"
+	// TakeFork always adopts the fork.
+	TakeFork MergeStrategy = "take_fork"
+)
+
+// ParseMergeStrategy converts a strategy name (e.g. "take_fork") to a
+// MergeStrategy. Returns an error if the name is not a known strategy.
+func ParseMergeStrategy(name string) (MergeStrategy, error) {
+	switch ms := MergeStrategy(name); ms {
+	case TakeHighestProduction, TakeLowestInfestation, TakeFork:
+		return ms, nil
+	default:
+		return "", fmt.Errorf("unknown merge strategy %q", name)
+	}
+}
+
+// Fork returns an independent deep copy of the engine's mines, refineries,
+// resources, status, config, production chains, throttle and rebellion
+// history, resource peaks, disruption and auto-scaling settings and
+// infestation state, for
+// trying out policies without touching live state. NPC assignments are
+// copied with their crews' current combined efficiencies, which the fork
+// holds fixed, so its production matches the engine's. The fork shares the
+// rebellion engine and random function but has no attached behavior engine,
+// random events, event recorder, listeners, resource callbacks or realtime
+// loop, so ticking it never mutates live NPCs. The fork starts unpaused
+// with no skipped ticks.
+func (s *SimulationEngine) Fork() *SimulationEngine {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fork := NewSimulationEngineWithConfig(s.rebellion, s.config)
+	fork.status = s.copyStatus()
+	fork.status.IsPaused, fork.status.SkippedTicks = false, 0
+	fork.tickCount.Store(fork.status.TickCount)
+	fork.mines = append([]Mine(nil), s.mines...)
+	fork.refineries = append([]Refinery(nil), s.refineries...)
+	fork.throttleHistory = append([]ThrottleRecord(nil), s.throttleHistory...)
+	fork.rebellionHistory = s.rebellionHistory.clone()
+	fork.chains = append([]ProductionChain(nil), s.chains...)
+	for rt, peak := range s.resourcePeaks {
+		fork.resourcePeaks[rt] = peak
+	}
+	fork.nextID = s.nextID
+	for npcID, a := range s.assignments {
+		fork.assignments[npcID] = a
+	}
+	fork.crewEfficiency = s.assignedEfficiency()
+	fork.disruption = s.disruption
+	fork.autoScale = s.autoScale
+	fork.randFn = s.randFn
+	if s.infestation != nil {
+		fork.infestation = s.infestation.Clone()
+	}
+	return fork
+}
+
+// MergeFrom adopts fork's mines, refineries, resources, tick count, world
+// age, throttle history and infestation state if strategy favors the fork, and reports whether
+// it did. NPC statistics, the config and callbacks are kept. Merges are not
+// recorded in an event-sourced log.
+func (s *SimulationEngine) MergeFrom(fork *SimulationEngine, strategy MergeStrategy) (bool, error) {
+	if fork == nil {
+		return false, errors.New("merge source engine is nil")
+	}
+	if fork == s {
+		return false, errors.New("cannot merge an engine into itself")
+	}
+	if _, err := ParseMergeStrategy(string(strategy)); err != nil {
+		return false, err
+	}
+
+	// Snapshot the fork first so only one engine lock is held at a time.
+	snapshot := fork.Fork()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	take := false
+	switch strategy {
+	case TakeFork:
+		take = true
+	case TakeHighestProduction:
+		take = snapshot.totalProduction() > s.totalProduction()
+	case TakeLowestInfestation:
+		take = snapshot.infestationCounter() < s.infestationCounter()
+	}
+	if take {
+		s.restoreFrom(snapshot)
+	}
+	return take, nil
+}
+
+// totalProduction returns the production rate summed across resources for
+// the current mines, refineries and config, scaled by the infestation
+// throttle. Caller must hold s.mu (or own s exclusively).
+func (s *SimulationEngine) totalProduction() float64 {
+	return s.unthrottledProduction() * s.status.ThrottleMultiplier
+}
+
+// unthrottledProduction is totalProduction before the infestation throttle.
+// Caller must hold s.mu (or own s exclusively).
+func (s *SimulationEngine) unthrottledProduction() float64 {
+	resources := make(map[ResourceType]*ResourceState, len(s.status.Resources))
+	for k, v := range s.status.Resources {
+		copied := *v
+		resources[k] = &copied
+	}
+	s.recalculateRates(resources)
+
+	total := 0.0
+	for _, res := range resources {
+		total += res.ProductionRate
+	}
+	return total
+}
+
+// infestationCounter returns the current infestation counter, or 0 without
+// an infestation engine.
+func (s *SimulationEngine) infestationCounter() float64 {
+	if s.infestation == nil {
+		return 0
+	}
+	return s.infestation.GetState().Counter
+}
@@ -0,0 +1,92 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFork_IsIndependent(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	sim.AddMine(10)
+	sim.Tick()
+
+	fork := sim.Fork()
+	fork.AddMine(5)
+	fork.Tick()
+	require.NoError(t, fork.GetInfestationEngine().ForceSetCounter(40))
+
+	status := sim.GetStatus()
+	assert.Equal(t, 1, status.Mines)
+	assert.Equal(t, int64(1), status.TickCount)
+	assert.Equal(t, 10.0, status.Resources[ResourceMineral].Quantity)
+	assert.Zero(t, sim.GetInfestationState().Counter)
+
+	forkStatus := fork.GetStatus()
+	assert.Equal(t, 2, forkStatus.Mines)
+	assert.Equal(t, int64(2), forkStatus.TickCount)
+	assert.Equal(t, 25.0, forkStatus.Resources[ResourceMineral].Quantity)
+	assert.Equal(t, "mine-3", fork.AddMine(1), "fork continues the original's ID sequence")
+}
+
+func TestMergeFrom_TakeLowestInfestation(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(10)
+
+	forkA := sim.Fork()
+	forkB := sim.Fork()
+	forkA.AddMine(20)
+	require.NoError(t, forkA.GetInfestationEngine().ForceSetCounter(60))
+	forkB.AddRefinery(0.5)
+	require.NoError(t, forkB.GetInfestationEngine().ForceSetCounter(30))
+
+	require.NoError(t, sim.GetInfestationEngine().ForceSetCounter(50))
+	took, err := sim.MergeFrom(forkA, TakeLowestInfestation)
+	require.NoError(t, err)
+	assert.False(t, took, "fork A has the higher counter")
+
+	took, err = sim.MergeFrom(forkB, TakeLowestInfestation)
+	require.NoError(t, err)
+	assert.True(t, took)
+	assert.Equal(t, 30.0, sim.GetInfestationState().Counter)
+	status := sim.GetStatus()
+	assert.Equal(t, 30.0, status.InfestationLevel)
+	assert.Equal(t, 1, status.Mines)
+	assert.Equal(t, 1, status.Refineries)
+
+	// The fork remains independent after the merge.
+	forkB.AddMine(1)
+	assert.Equal(t, 1, sim.GetStatus().Mines)
+}
+
+func TestMergeFrom_TakeHighestProduction(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(10)
+
+	weaker := sim.Fork()
+	require.NoError(t, weaker.RemoveMine("mine-1"))
+	took, err := sim.MergeFrom(weaker, TakeHighestProduction)
+	require.NoError(t, err)
+	assert.False(t, took)
+
+	stronger := sim.Fork()
+	stronger.AddMine(5)
+	took, err = sim.MergeFrom(stronger, TakeHighestProduction)
+	require.NoError(t, err)
+	assert.True(t, took)
+	assert.Equal(t, 2, sim.GetStatus().Mines)
+}
+
+func TestMergeFrom_Errors(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	_, err := sim.MergeFrom(nil, TakeFork)
+	assert.Error(t, err)
+	_, err = sim.MergeFrom(sim, TakeFork)
+	assert.Error(t, err)
+	_, err = sim.MergeFrom(sim.Fork(), "take_best")
+	assert.EqualError(t, err, `unknown merge strategy "take_best"`)
+}
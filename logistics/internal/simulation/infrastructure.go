@@ -0,0 +1,111 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ImportSummary reports the outcome of ImportInfrastructure.
+type ImportSummary struct {
+	MinesAdded      int
+	RefineriesAdded int
+	Errors          []string // One message per skipped entry
+}
+
+// infrastructureDocument is the JSON layout shared by ImportInfrastructure
+// and ExportInfrastructure.
+type infrastructureDocument struct {
+	Mines      []mineEntry     `json:"mines"`
+	Refineries []refineryEntry `json:"refineries"`
+}
+
+type mineEntry struct {
+	YieldRate *float64 `json:"yield_rate"`
+}
+
+type refineryEntry struct {
+	Efficiency   *float64 `json:"efficiency"`
+	FacilityType string   `json:"facility_type,omitempty"` // Defaults to RefineryFacility
+}
+
+// ImportInfrastructure adds the mines and refineries described by data:
+//
+//	{"mines": [{"yield_rate": 10.0}], "refineries": [{"efficiency": 0.8}]}
+//
+// A refinery entry may set "facility_type" to add another kind of conversion
+// facility (see AddFacility).
+//
+// Invalid entries (missing or negative yield_rate, efficiency outside [0, 1])
+// are skipped and reported in Errors; the remaining entries are all added
+// under a single lock, so no Tick observes a partial import.
+// Returns an error only if data is not valid JSON.
+func (s *SimulationEngine) ImportInfrastructure(data []byte) (ImportSummary, error) {
+	var doc infrastructureDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return ImportSummary{}, fmt.Errorf("parse infrastructure JSON: %w", err)
+	}
+
+	var summary ImportSummary
+	yields := make([]float64, 0, len(doc.Mines))
+	for i, m := range doc.Mines {
+		switch {
+		case m.YieldRate == nil:
+			summary.Errors = append(summary.Errors, fmt.Sprintf("mines[%d]: yield_rate is required", i))
+		case *m.YieldRate < 0:
+			summary.Errors = append(summary.Errors, fmt.Sprintf("mines[%d]: yield_rate must be non-negative, got %v", i, *m.YieldRate))
+		default:
+			yields = append(yields, *m.YieldRate)
+		}
+	}
+	facilities := make([]refineryEntry, 0, len(doc.Refineries))
+	for i, r := range doc.Refineries {
+		switch {
+		case r.Efficiency == nil:
+			summary.Errors = append(summary.Errors, fmt.Sprintf("refineries[%d]: efficiency is required", i))
+		case *r.Efficiency < 0 || *r.Efficiency > 1:
+			summary.Errors = append(summary.Errors, fmt.Sprintf("refineries[%d]: efficiency must be in [0, 1], got %v", i, *r.Efficiency))
+		default:
+			facilities = append(facilities, r)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, y := range yields {
+		s.addMineLocked(y, 0, false)
+	}
+	for _, f := range facilities {
+		facilityType := f.FacilityType
+		if facilityType == "" {
+			facilityType = RefineryFacility
+		}
+		s.addFacilityLocked(facilityType, *f.Efficiency)
+	}
+	summary.MinesAdded = len(yields)
+	summary.RefineriesAdded = len(facilities)
+	return summary, nil
+}
+
+// ExportInfrastructure renders the engine's mines and refineries in the
+// format accepted by ImportInfrastructure.
+func (s *SimulationEngine) ExportInfrastructure() ([]byte, error) {
+	s.mu.RLock()
+	doc := infrastructureDocument{
+		Mines:      make([]mineEntry, len(s.mines)),
+		Refineries: make([]refineryEntry, len(s.refineries)),
+	}
+	for i, m := range s.mines {
+		yield := m.YieldRate
+		doc.Mines[i] = mineEntry{YieldRate: &yield}
+	}
+	for i, r := range s.refineries {
+		efficiency := r.Efficiency
+		doc.Refineries[i] = refineryEntry{Efficiency: &efficiency}
+		if r.FacilityType != RefineryFacility {
+			doc.Refineries[i].FacilityType = r.FacilityType
+		}
+	}
+	s.mu.RUnlock()
+
+	return json.Marshal(doc)
+}
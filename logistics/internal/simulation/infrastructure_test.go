@@ -0,0 +1,64 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportInfrastructure(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	summary, err := sim.ImportInfrastructure([]byte(`{
+		"mines": [{"yield_rate": 10}, {"yield_rate": 12.5}, {"yield_rate": 8}, {"yield_rate": 0}, {"yield_rate": 3}],
+		"refineries": [{"efficiency": 0.8}, {"efficiency": 1.0}, {"efficiency": 0.25}]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, ImportSummary{MinesAdded: 5, RefineriesAdded: 3}, summary)
+
+	status := sim.GetStatus()
+	assert.Equal(t, 5, status.Mines)
+	assert.Equal(t, 3, status.Refineries)
+}
+
+func TestImportInfrastructure_SkipsInvalidEntries(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	summary, err := sim.ImportInfrastructure([]byte(`{
+		"mines": [{"yield_rate": 10}, {"yield_rate": -1}, {}],
+		"refineries": [{"efficiency": 1.5}, {"efficiency": 0.5}]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.MinesAdded)
+	assert.Equal(t, 1, summary.RefineriesAdded)
+	assert.Equal(t, []string{
+		"mines[1]: yield_rate must be non-negative, got -1",
+		"mines[2]: yield_rate is required",
+		"refineries[0]: efficiency must be in [0, 1], got 1.5",
+	}, summary.Errors)
+
+	_, err = sim.ImportInfrastructure([]byte(`{"mines": [`))
+	assert.Error(t, err)
+}
+
+func TestExportInfrastructure_RoundTrip(t *testing.T) {
+	src := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	src.AddMine(7.5)
+	src.AddMine(2)
+	src.AddRefinery(0.6)
+
+	data, err := src.ExportInfrastructure()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"mines":[{"yield_rate":7.5},{"yield_rate":2}],"refineries":[{"efficiency":0.6}]}`, string(data))
+
+	dst := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	summary, err := dst.ImportInfrastructure(data)
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.MinesAdded)
+	assert.Equal(t, 1, summary.RefineriesAdded)
+
+	// Identical infrastructure produces identical output
+	assert.Equal(t, src.Tick().Resources[ResourceRapidlum].Quantity, dst.Tick().Resources[ResourceRapidlum].Quantity)
+}
@@ -0,0 +1,118 @@
+package simulation
+
+// MineInfo is an inventory snapshot of a mine.
+type MineInfo struct {
+	Mine
+	DegradedYieldRate float64  // YieldRate scaled by world age and the assigned NPCs' combined efficiency
+	Paused            bool     // Offline because of a disruption
+	AssignedNPCs      []string // Sorted IDs of the NPCs working the mine
+}
+
+// RefineryInfo is an inventory snapshot of a refinery or other facility.
+type RefineryInfo struct {
+	Refinery
+	DegradedEfficiency float64  // Efficiency scaled by world age and the assigned NPCs' combined efficiency
+	Paused             bool     // Offline because of a disruption
+	AssignedNPCs       []string // Sorted IDs of the NPCs working the refinery
+}
+
+// GetMines returns a deep copy of every mine, in the order they were added;
+// Mine holds no references, so the copy shares no memory with the engine.
+func (s *SimulationEngine) GetMines() []Mine {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Mine{}, s.mines...)
+}
+
+// GetRefineries returns a deep copy of every refinery and other facility, in
+// the order they were added.
+func (s *SimulationEngine) GetRefineries() []Refinery {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Refinery{}, s.refineries...)
+}
+
+// GetAllMines returns a snapshot of every mine, in the order they were added.
+func (s *SimulationEngine) GetAllMines() []MineInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	efficiency := s.assignedEfficiency()
+	mines := make([]MineInfo, len(s.mines))
+	for i, m := range s.mines {
+		mines[i] = s.mineInfo(m, efficiency)
+	}
+	return mines
+}
+
+// GetMine returns a snapshot of one mine.
+// Returns an *InfrastructureNotFoundError if no such mine exists.
+func (s *SimulationEngine) GetMine(mineID string) (MineInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, m := range s.mines {
+		if m.MineID == mineID {
+			return s.mineInfo(m, s.assignedEfficiency()), nil
+		}
+	}
+	return MineInfo{}, &InfrastructureNotFoundError{Kind: "mine", ID: mineID}
+}
+
+// GetAllRefineries returns a snapshot of every refinery and other facility,
+// in the order they were added.
+func (s *SimulationEngine) GetAllRefineries() []RefineryInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	efficiency := s.assignedEfficiency()
+	refineries := make([]RefineryInfo, len(s.refineries))
+	for i, r := range s.refineries {
+		refineries[i] = s.refineryInfo(r, efficiency)
+	}
+	return refineries
+}
+
+// GetRefinery returns a snapshot of one refinery or other facility.
+// Returns an *InfrastructureNotFoundError if no such refinery exists.
+func (s *SimulationEngine) GetRefinery(refineryID string) (RefineryInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, r := range s.refineries {
+		if r.RefineryID == refineryID {
+			return s.refineryInfo(r, s.assignedEfficiency()), nil
+		}
+	}
+	return RefineryInfo{}, &InfrastructureNotFoundError{Kind: "refinery", ID: refineryID}
+}
+
+// mineInfo builds the snapshot of m given the assigned crews' efficiencies
+// (see assignedEfficiency). Caller must hold s.mu.
+func (s *SimulationEngine) mineInfo(m Mine, efficiency map[assignment]float64) MineInfo {
+	degraded := m.YieldRate * s.status.WorldAgeMultiplier
+	if eff, ok := efficiency[assignment{Kind: "mine", ID: m.MineID}]; ok {
+		degraded *= eff
+	}
+	return MineInfo{
+		Mine:              m,
+		DegradedYieldRate: degraded,
+		Paused:            m.DisruptedTicks > 0,
+		AssignedNPCs:      s.assignedNPCs("mine", m.MineID),
+	}
+}
+
+// refineryInfo builds the snapshot of r given the assigned crews'
+// efficiencies (see assignedEfficiency). Caller must hold s.mu.
+func (s *SimulationEngine) refineryInfo(r Refinery, efficiency map[assignment]float64) RefineryInfo {
+	degraded := r.Efficiency * s.status.WorldAgeMultiplier
+	if eff, ok := efficiency[assignment{Kind: "refinery", ID: r.RefineryID}]; ok {
+		degraded *= eff
+	}
+	return RefineryInfo{
+		Refinery:           r,
+		DegradedEfficiency: degraded,
+		Paused:             r.DisruptedTicks > 0,
+		AssignedNPCs:       s.assignedNPCs("refinery", r.RefineryID),
+	}
+}
@@ -0,0 +1,123 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllMines(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t, "npc-1")
+	first := sim.AddMine(10)
+	second := sim.AddMine(4)
+	require.NoError(t, sim.AssignNPCToMine("npc-1", first))
+	require.NoError(t, sim.DisruptMine(second, 3))
+
+	mines := sim.GetAllMines()
+
+	require.Len(t, mines, 2)
+	assert.Equal(t, first, mines[0].MineID)
+	assert.InDelta(t, 5.0, mines[0].DegradedYieldRate, 1e-9) // 10 × 0.5 crew efficiency
+	assert.False(t, mines[0].Paused)
+	assert.Equal(t, []string{"npc-1"}, mines[0].AssignedNPCs)
+	assert.Equal(t, second, mines[1].MineID)
+	assert.True(t, mines[1].Paused)
+	assert.Empty(t, mines[1].AssignedNPCs)
+}
+
+func TestGetMine_DegradedByWorldAge(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t)
+	cfg := sim.GetConfig()
+	cfg.WorldAgingRate = 0.2
+	require.NoError(t, sim.UpdateConfig(cfg))
+	sim.Tick()
+	require.InDelta(t, 0.8, sim.GetWorldAge(), 1e-9)
+	id := sim.AddMine(10)
+
+	mine, err := sim.GetMine(id)
+
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, mine.YieldRate)
+	assert.InDelta(t, 8.0, mine.DegradedYieldRate, 1e-9)
+
+	_, err = sim.GetMine("mine-404")
+	assert.True(t, errors.Is(err, ErrInfrastructureNotFound))
+}
+
+func TestGetAllRefineries(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t, "npc-1")
+	id := sim.AddRefinery(0.8)
+	require.NoError(t, sim.AssignNPCToRefinery("npc-1", id))
+
+	refineries := sim.GetAllRefineries()
+
+	require.Len(t, refineries, 1)
+	assert.Equal(t, RefineryFacility, refineries[0].FacilityType)
+	assert.InDelta(t, 0.4, refineries[0].DegradedEfficiency, 1e-9)
+	assert.Equal(t, []string{"npc-1"}, refineries[0].AssignedNPCs)
+
+	refinery, err := sim.GetRefinery(id)
+	require.NoError(t, err)
+	assert.Equal(t, refineries[0], refinery)
+	_, err = sim.GetRefinery("refinery-404")
+	assert.True(t, errors.Is(err, ErrInfrastructureNotFound))
+}
+
+func TestGetMinesAndRefineries_ReturnCopies(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t)
+	mineID := sim.AddMine(10)
+	refineryID := sim.AddRefinery(0.8)
+
+	mines := sim.GetMines()
+	require.Len(t, mines, 1)
+	assert.Equal(t, Mine{MineID: mineID, YieldRate: 10}, mines[0])
+	refineries := sim.GetRefineries()
+	require.Len(t, refineries, 1)
+	assert.Equal(t, Refinery{RefineryID: refineryID, FacilityType: RefineryFacility, Efficiency: 0.8}, refineries[0])
+
+	mines[0].YieldRate = 99
+	refineries[0].Efficiency = 0
+	assert.Equal(t, 10.0, sim.GetMines()[0].YieldRate)
+	assert.Equal(t, 0.8, sim.GetRefineries()[0].Efficiency)
+}
+
+func TestRemoveMine_ProductionDropsNextTick(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t)
+	sim.AddMine(10)
+	removed := sim.AddMine(4)
+
+	sim.Tick()
+	before := sim.GetResourceAuditLog(ResourceMineral, 1)
+	require.Len(t, before, 1)
+
+	require.NoError(t, sim.RemoveMine(removed))
+	assert.Len(t, sim.GetMines(), 1)
+	assert.Equal(t, 1, sim.GetStatus().Mines)
+
+	sim.Tick()
+	after := sim.GetResourceAuditLog(ResourceMineral, 1)
+	require.Len(t, after, 1)
+	assert.InDelta(t, before[0].Produced-4, after[0].Produced, 1e-9)
+
+	err := sim.RemoveMine(removed)
+	assert.EqualError(t, err, `mine "`+removed+`" not found`)
+	assert.Equal(t, 1, sim.GetStatus().Mines, "failed removal leaves the count unchanged")
+}
+
+func TestRemoveRefinery_CountAndUnknownID(t *testing.T) {
+	sim, _ := newAssignmentTestEngine(t)
+	keep := sim.AddRefinery(0.5)
+	removed := sim.AddRefinery(0.9)
+
+	require.NoError(t, sim.RemoveRefinery(removed))
+	assert.Equal(t, 1, sim.GetStatus().Refineries)
+	refineries := sim.GetRefineries()
+	require.Len(t, refineries, 1)
+	assert.Equal(t, keep, refineries[0].RefineryID)
+
+	err := sim.RemoveRefinery(removed)
+	assert.True(t, errors.Is(err, ErrInfrastructureNotFound))
+	assert.EqualError(t, err, `refinery "`+removed+`" not found`)
+}
@@ -0,0 +1,43 @@
+package simulation
+
+import "errors"
+
+var (
+	// ErrAlreadyPaused is returned by Pause when the simulation is paused.
+	ErrAlreadyPaused = errors.New("simulation already paused")
+	// ErrNotPaused is returned by Resume when the simulation is not paused.
+	ErrNotPaused = errors.New("simulation not paused")
+)
+
+// Pause freezes the simulation: until Resume, Tick leaves the state
+// unchanged and only counts the skipped tick in SkippedTicks. Real-time
+// playback keeps calling Tick, so its ticks are skipped too.
+// Returns ErrAlreadyPaused if the simulation is already paused.
+func (s *SimulationEngine) Pause() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status.IsPaused {
+		return ErrAlreadyPaused
+	}
+	s.status.IsPaused = true
+	return nil
+}
+
+// Resume unfreezes a paused simulation. SkippedTicks is kept.
+// Returns ErrNotPaused if the simulation is not paused.
+func (s *SimulationEngine) Resume() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.status.IsPaused {
+		return ErrNotPaused
+	}
+	s.status.IsPaused = false
+	return nil
+}
+
+// IsPaused reports whether the simulation is paused.
+func (s *SimulationEngine) IsPaused() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status.IsPaused
+}
@@ -0,0 +1,52 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPause_SkipsTicksUntilResume(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	require.NoError(t, sim.Pause())
+	assert.True(t, sim.IsPaused())
+	for i := 0; i < 5; i++ {
+		status := sim.Tick()
+		assert.True(t, status.IsPaused)
+	}
+
+	status := sim.GetStatus()
+	assert.Equal(t, int64(0), status.TickCount)
+	assert.Equal(t, int64(5), status.SkippedTicks)
+	assert.Equal(t, 0.0, status.Resources[ResourceSim].Quantity, "no production while paused")
+
+	require.NoError(t, sim.Resume())
+	assert.False(t, sim.IsPaused())
+	status = sim.Tick()
+	assert.Equal(t, int64(1), status.TickCount)
+	assert.Equal(t, int64(5), status.SkippedTicks)
+	assert.False(t, status.IsPaused)
+}
+
+func TestPause_Errors(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	assert.ErrorIs(t, sim.Resume(), ErrNotPaused)
+	require.NoError(t, sim.Pause())
+	assert.ErrorIs(t, sim.Pause(), ErrAlreadyPaused)
+}
+
+func TestPause_ForkStartsUnpaused(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	require.NoError(t, sim.Pause())
+	sim.Tick()
+
+	fork := sim.Fork()
+
+	assert.False(t, fork.IsPaused())
+	assert.Equal(t, int64(1), fork.Tick().TickCount)
+	assert.Equal(t, int64(0), fork.GetStatus().SkippedTicks)
+}
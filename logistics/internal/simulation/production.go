@@ -0,0 +1,135 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// productionFlow is the combined per-tick throughput of the operating
+// facilities of one production chain.
+type productionFlow struct {
+	input      ResourceType
+	output     ResourceType
+	inputRate  float64 // Input units consumed per tick
+	outputRate float64 // Output units produced per tick (before throttling)
+}
+
+// AddProductionChain registers chain, which is then run every tick by each
+// facility of chain.FacilityType (see AddFacility). Chains are processed in
+// registration order, after the built-in mineral→rapidlum refinery chain.
+// Returns an error if either resource is unknown, the input and output are
+// the same, ConversionRatio is not positive, FacilityType is empty, or a
+// chain is already registered for FacilityType.
+func (s *SimulationEngine) AddProductionChain(chain ProductionChain) error {
+	if _, err := ParseResourceType(string(chain.InputResource)); err != nil {
+		return fmt.Errorf("input resource: %w", err)
+	}
+	if _, err := ParseResourceType(string(chain.OutputResource)); err != nil {
+		return fmt.Errorf("output resource: %w", err)
+	}
+	if chain.InputResource == chain.OutputResource {
+		return fmt.Errorf("production chain cannot convert %q into itself", chain.InputResource)
+	}
+	if chain.ConversionRatio <= 0 {
+		return fmt.Errorf("ConversionRatio must be positive, got %v", chain.ConversionRatio)
+	}
+	if chain.FacilityType == "" {
+		return errors.New("facility type is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.productionChains() {
+		if existing.FacilityType == chain.FacilityType {
+			return fmt.Errorf("facility type %q already has a production chain", chain.FacilityType)
+		}
+	}
+	s.chains = append(s.chains, chain)
+	return nil
+}
+
+// GetProductionChains returns the registered production chains in processing
+// order. The first is always the built-in mineral→rapidlum refinery chain,
+// whose ConversionRatio follows the config's refinery bases (0 if
+// RefineryRapidlumProductionBase is 0).
+func (s *SimulationEngine) GetProductionChains() []ProductionChain {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.productionChains()
+}
+
+// productionChains returns the built-in refinery chain followed by the
+// registered chains. Caller must hold s.mu.
+func (s *SimulationEngine) productionChains() []ProductionChain {
+	ratio := 0.0
+	if s.config.RefineryRapidlumProductionBase > 0 {
+		ratio = s.config.RefineryMineralConsumptionBase / s.config.RefineryRapidlumProductionBase
+	}
+	chains := make([]ProductionChain, 0, len(s.chains)+1)
+	chains = append(chains, ProductionChain{
+		InputResource:   ResourceMineral,
+		OutputResource:  ResourceRapidlum,
+		ConversionRatio: ratio,
+		FacilityType:    RefineryFacility,
+	})
+	return append(chains, s.chains...)
+}
+
+// AddFacility adds a conversion facility of the given type with the specified
+// efficiency and returns its unique ID. RefineryFacility adds a plain refinery
+// (see AddRefinery); other facilities run the chain registered for their type
+// and sit idle until one is. Facilities count towards Refineries in the
+// status and can be assigned NPCs, disrupted and removed like refineries.
+func (s *SimulationEngine) AddFacility(facilityType string, efficiency float64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addFacilityLocked(facilityType, efficiency)
+}
+
+// addFacilityLocked appends a facility and returns its ID. Caller must hold s.mu.
+func (s *SimulationEngine) addFacilityLocked(facilityType string, efficiency float64) string {
+	if facilityType == RefineryFacility {
+		return s.addRefineryLocked(efficiency)
+	}
+
+	id := fmt.Sprintf("facility-%d", s.nextID)
+	s.nextID++
+
+	s.refineries = append(s.refineries, Refinery{
+		RefineryID:   id,
+		FacilityType: facilityType,
+		Efficiency:   efficiency,
+	})
+	s.status.Refineries = len(s.refineries)
+	s.record(SimulationEvent{Type: EventAddFacility, TargetID: id, Value: efficiency, FacilityType: facilityType})
+
+	return id
+}
+
+// productionFlows returns the throughput of each production chain given the
+// per-facility efficiencies, skipping chains with no operating facilities.
+// The built-in chain produces RefineryRapidlumProductionBase per unit of
+// efficiency; the others produce their input divided by ConversionRatio.
+// Caller must hold s.mu.
+func (s *SimulationEngine) productionFlows(efficiencies map[string]float64) []productionFlow {
+	var flows []productionFlow
+	for i, chain := range s.productionChains() {
+		eff, ok := efficiencies[chain.FacilityType]
+		if !ok {
+			continue
+		}
+		flow := productionFlow{
+			input:     chain.InputResource,
+			output:    chain.OutputResource,
+			inputRate: eff * s.config.RefineryMineralConsumptionBase,
+		}
+		if i == 0 {
+			flow.outputRate = eff * s.config.RefineryRapidlumProductionBase
+		} else {
+			flow.outputRate = flow.inputRate / chain.ConversionRatio
+		}
+		flows = append(flows, flow)
+	}
+	return flows
+}
@@ -0,0 +1,157 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fabricatorChain converts 2 rapidlum into 1 sim.
+var fabricatorChain = ProductionChain{
+	InputResource:   ResourceRapidlum,
+	OutputResource:  ResourceSim,
+	ConversionRatio: 2.0,
+	FacilityType:    "fabricator",
+}
+
+func newChainTestEngine(t *testing.T) *SimulationEngine {
+	t.Helper()
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	require.NoError(t, sim.AddProductionChain(fabricatorChain))
+	return sim
+}
+
+func TestProductionChains_DefaultRefineryChain(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	assert.Equal(t, []ProductionChain{{
+		InputResource:   ResourceMineral,
+		OutputResource:  ResourceRapidlum,
+		ConversionRatio: 2.0,
+		FacilityType:    RefineryFacility,
+	}}, sim.GetProductionChains())
+}
+
+func TestAddProductionChain_RapidlumToSim(t *testing.T) {
+	sim := newChainTestEngine(t)
+	id := sim.AddFacility("fabricator", 1.0)
+	assert.Equal(t, "facility-1", id)
+	require.NoError(t, sim.AddResource(ResourceRapidlum, 100))
+
+	for i := 0; i < 3; i++ {
+		sim.Tick()
+	}
+
+	status := sim.GetStatus()
+	assert.InDelta(t, 70.0, status.Resources[ResourceRapidlum].Quantity, 1e-9, "10 rapidlum consumed per tick")
+	assert.InDelta(t, 3*1.0+3*5.0, status.Resources[ResourceSim].Quantity, 1e-9, "base sim plus 5 sim per tick")
+	assert.InDelta(t, 10.0, status.Resources[ResourceRapidlum].ConsumptionRate, 1e-9)
+	assert.InDelta(t, 6.0, status.Resources[ResourceSim].ProductionRate, 1e-9)
+	assert.Equal(t, 1, status.Refineries)
+}
+
+func TestAddProductionChain_ScalesOutputOnShortfall(t *testing.T) {
+	sim := newChainTestEngine(t)
+	sim.AddFacility("fabricator", 1.0)
+	require.NoError(t, sim.AddResource(ResourceRapidlum, 15))
+
+	sim.Tick()
+	status := sim.Tick()
+
+	assert.Equal(t, 0.0, status.Resources[ResourceRapidlum].Quantity)
+	// Tick 2 has only 5 of the 10 rapidlum needed, so it yields half the output.
+	assert.InDelta(t, (1.0+5.0)+(1.0+2.5), status.Resources[ResourceSim].Quantity, 1e-9)
+}
+
+func TestAddProductionChain_FedByRefinery(t *testing.T) {
+	sim := newChainTestEngine(t)
+	sim.AddMine(20.0)
+	sim.AddRefinery(1.0)
+	sim.AddFacility("fabricator", 0.5)
+
+	status := sim.Tick()
+
+	// The refinery turns 10 of the 20 mineral into 5 rapidlum, which the
+	// fabricator (5 rapidlum per tick at 0.5 efficiency) turns into 2.5 sim.
+	assert.InDelta(t, 10.0, status.Resources[ResourceMineral].Quantity, 1e-9)
+	assert.InDelta(t, 0.0, status.Resources[ResourceRapidlum].Quantity, 1e-9)
+	assert.InDelta(t, 1.0+2.5, status.Resources[ResourceSim].Quantity, 1e-9)
+}
+
+func TestAddProductionChain_Validation(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	tests := []struct {
+		name  string
+		chain ProductionChain
+	}{
+		{"unknown input", ProductionChain{InputResource: "gold", OutputResource: ResourceSim, ConversionRatio: 1, FacilityType: "x"}},
+		{"unknown output", ProductionChain{InputResource: ResourceSim, OutputResource: "gold", ConversionRatio: 1, FacilityType: "x"}},
+		{"same resource", ProductionChain{InputResource: ResourceSim, OutputResource: ResourceSim, ConversionRatio: 1, FacilityType: "x"}},
+		{"zero ratio", ProductionChain{InputResource: ResourceRapidlum, OutputResource: ResourceSim, FacilityType: "x"}},
+		{"no facility type", ProductionChain{InputResource: ResourceRapidlum, OutputResource: ResourceSim, ConversionRatio: 1}},
+		{"built-in facility type", ProductionChain{InputResource: ResourceRapidlum, OutputResource: ResourceSim, ConversionRatio: 1, FacilityType: RefineryFacility}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, sim.AddProductionChain(tt.chain))
+		})
+	}
+
+	require.NoError(t, sim.AddProductionChain(fabricatorChain))
+	assert.Error(t, sim.AddProductionChain(fabricatorChain), "duplicate facility type")
+	assert.Len(t, sim.GetProductionChains(), 2)
+}
+
+func TestAddFacility_IdleWithoutChain(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddFacility("fabricator", 1.0)
+	require.NoError(t, sim.AddResource(ResourceRapidlum, 100))
+
+	status := sim.Tick()
+
+	assert.Equal(t, 100.0, status.Resources[ResourceRapidlum].Quantity)
+}
+
+func TestAddFacility_Refinery(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	id := sim.AddFacility(RefineryFacility, 0.8)
+
+	assert.Equal(t, "refinery-1", id)
+	assert.Equal(t, 1, sim.GetStatus().Refineries)
+}
+
+func TestAddFacility_ReplayAndExport(t *testing.T) {
+	sim := newChainTestEngine(t)
+	es := NewEventSourcedSimulationEngine(sim)
+	id := es.AddFacility("fabricator", 0.5)
+	require.NoError(t, sim.AddResource(ResourceRapidlum, 100))
+	es.Tick()
+
+	events := es.ExportEventLog()
+	require.Len(t, events, 2)
+	assert.Equal(t, EventAddFacility, events[0].Type)
+	assert.Equal(t, "fabricator", events[0].FacilityType)
+	assert.Equal(t, id, events[0].TargetID)
+
+	replayed, err := es.ReplayFromEvents(events)
+	require.NoError(t, err)
+	assert.Equal(t, sim.GetProductionChains(), replayed.GetProductionChains())
+	assert.Equal(t, 1, replayed.GetStatus().Refineries)
+
+	data, err := sim.ExportInfrastructure()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"mines":[],"refineries":[{"efficiency":0.5,"facility_type":"fabricator"}]}`, string(data))
+
+	imported := newChainTestEngine(t)
+	summary, err := imported.ImportInfrastructure(data)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.RefineriesAdded)
+	require.NoError(t, imported.AddResource(ResourceRapidlum, 10))
+	status := imported.Tick()
+	assert.InDelta(t, 5.0, status.Resources[ResourceRapidlum].Quantity, 1e-9)
+}
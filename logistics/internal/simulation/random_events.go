@@ -0,0 +1,164 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// EventDefinition describes a random event that may fire on any tick.
+type EventDefinition struct {
+	Name               string
+	ProbabilityPerTick float64                  // Chance the event fires on a given tick, in [0, 1]
+	ResourceEffects    map[ResourceType]float64 // Production multipliers applied for the tick the event fires
+	NPCMoraleEffect    float64                  // Morale added to every NPC when the event fires, in [-1, 1]
+	TelemetryMessage   string                   // Human-readable description for telemetry
+}
+
+// RandomEventConfig holds the random events rolled each tick. A zero config
+// disables random events.
+type RandomEventConfig struct {
+	EventDefinitions []EventDefinition
+}
+
+// Validate returns an error if any definition has an empty or duplicate
+// name, a probability outside [0, 1], an unknown resource or negative
+// multiplier, or a morale effect outside [-1, 1].
+func (c RandomEventConfig) Validate() error {
+	seen := make(map[string]bool, len(c.EventDefinitions))
+	for i, def := range c.EventDefinitions {
+		if def.Name == "" {
+			return fmt.Errorf("EventDefinitions[%d]: name is required", i)
+		}
+		if seen[def.Name] {
+			return fmt.Errorf("EventDefinitions[%d]: duplicate event name %q", i, def.Name)
+		}
+		seen[def.Name] = true
+		if def.ProbabilityPerTick < 0 || def.ProbabilityPerTick > 1 {
+			return fmt.Errorf("event %q: ProbabilityPerTick must be in [0, 1], got %v", def.Name, def.ProbabilityPerTick)
+		}
+		for rt, multiplier := range def.ResourceEffects {
+			if _, err := ParseResourceType(string(rt)); err != nil {
+				return fmt.Errorf("event %q: %w", def.Name, err)
+			}
+			if multiplier < 0 {
+				return fmt.Errorf("event %q: %s multiplier must be non-negative, got %v", def.Name, rt, multiplier)
+			}
+		}
+		if def.NPCMoraleEffect < -1 || def.NPCMoraleEffect > 1 {
+			return fmt.Errorf("event %q: NPCMoraleEffect must be in [-1, 1], got %v", def.Name, def.NPCMoraleEffect)
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of c that shares no maps or slices with it.
+func (c RandomEventConfig) clone() RandomEventConfig {
+	defs := make([]EventDefinition, len(c.EventDefinitions))
+	for i, def := range c.EventDefinitions {
+		defs[i] = def
+		if def.ResourceEffects != nil {
+			defs[i].ResourceEffects = make(map[ResourceType]float64, len(def.ResourceEffects))
+			for rt, m := range def.ResourceEffects {
+				defs[i].ResourceEffects[rt] = m
+			}
+		}
+	}
+	return RandomEventConfig{EventDefinitions: defs}
+}
+
+// RandomEvent reports a random event that fired during a tick.
+type RandomEvent struct {
+	Name    string
+	Message string // The definition's TelemetryMessage
+	Tick    int64  // Tick count after the tick the event fired in
+}
+
+// RandomEventListener is notified of each random event that fires. It is
+// called after the engine lock is released.
+type RandomEventListener func(RandomEvent)
+
+// SetRandomEvents validates cfg and replaces the random event definitions.
+// Events are rolled, in definition order, from a source seeded with seed, so
+// the same seed and tick sequence fire the same events. Engines start with
+// no random events.
+func (s *SimulationEngine) SetRandomEvents(cfg RandomEventConfig, seed int64) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.randomEvents = cfg.clone()
+	s.eventRand = rand.New(rand.NewSource(seed))
+	s.activeEvents = nil
+	return nil
+}
+
+// GetRandomEvents returns a copy of the current random event definitions.
+func (s *SimulationEngine) GetRandomEvents() RandomEventConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.randomEvents.clone()
+}
+
+// GetActiveEvents returns the names of the random events that fired during
+// the most recent tick, in definition order.
+func (s *SimulationEngine) GetActiveEvents() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string{}, s.activeEvents...)
+}
+
+// SetRandomEventListener registers fn to be notified of fired random events,
+// replacing any previous listener. A nil fn removes it.
+func (s *SimulationEngine) SetRandomEventListener(fn RandomEventListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.randomEventListener = fn
+}
+
+// rollRandomEvents rolls every defined event, records the ones that fire as
+// active, and multiplies this tick's production rates by their resource
+// effects. It returns the fired definitions. Caller must hold s.mu.
+func (s *SimulationEngine) rollRandomEvents() []EventDefinition {
+	s.activeEvents = nil
+	if s.eventRand == nil {
+		return nil
+	}
+
+	var fired []EventDefinition
+	for _, def := range s.randomEvents.EventDefinitions {
+		if s.eventRand.Float64() >= def.ProbabilityPerTick {
+			continue
+		}
+		fired = append(fired, def)
+		s.activeEvents = append(s.activeEvents, def.Name)
+		for rt, multiplier := range def.ResourceEffects {
+			if res, ok := s.status.Resources[rt]; ok {
+				res.ProductionRate *= multiplier
+			}
+		}
+	}
+	return fired
+}
+
+// randomEventEffects returns a function applying the NPC morale effects of
+// fired events and notifying listener, for invoking after s.mu is released.
+// Returns nil if there is nothing to do. Caller must hold s.mu.
+func (s *SimulationEngine) randomEventEffects(fired []EventDefinition) func() {
+	if len(fired) == 0 {
+		return nil
+	}
+	behavior, listener, tick := s.behavior, s.randomEventListener, s.status.TickCount
+	return func() {
+		for _, def := range fired {
+			if behavior != nil && def.NPCMoraleEffect != 0 {
+				for _, n := range behavior.SnapshotNPCs() {
+					_ = behavior.ApplyMoraleModifier(n.NPCID, def.NPCMoraleEffect, "random event: "+def.Name)
+				}
+			}
+			if listener != nil {
+				listener(RandomEvent{Name: def.Name, Message: def.TelemetryMessage, Tick: tick})
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mineralVeinConfig(probability float64) RandomEventConfig {
+	return RandomEventConfig{EventDefinitions: []EventDefinition{{
+		Name:               "mineral_vein_discovered",
+		ProbabilityPerTick: probability,
+		ResourceEffects:    map[ResourceType]float64{ResourceMineral: 2.0},
+		TelemetryMessage:   "A rich mineral vein was discovered",
+	}}}
+}
+
+func TestRandomEvents_FireAtConfiguredRateWithResourceEffects(t *testing.T) {
+	const (
+		ticks       = 100
+		probability = 0.3
+		yield       = 10.0
+	)
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	sim.AddMine(yield)
+	require.NoError(t, sim.SetRandomEvents(mineralVeinConfig(probability), 42))
+
+	var notified []RandomEvent
+	sim.SetRandomEventListener(func(ev RandomEvent) { notified = append(notified, ev) })
+
+	fired := 0
+	for i := 0; i < ticks; i++ {
+		status := sim.Tick()
+		active := sim.GetActiveEvents()
+		expectedRate := yield
+		if len(active) > 0 {
+			assert.Equal(t, []string{"mineral_vein_discovered"}, active)
+			expectedRate *= 2.0
+			fired++
+		}
+		assert.InDelta(t, expectedRate, status.Resources[ResourceMineral].ProductionRate, 1e-9, "tick %d", i+1)
+	}
+
+	// Within 3 standard deviations of the binomial mean
+	mean := probability * ticks
+	sd := math.Sqrt(ticks * probability * (1 - probability))
+	assert.InDelta(t, mean, float64(fired), 3*sd)
+	require.Len(t, notified, fired)
+	assert.Equal(t, "A rich mineral vein was discovered", notified[0].Message)
+}
+
+func TestRandomEvents_SameSeedIsDeterministic(t *testing.T) {
+	run := func() []int {
+		sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+		require.NoError(t, sim.SetRandomEvents(mineralVeinConfig(0.5), 7))
+		var firedAt []int
+		for i := 0; i < 50; i++ {
+			sim.Tick()
+			if len(sim.GetActiveEvents()) > 0 {
+				firedAt = append(firedAt, i)
+			}
+		}
+		return firedAt
+	}
+	assert.Equal(t, run(), run())
+}
+
+func TestRandomEvents_NPCMoraleEffect(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	behavior := npc.NewBehaviorEngine()
+	behavior.RegisterNPC("npc-1")
+	sim.AttachBehaviorEngine(behavior)
+	require.NoError(t, sim.SetRandomEvents(RandomEventConfig{EventDefinitions: []EventDefinition{
+		{Name: "cave_in", ProbabilityPerTick: 1, NPCMoraleEffect: -0.2},
+	}}, 1))
+
+	sim.Tick()
+	n, _ := behavior.GetNPC("npc-1")
+	assert.InDelta(t, 0.3, n.Morale, 1e-9)
+}
+
+func TestRandomEventConfig_Validate(t *testing.T) {
+	for name, def := range map[string]EventDefinition{
+		"empty name":       {ProbabilityPerTick: 0.1},
+		"probability":      {Name: "e", ProbabilityPerTick: 1.5},
+		"unknown resource": {Name: "e", ResourceEffects: map[ResourceType]float64{"gold": 2}},
+		"negative effect":  {Name: "e", ResourceEffects: map[ResourceType]float64{ResourceSim: -1}},
+		"morale":           {Name: "e", NPCMoraleEffect: -2},
+	} {
+		cfg := RandomEventConfig{EventDefinitions: []EventDefinition{def}}
+		assert.Error(t, cfg.Validate(), name)
+	}
+
+	dup := RandomEventConfig{EventDefinitions: []EventDefinition{{Name: "e"}, {Name: "e"}}}
+	assert.Error(t, dup.Validate())
+
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	assert.Error(t, sim.SetRandomEvents(dup, 1))
+	assert.Empty(t, sim.GetRandomEvents().EventDefinitions)
+}
@@ -0,0 +1,149 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultTickRate is the real-time playback rate used until SetTickRate is called.
+const DefaultTickRate = 1.0
+
+// ErrRealtimeRunning is returned when real-time mode is started while it is
+// already active.
+var ErrRealtimeRunning = errors.New("real-time mode already running")
+
+// realtimeState tracks real-time playback. It has its own mutex so that
+// flow control never contends with Tick.
+type realtimeState struct {
+	mu             sync.Mutex
+	ticksPerSecond float64
+	running        bool
+	pause          chan struct{}   // closed by PauseRealtime to stop the current run
+	ctx            context.Context // context of the last run, reused by ResumeRealtime
+}
+
+// SetTickRate sets the real-time playback rate. A running loop picks up the
+// new rate after its next tick. Non-positive rates are ignored.
+func (s *SimulationEngine) SetTickRate(ticksPerSecond float64) {
+	if ticksPerSecond <= 0 {
+		return
+	}
+	s.realtime.mu.Lock()
+	defer s.realtime.mu.Unlock()
+	s.realtime.ticksPerSecond = ticksPerSecond
+}
+
+// TickRate returns the configured real-time playback rate.
+func (s *SimulationEngine) TickRate() float64 {
+	s.realtime.mu.Lock()
+	defer s.realtime.mu.Unlock()
+	return s.realtime.ticksPerSecond
+}
+
+// RunRealtime calls Tick at the configured rate until ctx is cancelled or
+// PauseRealtime is called. It returns nil when paused and ctx.Err() when cancelled.
+// Returns ErrRealtimeRunning if real-time mode is already active.
+func (s *SimulationEngine) RunRealtime(ctx context.Context) error {
+	pause, err := s.beginRealtime(ctx)
+	if err != nil {
+		return err
+	}
+	return s.realtimeLoop(ctx, pause)
+}
+
+// StartRealtime is the non-blocking form of RunRealtime: it marks real-time
+// mode active and runs the tick loop in a new goroutine.
+func (s *SimulationEngine) StartRealtime(ctx context.Context) error {
+	pause, err := s.beginRealtime(ctx)
+	if err != nil {
+		return err
+	}
+	go func() { _ = s.realtimeLoop(ctx, pause) }()
+	return nil
+}
+
+// beginRealtime marks real-time mode active and returns its pause channel.
+func (s *SimulationEngine) beginRealtime(ctx context.Context) (<-chan struct{}, error) {
+	s.realtime.mu.Lock()
+	defer s.realtime.mu.Unlock()
+
+	if s.realtime.running {
+		return nil, ErrRealtimeRunning
+	}
+	s.realtime.running = true
+	s.realtime.pause = make(chan struct{})
+	s.realtime.ctx = ctx
+	return s.realtime.pause, nil
+}
+
+func (s *SimulationEngine) realtimeLoop(ctx context.Context, pause <-chan struct{}) error {
+	defer func() {
+		s.realtime.mu.Lock()
+		s.realtime.running = false
+		s.realtime.mu.Unlock()
+	}()
+
+	interval := tickInterval(s.TickRate())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-pause:
+			return nil
+		case <-ticker.C:
+			s.Tick()
+			if next := tickInterval(s.TickRate()); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// PauseRealtime stops a running real-time loop. It is a no-op when not
+// running. Unlike Pause, it does not freeze Tick.
+func (s *SimulationEngine) PauseRealtime() {
+	s.realtime.mu.Lock()
+	defer s.realtime.mu.Unlock()
+	if s.realtime.running && s.realtime.pause != nil {
+		close(s.realtime.pause)
+		s.realtime.pause = nil
+	}
+}
+
+// ResumeRealtime restarts real-time mode in a new goroutine using the
+// context of the previous RunRealtime/StartRealtime call. Returns an error if
+// real-time mode was never started, is already running, or its context has
+// been cancelled.
+func (s *SimulationEngine) ResumeRealtime() error {
+	s.realtime.mu.Lock()
+	ctx, running := s.realtime.ctx, s.realtime.running
+	s.realtime.mu.Unlock()
+
+	switch {
+	case running:
+		return ErrRealtimeRunning
+	case ctx == nil:
+		return errors.New("real-time mode has not been started")
+	case ctx.Err() != nil:
+		return fmt.Errorf("real-time context is done: %w", ctx.Err())
+	}
+	return s.StartRealtime(ctx)
+}
+
+// IsRunning reports whether real-time mode is active.
+func (s *SimulationEngine) IsRunning() bool {
+	s.realtime.mu.Lock()
+	defer s.realtime.mu.Unlock()
+	return s.realtime.running
+}
+
+func tickInterval(ticksPerSecond float64) time.Duration {
+	return time.Duration(float64(time.Second) / ticksPerSecond)
+}
@@ -0,0 +1,95 @@
+package simulation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRealtime_TickRate(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.SetTickRate(10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	err := sim.RunRealtime(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, sim.IsRunning())
+
+	ticks := sim.GetStatus().TickCount
+	assert.GreaterOrEqual(t, ticks, int64(2))
+	assert.LessOrEqual(t, ticks, int64(4))
+}
+
+func TestRunRealtime_PauseStopsTicks(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.SetTickRate(10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- sim.RunRealtime(ctx) }()
+
+	require.Eventually(t, sim.IsRunning, time.Second, 5*time.Millisecond)
+	time.Sleep(300 * time.Millisecond)
+	sim.PauseRealtime()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "PauseRealtime should end RunRealtime without error")
+	case <-time.After(time.Second):
+		t.Fatal("RunRealtime did not return after PauseRealtime")
+	}
+	assert.False(t, sim.IsRunning())
+
+	paused := sim.GetStatus().TickCount
+	assert.GreaterOrEqual(t, paused, int64(2))
+	time.Sleep(250 * time.Millisecond)
+	assert.Equal(t, paused, sim.GetStatus().TickCount, "no ticks while paused")
+
+	// ResumeRealtime continues with the original context
+	require.NoError(t, sim.ResumeRealtime())
+	assert.True(t, sim.IsRunning())
+	assert.Eventually(t, func() bool { return sim.GetStatus().TickCount > paused }, time.Second, 10*time.Millisecond)
+	sim.PauseRealtime()
+	assert.Eventually(t, func() bool { return !sim.IsRunning() }, time.Second, 5*time.Millisecond)
+}
+
+func TestRunRealtime_AlreadyRunning(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, sim.StartRealtime(ctx))
+	assert.True(t, sim.IsRunning())
+	assert.ErrorIs(t, sim.RunRealtime(ctx), ErrRealtimeRunning)
+	assert.ErrorIs(t, sim.ResumeRealtime(), ErrRealtimeRunning)
+
+	cancel()
+	assert.Eventually(t, func() bool { return !sim.IsRunning() }, time.Second, 5*time.Millisecond)
+	assert.Error(t, sim.ResumeRealtime(), "cancelled context cannot be resumed")
+}
+
+func TestSetTickRate_IgnoresNonPositive(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	assert.Equal(t, DefaultTickRate, sim.TickRate())
+
+	sim.SetTickRate(0)
+	sim.SetTickRate(-5)
+	assert.Equal(t, DefaultTickRate, sim.TickRate())
+
+	sim.SetTickRate(4)
+	assert.Equal(t, 4.0, sim.TickRate())
+}
+
+func TestResumeRealtime_NeverStarted(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	assert.Error(t, sim.ResumeRealtime())
+}
@@ -0,0 +1,75 @@
+package simulation
+
+import "math"
+
+// AddMineWithReserve adds a mine that yields yieldRate mineral per tick until
+// it has produced maxReserve in total, after which it produces nothing until
+// removed. A maxReserve of 0 makes the reserve infinite, like AddMine.
+// Returns the mine's unique ID.
+func (s *SimulationEngine) AddMineWithReserve(yieldRate, maxReserve float64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addMineLocked(yieldRate, math.Max(maxReserve, 0), false)
+}
+
+// GetMineReserve returns the ore left in a mine, or +Inf if its reserve is
+// infinite.
+// Returns an *InfrastructureNotFoundError if no such mine exists.
+func (s *SimulationEngine) GetMineReserve(mineID string) (float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, m := range s.mines {
+		if m.MineID == mineID {
+			if m.MaxReserve <= 0 {
+				return math.Inf(1), nil
+			}
+			return m.CurrentReserve, nil
+		}
+	}
+	return 0, &InfrastructureNotFoundError{Kind: "mine", ID: mineID}
+}
+
+// mineYields returns the mineral each of mines produces per tick, in order:
+// its yield rate scaled by the world age multiplier age and its crew's
+// efficiency (see assignedEfficiency), capped at its remaining reserve.
+// Disrupted and depleted mines yield 0.
+func mineYields(mines []Mine, age float64, efficiency map[assignment]float64) []float64 {
+	yields := make([]float64, len(mines))
+	for i, mine := range mines {
+		if mine.DisruptedTicks > 0 || mine.Depleted() {
+			continue
+		}
+		yield := mine.YieldRate * age
+		if eff, ok := efficiency[assignment{Kind: "mine", ID: mine.MineID}]; ok {
+			yield *= eff
+		}
+		if mine.MaxReserve > 0 {
+			yield = math.Min(yield, mine.CurrentReserve)
+		}
+		yields[i] = yield
+	}
+	return yields
+}
+
+// extractOre deducts one tick of production, the mine yields scaled by
+// throttle as in applyProduction, from the reserves of the finite mines
+// among mines. It returns the mines this exhausted, with CurrentReserve
+// still holding their reserve from before the tick.
+func extractOre(mines []Mine, yields []float64, throttle float64) []Mine {
+	var depleted []Mine
+	for i := range mines {
+		m := &mines[i]
+		if m.MaxReserve <= 0 || m.Depleted() || yields[i] <= 0 {
+			continue
+		}
+		before := m.CurrentReserve
+		m.CurrentReserve = math.Max(before-yields[i]*throttle, 0)
+		if m.CurrentReserve == 0 {
+			exhausted := *m
+			exhausted.CurrentReserve = before
+			depleted = append(depleted, exhausted)
+		}
+	}
+	return depleted
+}
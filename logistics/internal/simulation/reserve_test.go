@@ -0,0 +1,114 @@
+package simulation
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReserveTestEngine(t *testing.T) *SimulationEngine {
+	t.Helper()
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	return sim
+}
+
+func TestMineReserve_DepletesAfterProducingReserve(t *testing.T) {
+	sim := newReserveTestEngine(t)
+	mineID := sim.AddMineWithReserve(3, 10)
+
+	var produced, reserves []float64
+	for i := 0; i < 5; i++ {
+		sim.Tick()
+		entries := sim.GetResourceAuditLog(ResourceMineral, 1)
+		require.Len(t, entries, 1)
+		produced = append(produced, entries[0].Produced)
+		reserve, err := sim.GetMineReserve(mineID)
+		require.NoError(t, err)
+		reserves = append(reserves, reserve)
+	}
+
+	assert.Equal(t, []float64{3, 3, 3, 1, 0}, produced)
+	assert.Equal(t, []float64{7, 4, 1, 0, 0}, reserves)
+
+	mine, err := sim.GetMine(mineID)
+	require.NoError(t, err)
+	assert.True(t, mine.Depleted())
+	assert.Equal(t, 10.0, mine.MaxReserve)
+}
+
+func TestMineReserve_MineralStopsIncreasingAfterDepletion(t *testing.T) {
+	sim := newReserveTestEngine(t)
+	sim.AddMineWithReserve(3, 10)
+
+	for i := 0; i < 4; i++ {
+		sim.Tick()
+	}
+	depletedAt := sim.GetStatus().Resources[ResourceMineral].Quantity
+	assert.Equal(t, 10.0, depletedAt)
+
+	for i := 0; i < 5; i++ {
+		sim.Tick()
+		assert.Equal(t, depletedAt, sim.GetStatus().Resources[ResourceMineral].Quantity)
+	}
+	assert.Zero(t, sim.GetStatus().Resources[ResourceMineral].ProductionRate)
+}
+
+func TestMineReserve_ZeroIsInfinite(t *testing.T) {
+	sim := newReserveTestEngine(t)
+	mineID := sim.AddMine(3)
+
+	for i := 0; i < 10; i++ {
+		sim.Tick()
+	}
+	reserve, err := sim.GetMineReserve(mineID)
+	require.NoError(t, err)
+	assert.True(t, math.IsInf(reserve, 1))
+	assert.Equal(t, 30.0, sim.GetStatus().Resources[ResourceMineral].Quantity)
+}
+
+func TestGetMineReserve_UnknownMine(t *testing.T) {
+	sim := newReserveTestEngine(t)
+
+	_, err := sim.GetMineReserve("mine-404")
+	var notFound *InfrastructureNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "mine-404", notFound.ID)
+}
+
+func TestMineReserve_EmitsTelemetryOnDepletion(t *testing.T) {
+	sim := newReserveTestEngine(t)
+	sink := &recordingSink{}
+	sim.SetTelemetryService(sink)
+	require.NoError(t, sim.SetTelemetryTickInterval(0))
+	mineID := sim.AddMineWithReserve(3, 10)
+
+	for i := 0; i < 6; i++ {
+		sim.Tick()
+	}
+
+	depleted := sink.byKind(TelemetryMineDepleted)
+	require.Len(t, depleted, 1, "a mine is depleted only once")
+	assert.Equal(t, mineID+"_reserve", depleted[0].Attribute)
+	assert.Equal(t, 1.0, depleted[0].OldValue)
+	assert.Equal(t, 0.0, depleted[0].NewValue)
+	assert.Equal(t, int64(4), depleted[0].TickCount)
+}
+
+func TestMineReserve_ReplayRestoresReserve(t *testing.T) {
+	sim := newReserveTestEngine(t)
+	es := NewEventSourcedSimulationEngine(sim)
+	mineID := es.AddMineWithReserve(3, 10)
+	es.Tick()
+	es.Tick()
+
+	replayed, err := es.ReplayFromEvents(es.ExportEventLog())
+	require.NoError(t, err)
+	reserve, err := replayed.GetMineReserve(mineID)
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, reserve)
+}
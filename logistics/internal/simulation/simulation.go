@@ -1,41 +1,77 @@
 package simulation
 
 import (
+	"errors"
 	"fmt"
+	"math/rand"
 	"sync"
+	"sync/atomic"
+	"unsafe"
 
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/infestation"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
 )
 
-const (
-	// baseSimProduction is the base Sim resource production per tick.
-	baseSimProduction = 1.0
-
-	// refineryMineralConsumptionBase is the base mineral consumed per refinery per tick,
-	// multiplied by refinery efficiency.
-	refineryMineralConsumptionBase = 10.0
-
-	// refineryRapidlumProductionBase is the base rapidlum produced per refinery per tick,
-	// multiplied by refinery efficiency.
-	refineryRapidlumProductionBase = 5.0
-)
-
 // SimulationEngine manages the resource simulation, including mines, refineries,
 // and resource production/consumption per tick. It is safe for concurrent use.
 type SimulationEngine struct {
 	status      SimulationStatus
+	config      SimulationConfig
 	mines       []Mine
 	refineries  []Refinery
+	chains      []ProductionChain // registered beyond the built-in refinery chain
 	mu          sync.RWMutex
 	rebellion   *rebellion.Engine
 	infestation *infestation.Engine
+	behavior    *npc.BehaviorEngine // optional; enables aggregate NPC stats
 	nextID      int
+	realtime    realtimeState
+
+	assignments    map[string]assignment  // NPC ID → mine/refinery it works at
+	crewEfficiency map[assignment]float64 // forks only: crew efficiencies captured from the original
+
+	resourceWatches map[int]resourceWatch // pending threshold callbacks by ID
+	nextWatchID     int
+	recorder        func(SimulationEvent) // optional; called under mu for each recorded change
+
+	disruption         DisruptionConfig
+	disruptionListener DisruptionListener // optional; notified after mu is released
+	randFn             func() float64
+
+	randomEvents        RandomEventConfig
+	eventRand           *rand.Rand // seeded by SetRandomEvents; nil until then
+	activeEvents        []string   // events fired during the last tick
+	randomEventListener RandomEventListener
+
+	throttleHistory  []ThrottleRecord // throttled periods, oldest first
+	rebellionHistory float64Ring      // OverallRebellionProb of recent ticks
+
+	resourcePeaks map[ResourceType]float64 // highest end-of-production quantity per resource
+	decayListener ResourceDecayListener
+
+	autoScale         AutoScaleConfig
+	autoScaleListener AutoScaleListener
+
+	telemetry             TelemetrySink
+	telemetryTickInterval int
+	milestonesReached     map[ResourceType]int // ResourceMilestones reached so far per resource
+
+	tickCount atomic.Int64 // mirrors status.TickCount for CurrentTick
+
+	auditLog     map[ResourceType][]ResourceAuditEntry // per resource, oldest first
+	auditLogSize int                                   // entries kept per resource
 }
 
 // NewSimulationEngine creates a new simulation engine initialized with zero resources
-// and the given rebellion engine for probability calculations.
+// and the given rebellion engine for probability calculations, using DefaultConfig.
 func NewSimulationEngine(rebellionEngine *rebellion.Engine) *SimulationEngine {
+	return NewSimulationEngineWithConfig(rebellionEngine, DefaultConfig())
+}
+
+// NewSimulationEngineWithConfig creates a new simulation engine with custom
+// production rates.
+func NewSimulationEngineWithConfig(rebellionEngine *rebellion.Engine, cfg SimulationConfig) *SimulationEngine {
 	infestationEngine := infestation.NewEngine(infestation.DefaultConfig())
 	return &SimulationEngine{
 		status: SimulationStatus{
@@ -45,11 +81,12 @@ func NewSimulationEngine(rebellionEngine *rebellion.Engine) *SimulationEngine {
 			TickCount:            0,
 			OverallRebellionProb: 0.0,
 			ThrottleMultiplier:   1.0,
+			WorldAgeMultiplier:   1.0,
 			Resources: map[ResourceType]*ResourceState{
 				ResourceSim: {
 					Type:            ResourceSim,
 					Quantity:        0,
-					ProductionRate:  baseSimProduction,
+					ProductionRate:  cfg.BaseSimProduction,
 					ConsumptionRate: 0,
 				},
 				ResourceRapidlum: {
@@ -66,87 +103,148 @@ func NewSimulationEngine(rebellionEngine *rebellion.Engine) *SimulationEngine {
 				},
 			},
 		},
+		config:      cfg.clone(),
 		mines:       make([]Mine, 0),
 		refineries:  make([]Refinery, 0),
 		rebellion:   rebellionEngine,
 		infestation: infestationEngine,
 		nextID:      1,
+		realtime:    realtimeState{ticksPerSecond: DefaultTickRate},
+		assignments: make(map[string]assignment),
+		randFn:      rand.Float64,
+
+		rebellionHistory: newFloat64Ring(RebellionHistorySize),
+		resourcePeaks:    make(map[ResourceType]float64),
+		autoScale:        DefaultAutoScaleConfig(),
+
+		telemetryTickInterval: DefaultTelemetryTickInterval,
+		milestonesReached:     make(map[ResourceType]int),
+
+		auditLog:     make(map[ResourceType][]ResourceAuditEntry),
+		auditLogSize: DefaultAuditLogSize,
 	}
 }
 
-// Tick advances the simulation by one tick. Each tick:
-// 1. Recalculates production/consumption rates from mines and refineries
+// Tick advances the simulation by one tick. While paused (see Pause) it only
+// increments SkippedTicks and returns the status otherwise unchanged.
+// Each tick:
+// 1. Recalculates production/consumption rates from mines and refineries,
+// then rolls random events and applies their production multipliers
 // 2. Applies production (adds to quantity)
-// 3. Applies consumption (subtracts from quantity, floored at 0)
-// 4. Increments tick counter
+// 3. Applies consumption (subtracts from quantity, floored at 0), then decays
+// perishable resources (see SetResourceDecayRate) and ages the world (see
+// GetWorldAge)
+// 4. Increments tick counter and records the overall rebellion probability
+// (see GetRebellionTrend), then adds a mine if mineral is low (see
+// SetAutoScaleConfig)
+// 5. Advances mine/refinery disruptions and rolls for new ones
+// 6. Fires infestation telemetry and message bus notifications, the Plague
+// Heart morale penalty, resource threshold callbacks, disruption, decay and
+// auto-scaling notifications, telemetry (see SetTelemetryService) and random
+// event morale effects and notifications (after the lock is released)
+//
+// Passive NPC recovery (see SimulationConfig.NPCMoraleRecoveryRate) runs
+// before the lock is taken, so the tick's NPC statistics include it; a Plague
+// Heart morale penalty shows in the statistics from the next tick.
 // Returns the updated simulation status.
 func (s *SimulationEngine) Tick() SimulationStatus {
+	s.recoverNPCs()
+	status, fired := s.tick()
+	for _, fn := range fired {
+		fn()
+	}
+	return status
+}
+
+// tick performs one Tick under the write lock and returns the new status
+// along with any resource callbacks, disruption notifications and random
+// event effects that became due.
+func (s *SimulationEngine) tick() (SimulationStatus, []func()) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Recalculate production rates from mines
-	totalMineralProduction := 0.0
-	for _, mine := range s.mines {
-		totalMineralProduction += mine.YieldRate
-	}
-
-	// Recalculate refinery rates
-	totalMineralConsumption := 0.0
-	totalRapidlumProduction := 0.0
-	for _, ref := range s.refineries {
-		totalMineralConsumption += ref.Efficiency * refineryMineralConsumptionBase
-		totalRapidlumProduction += ref.Efficiency * refineryRapidlumProductionBase
+	if s.status.IsPaused {
+		s.status.SkippedTicks++
+		return s.copyStatus(), nil
 	}
 
-	// Update rates
-	s.status.Resources[ResourceMineral].ProductionRate = totalMineralProduction
-	s.status.Resources[ResourceMineral].ConsumptionRate = totalMineralConsumption
-	s.status.Resources[ResourceRapidlum].ProductionRate = totalRapidlumProduction
-	s.status.Resources[ResourceSim].ProductionRate = baseSimProduction
+	flows := s.recalculateRates(s.status.Resources)
+	yields := mineYields(s.mines, s.status.WorldAgeMultiplier, s.assignedEfficiency())
+	randomEvents := s.rollRandomEvents()
 
-	// Tick infestation engine (uses average rebellion + simulated avg trauma)
+	// Tick infestation engine (uses average rebellion + NPC avg trauma, or an
+	// estimate from rebellion when no behavior engine is attached)
 	avgTrauma := 1.0 - s.status.OverallRebellionProb // approximate: low rebellion ≈ low trauma
+	if s.behavior != nil {
+		avgTrauma = s.status.AvgNPCTrauma
+	}
+	oldThrottle := s.status.ThrottleMultiplier
+	var infResult infestation.InfestationTickResult
+	var fired []func()
 	if s.infestation != nil {
-		infResult := s.infestation.Tick(s.status.OverallRebellionProb, avgTrauma, s.status.TickCount+1)
-		infState := s.infestation.GetState()
-		s.status.InfestationLevel = infState.Counter
-		s.status.IsPlagueHeart = infState.IsPlagueHeart
-		s.status.ThrottleMultiplier = infState.ThrottleMultiplier
-		_ = infResult // result used for telemetry by caller
+		var notify func()
+		infResult, notify = s.infestation.TickDeferred(s.status.OverallRebellionProb, avgTrauma, s.status.TickCount+1)
+		s.syncInfestationStatus()
+		if notify != nil {
+			fired = append(fired, notify)
+		}
+		if fn := s.plagueHeartPenalty(infResult); fn != nil {
+			fired = append(fired, fn)
+		}
 	}
 
-	// Apply production (throttled by infestation)
-	throttle := s.status.ThrottleMultiplier
-	if throttle <= 0 {
-		throttle = 1.0
-	}
-	for _, res := range s.status.Resources {
-		res.Quantity += res.ProductionRate * throttle
+	// Aggregate NPC statistics from the attached behavior engine (recovered by Tick)
+	if s.behavior != nil {
+		s.updateNPCStats()
 	}
 
-	// Apply consumption (mineral consumed by refineries)
-	mineralRes := s.status.Resources[ResourceMineral]
-	consumed := mineralRes.ConsumptionRate
-	if consumed > mineralRes.Quantity {
-		// Cannot consume more than available - scale down rapidlum production proportionally
-		ratio := mineralRes.Quantity / consumed
-		consumed = mineralRes.Quantity
-		// Reduce rapidlum production proportionally
-		s.status.Resources[ResourceRapidlum].Quantity -= totalRapidlumProduction
-		s.status.Resources[ResourceRapidlum].Quantity += totalRapidlumProduction * ratio
-	}
-	mineralRes.Quantity -= consumed
-
-	// Floor at 0
-	for _, res := range s.status.Resources {
-		if res.Quantity < 0 {
-			res.Quantity = 0
-		}
-	}
+	quantitiesBefore := s.resourceQuantities()
+	_, tallies := applyProduction(s.status.Resources, s.status.ThrottleMultiplier, flows)
+	quantitiesProduced := s.resourceQuantities()
+	depleted := extractOre(s.mines, yields, s.status.ThrottleMultiplier)
+	decayAlerts := s.applyResourceDecay()
+	s.status.WorldAgeMultiplier *= 1 - s.config.WorldAgingRate
 
 	s.status.TickCount++
+	s.tickCount.Store(s.status.TickCount)
+	s.recordAudit(quantitiesBefore, quantitiesProduced, tallies)
+	s.rebellionHistory.push(s.status.OverallRebellionProb)
+	s.record(SimulationEvent{Type: EventTick})
+	autoScaled, scaledUp := s.applyAutoScale()
 
-	return s.copyStatus()
+	fired = append(fired, s.collectTriggeredWatches()...)
+	if disruptions := s.advanceDisruptions(); len(disruptions) > 0 && s.disruptionListener != nil {
+		listener := s.disruptionListener
+		fired = append(fired, func() {
+			for _, ev := range disruptions {
+				listener(ev)
+			}
+		})
+	}
+	if len(decayAlerts) > 0 && s.decayListener != nil {
+		listener := s.decayListener
+		fired = append(fired, func() {
+			for _, ev := range decayAlerts {
+				listener(ev)
+			}
+		})
+	}
+	if events := s.telemetryEvents(infResult, oldThrottle, depleted); len(events) > 0 {
+		sink := s.telemetry
+		fired = append(fired, func() {
+			for _, ev := range events {
+				sink.EmitSimulationTelemetry(ev)
+			}
+		})
+	}
+	if scaledUp && s.autoScaleListener != nil {
+		listener := s.autoScaleListener
+		fired = append(fired, func() { listener(autoScaled) })
+	}
+	if fn := s.randomEventEffects(randomEvents); fn != nil {
+		fired = append(fired, fn)
+	}
+	return s.copyStatus(), fired
 }
 
 // GetStatus returns a snapshot of the current simulation state.
@@ -156,20 +254,62 @@ func (s *SimulationEngine) GetStatus() SimulationStatus {
 	return s.copyStatus()
 }
 
+// CurrentTick returns the current tick count without taking the engine lock,
+// so it is safe to call from code the engine runs while holding it (e.g. a
+// rebellion engine tick source consulted during Tick).
+func (s *SimulationEngine) CurrentTick() int64 {
+	return s.tickCount.Load()
+}
+
+// GetConfig returns the engine's current production configuration.
+func (s *SimulationEngine) GetConfig() SimulationConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config.clone()
+}
+
+// UpdateConfig replaces the production configuration at runtime. The write lock
+// guarantees the change lands between ticks; it takes effect on the next Tick().
+// Returns an error if any rate is negative.
+func (s *SimulationEngine) UpdateConfig(cfg SimulationConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = cfg.clone()
+	return nil
+}
+
 // AddMine adds a mine with the specified yield rate to the simulation.
 // Returns the mine's unique ID.
 func (s *SimulationEngine) AddMine(yieldRate float64) string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.addMineLocked(yieldRate, 0, false)
+}
 
+// addMineLocked appends a mine holding maxReserve ore (0 = infinite),
+// flagged as auto-added if autoScaled, and returns its ID. Caller must hold
+// s.mu.
+func (s *SimulationEngine) addMineLocked(yieldRate, maxReserve float64, autoScaled bool) string {
 	id := fmt.Sprintf("mine-%d", s.nextID)
 	s.nextID++
 
 	s.mines = append(s.mines, Mine{
-		MineID:    id,
-		YieldRate: yieldRate,
+		MineID:         id,
+		YieldRate:      yieldRate,
+		AutoScaled:     autoScaled,
+		MaxReserve:     maxReserve,
+		CurrentReserve: maxReserve,
 	})
 	s.status.Mines = len(s.mines)
+	evType := EventAddMine
+	if autoScaled {
+		evType = EventAutoScaleMine
+	}
+	s.record(SimulationEvent{Type: evType, TargetID: id, Value: yieldRate, Reserve: maxReserve})
 
 	return id
 }
@@ -180,19 +320,162 @@ func (s *SimulationEngine) AddMine(yieldRate float64) string {
 func (s *SimulationEngine) AddRefinery(efficiency float64) string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.addRefineryLocked(efficiency)
+}
 
+// addRefineryLocked appends a refinery and returns its ID. Caller must hold s.mu.
+func (s *SimulationEngine) addRefineryLocked(efficiency float64) string {
 	id := fmt.Sprintf("refinery-%d", s.nextID)
 	s.nextID++
 
 	s.refineries = append(s.refineries, Refinery{
-		RefineryID: id,
-		Efficiency: efficiency,
+		RefineryID:   id,
+		FacilityType: RefineryFacility,
+		Efficiency:   efficiency,
 	})
 	s.status.Refineries = len(s.refineries)
+	s.record(SimulationEvent{Type: EventAddRefinery, TargetID: id, Value: efficiency})
 
 	return id
 }
 
+// RemoveMine removes the mine with the given ID.
+// Returns an *InfrastructureNotFoundError if no such mine exists.
+func (s *SimulationEngine) RemoveMine(mineID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, m := range s.mines {
+		if m.MineID == mineID {
+			s.mines = append(s.mines[:i], s.mines[i+1:]...)
+			s.status.Mines = len(s.mines)
+			s.pruneAssignments()
+			s.record(SimulationEvent{Type: EventRemoveMine, TargetID: mineID})
+			return nil
+		}
+	}
+	return &InfrastructureNotFoundError{Kind: "mine", ID: mineID}
+}
+
+// RemoveRefinery removes the refinery with the given ID.
+// Returns an *InfrastructureNotFoundError if no such refinery exists.
+func (s *SimulationEngine) RemoveRefinery(refineryID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.refineries {
+		if r.RefineryID == refineryID {
+			s.refineries = append(s.refineries[:i], s.refineries[i+1:]...)
+			s.status.Refineries = len(s.refineries)
+			s.pruneAssignments()
+			s.record(SimulationEvent{Type: EventRemoveRefinery, TargetID: refineryID})
+			return nil
+		}
+	}
+	return &InfrastructureNotFoundError{Kind: "refinery", ID: refineryID}
+}
+
+// TransferResource atomically moves amount of the given resource from this engine
+// to toEngine. Both engines' write locks are held for the duration of the transfer,
+// acquired in pointer-address order so concurrent opposite-direction transfers
+// cannot deadlock.
+// Returns ErrInsufficientResource (wrapped) if this engine holds less than amount.
+func (s *SimulationEngine) TransferResource(toEngine *SimulationEngine, rt ResourceType, amount float64) error {
+	if toEngine == nil {
+		return errors.New("transfer target engine is nil")
+	}
+	if toEngine == s {
+		return errors.New("cannot transfer resource to the same engine")
+	}
+	if amount <= 0 {
+		return fmt.Errorf("transfer amount must be positive, got %v", amount)
+	}
+
+	first, second := s, toEngine
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+
+	src, ok := s.status.Resources[rt]
+	if !ok {
+		return fmt.Errorf("unknown resource type %q", rt)
+	}
+	dst, ok := toEngine.status.Resources[rt]
+	if !ok {
+		return fmt.Errorf("unknown resource type %q", rt)
+	}
+	if src.Quantity < amount {
+		return fmt.Errorf("%w: %s has %.2f, transfer requires %.2f", ErrInsufficientResource, rt, src.Quantity, amount)
+	}
+
+	src.Quantity -= amount
+	dst.Quantity += amount
+	return nil
+}
+
+// AddResource increases the stored quantity of a resource by amount.
+func (s *SimulationEngine) AddResource(rt ResourceType, amount float64) error {
+	if amount <= 0 {
+		return fmt.Errorf("amount must be positive, got %v", amount)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, ok := s.status.Resources[rt]
+	if !ok {
+		return fmt.Errorf("unknown resource type %q", rt)
+	}
+	res.Quantity += amount
+	return nil
+}
+
+// DeductResource decreases the stored quantity of a resource by amount.
+// Returns ErrInsufficientResource (wrapped) if less than amount is held; the
+// quantity is left unchanged in that case.
+func (s *SimulationEngine) DeductResource(rt ResourceType, amount float64) error {
+	return s.SubtractResource(rt, amount, false)
+}
+
+// SubtractResource decreases the stored quantity of a resource by amount.
+// If less than amount is held, it returns ErrInsufficientResource (wrapped)
+// and leaves the quantity unchanged, unless allowPartial is true, in which
+// case the quantity is floored at 0.
+func (s *SimulationEngine) SubtractResource(rt ResourceType, amount float64, allowPartial bool) error {
+	if amount <= 0 {
+		return fmt.Errorf("amount must be positive, got %v", amount)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, ok := s.status.Resources[rt]
+	if !ok {
+		return fmt.Errorf("unknown resource type %q", rt)
+	}
+	if res.Quantity < amount {
+		if !allowPartial {
+			return fmt.Errorf("%w: %s has %.2f, requires %.2f", ErrInsufficientResource, rt, res.Quantity, amount)
+		}
+		res.Quantity = 0
+		return nil
+	}
+	res.Quantity -= amount
+	return nil
+}
+
+// AttachBehaviorEngine connects a BehaviorEngine so that each Tick computes
+// ActiveNPCs and the aggregate NPC statistics in SimulationStatus.
+func (s *SimulationEngine) AttachBehaviorEngine(b *npc.BehaviorEngine) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.behavior = b
+}
+
 // GetInfestationEngine returns the underlying infestation engine for direct manipulation
 // (e.g., cleansing operations). Returns nil if not initialized.
 func (s *SimulationEngine) GetInfestationEngine() *infestation.Engine {
@@ -209,6 +492,166 @@ func (s *SimulationEngine) GetInfestationState() infestation.InfestationState {
 	return s.infestation.GetState()
 }
 
+// tickInfestation advances only the infestation engine with explicit inputs
+// (used by ZoneManager) and mirrors the new state into the status. Infestation
+// notifications and the Plague Heart morale penalty run after the lock is
+// released.
+func (s *SimulationEngine) tickInfestation(avgRebellion, avgTrauma float64, tick int64) infestation.InfestationTickResult {
+	s.mu.Lock()
+	result, notify := s.infestation.TickDeferred(avgRebellion, avgTrauma, tick)
+	s.syncInfestationStatus()
+	penalty := s.plagueHeartPenalty(result)
+	s.mu.Unlock()
+
+	for _, fn := range []func(){notify, penalty} {
+		if fn != nil {
+			fn()
+		}
+	}
+	return result
+}
+
+// plagueHeartPenalty returns a function that lowers the morale of every NPC
+// in the attached behavior engine by PlagueHeartMoralePenalty if result
+// activated the Plague Heart, or nil otherwise. Caller must hold s.mu; the
+// function must be called after it is released.
+func (s *SimulationEngine) plagueHeartPenalty(result infestation.InfestationTickResult) func() {
+	if s.behavior == nil || !result.PlagueHeartChanged || !result.PlagueHeartActive {
+		return nil
+	}
+	behavior := s.behavior
+	return func() { behavior.ApplyGroupMoraleModifier(-PlagueHeartMoralePenalty) }
+}
+
+// recoverNPCs applies one tick of passive NPC recovery to the attached
+// behavior engine, outside the engine lock. It does nothing while paused.
+func (s *SimulationEngine) recoverNPCs() {
+	s.mu.RLock()
+	behavior := s.behavior
+	moraleRate, traumaRate := s.config.NPCMoraleRecoveryRate, s.config.NPCTraumaDecayRate
+	paused := s.status.IsPaused
+	s.mu.RUnlock()
+
+	if behavior != nil && !paused && (moraleRate > 0 || traumaRate > 0) {
+		behavior.TickNPCs(1, moraleRate, traumaRate)
+	}
+}
+
+// recalculateRates sets the production and consumption rates of resources
+// from the current mines, refineries and config (see recalculateRatesAt).
+// Caller must hold s.mu.
+func (s *SimulationEngine) recalculateRates(resources map[ResourceType]*ResourceState) []productionFlow {
+	return s.recalculateRatesAt(resources, s.mines, s.status.WorldAgeMultiplier)
+}
+
+// recalculateRatesAt is recalculateRates for the given mines (s.mines or a
+// forecast copy), with mine yields and refinery efficiencies scaled by the
+// world age multiplier age and, with assigned NPCs, by those NPCs' combined
+// work efficiency. It returns the production chain flows making up the
+// rates. Caller must hold s.mu.
+func (s *SimulationEngine) recalculateRatesAt(resources map[ResourceType]*ResourceState, mines []Mine, age float64) []productionFlow {
+	efficiency := s.assignedEfficiency()
+
+	totalMineralProduction := 0.0
+	for _, yield := range mineYields(mines, age, efficiency) {
+		totalMineralProduction += yield
+	}
+
+	// Combined efficiency of the operating facilities of each type
+	facilityEfficiency := make(map[string]float64)
+	for _, ref := range s.refineries {
+		if ref.DisruptedTicks > 0 {
+			continue
+		}
+		refEfficiency := ref.Efficiency * age
+		if eff, ok := efficiency[assignment{Kind: "refinery", ID: ref.RefineryID}]; ok {
+			refEfficiency *= eff
+		}
+		facilityEfficiency[ref.FacilityType] += refEfficiency
+	}
+
+	for _, res := range resources {
+		res.ProductionRate = 0
+		res.ConsumptionRate = 0
+	}
+	resources[ResourceMineral].ProductionRate = totalMineralProduction
+	resources[ResourceSim].ProductionRate = s.config.BaseSimProduction
+	flows := s.productionFlows(facilityEfficiency)
+	for _, f := range flows {
+		resources[f.input].ConsumptionRate += f.inputRate
+		resources[f.output].ProductionRate += f.outputRate
+	}
+	return flows
+}
+
+// resourceTally is how much of a resource one tick of production added and
+// consumed.
+type resourceTally struct {
+	produced float64
+	consumed float64
+}
+
+// applyProduction applies one tick of production (scaled by throttle) and
+// production chain consumption to resources, flooring quantities at 0. It
+// returns the resources whose consumption could not be fully met and what
+// was produced and consumed of each resource.
+func applyProduction(resources map[ResourceType]*ResourceState, throttle float64, flows []productionFlow) ([]ResourceType, map[ResourceType]*resourceTally) {
+	tallies := make(map[ResourceType]*resourceTally, len(resources))
+	for rt, res := range resources {
+		produced := res.ProductionRate * throttle
+		res.Quantity += produced
+		tallies[rt] = &resourceTally{produced: produced}
+	}
+
+	// Apply consumption, one input resource at a time in chain order
+	var deficits []ResourceType
+	seen := make(map[ResourceType]bool, len(flows))
+	for _, flow := range flows {
+		if seen[flow.input] {
+			continue
+		}
+		seen[flow.input] = true
+
+		inputRes := resources[flow.input]
+		consumed := inputRes.ConsumptionRate
+		if consumed > inputRes.Quantity {
+			// Cannot consume more than available - scale down the chains' output proportionally
+			ratio := inputRes.Quantity / consumed
+			consumed = inputRes.Quantity
+			for _, f := range flows {
+				if f.input == flow.input {
+					resources[f.output].Quantity -= f.outputRate
+					resources[f.output].Quantity += f.outputRate * ratio
+					tallies[f.output].produced += f.outputRate*ratio - f.outputRate
+				}
+			}
+			deficits = append(deficits, flow.input)
+		}
+		inputRes.Quantity -= consumed
+		tallies[flow.input].consumed = consumed
+	}
+
+	// Floor at 0
+	for rt, res := range resources {
+		if res.Quantity < 0 {
+			tallies[rt].produced -= res.Quantity
+			res.Quantity = 0
+		}
+	}
+	return deficits, tallies
+}
+
+// syncInfestationStatus copies the infestation state into the status and
+// tracks throttle changes.
+// Caller must hold s.mu.
+func (s *SimulationEngine) syncInfestationStatus() {
+	infState := s.infestation.GetState()
+	s.status.InfestationLevel = infState.Counter
+	s.status.IsPlagueHeart = infState.IsPlagueHeart
+	s.status.ThrottleMultiplier = infState.ThrottleMultiplier
+	s.trackThrottle(infState.ThrottleMultiplier)
+}
+
 // copyStatus creates a deep copy of the current simulation status.
 func (s *SimulationEngine) copyStatus() SimulationStatus {
 	resources := make(map[ResourceType]*ResourceState, len(s.status.Resources))
@@ -227,5 +670,61 @@ func (s *SimulationEngine) copyStatus() SimulationStatus {
 		InfestationLevel:     s.status.InfestationLevel,
 		IsPlagueHeart:        s.status.IsPlagueHeart,
 		ThrottleMultiplier:   s.status.ThrottleMultiplier,
+		IsPaused:             s.status.IsPaused,
+		SkippedTicks:         s.status.SkippedTicks,
+		WorldAgeMultiplier:   s.status.WorldAgeMultiplier,
+
+		AvgNPCMorale:                s.status.AvgNPCMorale,
+		AvgNPCEfficiency:            s.status.AvgNPCEfficiency,
+		AvgNPCTrauma:                s.status.AvgNPCTrauma,
+		NPCsBelowMoraleThreshold:    s.status.NPCsBelowMoraleThreshold,
+		NPCsAboveRebellionThreshold: s.status.NPCsAboveRebellionThreshold,
+	}
+}
+
+// updateNPCStats recomputes the aggregate NPC fields of the status, including
+// OverallRebellionProb as the mean NPC rebellion probability, from a snapshot
+// of the attached behavior engine. Caller must hold s.mu.
+func (s *SimulationEngine) updateNPCStats() {
+	npcs := s.behavior.SnapshotNPCs()
+
+	var sumMorale, sumEfficiency, sumTrauma, sumRebellion float64
+	belowMorale, aboveRebellion := 0, 0
+	for _, n := range npcs {
+		trauma := n.AvgTrauma
+		sumMorale += n.Morale
+		sumEfficiency += n.WorkEfficiency
+		sumTrauma += trauma
+
+		if n.Morale < s.config.LowMoraleThreshold {
+			belowMorale++
+		}
+		if s.rebellion != nil {
+			result := s.rebellion.EvaluateProbability(rebellion.NPCRebellionProfile{
+				NPCID:          n.NPCID,
+				AvgTrauma:      trauma,
+				WorkEfficiency: n.WorkEfficiency,
+				Morale:         n.Morale,
+				Role:           n.Role,
+			})
+			sumRebellion += result.Probability
+			if result.ThresholdExceeded {
+				aboveRebellion++
+			}
+		}
+	}
+
+	s.status.ActiveNPCs = len(npcs)
+	s.status.NPCsBelowMoraleThreshold = belowMorale
+	s.status.NPCsAboveRebellionThreshold = aboveRebellion
+	if len(npcs) == 0 {
+		s.status.AvgNPCMorale, s.status.AvgNPCEfficiency, s.status.AvgNPCTrauma = 0, 0, 0
+		s.status.OverallRebellionProb = 0
+		return
 	}
+	n := float64(len(npcs))
+	s.status.AvgNPCMorale = sumMorale / n
+	s.status.AvgNPCEfficiency = sumEfficiency / n
+	s.status.AvgNPCTrauma = sumTrauma / n
+	s.status.OverallRebellionProb = sumRebellion / n
 }
@@ -1,10 +1,17 @@
 package simulation
 
 import (
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/infestation"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
 	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewSimulationEngine(t *testing.T) {
@@ -109,8 +116,8 @@ func TestMultipleTicks(t *testing.T) {
 	assert.Equal(t, int64(5), status.TickCount)
 
 	mineralState := status.Resources[ResourceMineral]
-	// 5 ticks * 10.0 yield = 50.0 minerals (no consumption)
-	assert.InDelta(t, 50.0, mineralState.Quantity, 0.001, "Minerals should accumulate over ticks")
+	// 10.0 yield aged 0.1% per tick: 10 + 9.99 + 9.98001 + ... ≈ 49.90 minerals (no consumption)
+	assert.InDelta(t, 49.9001, mineralState.Quantity, 0.001, "Minerals should accumulate over ticks")
 }
 
 func TestTick_SimResourceProduction(t *testing.T) {
@@ -151,3 +158,355 @@ func TestGetStatus_ThreadSafety(t *testing.T) {
 	status := sim.GetStatus()
 	assert.True(t, status.TickCount >= 0, "TickCount should be non-negative")
 }
+
+func TestTransferResource(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	source := NewSimulationEngine(rebEngine)
+	target := NewSimulationEngine(rebEngine)
+
+	source.AddMine(100.0)
+	source.Tick() // source: 100 mineral
+
+	err := source.TransferResource(target, ResourceMineral, 40.0)
+	assert.NoError(t, err)
+
+	assert.InDelta(t, 60.0, source.GetStatus().Resources[ResourceMineral].Quantity, 0.001)
+	assert.InDelta(t, 40.0, target.GetStatus().Resources[ResourceMineral].Quantity, 0.001)
+}
+
+func TestTransferResource_Insufficient(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	source := NewSimulationEngine(rebEngine)
+	target := NewSimulationEngine(rebEngine)
+
+	source.AddMine(10.0)
+	source.Tick() // source: 10 mineral
+
+	err := source.TransferResource(target, ResourceMineral, 50.0)
+	assert.ErrorIs(t, err, ErrInsufficientResource)
+
+	// Neither side changes on failure
+	assert.InDelta(t, 10.0, source.GetStatus().Resources[ResourceMineral].Quantity, 0.001)
+	assert.InDelta(t, 0.0, target.GetStatus().Resources[ResourceMineral].Quantity, 0.001)
+}
+
+func TestTransferResource_InvalidArguments(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	source := NewSimulationEngine(rebEngine)
+	target := NewSimulationEngine(rebEngine)
+
+	assert.Error(t, source.TransferResource(source, ResourceSim, 1.0), "self-transfer should fail")
+	assert.Error(t, source.TransferResource(nil, ResourceSim, 1.0), "nil target should fail")
+	assert.Error(t, source.TransferResource(target, ResourceSim, 0), "zero amount should fail")
+	assert.Error(t, source.TransferResource(target, ResourceType("gold"), 1.0), "unknown resource should fail")
+}
+
+func TestTransferResource_ConcurrentWithTicks(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	a := NewSimulationEngine(rebEngine)
+	b := NewSimulationEngine(rebEngine)
+
+	// Seed both engines with sim so transfers in either direction succeed
+	for i := 0; i < 100; i++ {
+		a.Tick()
+		b.Tick()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(4)
+		go func() { defer wg.Done(); a.Tick() }()
+		go func() { defer wg.Done(); b.Tick() }()
+		go func() { defer wg.Done(); _ = a.TransferResource(b, ResourceSim, 1.0) }()
+		go func() { defer wg.Done(); _ = b.TransferResource(a, ResourceSim, 1.0) }()
+	}
+	wg.Wait()
+
+	// Each engine produced 150 sim (100 + 50 ticks); transfers only move it between them
+	total := a.GetStatus().Resources[ResourceSim].Quantity + b.GetStatus().Resources[ResourceSim].Quantity
+	assert.InDelta(t, 300.0, total, 0.001, "transfers should conserve total quantity")
+}
+
+func TestNewSimulationEngineWithConfig_RapidlumProduction(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+
+	defaultSim := NewSimulationEngine(rebEngine)
+	cfg := DefaultConfig()
+	cfg.RefineryRapidlumProductionBase = 10.0
+	customSim := NewSimulationEngineWithConfig(rebEngine, cfg)
+
+	for _, sim := range []*SimulationEngine{defaultSim, customSim} {
+		sim.AddMine(100.0)
+		sim.AddRefinery(1.0)
+	}
+
+	defaultRapidlum := defaultSim.Tick().Resources[ResourceRapidlum].Quantity
+	customRapidlum := customSim.Tick().Resources[ResourceRapidlum].Quantity
+
+	assert.InDelta(t, 5.0, defaultRapidlum, 0.001)
+	assert.InDelta(t, 10.0, customRapidlum, 0.001, "doubling the base should double rapidlum output")
+}
+
+func TestNewSimulationEngineWithConfig_SimProduction(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	cfg := DefaultConfig()
+	cfg.BaseSimProduction = 2.0
+	sim := NewSimulationEngineWithConfig(rebEngine, cfg)
+
+	var status SimulationStatus
+	for i := 0; i < 5; i++ {
+		status = sim.Tick()
+	}
+	assert.InDelta(t, 10.0, status.Resources[ResourceSim].Quantity, 0.001, "2.0/tick × 5 ticks")
+}
+
+func TestUpdateConfig(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	sim := NewSimulationEngine(rebEngine)
+
+	sim.Tick() // 1.0 sim at default rate
+
+	cfg := sim.GetConfig()
+	cfg.BaseSimProduction = 3.0
+	assert.NoError(t, sim.UpdateConfig(cfg))
+	assert.InDelta(t, 3.0, sim.GetConfig().BaseSimProduction, 0.001)
+
+	status := sim.Tick()
+	assert.InDelta(t, 4.0, status.Resources[ResourceSim].Quantity, 0.001, "new rate applies from the next tick")
+
+	cfg.RefineryMineralConsumptionBase = -1
+	assert.Error(t, sim.UpdateConfig(cfg), "negative rates should be rejected")
+}
+
+func TestTick_AggregateNPCStats(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	sim := NewSimulationEngine(rebEngine)
+	behaviorEngine := npc.NewBehaviorEngine()
+	sim.AttachBehaviorEngine(behaviorEngine)
+
+	// Registered NPCs start at morale 0.5 and trauma 0; shift each to known
+	// values, with trauma mirroring morale
+	morales := map[string]float64{"npc-1": 0.1, "npc-2": 0.2, "npc-3": 0.5, "npc-4": 0.8, "npc-5": 0.9}
+	for id, morale := range morales {
+		behaviorEngine.RegisterNPC(id)
+		assert.NoError(t, behaviorEngine.ApplyMoraleModifier(id, morale-0.5, "test"))
+		assert.NoError(t, behaviorEngine.ApplyTraumaModifier(id, 1-morale, "test"))
+	}
+
+	status := sim.Tick()
+
+	assert.Equal(t, 5, status.ActiveNPCs)
+	assert.InDelta(t, 0.5, status.AvgNPCMorale, 1e-9, "mean of 0.1, 0.2, 0.5, 0.8, 0.9")
+	assert.InDelta(t, 0.5, status.AvgNPCEfficiency, 1e-9)
+	assert.InDelta(t, 0.5, status.AvgNPCTrauma, 1e-9)
+	assert.Equal(t, 2, status.NPCsBelowMoraleThreshold, "0.1 and 0.2 are below 0.3")
+
+	// Expected count from the same profiles the engine builds
+	above := 0
+	for id, morale := range morales {
+		r := rebEngine.CalculateProbability(rebellion.NPCRebellionProfile{
+			NPCID: id, AvgTrauma: 1 - morale, WorkEfficiency: 0.5, Morale: morale,
+		})
+		if r.ThresholdExceeded {
+			above++
+		}
+	}
+	assert.Equal(t, above, status.NPCsAboveRebellionThreshold)
+	assert.Greater(t, status.NPCsAboveRebellionThreshold, 0)
+
+	assert.Equal(t, status.AvgNPCMorale, sim.GetStatus().AvgNPCMorale, "stats are part of the snapshot")
+}
+
+func TestTick_TraumaDecayLowersRebellionRisk(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	cfg := DefaultConfig()
+	cfg.NPCTraumaDecayRate = 0.1
+	sim := NewSimulationEngineWithConfig(rebEngine, cfg)
+	behaviorEngine := npc.NewBehaviorEngine()
+	sim.AttachBehaviorEngine(behaviorEngine)
+	behaviorEngine.RegisterNPC("npc-1")
+	require.NoError(t, behaviorEngine.ApplyTraumaModifier("npc-1", 0.9, "test"))
+
+	first := sim.Tick()
+	assert.InDelta(t, 0.8, first.AvgNPCTrauma, 1e-9, "stats use the NPC's decayed trauma")
+	for i := 0; i < 8; i++ {
+		sim.Tick()
+	}
+	last := sim.GetStatus()
+	assert.InDelta(t, 0.0, last.AvgNPCTrauma, 1e-9)
+	assert.Equal(t, 1, first.NPCsAboveRebellionThreshold)
+	assert.Equal(t, 0, last.NPCsAboveRebellionThreshold, "decayed trauma no longer pushes the NPC over the threshold")
+}
+
+func TestTick_SuppressedNPCsAreNotAboveRebellionThreshold(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	sim := NewSimulationEngine(rebEngine)
+	rebEngine.SetTickSource(sim.CurrentTick) // consulted while Tick holds the engine lock
+	behaviorEngine := npc.NewBehaviorEngine()
+	sim.AttachBehaviorEngine(behaviorEngine)
+
+	behaviorEngine.RegisterNPC("npc-1")
+	assert.NoError(t, behaviorEngine.ApplyMoraleModifier("npc-1", -0.5, "test"))
+	rebEngine.ApplySuppression(rebellion.NPCAction{NPCID: "npc-1", ActionType: rebellion.SuppressActionType, SuppressDuration: 2})
+
+	assert.Equal(t, 0, sim.Tick().NPCsAboveRebellionThreshold)
+	assert.Equal(t, int64(1), sim.CurrentTick())
+	sim.Tick()
+	assert.Equal(t, 1, sim.Tick().NPCsAboveRebellionThreshold, "suppression expired at tick 2")
+	assert.Equal(t, int64(3), sim.Fork().CurrentTick())
+}
+
+func TestTick_NoBehaviorEngineLeavesNPCStatsZero(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	status := sim.Tick()
+
+	assert.Equal(t, 0, status.ActiveNPCs)
+	assert.Zero(t, status.AvgNPCMorale)
+	assert.Zero(t, status.NPCsBelowMoraleThreshold)
+}
+
+func TestInfrastructureNotFoundError(t *testing.T) {
+	err := fmt.Errorf("remove: %w", &InfrastructureNotFoundError{Kind: "mine", ID: "mine-9"})
+
+	assert.ErrorIs(t, err, ErrInfrastructureNotFound)
+	assert.NotErrorIs(t, err, ErrInsufficientResource)
+
+	var infErr *InfrastructureNotFoundError
+	if assert.True(t, errors.As(err, &infErr)) {
+		assert.Equal(t, "mine", infErr.Kind)
+		assert.Equal(t, "mine-9", infErr.ID)
+	}
+	assert.Equal(t, `remove: mine "mine-9" not found`, err.Error())
+}
+
+func TestTick_NPCRecovery(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.NPCMoraleRecoveryRate = 0.05
+	sim := NewSimulationEngineWithConfig(rebellion.NewEngine(rebellion.DefaultConfig()), cfg)
+	behaviorEngine := npc.NewBehaviorEngine()
+	sim.AttachBehaviorEngine(behaviorEngine)
+	behaviorEngine.RegisterNPC("npc-1")
+
+	status := sim.Tick()
+	assert.InDelta(t, 0.55, status.AvgNPCMorale, 1e-9)
+
+	cfg.NPCTraumaDecayRate = -0.1
+	assert.Error(t, sim.UpdateConfig(cfg), "negative recovery rates should be rejected")
+}
+
+func TestTick_PlagueHeartActivationLowersNPCMorale(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	behavior := npc.NewBehaviorEngine()
+	behavior.RegisterNPC("npc-1")
+	behavior.RegisterNPC("npc-2")
+	sim.AttachBehaviorEngine(behavior)
+	inf := sim.GetInfestationEngine()
+	if err := inf.ForceSetCounter(inf.GetConfig().PlagueHeartThreshold - 1); err != nil {
+		t.Fatal(err)
+	}
+
+	result := sim.tickInfestation(0.9, 0.9, 1)
+	assert.True(t, result.PlagueHeartChanged && result.PlagueHeartActive)
+	sim.tickInfestation(0.9, 0.9, 2) // already active: no further penalty
+
+	for _, n := range behavior.SnapshotNPCs() {
+		assert.InDelta(t, 0.5-PlagueHeartMoralePenalty, n.Morale, 1e-9)
+	}
+}
+
+func TestTick_SiegeModeFromNPCRebellion(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	behavior := npc.NewBehaviorEngine()
+	behavior.RegisterNPC("npc-1")
+	sim.AttachBehaviorEngine(behavior)
+	require.NoError(t, behavior.ApplyMoraleModifier("npc-1", -1, "test"))
+	require.NoError(t, behavior.ApplyWorkEfficiencyModifier("npc-1", -1, "test"))
+	require.NoError(t, behavior.ApplyTraumaModifier("npc-1", 1, "test"))
+	inf := sim.GetInfestationEngine()
+	cfg := inf.GetConfig()
+
+	// The first tick measures the NPC; the infestation reads it on the next
+	first := sim.Tick()
+	require.GreaterOrEqual(t, first.OverallRebellionProb, cfg.SiegeModeThreshold)
+	assert.False(t, inf.GetState().SiegeModeActive)
+
+	counter := inf.GetState().Counter
+	sim.Tick()
+
+	assert.True(t, inf.GetState().SiegeModeActive)
+	assert.InDelta(t, counter+cfg.AccumulationRate*cfg.SiegeModeMultiplier, inf.GetState().Counter, 1e-9)
+}
+
+func TestTick_NPCStatsLeaveRebellionStatsAndCacheUntouched(t *testing.T) {
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+	rebEngine.EnableProbabilityCache(time.Minute)
+	sim := NewSimulationEngine(rebEngine)
+	behavior := npc.NewBehaviorEngine()
+	behavior.RegisterNPC("npc-1")
+	sim.AttachBehaviorEngine(behavior)
+	before := rebEngine.GetEngineStats()
+
+	sim.Tick()
+	require.NoError(t, behavior.ApplyMoraleModifier("npc-1", -0.4, "test"))
+	status := sim.Tick()
+
+	assert.Equal(t, before, rebEngine.GetEngineStats(), "ticks must not count as rebellion evaluations")
+	profile, _ := behavior.RebellionProfile("npc-1")
+	want := rebellion.NewEngine(rebellion.DefaultConfig()).CalculateProbability(profile).Probability
+	assert.InDelta(t, want, status.OverallRebellionProb, 1e-9)
+	assert.InDelta(t, want, rebEngine.CalculateProbability(profile).Probability, 1e-9, "nothing stale is cached for the NPC")
+}
+
+// publisherFunc adapts a function to infestation.MessageBusPublisher.
+type publisherFunc func(topic string, payload []byte) error
+
+func (f publisherFunc) Publish(topic string, payload []byte) error { return f(topic, payload) }
+
+func TestTick_NotifiesOutsideTheEngineLock(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	cfg := sim.GetConfig()
+	cfg.NPCMoraleRecoveryRate = 0.06
+	require.NoError(t, sim.UpdateConfig(cfg))
+	behavior := npc.NewBehaviorEngine()
+	behavior.RegisterNPC("npc-1")
+	require.NoError(t, behavior.ApplyMoraleModifier("npc-1", -0.05, "test")) // 0.45: discouraged
+	require.NoError(t, behavior.ApplyWorkEfficiencyModifier("npc-1", -1, "test"))
+	require.NoError(t, behavior.ApplyTraumaModifier("npc-1", 1, "test"))
+	sim.AttachBehaviorEngine(behavior)
+	inf := sim.GetInfestationEngine()
+	require.NoError(t, inf.ForceSetCounter(inf.GetConfig().PlagueHeartThreshold-1))
+
+	// Each callback reads the simulation, which deadlocks if Tick still holds its lock
+	var mu sync.Mutex
+	var observed []string
+	observe := func(what string) {
+		sim.GetStatus()
+		mu.Lock()
+		observed = append(observed, what)
+		mu.Unlock()
+	}
+	behavior.SetEmotionalStateListener(func(tr npc.EmotionalTransition) { observe(string(tr.To)) })
+	inf.SetPublisher(publisherFunc(func(topic string, _ []byte) error {
+		observe(topic)
+		return nil
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3 && !sim.GetStatus().IsPlagueHeart; i++ {
+			sim.Tick()
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Tick deadlocked on a callback that reads the simulation")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Contains(t, observed, string(npc.EmotionalStateNeutral), "recovery lifted the NPC out of discouraged")
+	assert.Contains(t, observed, infestation.TopicPlagueHeartActivated)
+	assert.Contains(t, observed, string(npc.EmotionalStateDiscouraged), "the Plague Heart penalty lowered morale")
+}
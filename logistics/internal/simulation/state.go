@@ -0,0 +1,292 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/infestation"
+)
+
+// SimulationSnapshot is the saved state of a simulation, written by
+// ExportState and restored by ImportState. It serializes to JSON with
+// snake_case keys.
+type SimulationSnapshot struct {
+	TickCount            int64                             `json:"tick_count"`
+	NextID               int                               `json:"next_id"` // Numeric suffix of the next mine/refinery ID
+	WorldAgeMultiplier   float64                           `json:"world_age_multiplier"`
+	OverallRebellionProb float64                           `json:"overall_rebellion_prob"`
+	MineCount            int                               `json:"mine_count"`
+	RefineryCount        int                               `json:"refinery_count"`
+	Mines                []MineSnapshot                    `json:"mines"`
+	Refineries           []RefinerySnapshot                `json:"refineries"`
+	Resources            map[ResourceType]ResourceSnapshot `json:"resources"`
+	Infestation          *InfestationSnapshot              `json:"infestation,omitempty"` // nil without an infestation engine
+}
+
+// MineSnapshot is the saved state of a mine.
+type MineSnapshot struct {
+	MineID         string  `json:"mine_id"`
+	YieldRate      float64 `json:"yield_rate"`
+	DisruptedTicks int     `json:"disrupted_ticks"`
+	AutoScaled     bool    `json:"auto_scaled"`
+	MaxReserve     float64 `json:"max_reserve"`
+	CurrentReserve float64 `json:"current_reserve"`
+}
+
+// RefinerySnapshot is the saved state of a refinery or other facility.
+type RefinerySnapshot struct {
+	RefineryID     string  `json:"refinery_id"`
+	FacilityType   string  `json:"facility_type"`
+	Efficiency     float64 `json:"efficiency"`
+	DisruptedTicks int     `json:"disrupted_ticks"`
+}
+
+// ResourceSnapshot is the saved state of a resource.
+type ResourceSnapshot struct {
+	Quantity        float64 `json:"quantity"`
+	ProductionRate  float64 `json:"production_rate"`
+	ConsumptionRate float64 `json:"consumption_rate"`
+}
+
+// InfestationSnapshot is the saved state of the infestation engine.
+type InfestationSnapshot struct {
+	Counter                float64 `json:"counter"`
+	IsPlagueHeart          bool    `json:"is_plague_heart"`
+	ThrottleMultiplier     float64 `json:"throttle_multiplier"`
+	LastTick               int64   `json:"last_tick"`
+	PlagueHeartActivatedAt int64   `json:"plague_heart_activated_at"`
+	EscalationLevel        int     `json:"escalation_level"`
+	SiegeModeActive        bool    `json:"siege_mode_active"`
+}
+
+// ExportState returns a snapshot of the engine's mines, refineries,
+// resources, tick count, world age, infestation state and overall rebellion
+// probability for ImportState. The error is reserved for state that cannot
+// be captured; with the current fields it is always nil.
+func (s *SimulationEngine) ExportState() (SimulationSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := SimulationSnapshot{
+		TickCount:            s.status.TickCount,
+		NextID:               s.nextID,
+		WorldAgeMultiplier:   s.status.WorldAgeMultiplier,
+		OverallRebellionProb: s.status.OverallRebellionProb,
+		MineCount:            len(s.mines),
+		RefineryCount:        len(s.refineries),
+		Mines:                make([]MineSnapshot, len(s.mines)),
+		Refineries:           make([]RefinerySnapshot, len(s.refineries)),
+		Resources:            make(map[ResourceType]ResourceSnapshot, len(s.status.Resources)),
+	}
+	for i, m := range s.mines {
+		snap.Mines[i] = MineSnapshot{
+			MineID:         m.MineID,
+			YieldRate:      m.YieldRate,
+			DisruptedTicks: m.DisruptedTicks,
+			AutoScaled:     m.AutoScaled,
+			MaxReserve:     m.MaxReserve,
+			CurrentReserve: m.CurrentReserve,
+		}
+	}
+	for i, r := range s.refineries {
+		snap.Refineries[i] = RefinerySnapshot{
+			RefineryID:     r.RefineryID,
+			FacilityType:   r.FacilityType,
+			Efficiency:     r.Efficiency,
+			DisruptedTicks: r.DisruptedTicks,
+		}
+	}
+	for rt, res := range s.status.Resources {
+		snap.Resources[rt] = ResourceSnapshot{
+			Quantity:        res.Quantity,
+			ProductionRate:  res.ProductionRate,
+			ConsumptionRate: res.ConsumptionRate,
+		}
+	}
+	if s.infestation != nil {
+		st := s.infestation.GetState()
+		snap.Infestation = &InfestationSnapshot{
+			Counter:                st.Counter,
+			IsPlagueHeart:          st.IsPlagueHeart,
+			ThrottleMultiplier:     st.ThrottleMultiplier,
+			LastTick:               st.LastTick,
+			PlagueHeartActivatedAt: st.PlagueHeartActivatedAt,
+			EscalationLevel:        st.EscalationLevel,
+			SiegeModeActive:        st.SiegeModeActive,
+		}
+	}
+	return snap, nil
+}
+
+// ImportState validates snap and replaces the engine's mines, refineries,
+// resources, tick count, world age, infestation state and overall rebellion
+// probability with it under a single lock. Resources missing from snap are
+// reset to zero. NPC assignments to facilities that no longer exist are
+// dropped; config, NPC statistics, histories and callbacks are kept. The
+// import is not recorded in an event-sourced log.
+// Returns an error, leaving the engine unchanged, if snap is invalid.
+func (s *SimulationEngine) ImportState(snap SimulationSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.validateSnapshot(snap); err != nil {
+		return fmt.Errorf("invalid simulation snapshot: %w", err)
+	}
+
+	nextID := snap.NextID
+	s.mines = make([]Mine, len(snap.Mines))
+	for i, m := range snap.Mines {
+		s.mines[i] = Mine{
+			MineID:         m.MineID,
+			YieldRate:      m.YieldRate,
+			DisruptedTicks: m.DisruptedTicks,
+			AutoScaled:     m.AutoScaled,
+			MaxReserve:     m.MaxReserve,
+			CurrentReserve: m.CurrentReserve,
+		}
+		nextID = max(nextID, idSuffix(m.MineID)+1)
+	}
+	s.refineries = make([]Refinery, len(snap.Refineries))
+	for i, r := range snap.Refineries {
+		facilityType := r.FacilityType
+		if facilityType == "" {
+			facilityType = RefineryFacility
+		}
+		s.refineries[i] = Refinery{
+			RefineryID:     r.RefineryID,
+			FacilityType:   facilityType,
+			Efficiency:     r.Efficiency,
+			DisruptedTicks: r.DisruptedTicks,
+		}
+		nextID = max(nextID, idSuffix(r.RefineryID)+1)
+	}
+	s.nextID = nextID
+	s.pruneAssignments()
+
+	for rt, res := range s.status.Resources {
+		saved := snap.Resources[rt]
+		res.Quantity = saved.Quantity
+		res.ProductionRate = saved.ProductionRate
+		res.ConsumptionRate = saved.ConsumptionRate
+	}
+	s.status.Mines = len(s.mines)
+	s.status.Refineries = len(s.refineries)
+	s.status.TickCount = snap.TickCount
+	s.tickCount.Store(s.status.TickCount)
+	s.status.OverallRebellionProb = snap.OverallRebellionProb
+	s.status.WorldAgeMultiplier = snap.WorldAgeMultiplier
+
+	if s.infestation != nil && snap.Infestation != nil {
+		inf := snap.Infestation
+		s.infestation.SetState(infestation.InfestationState{
+			Counter:                inf.Counter,
+			IsPlagueHeart:          inf.IsPlagueHeart,
+			ThrottleMultiplier:     inf.ThrottleMultiplier,
+			LastTick:               inf.LastTick,
+			PlagueHeartActivatedAt: inf.PlagueHeartActivatedAt,
+			EscalationLevel:        inf.EscalationLevel,
+			SiegeModeActive:        inf.SiegeModeActive,
+		})
+		s.syncInfestationStatus()
+	}
+	return nil
+}
+
+// validateSnapshot returns an error describing the first problem with snap:
+// counts that disagree with the mine and refinery lists, missing or
+// duplicate IDs, out-of-range facility, resource or infestation values, or
+// resources the engine does not track. Caller must hold s.mu.
+func (s *SimulationEngine) validateSnapshot(snap SimulationSnapshot) error {
+	switch {
+	case snap.TickCount < 0:
+		return fmt.Errorf("tick_count must be non-negative, got %d", snap.TickCount)
+	case snap.NextID < 1:
+		return fmt.Errorf("next_id must be at least 1, got %d", snap.NextID)
+	case snap.WorldAgeMultiplier <= 0 || snap.WorldAgeMultiplier > 1:
+		return fmt.Errorf("world_age_multiplier must be in (0, 1], got %v", snap.WorldAgeMultiplier)
+	case snap.OverallRebellionProb < 0 || snap.OverallRebellionProb > 1:
+		return fmt.Errorf("overall_rebellion_prob must be in [0, 1], got %v", snap.OverallRebellionProb)
+	case snap.MineCount != len(snap.Mines):
+		return fmt.Errorf("mine_count is %d but %d mines are listed", snap.MineCount, len(snap.Mines))
+	case snap.RefineryCount != len(snap.Refineries):
+		return fmt.Errorf("refinery_count is %d but %d refineries are listed", snap.RefineryCount, len(snap.Refineries))
+	}
+
+	seen := make(map[string]bool, len(snap.Mines)+len(snap.Refineries))
+	checkID := func(kind, id string) error {
+		if id == "" {
+			return fmt.Errorf("%s ID is required", kind)
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate facility ID %q", id)
+		}
+		seen[id] = true
+		return nil
+	}
+	for _, m := range snap.Mines {
+		if err := checkID("mine", m.MineID); err != nil {
+			return err
+		}
+		switch {
+		case m.YieldRate < 0:
+			return fmt.Errorf("mine %q: yield_rate must be non-negative, got %v", m.MineID, m.YieldRate)
+		case m.DisruptedTicks < 0:
+			return fmt.Errorf("mine %q: disrupted_ticks must be non-negative, got %d", m.MineID, m.DisruptedTicks)
+		case m.MaxReserve < 0 || m.CurrentReserve < 0:
+			return fmt.Errorf("mine %q: reserves must be non-negative", m.MineID)
+		case m.CurrentReserve > m.MaxReserve:
+			return fmt.Errorf("mine %q: current_reserve %v exceeds max_reserve %v", m.MineID, m.CurrentReserve, m.MaxReserve)
+		}
+	}
+	for _, r := range snap.Refineries {
+		if err := checkID("refinery", r.RefineryID); err != nil {
+			return err
+		}
+		switch {
+		case r.Efficiency < 0 || r.Efficiency > 1:
+			return fmt.Errorf("refinery %q: efficiency must be in [0, 1], got %v", r.RefineryID, r.Efficiency)
+		case r.DisruptedTicks < 0:
+			return fmt.Errorf("refinery %q: disrupted_ticks must be non-negative, got %d", r.RefineryID, r.DisruptedTicks)
+		}
+	}
+
+	for rt, res := range snap.Resources {
+		if _, ok := s.status.Resources[rt]; !ok {
+			return fmt.Errorf("unknown resource type %q", rt)
+		}
+		if res.Quantity < 0 {
+			return fmt.Errorf("resource %q: quantity must be non-negative, got %v", rt, res.Quantity)
+		}
+		if res.ProductionRate < 0 || res.ConsumptionRate < 0 {
+			return fmt.Errorf("resource %q: rates must be non-negative", rt)
+		}
+	}
+
+	if inf := snap.Infestation; inf != nil {
+		switch {
+		case inf.Counter < 0 || inf.Counter > 100:
+			return fmt.Errorf("infestation counter must be in [0, 100], got %v", inf.Counter)
+		case inf.ThrottleMultiplier < 0 || inf.ThrottleMultiplier > 1:
+			return fmt.Errorf("infestation throttle_multiplier must be in [0, 1], got %v", inf.ThrottleMultiplier)
+		case inf.EscalationLevel < 0:
+			return errors.New("infestation escalation_level must be non-negative")
+		}
+	}
+	return nil
+}
+
+// idSuffix returns the number after the last '-' of a generated facility ID
+// such as "mine-3", or 0 if there is none.
+func idSuffix(id string) int {
+	i := strings.LastIndexByte(id, '-')
+	if i < 0 {
+		return 0
+	}
+	n, err := strconv.Atoi(id[i+1:])
+	if err != nil {
+		return 0
+	}
+	return n
+}
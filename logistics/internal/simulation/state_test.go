@@ -0,0 +1,98 @@
+package simulation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportState_RoundTrip(t *testing.T) {
+	src := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	src.AddMine(12.0)
+	mineID := src.AddMineWithReserve(5.0, 40)
+	src.AddRefinery(0.8)
+	src.AddFacility("smelter", 0.5)
+	for i := 0; i < 7; i++ {
+		src.Tick()
+	}
+	src.GetInfestationEngine().ForceActivatePlagueHeart()
+	src.Tick()
+	want := src.GetStatus()
+	require.True(t, want.IsPlagueHeart)
+
+	snap, err := src.ExportState()
+	require.NoError(t, err)
+	data, err := json.Marshal(snap)
+	require.NoError(t, err)
+	var decoded SimulationSnapshot
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	dst := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	dst.AddMine(99.0) // replaced by the import
+	require.NoError(t, dst.ImportState(decoded))
+
+	got := dst.GetStatus()
+	assert.Equal(t, want.TickCount, got.TickCount)
+	assert.Equal(t, want.Mines, got.Mines)
+	assert.Equal(t, want.Refineries, got.Refineries)
+	assert.Equal(t, want.IsPlagueHeart, got.IsPlagueHeart)
+	assert.Equal(t, want.InfestationLevel, got.InfestationLevel)
+	assert.Equal(t, want.ThrottleMultiplier, got.ThrottleMultiplier)
+	assert.Equal(t, want.OverallRebellionProb, got.OverallRebellionProb)
+	for rt, res := range want.Resources {
+		assert.Equal(t, *res, *got.Resources[rt], rt)
+	}
+	assert.Equal(t, src.GetMines(), dst.GetMines())
+	assert.Equal(t, src.GetRefineries(), dst.GetRefineries())
+	assert.Equal(t, src.GetWorldAge(), dst.GetWorldAge())
+	assert.Equal(t, want.TickCount, dst.CurrentTick())
+
+	srcReserve, err := src.GetMineReserve(mineID)
+	require.NoError(t, err)
+	dstReserve, err := dst.GetMineReserve(mineID)
+	require.NoError(t, err)
+	assert.Equal(t, srcReserve, dstReserve)
+
+	// Both engines continue identically, and new IDs do not collide
+	assert.Equal(t, src.Tick().Resources[ResourceMineral].Quantity, dst.Tick().Resources[ResourceMineral].Quantity)
+	assert.Equal(t, src.AddMine(1), dst.AddMine(1))
+}
+
+func TestImportState_RejectsInvalidSnapshots(t *testing.T) {
+	src := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	src.AddMine(10.0)
+	src.AddRefinery(0.8)
+	src.Tick()
+
+	tests := []struct {
+		name   string
+		mutate func(*SimulationSnapshot)
+	}{
+		{"negative quantity", func(s *SimulationSnapshot) {
+			s.Resources[ResourceMineral] = ResourceSnapshot{Quantity: -1}
+		}},
+		{"mine count mismatch", func(s *SimulationSnapshot) { s.MineCount = 3 }},
+		{"refinery count mismatch", func(s *SimulationSnapshot) { s.RefineryCount = 0 }},
+		{"duplicate ID", func(s *SimulationSnapshot) { s.Refineries[0].RefineryID = s.Mines[0].MineID }},
+		{"unknown resource", func(s *SimulationSnapshot) { s.Resources["unobtainium"] = ResourceSnapshot{} }},
+		{"negative tick count", func(s *SimulationSnapshot) { s.TickCount = -1 }},
+		{"reserve above max", func(s *SimulationSnapshot) { s.Mines[0].CurrentReserve = 5 }},
+		{"infestation out of range", func(s *SimulationSnapshot) { s.Infestation.Counter = 150 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap, err := src.ExportState()
+			require.NoError(t, err)
+			tt.mutate(&snap)
+
+			dst := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+			dst.AddMine(3.0)
+			before := dst.GetStatus()
+			require.Error(t, dst.ImportState(snap))
+			assert.Equal(t, before, dst.GetStatus(), "a rejected import leaves the engine unchanged")
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package simulation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/infestation"
+)
+
+// DefaultTelemetryTickInterval is how many ticks pass between tick
+// completion telemetry events unless changed with SetTelemetryTickInterval.
+const DefaultTelemetryTickInterval = 10
+
+// ResourceMilestones are the quantities whose first crossing by a resource
+// emits a milestone telemetry event, in ascending order.
+var ResourceMilestones = []float64{100, 500, 1000}
+
+// TelemetryKind identifies what a SimulationTelemetryEvent reports.
+type TelemetryKind string
+
+const (
+	TelemetryTickCompleted     TelemetryKind = "tick_completed"
+	TelemetryPlagueHeart       TelemetryKind = "plague_heart"
+	TelemetryResourceMilestone TelemetryKind = "resource_milestone"
+	TelemetryThrottleChanged   TelemetryKind = "throttle_changed"
+	TelemetryMineDepleted      TelemetryKind = "mine_depleted"
+)
+
+// SimulationTelemetryEvent is a significant change observed during a Tick.
+type SimulationTelemetryEvent struct {
+	Kind      TelemetryKind
+	Attribute string // Value that changed, e.g. "tick_count" or "mineral_quantity"
+	OldValue  float64
+	NewValue  float64
+	Cause     string
+	TickCount int64                    // Tick count after the tick
+	Resources map[ResourceType]float64 // Resource quantities after the tick
+}
+
+// TelemetrySink receives the simulation's telemetry events. Events are
+// delivered after the engine lock is released.
+type TelemetrySink interface {
+	EmitSimulationTelemetry(SimulationTelemetryEvent)
+}
+
+// SetTelemetryService registers svc to receive tick completion, Plague
+// Heart, resource milestone, throttle change and mine depletion events from
+// Tick, replacing any previous sink. A nil svc stops emission.
+func (s *SimulationEngine) SetTelemetryService(svc TelemetrySink) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.telemetry = svc
+}
+
+// SetTelemetryTickInterval sets how many ticks pass between tick completion
+// events; 0 stops them. Returns an error if n is negative.
+func (s *SimulationEngine) SetTelemetryTickInterval(n int) error {
+	if n < 0 {
+		return fmt.Errorf("telemetry tick interval must be non-negative, got %d", n)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.telemetryTickInterval = n
+	return nil
+}
+
+// telemetryEvents marks newly crossed resource milestones as reached and
+// returns the telemetry events for the tick just completed, given its
+// infestation result, the throttle multiplier from before it and the mines
+// it depleted (see extractOre). Returns nil
+// without a telemetry sink. Caller must hold s.mu.
+func (s *SimulationEngine) telemetryEvents(infResult infestation.InfestationTickResult, oldThrottle float64, depleted []Mine) []SimulationTelemetryEvent {
+	crossed := s.crossMilestones()
+	if s.telemetry == nil {
+		return nil
+	}
+
+	quantities := make(map[ResourceType]float64, len(s.status.Resources))
+	for rt, res := range s.status.Resources {
+		quantities[rt] = res.Quantity
+	}
+	tick := s.status.TickCount
+	var events []SimulationTelemetryEvent
+	emit := func(kind TelemetryKind, attribute string, oldValue, newValue float64, cause string) {
+		events = append(events, SimulationTelemetryEvent{
+			Kind:      kind,
+			Attribute: attribute,
+			OldValue:  oldValue,
+			NewValue:  newValue,
+			Cause:     cause,
+			TickCount: tick,
+			Resources: quantities,
+		})
+	}
+
+	if n := int64(s.telemetryTickInterval); n > 0 && tick%n == 0 {
+		emit(TelemetryTickCompleted, "tick_count", float64(tick-n), float64(tick), fmt.Sprintf("completed tick %d", tick))
+	}
+	if infResult.PlagueHeartChanged {
+		if infResult.PlagueHeartActive {
+			emit(TelemetryPlagueHeart, "plague_heart_active", 0, 1, fmt.Sprintf("Plague Heart activated at infestation %.1f", s.status.InfestationLevel))
+		} else {
+			emit(TelemetryPlagueHeart, "plague_heart_active", 1, 0, fmt.Sprintf("Plague Heart cleared at infestation %.1f", s.status.InfestationLevel))
+		}
+	}
+
+	for _, m := range crossed {
+		emit(TelemetryResourceMilestone, string(m.resource)+"_quantity", m.milestone, quantities[m.resource], fmt.Sprintf("%s reached %.0f for the first time", m.resource, m.milestone))
+	}
+
+	if s.status.ThrottleMultiplier != oldThrottle {
+		emit(TelemetryThrottleChanged, "throttle_multiplier", oldThrottle, s.status.ThrottleMultiplier, fmt.Sprintf("production throttle changed from %.2f to %.2f", oldThrottle, s.status.ThrottleMultiplier))
+	}
+
+	for _, m := range depleted {
+		emit(TelemetryMineDepleted, m.MineID+"_reserve", m.CurrentReserve, 0, fmt.Sprintf("mine %s exhausted its ore reserve of %.0f", m.MineID, m.MaxReserve))
+	}
+	return events
+}
+
+// crossedMilestone is a resource milestone reached for the first time.
+type crossedMilestone struct {
+	resource  ResourceType
+	milestone float64
+}
+
+// crossMilestones marks every resource milestone that the current
+// quantities reach for the first time as reached and returns them, ordered
+// by resource then milestone. Caller must hold s.mu.
+func (s *SimulationEngine) crossMilestones() []crossedMilestone {
+	resources := make([]ResourceType, 0, len(s.status.Resources))
+	for rt := range s.status.Resources {
+		resources = append(resources, rt)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i] < resources[j] })
+
+	var crossed []crossedMilestone
+	for _, rt := range resources {
+		quantity := s.status.Resources[rt].Quantity
+		for next := s.milestonesReached[rt]; next < len(ResourceMilestones) && quantity >= ResourceMilestones[next]; next++ {
+			crossed = append(crossed, crossedMilestone{resource: rt, milestone: ResourceMilestones[next]})
+			s.milestonesReached[rt] = next + 1
+		}
+	}
+	return crossed
+}
@@ -0,0 +1,95 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSink collects the telemetry events it receives.
+type recordingSink struct {
+	events []SimulationTelemetryEvent
+}
+
+func (r *recordingSink) EmitSimulationTelemetry(ev SimulationTelemetryEvent) {
+	r.events = append(r.events, ev)
+}
+
+func (r *recordingSink) byKind(kind TelemetryKind) []SimulationTelemetryEvent {
+	var out []SimulationTelemetryEvent
+	for _, ev := range r.events {
+		if ev.Kind == kind {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+func TestTelemetry_HundredTicks(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sink := &recordingSink{}
+	sim.SetTelemetryService(sink)
+	sim.AddMine(10)
+	sim.GetInfestationEngine().ForceActivatePlagueHeart()
+
+	for i := 0; i < 100; i++ {
+		sim.Tick()
+	}
+	require.False(t, sim.GetStatus().IsPlagueHeart, "counter decays below ClearThreshold without rebellion")
+
+	ticks := sink.byKind(TelemetryTickCompleted)
+	require.Len(t, ticks, 100/DefaultTelemetryTickInterval)
+	assert.Equal(t, int64(10), ticks[0].TickCount)
+	assert.Equal(t, "tick_count", ticks[0].Attribute)
+	assert.Contains(t, ticks[0].Resources, ResourceMineral)
+
+	plague := sink.byKind(TelemetryPlagueHeart)
+	require.Len(t, plague, 1)
+	assert.Equal(t, 1.0, plague[0].OldValue)
+	assert.Equal(t, 0.0, plague[0].NewValue)
+
+	milestones := sink.byKind(TelemetryResourceMilestone)
+	require.NotEmpty(t, milestones)
+	assert.Equal(t, "mineral_quantity", milestones[0].Attribute)
+	assert.Equal(t, 100.0, milestones[0].OldValue)
+	assert.GreaterOrEqual(t, milestones[0].Resources[ResourceMineral], 100.0)
+
+	throttle := sink.byKind(TelemetryThrottleChanged)
+	require.NotEmpty(t, throttle)
+	last := throttle[len(throttle)-1]
+	assert.Equal(t, plague[0].TickCount, last.TickCount)
+	assert.Greater(t, last.NewValue, last.OldValue)
+}
+
+func TestTelemetry_MilestonesFireOnce(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sink := &recordingSink{}
+	sim.SetTelemetryService(sink)
+	require.NoError(t, sim.SetTelemetryTickInterval(0))
+	disableWorldAging(t, sim)
+
+	require.NoError(t, sim.AddResource(ResourceRapidlum, 600))
+	sim.Tick()
+	sim.Tick()
+
+	milestones := sink.byKind(TelemetryResourceMilestone)
+	require.Len(t, milestones, 2, "a single tick can cross several milestones; staying above them emits nothing")
+	assert.Equal(t, 100.0, milestones[0].OldValue)
+	assert.Equal(t, 500.0, milestones[1].OldValue)
+	assert.Empty(t, sink.byKind(TelemetryTickCompleted))
+}
+
+func TestTelemetry_NoSinkEmitsNothing(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	require.NoError(t, sim.AddResource(ResourceSim, 200))
+	sim.Tick()
+
+	sink := &recordingSink{}
+	sim.SetTelemetryService(sink)
+	sim.Tick()
+
+	assert.Empty(t, sink.byKind(TelemetryResourceMilestone), "milestones crossed before registration are not replayed")
+	assert.Error(t, sim.SetTelemetryTickInterval(-1))
+}
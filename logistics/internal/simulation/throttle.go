@@ -0,0 +1,64 @@
+package simulation
+
+// ThrottleRecord is a period during which production ran under an
+// infestation throttle below 1.0.
+type ThrottleRecord struct {
+	StartTick  int64   // First tick produced under Multiplier
+	EndTick    int64   // First tick no longer produced under Multiplier (-1 while open)
+	Multiplier float64 // Production multiplier in effect
+}
+
+// GetThrottleHistory returns every throttled period, oldest first. A new
+// record starts whenever the throttle multiplier changes to a value other
+// than 1.0 and ends when it changes again; unthrottled periods are not
+// recorded. The current period, if throttled, has EndTick -1.
+func (s *SimulationEngine) GetThrottleHistory() []ThrottleRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]ThrottleRecord(nil), s.throttleHistory...)
+}
+
+// TotalProductionLostToThrottle estimates the production lost to throttling
+// as the sum over all throttled periods of
+// (1 - Multiplier) × production per tick × period length in ticks, where
+// production per tick is the current unthrottled rate summed across
+// resources. An open period counts the ticks produced so far.
+func (s *SimulationEngine) TotalProductionLostToThrottle() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	perTick := s.unthrottledProduction()
+	lost := 0.0
+	for _, rec := range s.throttleHistory {
+		end := rec.EndTick
+		if end < 0 {
+			end = s.status.TickCount + 1
+		}
+		lost += (1 - rec.Multiplier) * perTick * float64(end-rec.StartTick)
+	}
+	return lost
+}
+
+// trackThrottle records a change of the throttle multiplier: if multiplier
+// differs from the open record's (1.0 if none), the open record ends and,
+// unless multiplier is 1.0, a new one starts. Both take effect from the tick
+// about to be produced. Caller must hold s.mu.
+func (s *SimulationEngine) trackThrottle(multiplier float64) {
+	current := 1.0
+	n := len(s.throttleHistory)
+	open := n > 0 && s.throttleHistory[n-1].EndTick < 0
+	if open {
+		current = s.throttleHistory[n-1].Multiplier
+	}
+	if multiplier == current {
+		return
+	}
+
+	tick := s.status.TickCount + 1
+	if open {
+		s.throttleHistory[n-1].EndTick = tick
+	}
+	if multiplier != 1.0 {
+		s.throttleHistory = append(s.throttleHistory, ThrottleRecord{StartTick: tick, EndTick: -1, Multiplier: multiplier})
+	}
+}
@@ -0,0 +1,78 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/infestation"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottleHistory_PlagueHeartPeriod(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	sim.Tick()
+	assert.Empty(t, sim.GetThrottleHistory(), "unthrottled ticks are not recorded")
+
+	sim.GetInfestationEngine().ForceActivatePlagueHeart()
+	for i := 0; i < 5; i++ {
+		sim.Tick()
+	}
+	assert.Equal(t, []ThrottleRecord{{StartTick: 2, EndTick: -1, Multiplier: 0.5}}, sim.GetThrottleHistory())
+
+	require.NoError(t, sim.GetInfestationEngine().Cleanse())
+	sim.Tick()
+	sim.Tick()
+
+	assert.Equal(t, []ThrottleRecord{{StartTick: 2, EndTick: 7, Multiplier: 0.5}}, sim.GetThrottleHistory())
+	// Base sim production of 1/tick, halved for 5 ticks
+	assert.InDelta(t, 2.5, sim.TotalProductionLostToThrottle(), 1e-9)
+}
+
+func TestThrottleHistory_EscalationStartsNewRecord(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	inf := sim.GetInfestationEngine()
+	cfg := inf.GetConfig()
+	cfg.EscalationStages = []infestation.EscalationStage{{DurationTicks: 2, AdditionalThrottleReduction: 0.2}}
+	require.NoError(t, inf.UpdateConfig(cfg))
+
+	inf.ForceActivatePlagueHeart()
+	sim.Tick()
+	sim.Tick()
+
+	history := sim.GetThrottleHistory()
+	require.Len(t, history, 2)
+	assert.Equal(t, ThrottleRecord{StartTick: 1, EndTick: 2, Multiplier: 0.5}, history[0])
+	assert.Equal(t, int64(2), history[1].StartTick)
+	assert.Equal(t, int64(-1), history[1].EndTick)
+	assert.InDelta(t, 0.3, history[1].Multiplier, 1e-9)
+	// The open record counts the one tick produced so far.
+	assert.InDelta(t, 0.5+0.7, sim.TotalProductionLostToThrottle(), 1e-9)
+}
+
+func TestTick_FullyEscalatedPlagueHeartStopsProduction(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	mineID := sim.AddMineWithReserve(5.0, 100)
+	inf := sim.GetInfestationEngine()
+	cfg := inf.GetConfig()
+	cfg.EscalationStages = []infestation.EscalationStage{{DurationTicks: 1, AdditionalThrottleReduction: 0.5}}
+	require.NoError(t, inf.UpdateConfig(cfg))
+
+	inf.ForceActivatePlagueHeart()
+	sim.Tick()
+	sim.Tick()
+	require.True(t, sim.GetStatus().IsPlagueHeart)
+	require.Zero(t, sim.GetStatus().ThrottleMultiplier)
+
+	before := sim.GetStatus()
+	reserve := sim.GetAllMines()[0].CurrentReserve
+	after := sim.Tick()
+
+	assert.Zero(t, after.ThrottleMultiplier)
+	assert.Equal(t, before.Resources[ResourceMineral].Quantity, after.Resources[ResourceMineral].Quantity, "no mineral is mined")
+	assert.Equal(t, before.Resources[ResourceSim].Quantity, after.Resources[ResourceSim].Quantity, "no sim is produced")
+	assert.Equal(t, reserve, sim.GetAllMines()[0].CurrentReserve, "mine %s keeps its reserve", mineID)
+}
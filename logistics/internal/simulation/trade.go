@@ -0,0 +1,43 @@
+package simulation
+
+import (
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/economy"
+)
+
+// ExecuteTrade trades quantity of a resource with the market: a sell deducts
+// it from sim, a buy adds it. The trade is recorded in econ's ledger only if
+// the resource update succeeds, and the resource update is rolled back if the
+// ledger rejects the trade, so the two never disagree.
+func ExecuteTrade(sim *SimulationEngine, econ *economy.EconomyEngine, resource string, quantity float64, isBuy bool) (economy.TradeRecord, error) {
+	ert, err := economy.ParseResourceType(resource)
+	if err != nil {
+		return economy.TradeRecord{}, err
+	}
+	rt := ResourceType(ert)
+
+	update, rollback := sim.DeductResource, sim.AddResource
+	if isBuy {
+		update, rollback = sim.AddResource, sim.DeductResource
+	}
+	if err := update(rt, quantity); err != nil {
+		return economy.TradeRecord{}, err
+	}
+
+	record, err := econ.RecordTrade(ert, quantity, isBuy)
+	if err != nil {
+		_ = rollback(rt, quantity)
+		return economy.TradeRecord{}, err
+	}
+	return record, nil
+}
+
+// SyncProductionVolumes weights econ's commodity index (see
+// economy.EconomyEngine.GetCommodityIndex) by sim's current production rates.
+func SyncProductionVolumes(sim *SimulationEngine, econ *economy.EconomyEngine) error {
+	status := sim.GetStatus()
+	volumes := make(map[economy.ResourceType]float64, len(status.Resources))
+	for rt, res := range status.Resources {
+		volumes[economy.ResourceType(rt)] = res.ProductionRate
+	}
+	return econ.SetProductionVolumes(volumes)
+}
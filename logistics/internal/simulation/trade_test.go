@@ -0,0 +1,103 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/economy"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteTrade_SellDeductsAndRecords(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	econ := economy.NewEconomyEngine()
+	require.NoError(t, sim.AddResource(ResourceMineral, 150))
+
+	record, err := ExecuteTrade(sim, econ, "mineral", 100, false)
+	require.NoError(t, err)
+	assert.Equal(t, economy.ResourceMineral, record.Resource)
+	assert.InDelta(t, 30.0, record.TotalValue, 0.001)
+
+	assert.InDelta(t, 50.0, sim.GetStatus().Resources[ResourceMineral].Quantity, 0.001)
+
+	ledger := econ.GetLedger(10)
+	require.Len(t, ledger, 1)
+	assert.Equal(t, record.ID, ledger[0].ID)
+	assert.InDelta(t, 100.0, ledger[0].Quantity, 0.001)
+}
+
+func TestExecuteTrade_BuyAddsResource(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	econ := economy.NewEconomyEngine()
+
+	record, err := ExecuteTrade(sim, econ, "rapidlum", 4, true)
+	require.NoError(t, err)
+	assert.True(t, record.IsBuy)
+	assert.InDelta(t, 4.0, sim.GetStatus().Resources[ResourceRapidlum].Quantity, 0.001)
+}
+
+func TestExecuteTrade_InsufficientResourceCreatesNoRecord(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	econ := economy.NewEconomyEngine()
+	require.NoError(t, sim.AddResource(ResourceMineral, 10))
+
+	_, err := ExecuteTrade(sim, econ, "mineral", 100, false)
+	assert.True(t, errors.Is(err, ErrInsufficientResource))
+	assert.Empty(t, econ.GetLedger(0))
+	assert.InDelta(t, 10.0, sim.GetStatus().Resources[ResourceMineral].Quantity, 0.001)
+}
+
+func TestExecuteTrade_InvalidInputs(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	econ := economy.NewEconomyEngine()
+
+	_, err := ExecuteTrade(sim, econ, "unobtainium", 1, true)
+	assert.True(t, errors.Is(err, economy.ErrUnknownResource))
+
+	_, err = ExecuteTrade(sim, econ, "sim", -5, true)
+	assert.Error(t, err)
+	assert.Empty(t, econ.GetLedger(0))
+}
+
+func TestDeductResource_LeavesQuantityOnFailure(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	require.NoError(t, sim.AddResource(ResourceSim, 5))
+
+	assert.True(t, errors.Is(sim.DeductResource(ResourceSim, 6), ErrInsufficientResource))
+	assert.NoError(t, sim.DeductResource(ResourceSim, 5))
+	assert.InDelta(t, 0.0, sim.GetStatus().Resources[ResourceSim].Quantity, 0.001)
+	assert.Error(t, sim.AddResource(ResourceType("unobtainium"), 1))
+}
+
+func TestAddSubtractResource(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	require.NoError(t, sim.AddResource(ResourceMineral, 500))
+	assert.InDelta(t, 500.0, sim.GetStatus().Resources[ResourceMineral].Quantity, 0.001)
+
+	err := sim.SubtractResource(ResourceMineral, 600, false)
+	assert.True(t, errors.Is(err, ErrInsufficientResource))
+	assert.InDelta(t, 500.0, sim.GetStatus().Resources[ResourceMineral].Quantity, 0.001, "unchanged on error")
+
+	require.NoError(t, sim.SubtractResource(ResourceMineral, 600, true))
+	assert.InDelta(t, 0.0, sim.GetStatus().Resources[ResourceMineral].Quantity, 0.001, "floored at 0")
+
+	assert.Error(t, sim.SubtractResource(ResourceMineral, 0, true))
+	assert.Error(t, sim.AddResource(ResourceMineral, -1))
+}
+
+func TestSyncProductionVolumes(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	disableWorldAging(t, sim)
+	sim.AddMine(10)
+	sim.Tick()
+	econ := economy.NewEconomyEngine()
+	require.NoError(t, econ.SetPrice(economy.ResourceMineral, 1.0, 0.6)) // 2× default sell
+
+	require.NoError(t, SyncProductionVolumes(sim, econ))
+
+	// Mineral (10/tick, 2×) outweighs sim (1/tick, 1×); rapidlum produces nothing
+	assert.InDelta(t, (10*2.0+1*1.0)/11, econ.GetCommodityIndex(), 1e-9)
+}
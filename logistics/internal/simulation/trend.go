@@ -0,0 +1,118 @@
+package simulation
+
+import "math"
+
+// RebellionHistorySize is the number of per-tick OverallRebellionProb
+// samples kept for trend analysis.
+const RebellionHistorySize = 256
+
+// Rebellion trend directions.
+const (
+	TrendRising  = "rising"
+	TrendFalling = "falling"
+	TrendStable  = "stable"
+)
+
+// trendStableSlope is the largest per-tick change, in either direction,
+// still reported as TrendStable.
+const trendStableSlope = 0.001
+
+// RebellionTrend summarizes how OverallRebellionProb moved over recent ticks.
+type RebellionTrend struct {
+	Direction     string  // TrendRising, TrendFalling or TrendStable
+	ChangePerTick float64 // Slope of the least-squares fit
+	Confidence    float64 // R² of the fit, scaled by Samples / lookback
+	Samples       int     // Ticks the fit covers (at most the lookback)
+}
+
+// GetRebellionTrend fits a line to the OverallRebellionProb of the last
+// lookbackTicks ticks. With fewer ticks recorded it uses those available and
+// scales Confidence down accordingly; with fewer than two (or a non-positive
+// lookback) it reports a stable trend with zero confidence. Only the last
+// RebellionHistorySize ticks are kept, and paused ticks are not recorded.
+func (s *SimulationEngine) GetRebellionTrend(lookbackTicks int) RebellionTrend {
+	if lookbackTicks <= 0 {
+		return RebellionTrend{Direction: TrendStable}
+	}
+
+	s.mu.RLock()
+	samples := s.rebellionHistory.last(lookbackTicks)
+	s.mu.RUnlock()
+
+	trend := RebellionTrend{Direction: TrendStable, Samples: len(samples)}
+	if len(samples) < 2 {
+		return trend
+	}
+
+	slope, r2 := linearFit(samples)
+	trend.ChangePerTick = slope
+	trend.Confidence = r2 * float64(len(samples)) / float64(lookbackTicks)
+	switch {
+	case slope > trendStableSlope:
+		trend.Direction = TrendRising
+	case slope < -trendStableSlope:
+		trend.Direction = TrendFalling
+	}
+	return trend
+}
+
+// linearFit returns the least-squares slope of ys against their indices and
+// the fit's R². A constant series is fitted perfectly (R² = 1).
+func linearFit(ys []float64) (slope, r2 float64) {
+	n := float64(len(ys))
+	meanX := (n - 1) / 2
+	meanY := 0.0
+	for _, y := range ys {
+		meanY += y
+	}
+	meanY /= n
+
+	var sxy, sxx, syy float64
+	for i, y := range ys {
+		dx, dy := float64(i)-meanX, y-meanY
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	slope = sxy / sxx
+	if syy == 0 {
+		return slope, 1
+	}
+	return slope, math.Min(sxy*sxy/(sxx*syy), 1)
+}
+
+// float64Ring is a fixed-capacity buffer that keeps the most recent values.
+type float64Ring struct {
+	values []float64
+	next   int // index overwritten by the next push once full
+}
+
+func newFloat64Ring(capacity int) float64Ring {
+	return float64Ring{values: make([]float64, 0, capacity)}
+}
+
+// push appends v, evicting the oldest value when full.
+func (r *float64Ring) push(v float64) {
+	if len(r.values) < cap(r.values) {
+		r.values = append(r.values, v)
+		return
+	}
+	r.values[r.next] = v
+	r.next = (r.next + 1) % len(r.values)
+}
+
+// last returns up to n of the most recent values, oldest first.
+func (r *float64Ring) last(n int) []float64 {
+	ordered := append(append([]float64(nil), r.values[r.next:]...), r.values[:r.next]...)
+	if n < len(ordered) {
+		ordered = ordered[len(ordered)-n:]
+	}
+	return ordered
+}
+
+// clone returns a copy of r that shares no memory with it.
+func (r float64Ring) clone() float64Ring {
+	values := make([]float64, len(r.values), cap(r.values))
+	copy(values, r.values)
+	return float64Ring{values: values, next: r.next}
+}
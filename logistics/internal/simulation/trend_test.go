@@ -0,0 +1,152 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/npc"
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTrendTestEngine returns an engine with one attached NPC.
+func newTrendTestEngine(t *testing.T) (*SimulationEngine, *npc.BehaviorEngine) {
+	t.Helper()
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	behavior := npc.NewBehaviorEngine()
+	behavior.RegisterNPC("npc-1")
+	sim.AttachBehaviorEngine(behavior)
+	return sim, behavior
+}
+
+// tickWithMorale shifts the NPC's morale by each delta in turn, ticking once
+// after every shift.
+func tickWithMorale(t *testing.T, sim *SimulationEngine, behavior *npc.BehaviorEngine, deltas ...float64) {
+	t.Helper()
+	for _, d := range deltas {
+		require.NoError(t, behavior.ApplyMoraleModifier("npc-1", d, "trend test"))
+		sim.Tick()
+	}
+}
+
+func TestTick_SetsOverallRebellionProbFromNPCs(t *testing.T) {
+	sim, behavior := newTrendTestEngine(t)
+	behavior.RegisterNPC("npc-2")
+	require.NoError(t, behavior.ApplyMoraleModifier("npc-2", -0.4, "trend test"))
+	rebEngine := rebellion.NewEngine(rebellion.DefaultConfig())
+
+	status := sim.Tick()
+
+	var want float64
+	for _, n := range behavior.SnapshotNPCs() {
+		want += rebEngine.CalculateProbability(rebellion.NPCRebellionProfile{
+			NPCID:          n.NPCID,
+			AvgTrauma:      n.AvgTrauma,
+			WorkEfficiency: n.WorkEfficiency,
+			Morale:         n.Morale,
+			Role:           n.Role,
+		}).Probability
+	}
+	assert.Greater(t, status.OverallRebellionProb, 0.0)
+	assert.InDelta(t, want/2, status.OverallRebellionProb, 1e-9)
+}
+
+func TestGetRebellionTrend_Rising(t *testing.T) {
+	sim, behavior := newTrendTestEngine(t)
+	tickWithMorale(t, sim, behavior, -0.04, -0.04, -0.04, -0.04, -0.04, -0.04, -0.04, -0.04, -0.04, -0.04)
+
+	trend := sim.GetRebellionTrend(10)
+
+	assert.Equal(t, TrendRising, trend.Direction)
+	assert.Greater(t, trend.ChangePerTick, trendStableSlope)
+	assert.Greater(t, trend.Confidence, 0.95)
+	assert.Equal(t, 10, trend.Samples)
+}
+
+func TestGetRebellionTrend_Falling(t *testing.T) {
+	sim, behavior := newTrendTestEngine(t)
+	require.NoError(t, behavior.ApplyMoraleModifier("npc-1", -1, "trend test"))
+	tickWithMorale(t, sim, behavior, 0.1, 0.1, 0.1, 0.1)
+
+	trend := sim.GetRebellionTrend(4)
+
+	assert.Equal(t, TrendFalling, trend.Direction)
+	assert.Less(t, trend.ChangePerTick, -trendStableSlope)
+	assert.InDelta(t, 1.0, trend.Confidence, 1e-9)
+}
+
+func TestGetRebellionTrend_Stable(t *testing.T) {
+	sim, behavior := newTrendTestEngine(t)
+	require.NoError(t, behavior.ApplyMoraleModifier("npc-1", -0.3, "trend test"))
+	for i := 0; i < 10; i++ {
+		sim.Tick()
+	}
+
+	trend := sim.GetRebellionTrend(10)
+
+	assert.Greater(t, sim.GetStatus().OverallRebellionProb, 0.0)
+	assert.Equal(t, TrendStable, trend.Direction)
+	assert.InDelta(t, 0.0, trend.ChangePerTick, 1e-12)
+}
+
+func TestGetRebellionTrend_FewerSamplesThanLookback(t *testing.T) {
+	sim, behavior := newTrendTestEngine(t)
+	tickWithMorale(t, sim, behavior, -0.1, -0.1, -0.1, -0.1, -0.1)
+
+	full := sim.GetRebellionTrend(5)
+	partial := sim.GetRebellionTrend(10)
+
+	assert.Equal(t, 5, partial.Samples)
+	assert.InDelta(t, full.ChangePerTick, partial.ChangePerTick, 1e-12)
+	assert.InDelta(t, full.Confidence/2, partial.Confidence, 1e-12)
+	assert.Less(t, partial.Confidence, full.Confidence)
+}
+
+func TestGetRebellionTrend_InsufficientData(t *testing.T) {
+	sim, _ := newTrendTestEngine(t)
+	assert.Equal(t, RebellionTrend{Direction: TrendStable}, sim.GetRebellionTrend(10))
+
+	sim.Tick()
+	assert.Equal(t, RebellionTrend{Direction: TrendStable, Samples: 1}, sim.GetRebellionTrend(10))
+
+	sim.Tick()
+	assert.Equal(t, RebellionTrend{Direction: TrendStable}, sim.GetRebellionTrend(0))
+}
+
+func TestGetRebellionTrend_KeepsMostRecentTicks(t *testing.T) {
+	sim, behavior := newTrendTestEngine(t)
+	require.NoError(t, behavior.ApplyMoraleModifier("npc-1", -1, "trend test"))
+	for i := 0; i < RebellionHistorySize; i++ {
+		sim.Tick()
+	}
+	tickWithMorale(t, sim, behavior, 0.1, 0.1, 0.1, 0.1, 0.1)
+
+	trend := sim.GetRebellionTrend(5)
+
+	assert.Equal(t, TrendFalling, trend.Direction)
+	assert.Equal(t, RebellionHistorySize, sim.GetRebellionTrend(RebellionHistorySize+10).Samples)
+}
+
+func TestGetRebellionTrend_SkipsPausedTicks(t *testing.T) {
+	sim, behavior := newTrendTestEngine(t)
+	tickWithMorale(t, sim, behavior, -0.1, -0.1)
+	require.NoError(t, sim.Pause())
+	sim.Tick()
+	require.NoError(t, sim.Resume())
+	tickWithMorale(t, sim, behavior, -0.1)
+
+	assert.Equal(t, 3, sim.GetRebellionTrend(10).Samples)
+}
+
+func TestLinearFit(t *testing.T) {
+	slope, r2 := linearFit([]float64{0.5, 0.4, 0.3, 0.2})
+	assert.InDelta(t, -0.1, slope, 1e-9)
+	assert.InDelta(t, 1.0, r2, 1e-9)
+
+	slope, r2 = linearFit([]float64{0.3, 0.3, 0.3})
+	assert.InDelta(t, 0.0, slope, 1e-12)
+	assert.Equal(t, 1.0, r2)
+
+	_, r2 = linearFit([]float64{0.1, 0.3, 0.1, 0.3})
+	assert.Less(t, r2, 0.5)
+}
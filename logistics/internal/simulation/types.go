@@ -1,5 +1,7 @@
 package simulation
 
+import "fmt"
+
 // ResourceType represents the type of resource in the Epoch Engine economy.
 type ResourceType string
 
@@ -9,6 +11,17 @@ const (
 	ResourceMineral  ResourceType = "mineral"
 )
 
+// ParseResourceType converts a resource name (e.g. "mineral") to a ResourceType.
+// Returns an error if the name does not match a known resource.
+func ParseResourceType(name string) (ResourceType, error) {
+	switch rt := ResourceType(name); rt {
+	case ResourceSim, ResourceRapidlum, ResourceMineral:
+		return rt, nil
+	default:
+		return "", fmt.Errorf("unknown resource type %q", name)
+	}
+}
+
 // ResourceState tracks the current state of a single resource type.
 type ResourceState struct {
 	Type            ResourceType
@@ -28,16 +41,157 @@ type SimulationStatus struct {
 	InfestationLevel     float64 // 0-100: current infestation counter
 	IsPlagueHeart        bool    // true when Plague Heart active
 	ThrottleMultiplier   float64 // production multiplier (1.0 normal, 0.50 plague heart)
+	IsPaused             bool    // true while Pause is in effect
+	SkippedTicks         int64   // Ticks skipped while paused
+	WorldAgeMultiplier   float64 // Scales mine yields and refinery efficiencies, in (0, 1] (see GetWorldAge)
+
+	// Aggregate NPC statistics, computed each tick when a BehaviorEngine is attached
+	AvgNPCMorale                float64
+	AvgNPCEfficiency            float64
+	AvgNPCTrauma                float64 // Mean of the NPCs' AvgTrauma
+	NPCsBelowMoraleThreshold    int     // NPCs with morale < LowMoraleThreshold
+	NPCsAboveRebellionThreshold int     // NPCs with rebellion probability >= HaltThreshold
+}
+
+// PlagueHeartMoralePenalty is the morale every NPC loses when the Plague
+// Heart activates.
+const PlagueHeartMoralePenalty = 0.1
+
+// SimulationConfig defines the base production rates used by each tick.
+type SimulationConfig struct {
+	BaseSimProduction              float64 // Sim produced per tick (default: 1.0)
+	RefineryMineralConsumptionBase float64 // Mineral consumed per refinery per tick, × efficiency (default: 10.0)
+	RefineryRapidlumProductionBase float64 // Rapidlum produced per refinery per tick, × efficiency (default: 5.0)
+	LowMoraleThreshold             float64 // Morale below which an NPC counts as demoralized (default: 0.3)
+	NPCMoraleRecoveryRate          float64 // Morale regained per NPC per tick (default: 0, disabled)
+	NPCTraumaDecayRate             float64 // Trauma shed per NPC per tick (default: 0, disabled)
+	WorldAgingRate                 float64 // Fraction of the world age multiplier lost per tick, in [0, 1) (default: 0.001)
+
+	// ResourceDecayRate is the fraction of each perishable resource's
+	// quantity lost per tick, in [0, 1]. Unlisted resources do not decay
+	// (default: none).
+	ResourceDecayRate map[ResourceType]float64
+
+	// EfficiencyAggregation combines the work efficiencies of the NPCs
+	// assigned to a facility (default: EfficiencyMean).
+	EfficiencyAggregation EfficiencyAggregation
+	// RoleEfficiencyWeights weights each role's efficiency under
+	// EfficiencyWeightedByRole; unlisted roles weigh 1.0 (default: none, all
+	// roles equal).
+	RoleEfficiencyWeights map[string]float64
+}
+
+// DefaultConfig returns the standard simulation production rates.
+func DefaultConfig() SimulationConfig {
+	return SimulationConfig{
+		BaseSimProduction:              1.0,
+		RefineryMineralConsumptionBase: 10.0,
+		RefineryRapidlumProductionBase: 5.0,
+		LowMoraleThreshold:             0.3,
+		WorldAgingRate:                 0.001,
+		EfficiencyAggregation:          EfficiencyMean,
+	}
+}
+
+// Validate returns an error if any production or recovery rate is negative,
+// LowMoraleThreshold is outside [0, 1], WorldAgingRate is outside [0, 1), a
+// ResourceDecayRate entry names an
+// unknown resource or is outside [0, 1], EfficiencyAggregation is unknown or
+// a RoleEfficiencyWeights entry is negative.
+func (c SimulationConfig) Validate() error {
+	if c.BaseSimProduction < 0 {
+		return fmt.Errorf("BaseSimProduction must be non-negative, got %v", c.BaseSimProduction)
+	}
+	if c.RefineryMineralConsumptionBase < 0 {
+		return fmt.Errorf("RefineryMineralConsumptionBase must be non-negative, got %v", c.RefineryMineralConsumptionBase)
+	}
+	if c.RefineryRapidlumProductionBase < 0 {
+		return fmt.Errorf("RefineryRapidlumProductionBase must be non-negative, got %v", c.RefineryRapidlumProductionBase)
+	}
+	if c.NPCMoraleRecoveryRate < 0 {
+		return fmt.Errorf("NPCMoraleRecoveryRate must be non-negative, got %v", c.NPCMoraleRecoveryRate)
+	}
+	if c.NPCTraumaDecayRate < 0 {
+		return fmt.Errorf("NPCTraumaDecayRate must be non-negative, got %v", c.NPCTraumaDecayRate)
+	}
+	if c.LowMoraleThreshold < 0 || c.LowMoraleThreshold > 1 {
+		return fmt.Errorf("LowMoraleThreshold must be in [0, 1], got %v", c.LowMoraleThreshold)
+	}
+	if c.WorldAgingRate < 0 || c.WorldAgingRate >= 1 {
+		return fmt.Errorf("WorldAgingRate must be in [0, 1), got %v", c.WorldAgingRate)
+	}
+	for rt, rate := range c.ResourceDecayRate {
+		if err := validateDecayRate(rt, rate); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseEfficiencyAggregation(string(c.EfficiencyAggregation)); err != nil {
+		return err
+	}
+	for role, weight := range c.RoleEfficiencyWeights {
+		if weight < 0 {
+			return fmt.Errorf("RoleEfficiencyWeights[%q] must be non-negative, got %v", role, weight)
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of c that shares no maps with it.
+func (c SimulationConfig) clone() SimulationConfig {
+	if c.ResourceDecayRate != nil {
+		rates := make(map[ResourceType]float64, len(c.ResourceDecayRate))
+		for rt, rate := range c.ResourceDecayRate {
+			rates[rt] = rate
+		}
+		c.ResourceDecayRate = rates
+	}
+	if c.RoleEfficiencyWeights != nil {
+		weights := make(map[string]float64, len(c.RoleEfficiencyWeights))
+		for role, weight := range c.RoleEfficiencyWeights {
+			weights[role] = weight
+		}
+		c.RoleEfficiencyWeights = weights
+	}
+	return c
 }
 
 // Mine represents a mineral extraction facility.
 type Mine struct {
-	MineID    string
-	YieldRate float64 // Mineral produced per tick
+	MineID         string
+	YieldRate      float64 // Mineral produced per tick
+	DisruptedTicks int     // Remaining ticks offline (0 = operating)
+	AutoScaled     bool    // Added by auto-scaling (see AutoScaleConfig)
+	MaxReserve     float64 // Total ore the mine holds (0 = infinite)
+	CurrentReserve float64 // Ore left to mine; unused when MaxReserve is 0
 }
 
-// Refinery represents a mineral-to-rapidlum conversion facility.
+// Depleted reports whether the mine has a finite reserve and has exhausted it.
+func (m Mine) Depleted() bool {
+	return m.MaxReserve > 0 && m.CurrentReserve <= 0
+}
+
+// Refinery represents a conversion facility. Plain refineries turn mineral
+// into rapidlum; other facility types run the production chain registered
+// for their FacilityType (see AddProductionChain).
 type Refinery struct {
-	RefineryID string
-	Efficiency float64 // 0.0-1.0: conversion efficiency
+	RefineryID     string
+	FacilityType   string  // Production chain the facility runs (RefineryFacility for AddRefinery)
+	Efficiency     float64 // 0.0-1.0: conversion efficiency
+	DisruptedTicks int     // Remaining ticks offline (0 = operating)
+}
+
+// RefineryFacility is the facility type of plain refineries, which run the
+// built-in mineral→rapidlum chain.
+const RefineryFacility = "refinery"
+
+// ProductionChain converts one resource into another at facilities of
+// FacilityType. Each operating facility consumes
+// Efficiency × RefineryMineralConsumptionBase input units per tick (scaled
+// like refineries by world age and assigned NPCs) and produces that amount
+// divided by ConversionRatio.
+type ProductionChain struct {
+	InputResource   ResourceType
+	OutputResource  ResourceType
+	ConversionRatio float64 // Input units consumed per output unit produced
+	FacilityType    string
 }
@@ -0,0 +1,19 @@
+package simulation
+
+// GetWorldAge returns the current world age multiplier: 1.0 for a fresh
+// world, shrinking by WorldAgingRate each tick. Mine yields and refinery
+// efficiencies are scaled by it during production; their stored values are
+// unchanged.
+func (s *SimulationEngine) GetWorldAge() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status.WorldAgeMultiplier
+}
+
+// Rejuvenate resets the world age multiplier to 1.0, representing a major
+// rebuild of the colony's infrastructure. It takes effect on the next Tick().
+func (s *SimulationEngine) Rejuvenate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.WorldAgeMultiplier = 1.0
+}
@@ -0,0 +1,125 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// disableWorldAging turns off world aging so tests can assert exact
+// production figures.
+func disableWorldAging(t *testing.T, sim *SimulationEngine) {
+	t.Helper()
+	cfg := sim.GetConfig()
+	cfg.WorldAgingRate = 0
+	require.NoError(t, sim.UpdateConfig(cfg))
+}
+
+func TestWorldAge_DecaysEachTick(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	assert.Equal(t, 1.0, sim.GetWorldAge())
+
+	for i := 0; i < 100; i++ {
+		sim.Tick()
+	}
+	assert.InDelta(t, math.Pow(0.999, 100), sim.GetWorldAge(), 1e-12)
+	assert.InDelta(t, 0.905, sim.GetWorldAge(), 0.001)
+}
+
+func TestWorldAge_ScalesProduction(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(10.0)
+	sim.AddRefinery(0.5)
+	require.NoError(t, sim.AddResource(ResourceMineral, 1000))
+
+	for i := 0; i < 100; i++ {
+		sim.Tick()
+	}
+	age := sim.GetWorldAge()
+	status := sim.Tick()
+	assert.InDelta(t, 10.0*age, status.Resources[ResourceMineral].ProductionRate, 1e-9)
+	assert.InDelta(t, 0.5*age*5.0, status.Resources[ResourceRapidlum].ProductionRate, 1e-9)
+	assert.InDelta(t, 0.5*age*10.0, status.Resources[ResourceMineral].ConsumptionRate, 1e-9)
+	assert.InDelta(t, 1.0, status.Resources[ResourceSim].ProductionRate, 1e-9, "sim production does not age")
+
+	// Stored values are unchanged
+	mines := sim.Fork().mines
+	require.Len(t, mines, 1)
+	assert.Equal(t, 10.0, mines[0].YieldRate)
+}
+
+func TestWorldAge_TotalMineralOverTicks(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(10.0)
+
+	var status SimulationStatus
+	for i := 0; i < 100; i++ {
+		status = sim.Tick()
+	}
+	// Geometric series: 10 × (1 - 0.999^100) / 0.001
+	want := 10.0 * (1 - math.Pow(0.999, 100)) / 0.001
+	assert.InDelta(t, want, status.Resources[ResourceMineral].Quantity, 1e-6)
+}
+
+func TestRejuvenate_ResetsWorldAge(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	sim.AddMine(10.0)
+	for i := 0; i < 50; i++ {
+		sim.Tick()
+	}
+	require.Less(t, sim.GetWorldAge(), 1.0)
+
+	sim.Rejuvenate()
+	assert.Equal(t, 1.0, sim.GetWorldAge())
+	status := sim.Tick()
+	assert.InDelta(t, 10.0, status.Resources[ResourceMineral].ProductionRate, 1e-9)
+}
+
+func TestWorldAge_IsStateNotConfig(t *testing.T) {
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+	cfg := sim.GetConfig()
+	cfg.WorldAgingRate = 0.5
+	require.NoError(t, sim.UpdateConfig(cfg))
+	status := sim.Tick()
+	assert.InDelta(t, 0.5, status.WorldAgeMultiplier, 1e-9)
+
+	require.NoError(t, sim.UpdateConfig(sim.GetConfig()))
+	assert.InDelta(t, 0.5, sim.GetWorldAge(), 1e-9, "updating the config keeps the world age")
+
+	fork := sim.Fork()
+	assert.InDelta(t, 0.5, fork.GetWorldAge(), 1e-9)
+	fork.Tick()
+	assert.InDelta(t, 0.5, sim.GetWorldAge(), 1e-9, "ticking a fork does not age the engine")
+
+	took, err := sim.MergeFrom(fork, TakeFork)
+	require.NoError(t, err)
+	require.True(t, took)
+	assert.InDelta(t, 0.25, sim.GetWorldAge(), 1e-9)
+}
+
+func TestWorldAge_ConfigValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WorldAgingRate = 1
+	assert.Error(t, cfg.Validate())
+
+	cfg = DefaultConfig()
+	cfg.WorldAgingRate = -0.1
+	assert.Error(t, cfg.Validate())
+}
+
+func TestWorldAge_ReplayStartsFresh(t *testing.T) {
+	es := NewEventSourcedSimulationEngine(NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig())))
+	es.AddMine(10.0)
+	for i := 0; i < 20; i++ {
+		es.Engine().Tick()
+	}
+
+	replayed, err := es.ReplayFromEvents(es.ExportEventLog())
+	require.NoError(t, err)
+	assert.InDelta(t, es.Engine().GetWorldAge(), replayed.GetWorldAge(), 1e-12)
+	assert.InDelta(t, es.Engine().GetStatus().Resources[ResourceMineral].Quantity,
+		replayed.GetStatus().Resources[ResourceMineral].Quantity, 1e-9)
+}
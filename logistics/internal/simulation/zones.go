@@ -0,0 +1,146 @@
+package simulation
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/infestation"
+)
+
+// zoneConnection is a directed infestation spread link between two zones.
+type zoneConnection struct {
+	from         string
+	to           string
+	spreadFactor float64
+}
+
+// ZoneManager groups SimulationEngine instances as zones and spreads
+// infestation along directed connections between them. It is safe for
+// concurrent use.
+type ZoneManager struct {
+	mu          sync.RWMutex
+	zones       map[string]*SimulationEngine
+	connections []zoneConnection
+}
+
+// NewZoneManager creates an empty zone manager.
+func NewZoneManager() *ZoneManager {
+	return &ZoneManager{
+		zones: make(map[string]*SimulationEngine),
+	}
+}
+
+// AddZone registers engine under zoneID. Returns an error if the ID is empty,
+// already registered, or the engine is nil.
+func (m *ZoneManager) AddZone(zoneID string, engine *SimulationEngine) error {
+	if zoneID == "" {
+		return fmt.Errorf("zone ID must not be empty")
+	}
+	if engine == nil {
+		return fmt.Errorf("zone %q: engine must not be nil", zoneID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.zones[zoneID]; exists {
+		return fmt.Errorf("zone %q already registered", zoneID)
+	}
+	m.zones[zoneID] = engine
+	return nil
+}
+
+// GetZone returns the engine registered under zoneID.
+func (m *ZoneManager) GetZone(zoneID string) (*SimulationEngine, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	engine, ok := m.zones[zoneID]
+	return engine, ok
+}
+
+// ZoneIDs returns the registered zone IDs in sorted order.
+func (m *ZoneManager) ZoneIDs() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := make([]string, 0, len(m.zones))
+	for id := range m.zones {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ConnectZones adds a directed spread link: each TickAllWithSpread adds
+// fromZone's counter * spreadFactor to toZone before toZone ticks.
+// Bidirectional spread requires a second call with the zones swapped.
+// Connecting an already connected pair replaces its spread factor.
+func (m *ZoneManager) ConnectZones(fromZoneID, toZoneID string, spreadFactor float64) error {
+	if spreadFactor < 0 || spreadFactor > 1 {
+		return fmt.Errorf("spreadFactor must be in [0, 1], got %v", spreadFactor)
+	}
+	if fromZoneID == toZoneID {
+		return fmt.Errorf("zone %q cannot be connected to itself", fromZoneID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range []string{fromZoneID, toZoneID} {
+		if _, ok := m.zones[id]; !ok {
+			return &ZoneNotFoundError{ZoneID: id}
+		}
+	}
+
+	for i := range m.connections {
+		if m.connections[i].from == fromZoneID && m.connections[i].to == toZoneID {
+			m.connections[i].spreadFactor = spreadFactor
+			return nil
+		}
+	}
+	m.connections = append(m.connections, zoneConnection{
+		from:         fromZoneID,
+		to:           toZoneID,
+		spreadFactor: spreadFactor,
+	})
+	return nil
+}
+
+// TickAllWithSpread advances the infestation engine of every zone by one tick.
+// perZoneInputs maps zone ID to {avgRebellion, avgTrauma}; zones without an
+// entry tick with zero inputs (decay).
+//
+// Spread is computed from the counters as they stand before this call, so the
+// order in which zones are processed does not matter. Spread is capped at the
+// target's PlagueHeartThreshold and cannot activate Plague Heart by itself;
+// only the target's own tick can.
+func (m *ZoneManager) TickAllWithSpread(perZoneInputs map[string][2]float64, tick int64) map[string]infestation.InfestationTickResult {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Snapshot source counters before any zone is modified
+	counters := make(map[string]float64, len(m.zones))
+	for id, zone := range m.zones {
+		if inf := zone.GetInfestationEngine(); inf != nil {
+			counters[id] = inf.GetState().Counter
+		}
+	}
+
+	for _, conn := range m.connections {
+		target := m.zones[conn.to].GetInfestationEngine()
+		if target == nil {
+			continue
+		}
+		target.AddSpread(counters[conn.from] * conn.spreadFactor)
+	}
+
+	results := make(map[string]infestation.InfestationTickResult, len(m.zones))
+	for id, zone := range m.zones {
+		if zone.GetInfestationEngine() == nil {
+			continue
+		}
+		inputs := perZoneInputs[id]
+		results[id] = zone.tickInfestation(inputs[0], inputs[1], tick)
+	}
+	return results
+}
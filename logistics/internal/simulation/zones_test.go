@@ -0,0 +1,108 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okanyucel2/project-ultima-epoch-engine/logistics/internal/rebellion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestZoneManager(t *testing.T, ids ...string) *ZoneManager {
+	t.Helper()
+	zm := NewZoneManager()
+	for _, id := range ids {
+		require.NoError(t, zm.AddZone(id, NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))))
+	}
+	return zm
+}
+
+func zoneCounter(t *testing.T, zm *ZoneManager, id string) float64 {
+	t.Helper()
+	zone, ok := zm.GetZone(id)
+	require.True(t, ok)
+	return zone.GetInfestationState().Counter
+}
+
+func TestZoneManager_SpreadAddsSourceCounterTimesFactor(t *testing.T) {
+	zm := newTestZoneManager(t, "A", "B")
+	require.NoError(t, zm.ConnectZones("A", "B", 0.1))
+
+	zoneA, _ := zm.GetZone("A")
+	require.NoError(t, zoneA.GetInfestationEngine().ForceSetCounter(60))
+
+	// A accumulates, B has low rebellion and decays after receiving spread
+	results := zm.TickAllWithSpread(map[string][2]float64{
+		"A": {0.6, 0.9},
+		"B": {0.05, 0.05},
+	}, 1)
+
+	cfg := zoneA.GetInfestationEngine().GetConfig()
+	assert.InDelta(t, 6.0, results["B"].PreviousCounter, 0.001, "spread applied before B's own tick")
+	assert.InDelta(t, 6.0-cfg.DecayRate, results["B"].NewCounter, 0.001)
+	assert.InDelta(t, 60+cfg.AccumulationRate, results["A"].NewCounter, 0.001)
+
+	// Status mirrors the ticked infestation state
+	zoneB, _ := zm.GetZone("B")
+	assert.InDelta(t, 6.0-cfg.DecayRate, zoneB.GetStatus().InfestationLevel, 0.001)
+}
+
+func TestZoneManager_ConnectionsAreDirected(t *testing.T) {
+	zm := newTestZoneManager(t, "A", "B")
+	require.NoError(t, zm.ConnectZones("A", "B", 0.1))
+
+	zoneB, _ := zm.GetZone("B")
+	require.NoError(t, zoneB.GetInfestationEngine().ForceSetCounter(60))
+
+	results := zm.TickAllWithSpread(nil, 1)
+	assert.InDelta(t, 0.0, results["A"].PreviousCounter, 0.001, "B→A not connected")
+
+	// Bidirectional spread needs the reverse connection too
+	require.NoError(t, zm.ConnectZones("B", "A", 0.1))
+	counterB := zoneCounter(t, zm, "B")
+	results = zm.TickAllWithSpread(nil, 2)
+	assert.InDelta(t, counterB*0.1, results["A"].PreviousCounter, 0.001)
+}
+
+func TestZoneManager_SpreadCannotActivatePlagueHeart(t *testing.T) {
+	zm := newTestZoneManager(t, "A", "B")
+	require.NoError(t, zm.ConnectZones("A", "B", 1.0))
+
+	zoneA, _ := zm.GetZone("A")
+	zoneB, _ := zm.GetZone("B")
+	require.NoError(t, zoneA.GetInfestationEngine().ForceSetCounter(90))
+	require.NoError(t, zoneB.GetInfestationEngine().ForceSetCounter(50))
+
+	results := zm.TickAllWithSpread(nil, 1)
+
+	threshold := zoneB.GetInfestationEngine().GetConfig().PlagueHeartThreshold
+	assert.LessOrEqual(t, results["B"].PreviousCounter, threshold)
+	assert.False(t, results["B"].PlagueHeartActive)
+	assert.False(t, zoneB.GetInfestationState().IsPlagueHeart)
+}
+
+func TestZoneManager_ConnectZonesValidation(t *testing.T) {
+	zm := newTestZoneManager(t, "A", "B")
+
+	err := zm.ConnectZones("A", "missing", 0.1)
+	assert.True(t, errors.Is(err, ErrZoneNotFound))
+	var zoneErr *ZoneNotFoundError
+	if assert.True(t, errors.As(err, &zoneErr)) {
+		assert.Equal(t, "missing", zoneErr.ZoneID)
+	}
+
+	assert.Error(t, zm.ConnectZones("A", "A", 0.1))
+	assert.Error(t, zm.ConnectZones("A", "B", -0.1))
+	assert.Error(t, zm.ConnectZones("A", "B", 1.5))
+}
+
+func TestZoneManager_AddZoneValidation(t *testing.T) {
+	zm := newTestZoneManager(t, "A")
+	sim := NewSimulationEngine(rebellion.NewEngine(rebellion.DefaultConfig()))
+
+	assert.Error(t, zm.AddZone("A", sim), "duplicate ID")
+	assert.Error(t, zm.AddZone("", sim))
+	assert.Error(t, zm.AddZone("C", nil))
+	assert.Equal(t, []string{"A"}, zm.ZoneIDs())
+}
@@ -0,0 +1,46 @@
+// Package tracing carries request trace IDs through context.Context so log
+// lines from every layer handling a request can be correlated.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// traceIDKey is the context key under which the trace ID is stored.
+type traceIDKey struct{}
+
+// WithTraceID returns a child of ctx carrying traceID.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// GetTraceID returns the trace ID stored in ctx, or "" if there is none.
+func GetTraceID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+// LogPrefix returns "trace_id=<id> " for prefixing log messages, or "" when
+// ctx carries no trace ID.
+func LogPrefix(ctx context.Context) string {
+	if id := GetTraceID(ctx); id != "" {
+		return "trace_id=" + id + " "
+	}
+	return ""
+}
+
+// NewTraceID returns a random (version 4) UUID.
+func NewTraceID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("tracing: reading random bytes: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
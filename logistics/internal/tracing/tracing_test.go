@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceIDRoundTrip(t *testing.T) {
+	ctx := WithTraceID(context.Background(), "req-1")
+
+	assert.Equal(t, "req-1", GetTraceID(ctx))
+	assert.Equal(t, "trace_id=req-1 ", LogPrefix(ctx))
+	assert.Empty(t, GetTraceID(context.Background()))
+	assert.Empty(t, LogPrefix(context.Background()))
+}
+
+func TestNewTraceID(t *testing.T) {
+	uuidV4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	a, b := NewTraceID(), NewTraceID()
+
+	assert.Regexp(t, uuidV4, a)
+	assert.NotEqual(t, a, b)
+}
@@ -0,0 +1,216 @@
+// Package webhook delivers rebellion events to an external HTTP endpoint so
+// other game systems can react without polling.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the request body.
+const SignatureHeader = "X-Webhook-Signature"
+
+// EventRebellionHalt is the event type sent when an action triggers a halt.
+const EventRebellionHalt = "rebellion.halt"
+
+const (
+	// DefaultInitialBackoff is the delay before the first retry when
+	// WebhookConfig.InitialBackoff is zero. Each further retry doubles it.
+	DefaultInitialBackoff = 200 * time.Millisecond
+	// defaultRequestTimeout bounds a single delivery attempt.
+	defaultRequestTimeout = 5 * time.Second
+)
+
+// WebhookConfig configures the delivery target.
+type WebhookConfig struct {
+	URL            string        // Target endpoint; empty disables delivery
+	Secret         string        // HMAC-SHA256 key for SignatureHeader; empty = unsigned
+	RetryCount     int           // Retries after the first failed attempt
+	InitialBackoff time.Duration // Delay before the first retry (0 = DefaultInitialBackoff)
+}
+
+// Validate checks that URL is empty or an absolute http(s) URL and that
+// RetryCount is not negative.
+func (c WebhookConfig) Validate() error {
+	if c.RetryCount < 0 {
+		return fmt.Errorf("retry count must not be negative, got %d", c.RetryCount)
+	}
+	if c.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook URL must be an absolute http(s) URL, got %q", c.URL)
+	}
+	return nil
+}
+
+// HaltEvent describes an NPC action whose resulting rebellion probability
+// reached the halt threshold.
+type HaltEvent struct {
+	NPCID       string
+	ActionID    string
+	ActionType  string
+	Probability float64
+	Timestamp   time.Time
+}
+
+// payload is the JSON body POSTed to the webhook URL.
+type payload struct {
+	Event       string  `json:"event"`
+	NPCID       string  `json:"npc_id"`
+	ActionID    string  `json:"action_id,omitempty"`
+	ActionType  string  `json:"action_type"`
+	Probability float64 `json:"probability"`
+	Timestamp   string  `json:"timestamp"`
+}
+
+// WebhookDispatcher POSTs events to the configured URL, retrying failed
+// deliveries with exponential backoff. It is safe for concurrent use.
+type WebhookDispatcher struct {
+	mu     sync.RWMutex
+	config WebhookConfig
+	client *http.Client
+	wg     sync.WaitGroup
+}
+
+// NewWebhookDispatcher creates a dispatcher. A nil client uses a client with
+// a 5 second timeout. Returns an error if cfg is invalid.
+func NewWebhookDispatcher(cfg WebhookConfig, client *http.Client) (*WebhookDispatcher, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = &http.Client{Timeout: defaultRequestTimeout}
+	}
+	return &WebhookDispatcher{config: cfg, client: client}, nil
+}
+
+// Config returns the current configuration.
+func (d *WebhookDispatcher) Config() WebhookConfig {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.config
+}
+
+// UpdateConfig replaces the configuration after validating it. Deliveries
+// already in flight keep the configuration they started with.
+func (d *WebhookDispatcher) UpdateConfig(cfg WebhookConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.config = cfg
+	return nil
+}
+
+// NotifyHalt delivers event in the background. It returns immediately;
+// delivery failures are logged. Use Wait to block until pending deliveries
+// finish.
+func (d *WebhookDispatcher) NotifyHalt(event HaltEvent) {
+	if d.Config().URL == "" {
+		return
+	}
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		if err := d.Deliver(context.Background(), event); err != nil {
+			log.Printf("[Webhook] Delivery for %s failed: %v", event.NPCID, err)
+		}
+	}()
+}
+
+// Wait blocks until all deliveries started by NotifyHalt have finished.
+func (d *WebhookDispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// Deliver POSTs event synchronously. A non-2xx response or transport error
+// is retried up to RetryCount times, waiting InitialBackoff, 2*InitialBackoff,
+// ... between attempts. Returns the last error if every attempt fails, or
+// nil if no URL is configured.
+func (d *WebhookDispatcher) Deliver(ctx context.Context, event HaltEvent) error {
+	cfg := d.Config()
+	if cfg.URL == "" {
+		return nil
+	}
+
+	ts := event.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	body, err := json.Marshal(payload{
+		Event:       EventRebellionHalt,
+		NPCID:       event.NPCID,
+		ActionID:    event.ActionID,
+		ActionType:  event.ActionType,
+		Probability: event.Probability,
+		Timestamp:   ts.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	backoff := cfg.InitialBackoff
+	if backoff <= 0 {
+		backoff = DefaultInitialBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = d.post(ctx, cfg, body)
+		if err == nil || attempt >= cfg.RetryCount {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post performs a single delivery attempt.
+func (d *WebhookDispatcher) post(ctx context.Context, cfg WebhookConfig, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(cfg.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook endpoint returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for body: "sha256=" followed by the
+// hex-encoded HMAC-SHA256 of body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "webhook-test-secret"
+
+func TestNotifyHalt_DeliversSignedPayloads(t *testing.T) {
+	var mu sync.Mutex
+	var received []map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, Sign(testSecret, body), r.Header.Get(SignatureHeader), "signature mismatch")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var p map[string]any
+		assert.NoError(t, json.Unmarshal(body, &p))
+		mu.Lock()
+		received = append(received, p)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	d, err := NewWebhookDispatcher(WebhookConfig{URL: srv.URL, Secret: testSecret}, nil)
+	require.NoError(t, err)
+
+	for _, id := range []string{"npc-1", "npc-2", "npc-3"} {
+		d.NotifyHalt(HaltEvent{NPCID: id, ActionType: "punishment", Probability: 0.4})
+	}
+	d.Wait()
+
+	require.Len(t, received, 3)
+	ids := map[any]bool{}
+	for _, p := range received {
+		assert.Equal(t, EventRebellionHalt, p["event"])
+		assert.Equal(t, "punishment", p["action_type"])
+		ids[p["npc_id"]] = true
+	}
+	assert.Len(t, ids, 3)
+}
+
+func TestDeliver_RetriesExactlyRetryCount(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	d, err := NewWebhookDispatcher(WebhookConfig{
+		URL:            srv.URL,
+		RetryCount:     3,
+		InitialBackoff: time.Millisecond,
+	}, nil)
+	require.NoError(t, err)
+
+	err = d.Deliver(context.Background(), HaltEvent{NPCID: "npc-1"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1+3), calls.Load(), "first attempt plus RetryCount retries")
+}
+
+func TestDeliver_SucceedsAfterTransientFailure(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	d, err := NewWebhookDispatcher(WebhookConfig{URL: srv.URL, RetryCount: 2, InitialBackoff: time.Millisecond}, nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, d.Deliver(context.Background(), HaltEvent{NPCID: "npc-1"}))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestDeliver_NoURLIsNoop(t *testing.T) {
+	d, err := NewWebhookDispatcher(WebhookConfig{}, nil)
+	require.NoError(t, err)
+	assert.NoError(t, d.Deliver(context.Background(), HaltEvent{NPCID: "npc-1"}))
+	d.NotifyHalt(HaltEvent{NPCID: "npc-1"})
+	d.Wait()
+}
+
+func TestWebhookConfig_Validate(t *testing.T) {
+	assert.NoError(t, WebhookConfig{}.Validate())
+	assert.NoError(t, WebhookConfig{URL: "https://example.com/hook", RetryCount: 2}.Validate())
+	assert.Error(t, WebhookConfig{URL: "ftp://example.com"}.Validate())
+	assert.Error(t, WebhookConfig{URL: "/relative"}.Validate())
+	assert.Error(t, WebhookConfig{RetryCount: -1}.Validate())
+
+	d, err := NewWebhookDispatcher(WebhookConfig{}, nil)
+	require.NoError(t, err)
+	assert.Error(t, d.UpdateConfig(WebhookConfig{URL: "not a url"}))
+	require.NoError(t, d.UpdateConfig(WebhookConfig{URL: "http://localhost:9/hook", RetryCount: 1}))
+	assert.Equal(t, 1, d.Config().RetryCount)
+}